@@ -0,0 +1,22 @@
+package common
+
+// KeycloakProviders contains providers available on the keycloak server.
+// +kubebuilder:object:generate=true
+type KeycloakProviders struct {
+	// Authenticators is a list of authenticator ids which can be used in authentication flows.
+	// +optional
+	Authenticators []string `json:"authenticators,omitempty"`
+
+	// IdentityProviders is a list of identity provider ids.
+	// +optional
+	IdentityProviders []string `json:"identityProviders,omitempty"`
+
+	// IdentityProviderMappers is a list of identity provider mapper ids.
+	// +optional
+	IdentityProviderMappers []string `json:"identityProviderMappers,omitempty"`
+
+	// Components is a map of component provider types managed by the operator
+	// (key providers, user storage providers and LDAP mappers) to the component provider ids.
+	// +optional
+	Components map[string][]string `json:"components,omitempty"`
+}
@@ -0,0 +1,17 @@
+package common
+
+import "golang.org/x/exp/slices"
+
+// KeycloakUrls returns ordered list of keycloak URLs starting with the main URL without duplicates.
+func KeycloakUrls(url string, fallbackUrls []string) []string {
+	urls := make([]string, 0, len(fallbackUrls)+1)
+	urls = append(urls, url)
+
+	for _, u := range fallbackUrls {
+		if !slices.Contains(urls, u) {
+			urls = append(urls, u)
+		}
+	}
+
+	return urls
+}
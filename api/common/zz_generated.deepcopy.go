@@ -0,0 +1,53 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package common
+
+import ()
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakProviders) DeepCopyInto(out *KeycloakProviders) {
+	*out = *in
+	if in.Authenticators != nil {
+		in, out := &in.Authenticators, &out.Authenticators
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IdentityProviders != nil {
+		in, out := &in.IdentityProviders, &out.IdentityProviders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IdentityProviderMappers != nil {
+		in, out := &in.IdentityProviderMappers, &out.IdentityProviderMappers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakProviders.
+func (in *KeycloakProviders) DeepCopy() *KeycloakProviders {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakProviders)
+	in.DeepCopyInto(out)
+	return out
+}
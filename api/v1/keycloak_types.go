@@ -2,6 +2,8 @@ package v1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/epam/edp-keycloak-operator/api/common"
 )
 
 // KeycloakSpec defines the desired state of Keycloak.
@@ -9,6 +11,11 @@ type KeycloakSpec struct {
 	// URL of keycloak service.
 	Url string `json:"url"`
 
+	// FallbackUrls is an ordered list of keycloak URLs which are used if the main URL is not available,
+	// e.g. in-cluster service URL in addition to the ingress URL.
+	// +optional
+	FallbackUrls []string `json:"fallbackUrls,omitempty"`
+
 	// Secret is a secret name which contains admin credentials.
 	Secret string `json:"secret"`
 
@@ -23,6 +30,16 @@ const (
 	KeycloakAdminTypeServiceAccount = "serviceAccount"
 )
 
+const (
+	KeycloakPathLayoutLegacy  = "legacy"
+	KeycloakPathLayoutCurrent = "current"
+)
+
+// GetUrls returns ordered list of keycloak URLs starting with the main URL.
+func (in *Keycloak) GetUrls() []string {
+	return common.KeycloakUrls(in.Spec.Url, in.Spec.FallbackUrls)
+}
+
 func (in *Keycloak) GetAdminType() string {
 	if in.Spec.AdminType == "" {
 		in.Spec.AdminType = KeycloakAdminTypeUser
@@ -35,12 +52,38 @@ func (in *Keycloak) GetAdminType() string {
 type KeycloakStatus struct {
 	// Connected shows if keycloak service is up and running.
 	Connected bool `json:"connected"`
+
+	// Providers contains providers available on the keycloak server.
+	// +optional
+	Providers *common.KeycloakProviders `json:"providers,omitempty"`
+
+	// ActiveUrl is the keycloak URL which is currently used for connection.
+	// +optional
+	ActiveUrl string `json:"activeUrl,omitempty"`
+
+	// Version is a version of the keycloak server.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Features is a list of the enabled keycloak server features.
+	// +optional
+	Features []string `json:"features,omitempty"`
+
+	// PathLayout is a path layout of the keycloak server API, legacy layout uses /auth prefix.
+	// +optional
+	// +kubebuilder:validation:Enum=legacy;current
+	PathLayout string `json:"pathLayout,omitempty"`
+
+	// LastConnected is the last time when the operator successfully connected to the keycloak server.
+	// +optional
+	LastConnected *metav1.Time `json:"lastConnected,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Connected",type="boolean",JSONPath=".status.connected",description="Is connected to keycloak"
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.version",description="Keycloak server version"
 
 // Keycloak is the Schema for the keycloaks API.
 type Keycloak struct {
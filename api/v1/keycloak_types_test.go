@@ -1,6 +1,9 @@
 package v1
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 func TestKeycloak_GetAdminType(t *testing.T) {
 	kc := Keycloak{}
@@ -13,3 +16,15 @@ func TestKeycloak_GetAdminType(t *testing.T) {
 		t.Fatal("wring admin type returned")
 	}
 }
+
+func TestKeycloak_GetUrls(t *testing.T) {
+	kc := Keycloak{Spec: KeycloakSpec{Url: "https://keycloak.example.com"}}
+	if !reflect.DeepEqual(kc.GetUrls(), []string{"https://keycloak.example.com"}) {
+		t.Fatal("wrong urls returned")
+	}
+
+	kc.Spec.FallbackUrls = []string{"http://keycloak.security:8080", "https://keycloak.example.com"}
+	if !reflect.DeepEqual(kc.GetUrls(), []string{"https://keycloak.example.com", "http://keycloak.security:8080"}) {
+		t.Fatal("wrong urls returned")
+	}
+}
@@ -0,0 +1,186 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/epam/edp-keycloak-operator/api/common"
+)
+
+// KeycloakAdminPermissionSpec defines the desired state of KeycloakAdminPermission.
+type KeycloakAdminPermissionSpec struct {
+	// RealmRef is reference to Realm custom resource.
+	RealmRef common.RealmRef `json:"realmRef"`
+
+	// Target is an object which admin permissions are enabled.
+	Target AdminPermissionTarget `json:"target"`
+
+	// Policies are the policies of the realm-management client which are managed by the resource.
+	// Policies are removed from Keycloak when they are removed from the list or the resource is deleted.
+	// +nullable
+	// +optional
+	Policies []AdminPermissionPolicy `json:"policies,omitempty"`
+
+	// Permissions bind the target scopes to the policies.
+	// Scopes which are not listed are not changed.
+	// +nullable
+	// +optional
+	Permissions []AdminScopePermission `json:"permissions,omitempty"`
+}
+
+// AdminPermissionTarget defines the object which admin permissions are enabled.
+type AdminPermissionTarget struct {
+	// Type is a type of the target.
+	// users target enables permissions of all realm users.
+	// +kubebuilder:validation:Enum=group;client;users;identityProvider
+	Type string `json:"type"`
+
+	// Name is a group name, clientId or identity provider alias. Not used for users target.
+	// Subgroup can be referenced by path, e.g. /parent/child.
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+// AdminPermissionPolicy defines the policy of the realm-management client.
+type AdminPermissionPolicy struct {
+	// Name is a unique name of the policy in realm-management client.
+	Name string `json:"name"`
+
+	// Type is a type of the policy.
+	// +kubebuilder:validation:Enum=user;group;role
+	Type string `json:"type"`
+
+	// Description is a description of the policy.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Logic is a logic of the policy. NEGATIVE logic inverts the policy decision.
+	// +kubebuilder:validation:Enum=POSITIVE;NEGATIVE
+	// +kubebuilder:default=POSITIVE
+	// +optional
+	Logic string `json:"logic,omitempty"`
+
+	// Users are the usernames of user policy.
+	// +nullable
+	// +optional
+	Users []string `json:"users,omitempty"`
+
+	// Groups are the groups of group policy.
+	// +nullable
+	// +optional
+	Groups []AdminPermissionPolicyGroup `json:"groups,omitempty"`
+
+	// Roles are the roles of role policy.
+	// +nullable
+	// +optional
+	Roles []AdminPermissionPolicyRole `json:"roles,omitempty"`
+}
+
+// AdminPermissionPolicyGroup defines the group of the group policy.
+type AdminPermissionPolicyGroup struct {
+	// Name is a group name or a group path, e.g. /parent/child.
+	Name string `json:"name"`
+
+	// ExtendChildren grants access to the members of the child groups.
+	// +optional
+	ExtendChildren bool `json:"extendChildren,omitempty"`
+}
+
+// AdminPermissionPolicyRole defines the role of the role policy.
+type AdminPermissionPolicyRole struct {
+	// Name is a role name.
+	Name string `json:"name"`
+
+	// ClientID is a clientId of the client role. Realm role is used if it is not specified.
+	// +optional
+	ClientID string `json:"clientId,omitempty"`
+
+	// Required requires the user to have the role.
+	// +optional
+	Required bool `json:"required,omitempty"`
+}
+
+// AdminScopePermission defines the binding of the target scope to the policies.
+type AdminScopePermission struct {
+	// Scope is a scope of the target, e.g. view, manage, manage-members or token-exchange.
+	Scope string `json:"scope"`
+
+	// Policies are the names of the policies from the spec or existing policies of realm-management client.
+	// Empty list removes all policies from the scope.
+	// +nullable
+	// +optional
+	Policies []string `json:"policies,omitempty"`
+
+	// DecisionStrategy is a decision strategy of the permission.
+	// +kubebuilder:validation:Enum=UNANIMOUS;AFFIRMATIVE;CONSENSUS
+	// +kubebuilder:default=UNANIMOUS
+	// +optional
+	DecisionStrategy string `json:"decisionStrategy,omitempty"`
+}
+
+// KeycloakAdminPermissionStatus defines the observed state of KeycloakAdminPermission.
+type KeycloakAdminPermissionStatus struct {
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// +optional
+	FailureCount int64 `json:"failureCount,omitempty"`
+
+	// Policies are the names of the policies created by the resource.
+	// +nullable
+	// +optional
+	Policies []string `json:"policies,omitempty"`
+
+	// ScopePermissions is a map of the target scope to the ID of the scope permission.
+	// +nullable
+	// +optional
+	ScopePermissions map[string]string `json:"scopePermissions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.value",description="Reconcilation status"
+// +kubebuilder:printcolumn:name="Target",type="string",JSONPath=".spec.target.type",description="Target type"
+
+// KeycloakAdminPermission is the Schema for the keycloak fine-grained admin permissions API.
+type KeycloakAdminPermission struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KeycloakAdminPermissionSpec   `json:"spec,omitempty"`
+	Status KeycloakAdminPermissionStatus `json:"status,omitempty"`
+}
+
+func (in *KeycloakAdminPermission) GetFailureCount() int64 {
+	return in.Status.FailureCount
+}
+
+func (in *KeycloakAdminPermission) SetFailureCount(count int64) {
+	in.Status.FailureCount = count
+}
+
+func (in *KeycloakAdminPermission) GetStatus() string {
+	return in.Status.Value
+}
+
+func (in *KeycloakAdminPermission) SetStatus(value string) {
+	in.Status.Value = value
+}
+
+func (in *KeycloakAdminPermission) GetRealmRef() common.RealmRef {
+	return in.Spec.RealmRef
+}
+
+// +kubebuilder:object:root=true
+
+// KeycloakAdminPermissionList contains a list of KeycloakAdminPermission.
+type KeycloakAdminPermissionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []KeycloakAdminPermission `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KeycloakAdminPermission{}, &KeycloakAdminPermissionList{})
+}
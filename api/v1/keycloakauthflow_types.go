@@ -45,6 +45,75 @@ type KeycloakAuthFlowSpec struct {
 	// ChildType is type for auth flow if it has a parent, available options: basic-flow, form-flow
 	// +optional
 	ChildType string `json:"childType,omitempty"`
+
+	// SubFlows is list of nested sub-flows which are managed within this auth flow.
+	// Sub-flow is placed into the flow referenced by its parent field according to its priority.
+	// Sub-flows which are not in the list are removed from the flow.
+	// +nullable
+	// +optional
+	SubFlows []AuthenticationSubFlow `json:"subFlows,omitempty"`
+}
+
+// AuthenticationSubFlow defines keycloak authentication sub-flow.
+type AuthenticationSubFlow struct {
+	// Alias is display name for sub-flow. It must be unique within the realm.
+	Alias string `json:"alias"`
+
+	// Description is description for sub-flow.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Parent is alias of the parent flow. It should be either alias of this auth flow or alias of another sub-flow.
+	// If empty, sub-flow is placed into this auth flow.
+	// +optional
+	Parent string `json:"parent,omitempty"`
+
+	// Type is type of sub-flow.
+	// +kubebuilder:validation:Enum=basic-flow;form-flow
+	// +kubebuilder:default=basic-flow
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Provider is form provider for sub-flow with form-flow type.
+	// +kubebuilder:default=registration-page-form
+	// +optional
+	Provider string `json:"provider,omitempty"`
+
+	// Priority is priority for this sub-flow in the parent flow. Lower values have higher priority.
+	// +optional
+	Priority int `json:"priority,omitempty"`
+
+	// Requirement is requirement for this sub-flow.
+	// Conditional sub-flow is executed only if all its condition executions are evaluated to true.
+	// +kubebuilder:validation:Enum=REQUIRED;ALTERNATIVE;DISABLED;CONDITIONAL
+	// +optional
+	Requirement string `json:"requirement,omitempty"`
+
+	// LevelOfAuthentication is step-up authentication settings for this sub-flow.
+	// If set, conditional-level-of-authentication condition is added as the first execution of the sub-flow.
+	// Sub-flow requirement should be CONDITIONAL in this case.
+	// +nullable
+	// +optional
+	LevelOfAuthentication *LevelOfAuthentication `json:"levelOfAuthentication,omitempty"`
+
+	// AuthenticationExecutions is list of authentication executions for this sub-flow.
+	// Use conditional-user-role, conditional-user-configured and other condition authenticators
+	// together with CONDITIONAL requirement of the sub-flow.
+	// +nullable
+	// +optional
+	AuthenticationExecutions []AuthenticationExecution `json:"authenticationExecutions,omitempty"`
+}
+
+// LevelOfAuthentication defines step-up authentication settings for sub-flow.
+type LevelOfAuthentication struct {
+	// Level is level of authentication which is provided by the sub-flow.
+	// +kubebuilder:validation:Minimum=0
+	Level int `json:"level"`
+
+	// MaxAge is time in seconds for which the level of authentication is valid.
+	// If 0, user is always required to re-authenticate with this level.
+	// +optional
+	MaxAge *int `json:"maxAge,omitempty"`
 }
 
 // AuthenticationExecution defines keycloak authentication execution.
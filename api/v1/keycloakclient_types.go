@@ -94,10 +94,36 @@ type KeycloakClientSpec struct {
 	ReconciliationStrategy string `json:"reconciliationStrategy,omitempty"`
 
 	// DefaultClientScopes is a list of default client scopes assigned to client.
+	// If the reconciliation strategy is full, default client scopes which are not in the list are removed from the client,
+	// except Keycloak built-in client scopes like profile, email, roles and web-origins.
+	// If DefaultClientScopes and DefaultClientScopeRefs are empty, default client scopes of the client are not changed.
 	// +nullable
 	// +optional
 	DefaultClientScopes []string `json:"defaultClientScopes,omitempty"`
 
+	// DefaultClientScopeRefs is a list of KeycloakClientScope custom resources names in the same namespace
+	// which are assigned to client as default client scopes.
+	// The client is not reconciled until all referenced client scopes are created.
+	// +nullable
+	// +optional
+	DefaultClientScopeRefs []string `json:"defaultClientScopeRefs,omitempty"`
+
+	// OptionalClientScopes is a list of optional client scopes assigned to client.
+	// If the reconciliation strategy is full, optional client scopes which are not in the list are removed from the client,
+	// except Keycloak built-in client scopes like address, phone and offline_access.
+	// If OptionalClientScopes and OptionalClientScopeRefs are empty, optional client scopes of the client are not changed.
+	// Client scope which is assigned as default is removed from optional client scopes and vice versa.
+	// +nullable
+	// +optional
+	OptionalClientScopes []string `json:"optionalClientScopes,omitempty"`
+
+	// OptionalClientScopeRefs is a list of KeycloakClientScope custom resources names in the same namespace
+	// which are assigned to client as optional client scopes.
+	// The client is not reconciled until all referenced client scopes are created.
+	// +nullable
+	// +optional
+	OptionalClientScopeRefs []string `json:"optionalClientScopeRefs,omitempty"`
+
 	// RedirectUris is a list of valid URI pattern a browser can redirect to after a successful login.
 	// Simple wildcards are allowed such as 'https://example.com/*'.
 	// Relative path can be specified too, such as /my/relative/path/*. Relative paths are relative to the client root URL.
@@ -146,11 +172,23 @@ type KeycloakClientSpec struct {
 	// +kubebuilder:default=true
 	Enabled bool `json:"enabled,omitempty"`
 
+	// RevokeSessionsOnDisable is a flag to revoke all client sessions and tokens
+	// when the client is disabled or deleted from Keycloak.
+	// +optional
+	RevokeSessionsOnDisable bool `json:"revokeSessionsOnDisable,omitempty"`
+
 	// FullScopeAllowed is a flag to enable full scope.
 	// +optional
 	// +kubebuilder:default=true
 	FullScopeAllowed bool `json:"fullScopeAllowed,omitempty"`
 
+	// ScopeMappings is a set of realm and client roles that client tokens may contain
+	// when FullScopeAllowed is disabled.
+	// If not set, scope mappings of the client are not changed.
+	// +nullable
+	// +optional
+	ScopeMappings *ScopeMappings `json:"scopeMappings,omitempty"`
+
 	// Name is a client name.
 	// +optional
 	Name string `json:"name,omitempty"`
@@ -185,11 +223,34 @@ type ServiceAccount struct {
 	Attributes map[string]string `json:"attributes,omitempty"`
 }
 
+type ScopeMappings struct {
+	// RealmRoles is a list of realm roles names.
+	// +nullable
+	// +optional
+	RealmRoles []string `json:"realmRoles,omitempty"`
+
+	// ClientRoles is a list of client roles grouped by client ID.
+	// +nullable
+	// +optional
+	ClientRoles []ClientRole `json:"clientRoles,omitempty"`
+}
+
+// GetClientRoles returns client roles of scope mappings as a map with client ID keys.
+func (in *ScopeMappings) GetClientRoles() map[string][]string {
+	clientRoles := make(map[string][]string, len(in.ClientRoles))
+
+	for _, r := range in.ClientRoles {
+		clientRoles[r.ClientID] = append(clientRoles[r.ClientID], r.Roles...)
+	}
+
+	return clientRoles
+}
+
 type ClientRole struct {
 	// ClientID is a client ID.
 	ClientID string `json:"clientId"`
 
-	// Roles is a list of client roles names assigned to service account.
+	// Roles is a list of client roles names.
 	// +nullable
 	// +optional
 	Roles []string `json:"roles,omitempty"`
@@ -233,6 +294,14 @@ type KeycloakClientStatus struct {
 
 	// +optional
 	FailureCount int64 `json:"failureCount,omitempty"`
+
+	// SessionsRevoked is true if the sessions of the disabled client are revoked.
+	// +optional
+	SessionsRevoked bool `json:"sessionsRevoked,omitempty"`
+
+	// SecretHashes are hashes of the last applied secret values keyed by secret reference in format 'secretName:secretKey'.
+	// +optional
+	SecretHashes map[string]string `json:"secretHashes,omitempty"`
 }
 
 // +kubebuilder:object:root=true
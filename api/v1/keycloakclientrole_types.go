@@ -0,0 +1,104 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KeycloakClientRoleSpec defines the desired state of KeycloakClientRole.
+type KeycloakClientRoleSpec struct {
+	// Name of keycloak client role.
+	Name string `json:"name"`
+
+	// ClientRef is a reference to KeycloakClient custom resource in the same namespace.
+	ClientRef ClientRef `json:"clientRef"`
+
+	// Description is a role description.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Attributes is a map of role attributes.
+	// +nullable
+	// +optional
+	Attributes map[string][]string `json:"attributes,omitempty"`
+
+	// Composites is a list of realm roles assigned to role as composites.
+	// +nullable
+	// +optional
+	Composites []Composite `json:"composites,omitempty"`
+
+	// CompositesClientRoles is a map of client id to the list of client roles assigned to role as composites.
+	// +nullable
+	// +optional
+	CompositesClientRoles map[string][]Composite `json:"compositesClientRoles,omitempty"`
+
+	// CompositesReconciliationStrategy is a strategy to reconcile role composites.
+	// full - composites which are not listed in composites and compositesClientRoles are removed from the role.
+	// addOnly - composites are only added to the role, so composites added by KeycloakClient or manually are kept.
+	// +kubebuilder:validation:Enum=full;addOnly
+	// +kubebuilder:default=addOnly
+	// +optional
+	CompositesReconciliationStrategy string `json:"compositesReconciliationStrategy,omitempty"`
+}
+
+// ClientRef is a reference to KeycloakClient custom resource.
+type ClientRef struct {
+	// Name is a name of KeycloakClient custom resource.
+	Name string `json:"name"`
+}
+
+// KeycloakClientRoleStatus defines the observed state of KeycloakClientRole.
+type KeycloakClientRoleStatus struct {
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// ID is a role ID.
+	// +optional
+	ID string `json:"id,omitempty"`
+
+	// +optional
+	FailureCount int64 `json:"failureCount,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.value",description="Reconcilation status"
+
+// KeycloakClientRole is the Schema for the keycloak client roles API.
+type KeycloakClientRole struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KeycloakClientRoleSpec   `json:"spec,omitempty"`
+	Status KeycloakClientRoleStatus `json:"status,omitempty"`
+}
+
+func (in *KeycloakClientRole) GetFailureCount() int64 {
+	return in.Status.FailureCount
+}
+
+func (in *KeycloakClientRole) SetFailureCount(count int64) {
+	in.Status.FailureCount = count
+}
+
+func (in *KeycloakClientRole) GetStatus() string {
+	return in.Status.Value
+}
+
+func (in *KeycloakClientRole) SetStatus(value string) {
+	in.Status.Value = value
+}
+
+// +kubebuilder:object:root=true
+
+// KeycloakClientRoleList contains a list of KeycloakClientRole.
+type KeycloakClientRoleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []KeycloakClientRole `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KeycloakClientRole{}, &KeycloakClientRoleList{})
+}
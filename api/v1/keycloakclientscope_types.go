@@ -6,6 +6,17 @@ import (
 	"github.com/epam/edp-keycloak-operator/api/common"
 )
 
+const (
+	// ClientScopeTypeDefault is a realm default client scope type.
+	ClientScopeTypeDefault = "default"
+
+	// ClientScopeTypeOptional is a realm optional client scope type.
+	ClientScopeTypeOptional = "optional"
+
+	// ClientScopeTypeNone is a type of client scope that is not assigned to realm.
+	ClientScopeTypeNone = "none"
+)
+
 // KeycloakClientScopeSpec defines the desired state of KeycloakClientScope.
 type KeycloakClientScopeSpec struct {
 	// Name of keycloak client scope.
@@ -32,14 +43,52 @@ type KeycloakClientScopeSpec struct {
 	// +optional
 	Attributes map[string]string `json:"attributes,omitempty"`
 
+	// Deprecated: use Type instead.
 	// Default is a flag to set client scope as default.
 	// +optional
 	Default bool `json:"default,omitempty"`
 
+	// Type is a realm assignment type of client scope.
+	// Default and optional client scopes are assigned to new clients of the realm automatically.
+	// If not set, the deprecated Default flag is used: the client scope is set as realm default if it is true,
+	// otherwise it is only removed from realm default client scopes.
+	// +kubebuilder:validation:Enum=default;optional;none
+	// +optional
+	Type string `json:"type,omitempty"`
+
 	// ProtocolMappers is a list of protocol mappers assigned to client scope.
 	// +nullable
 	// +optional
 	ProtocolMappers []ProtocolMapper `json:"protocolMappers,omitempty"`
+
+	// ScopeMappings is a set of realm and client roles that tokens of clients with this scope may contain.
+	// If not set, scope mappings of the client scope are not changed.
+	// +nullable
+	// +optional
+	ScopeMappings *ScopeMappings `json:"scopeMappings,omitempty"`
+
+	// ScopeMappingsReconciliationStrategy is a strategy to reconcile scope mappings of client scope.
+	// full - scope mappings which are not listed in scopeMappings are removed from the client scope.
+	// addOnly - scope mappings are only added to the client scope, so scope mappings added manually are kept.
+	// +kubebuilder:validation:Enum=full;addOnly
+	// +kubebuilder:default=full
+	// +optional
+	ScopeMappingsReconciliationStrategy string `json:"scopeMappingsReconciliationStrategy,omitempty"`
+}
+
+// GetType returns realm assignment type of client scope.
+// If Type is not set, it falls back to the deprecated Default flag:
+// default type if the flag is set, otherwise empty type, so the client scope is only removed from realm default client scopes.
+func (in *KeycloakClientScopeSpec) GetType() string {
+	if in.Type != "" {
+		return in.Type
+	}
+
+	if in.Default {
+		return ClientScopeTypeDefault
+	}
+
+	return ""
 }
 
 // KeycloakClientScopeStatus defines the observed state of KeycloakClientScope.
@@ -48,6 +48,15 @@ type KeycloakComponentStatus struct {
 
 	// +optional
 	FailureCount int64 `json:"failureCount,omitempty"`
+
+	// ID is an ID of the component in Keycloak.
+	// The component is looked up by ID, so Spec.Name can be changed without recreating the component.
+	// +optional
+	ID string `json:"id,omitempty"`
+
+	// SecretHashes are hashes of the last applied secret values keyed by secret reference in format 'secretName:secretKey'.
+	// +optional
+	SecretHashes map[string]string `json:"secretHashes,omitempty"`
 }
 
 // ParentComponent defines the parent component of KeycloakRealmComponent.
@@ -41,10 +41,19 @@ type KeycloakRealmSpec struct {
 	Users []User `json:"users,omitempty"`
 
 	// SSORealmMappers is a list of SSO realm mappers to create in the realm.
+	// Mappers are matched by name, mappers which are not in the list are removed only with the full reconciliation strategy.
 	// +nullable
 	// +optional
 	SSORealmMappers *[]SSORealmMapper `json:"ssoRealmMappers,omitempty"`
 
+	// SSORealmMappersReconciliationStrategy is a strategy to reconcile SSO realm mappers.
+	// full - mappers of the SSO identity provider which are not in the SSORealmMappers list are removed.
+	// addOnly - mappers are only created and updated.
+	// +kubebuilder:validation:Enum=full;addOnly
+	// +kubebuilder:default=addOnly
+	// +optional
+	SSORealmMappersReconciliationStrategy string `json:"ssoRealmMappersReconciliationStrategy,omitempty"`
+
 	// BrowserFlow specifies the authentication flow to use for the realm's browser clients.
 	// +nullable
 	// +optional
@@ -82,6 +91,19 @@ type KeycloakRealmSpec struct {
 	// FrontendURL Set the frontend URL for the realm. Use in combination with the default hostname provider to override the base URL for frontend requests for a specific realm.
 	// +optional
 	FrontendURL string `json:"frontendUrl,omitempty"`
+
+	// RevokeTokensBefore is the realm not-before time.
+	// Sessions and tokens issued before this time are revoked, the revocation is pushed to the clients with admin URL.
+	// +nullable
+	// +optional
+	RevokeTokensBefore *metav1.Time `json:"revokeTokensBefore,omitempty"`
+
+	// UserProfileConfig is the configuration of the declarative user profile in the realm.
+	// Attributes and groups are merged with the current profile by name,
+	// attributes and groups which are not in the spec are left unchanged.
+	// +nullable
+	// +optional
+	UserProfileConfig *UserProfileConfig `json:"userProfileConfig,omitempty"`
 }
 
 type User struct {
@@ -127,6 +149,141 @@ type RealmEventConfig struct {
 	EventsListeners []string `json:"eventsListeners,omitempty"`
 }
 
+// UserProfileConfig defines the declarative user profile of the realm.
+type UserProfileConfig struct {
+	// UnmanagedAttributePolicy are user attributes not explicitly defined in the user profile configuration.
+	// Empty value means that unmanaged attributes are disabled.
+	// Possible values:
+	// ENABLED - unmanaged attributes are allowed.
+	// ADMIN_VIEW - unmanaged attributes are read-only and only available through the administration console and API.
+	// ADMIN_EDIT - unmanaged attributes can be managed only through the administration console and API.
+	// +kubebuilder:validation:Enum=ENABLED;ADMIN_VIEW;ADMIN_EDIT
+	// +optional
+	UnmanagedAttributePolicy string `json:"unmanagedAttributePolicy,omitempty"`
+
+	// Attributes specify user profile attributes.
+	// +nullable
+	// +optional
+	Attributes []UserProfileAttribute `json:"attributes,omitempty"`
+
+	// Groups specify user profile groups.
+	// +nullable
+	// +optional
+	Groups []UserProfileGroup `json:"groups,omitempty"`
+}
+
+type UserProfileAttribute struct {
+	// Name of the user attribute, used to uniquely identify an attribute.
+	// +required
+	Name string `json:"name"`
+
+	// Display name for the attribute.
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+
+	// Group to which the attribute belongs.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Multivalued specifies if this attribute supports multiple values.
+	// +optional
+	Multivalued bool `json:"multivalued,omitempty"`
+
+	// Permissions specifies the permissions for the attribute.
+	// +nullable
+	// +optional
+	Permissions *UserProfileAttributePermissions `json:"permissions,omitempty"`
+
+	// Required indicates that the attribute must be set by users and administrators.
+	// +nullable
+	// +optional
+	Required *UserProfileAttributeRequired `json:"required,omitempty"`
+
+	// Selector specifies the scopes for which the attribute is available.
+	// +nullable
+	// +optional
+	Selector *UserProfileAttributeSelector `json:"selector,omitempty"`
+
+	// Annotations specifies the annotations for the attribute.
+	// +nullable
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Validations specifies the validations for the attribute.
+	// Key is a validator name, value is a map of validator parameters.
+	// +nullable
+	// +optional
+	Validations map[string]map[string]UserProfileAttributeValidation `json:"validations,omitempty"`
+}
+
+type UserProfileAttributePermissions struct {
+	// Edit specifies who can edit the attribute.
+	// +nullable
+	// +optional
+	Edit []string `json:"edit,omitempty"`
+
+	// View specifies who can view the attribute.
+	// +nullable
+	// +optional
+	View []string `json:"view,omitempty"`
+}
+
+type UserProfileAttributeRequired struct {
+	// Roles specifies the roles for which the attribute is required.
+	// +nullable
+	// +optional
+	Roles []string `json:"roles,omitempty"`
+
+	// Scopes specifies the scopes when the attribute is required.
+	// +nullable
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+type UserProfileAttributeSelector struct {
+	// Scopes specifies the scopes for which the attribute is available.
+	// +nullable
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// UserProfileAttributeValidation is a value of the validator parameter.
+// Only one of the fields should be set.
+type UserProfileAttributeValidation struct {
+	// +optional
+	StringVal string `json:"stringVal,omitempty"`
+
+	// +nullable
+	// +optional
+	MapVal map[string]string `json:"mapVal,omitempty"`
+
+	// +optional
+	IntVal int `json:"intVal,omitempty"`
+
+	// +nullable
+	// +optional
+	SliceVal []string `json:"sliceVal,omitempty"`
+}
+
+type UserProfileGroup struct {
+	// Name is unique name of the group.
+	// +required
+	Name string `json:"name"`
+
+	// DisplayHeader specifies a user-friendly name for the group that should be used when rendering a group of attributes in user-facing forms.
+	// +optional
+	DisplayHeader string `json:"displayHeader,omitempty"`
+
+	// DisplayDescription specifies a user-friendly description of the group that should be used when rendering a group of attributes in user-facing forms.
+	// +optional
+	DisplayDescription string `json:"displayDescription,omitempty"`
+
+	// Annotations specifies the annotations for the group.
+	// +nullable
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
 type RealmThemes struct {
 	// LoginTheme specifies the login theme to use for the realm.
 	// +nullable
@@ -162,6 +319,14 @@ func (in *KeycloakRealmSpec) SSOAutoRedirectEnabled() bool {
 	return in.SsoAutoRedirectEnabled == nil || *in.SsoAutoRedirectEnabled
 }
 
+func (in *KeycloakRealm) GetSSORealmMappersReconciliationStrategy() string {
+	if in.Spec.SSORealmMappersReconciliationStrategy == "" {
+		return ReconciliationStrategyAddOnly
+	}
+
+	return in.Spec.SSORealmMappersReconciliationStrategy
+}
+
 func (in *KeycloakRealm) GetKeycloakRef() common.KeycloakRef {
 	return in.Spec.KeycloakRef
 }
@@ -179,6 +344,11 @@ type SSORealmMapper struct {
 	// +nullable
 	// +optional
 	Config map[string]string `json:"config,omitempty"`
+
+	// SyncMode is a sync mode of the SSO realm mapper. If not set, the identity provider default sync mode is used.
+	// +kubebuilder:validation:Enum=IMPORT;LEGACY;FORCE
+	// +optional
+	SyncMode string `json:"syncMode,omitempty"`
 }
 
 // KeycloakRealmStatus defines the observed state of KeycloakRealm.
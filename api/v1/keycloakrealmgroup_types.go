@@ -39,15 +39,57 @@ type KeycloakRealmGroupSpec struct {
 	// +optional
 	RealmRoles []string `json:"realmRoles,omitempty"`
 
+	// Deprecated: use ParentGroupRef in the subgroups instead.
 	// SubGroups is a list of subgroups assigned to group.
 	// +nullable
 	// +optional
 	SubGroups []string `json:"subGroups,omitempty"`
 
+	// ParentGroupRef is a reference to the parent KeycloakRealmGroup custom resource in the same namespace.
+	// If it is set, the group is placed under the parent group.
+	// If it is removed, the group is moved to the top level. Groups nested manually in Keycloak are not moved.
+	// +optional
+	ParentGroupRef *GroupRef `json:"parentGroupRef,omitempty"`
+
 	// ClientRoles is a list of client roles assigned to group.
 	// +nullable
 	// +optional
 	ClientRoles []ClientRole `json:"clientRoles,omitempty"`
+
+	// Members is a list of group members.
+	// It allows managing membership from the group side, e.g. for users federated from LDAP or identity provider
+	// which are not managed by KeycloakRealmUser. Membership is not managed if the list is empty.
+	// +nullable
+	// +optional
+	Members []GroupMember `json:"members,omitempty"`
+
+	// MembersReconciliationStrategy is a strategy to reconcile group members.
+	// full - users which are not matched by members are removed from the group.
+	// addOnly - users are only added to the group, so membership can be also managed by KeycloakRealmUser groups.
+	// +kubebuilder:validation:Enum=full;addOnly
+	// +kubebuilder:default=addOnly
+	// +optional
+	MembersReconciliationStrategy string `json:"membersReconciliationStrategy,omitempty"`
+}
+
+// GroupMember selects users which are members of the group.
+// Either username or attributeSelector should be set.
+type GroupMember struct {
+	// Username is a name of the user.
+	// +optional
+	Username string `json:"username,omitempty"`
+
+	// AttributeSelector selects all users which have the given attribute values.
+	// Attribute names and values can't contain ':' and space characters.
+	// +nullable
+	// +optional
+	AttributeSelector map[string]string `json:"attributeSelector,omitempty"`
+}
+
+// GroupRef is a reference to KeycloakRealmGroup custom resource.
+type GroupRef struct {
+	// Name is a name of KeycloakRealmGroup custom resource.
+	Name string `json:"name"`
 }
 
 // KeycloakRealmGroupStatus defines the observed state of KeycloakRealmGroup.
@@ -59,6 +101,19 @@ type KeycloakRealmGroupStatus struct {
 	// +optional
 	ID string `json:"id,omitempty"`
 
+	// Path is a full path of the group.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// ParentID is an ID of the parent group under which the group was placed by the operator.
+	// It is used to move the group to the top level when ParentGroupRef is removed.
+	// +optional
+	ParentID string `json:"parentId,omitempty"`
+
+	// MissingMembers is a list of usernames from members which are not found in keycloak.
+	// +optional
+	MissingMembers []string `json:"missingMembers,omitempty"`
+
 	// +optional
 	FailureCount int64 `json:"failureCount,omitempty"`
 }
@@ -79,6 +134,14 @@ func (in *KeycloakRealmGroup) SetStatus(value string) {
 	in.Status.Value = value
 }
 
+func (in *KeycloakRealmGroup) GetMembersReconciliationStrategy() string {
+	if in.Spec.MembersReconciliationStrategy == "" {
+		return ReconciliationStrategyAddOnly
+	}
+
+	return in.Spec.MembersReconciliationStrategy
+}
+
 func (in *KeycloakRealmGroup) GetRealmRef() common.RealmRef {
 	return in.Spec.RealmRef
 }
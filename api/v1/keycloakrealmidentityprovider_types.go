@@ -26,8 +26,21 @@ type KeycloakRealmIdentityProviderSpec struct {
 	// Config is a map of identity provider configuration.
 	// Map key is a name of configuration property, map value is a value of configuration property.
 	// Any value can be a reference to k8s secret, in this case value should be in format $secretName:secretKey.
+	// Values from Config take precedence over the values generated from OIDC and SAML sections.
 	// +kubebuilder:example={"clientId": "provider-client", "clientSecret": "$clientSecret:secretKey"}
-	Config map[string]string `json:"config"`
+	// +nullable
+	// +optional
+	Config map[string]string `json:"config,omitempty"`
+
+	// OIDC is a typed OpenID Connect identity provider configuration.
+	// It can be used with oidc and keycloak-oidc providers instead of Config.
+	// +optional
+	OIDC *IdentityProviderOIDC `json:"oidc,omitempty"`
+
+	// SAML is a typed SAML identity provider configuration.
+	// It can be used with saml provider instead of Config.
+	// +optional
+	SAML *IdentityProviderSAML `json:"saml,omitempty"`
 
 	// Enabled is a flag to enable/disable identity provider.
 	Enabled bool `json:"enabled"`
@@ -61,9 +74,152 @@ type KeycloakRealmIdentityProviderSpec struct {
 	TrustEmail bool `json:"trustEmail,omitempty"`
 
 	// Mappers is a list of identity provider mappers.
+	// Mappers are matched by name. If the list is empty, mappers of the identity provider are not managed.
 	// +nullable
 	// +optional
 	Mappers []IdentityProviderMapper `json:"mappers,omitempty"`
+
+	// MappersReconciliationStrategy is a strategy to reconcile identity provider mappers.
+	// full - mappers which are not in the Mappers list are removed from the identity provider.
+	// addOnly - mappers are only created and updated.
+	// +kubebuilder:validation:Enum=full;addOnly
+	// +optional
+	MappersReconciliationStrategy string `json:"mappersReconciliationStrategy,omitempty"`
+}
+
+// IdentityProviderOIDC defines OpenID Connect identity provider configuration.
+type IdentityProviderOIDC struct {
+	// DiscoveryURL is a URL of OpenID Provider well-known configuration.
+	// If set, endpoints are imported from the discovery document on each reconciliation.
+	// Endpoints set explicitly take precedence over the imported ones.
+	// +kubebuilder:example="https://accounts.example.com/.well-known/openid-configuration"
+	// +optional
+	DiscoveryURL string `json:"discoveryUrl,omitempty"`
+
+	// ClientID is a client ID registered at the identity provider.
+	// +kubebuilder:validation:MinLength=1
+	ClientID string `json:"clientId"`
+
+	// ClientSecret is a client secret registered at the identity provider.
+	// Value should be a reference to k8s secret in format $secretName:secretKey.
+	// +kubebuilder:example="$clientSecret:secretKey"
+	// +optional
+	ClientSecret string `json:"clientSecret,omitempty"`
+
+	// ClientAuthMethod is a client authentication method.
+	// +kubebuilder:validation:Enum=client_secret_post;client_secret_basic;client_secret_jwt;private_key_jwt
+	// +optional
+	ClientAuthMethod string `json:"clientAuthMethod,omitempty"`
+
+	// DefaultScopes is a list of scopes sent when asking for authorization.
+	// +nullable
+	// +optional
+	DefaultScopes []string `json:"defaultScopes,omitempty"`
+
+	// AuthorizationURL is an authorization endpoint URL.
+	// +optional
+	AuthorizationURL string `json:"authorizationUrl,omitempty"`
+
+	// TokenURL is a token endpoint URL.
+	// +optional
+	TokenURL string `json:"tokenUrl,omitempty"`
+
+	// UserInfoURL is a user info endpoint URL.
+	// +optional
+	UserInfoURL string `json:"userInfoUrl,omitempty"`
+
+	// LogoutURL is an end session endpoint URL.
+	// +optional
+	LogoutURL string `json:"logoutUrl,omitempty"`
+
+	// JwksURL is a URL of the identity provider JSON Web Key Set.
+	// +optional
+	JwksURL string `json:"jwksUrl,omitempty"`
+
+	// Issuer is an issuer identifier of the identity provider.
+	// +optional
+	Issuer string `json:"issuer,omitempty"`
+
+	// ValidateSignature is a flag to validate signatures of tokens issued by the identity provider.
+	// +optional
+	ValidateSignature *bool `json:"validateSignature,omitempty"`
+
+	// PKCEMethod is a PKCE code challenge method. PKCE is enabled if the method is set.
+	// +kubebuilder:validation:Enum=plain;S256
+	// +optional
+	PKCEMethod string `json:"pkceMethod,omitempty"`
+
+	// SyncMode is a default sync mode for all mappers of the identity provider.
+	// +kubebuilder:validation:Enum=IMPORT;LEGACY;FORCE
+	// +optional
+	SyncMode string `json:"syncMode,omitempty"`
+}
+
+// IdentityProviderSAML defines SAML identity provider configuration.
+type IdentityProviderSAML struct {
+	// MetadataURL is a URL of the identity provider SAML metadata.
+	// Metadata is imported on each reconciliation and refreshed every MetadataRefreshInterval.
+	// Values set explicitly take precedence over the imported ones.
+	// +optional
+	MetadataURL string `json:"metadataUrl,omitempty"`
+
+	// MetadataConfigMapRef is a reference to ConfigMap key with the identity provider SAML metadata.
+	// It can't be used together with MetadataURL.
+	// +optional
+	MetadataConfigMapRef *ConfigMapKeyRef `json:"metadataConfigMapRef,omitempty"`
+
+	// MetadataRefreshInterval is an interval of the identity provider metadata refresh.
+	// It allows picking up rotation of the identity provider signing certificates. Defaults to 1h.
+	// +optional
+	MetadataRefreshInterval *metav1.Duration `json:"metadataRefreshInterval,omitempty"`
+
+	// EntityID is a service provider entity ID of the realm that is sent to the identity provider.
+	// +optional
+	EntityID string `json:"entityId,omitempty"`
+
+	// SingleSignOnServiceURL is a URL that must be used to send authentication requests.
+	// +optional
+	SingleSignOnServiceURL string `json:"singleSignOnServiceUrl,omitempty"`
+
+	// SingleLogoutServiceURL is a URL that must be used to send logout requests.
+	// +optional
+	SingleLogoutServiceURL string `json:"singleLogoutServiceUrl,omitempty"`
+
+	// NameIDPolicyFormat is a URI reference corresponding to a name identifier format.
+	// +kubebuilder:example="urn:oasis:names:tc:SAML:2.0:nameid-format:persistent"
+	// +optional
+	NameIDPolicyFormat string `json:"nameIDPolicyFormat,omitempty"`
+
+	// PrincipalType is a way to identify and track external users from the assertion.
+	// +kubebuilder:validation:Enum=SUBJECT;ATTRIBUTE;FRIENDLY_ATTRIBUTE
+	// +optional
+	PrincipalType string `json:"principalType,omitempty"`
+
+	// PrincipalAttribute is a name or friendly name of the attribute used to identify external users.
+	// +optional
+	PrincipalAttribute string `json:"principalAttribute,omitempty"`
+
+	// WantAuthnRequestsSigned is a flag to sign authentication requests.
+	// +optional
+	WantAuthnRequestsSigned *bool `json:"wantAuthnRequestsSigned,omitempty"`
+
+	// ValidateSignature is a flag to validate signatures of SAML responses.
+	// +optional
+	ValidateSignature *bool `json:"validateSignature,omitempty"`
+
+	// SyncMode is a default sync mode for all mappers of the identity provider.
+	// +kubebuilder:validation:Enum=IMPORT;LEGACY;FORCE
+	// +optional
+	SyncMode string `json:"syncMode,omitempty"`
+}
+
+// ConfigMapKeyRef is a reference to a key of ConfigMap.
+type ConfigMapKeyRef struct {
+	// Name is the name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Key is the key in the ConfigMap.
+	Key string `json:"key"`
 }
 
 type IdentityProviderMapper struct {
@@ -83,6 +239,11 @@ type IdentityProviderMapper struct {
 	// +nullable
 	// +optional
 	Config map[string]string `json:"config,omitempty"`
+
+	// SyncMode is a sync mode of the mapper. If not set, the identity provider default sync mode is used.
+	// +kubebuilder:validation:Enum=IMPORT;LEGACY;FORCE
+	// +optional
+	SyncMode string `json:"syncMode,omitempty"`
 }
 
 // KeycloakRealmIdentityProviderStatus defines the observed state of KeycloakRealmIdentityProvider.
@@ -92,6 +253,10 @@ type KeycloakRealmIdentityProviderStatus struct {
 
 	// +optional
 	FailureCount int64 `json:"failureCount,omitempty"`
+
+	// SecretHashes are hashes of the last applied secret values keyed by secret reference in format 'secretName:secretKey'.
+	// +optional
+	SecretHashes map[string]string `json:"secretHashes,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -124,6 +289,14 @@ func (in *KeycloakRealmIdentityProvider) SetStatus(value string) {
 	in.Status.Value = value
 }
 
+func (in *KeycloakRealmIdentityProvider) GetMappersReconciliationStrategy() string {
+	if in.Spec.MappersReconciliationStrategy == "" {
+		return ReconciliationStrategyFull
+	}
+
+	return in.Spec.MappersReconciliationStrategy
+}
+
 func (in *KeycloakRealmIdentityProvider) GetRealmRef() common.RealmRef {
 	return in.Spec.RealmRef
 }
@@ -0,0 +1,224 @@
+package v1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/epam/edp-keycloak-operator/api/common"
+)
+
+const (
+	// KeyProviderRSAGenerated is a provider of the generated RSA signing keys.
+	KeyProviderRSAGenerated = "rsa-generated"
+
+	// KeyProviderRSAEncGenerated is a provider of the generated RSA encryption keys.
+	KeyProviderRSAEncGenerated = "rsa-enc-generated"
+
+	// KeyProviderECDSAGenerated is a provider of the generated ECDSA signing keys.
+	KeyProviderECDSAGenerated = "ecdsa-generated"
+
+	// KeyProviderHMACGenerated is a provider of the generated HMAC signing secrets.
+	KeyProviderHMACGenerated = "hmac-generated"
+
+	// KeyProviderAESGenerated is a provider of the generated AES encryption secrets.
+	KeyProviderAESGenerated = "aes-generated"
+
+	// KeyProviderRSA is a provider of the RSA keys imported from PEM.
+	KeyProviderRSA = "rsa"
+
+	// KeyStateActive is a state of the key which is used for signing or encryption.
+	KeyStateActive = "active"
+
+	// KeyStatePassive is a state of the key which is used only for verification or decryption.
+	KeyStatePassive = "passive"
+
+	defaultKeyPriority        = 100
+	defaultKeyGracePeriod     = 24 * time.Hour
+	defaultKeyRetentionPeriod = 7 * 24 * time.Hour
+)
+
+// KeycloakRealmKeyRotationSpec defines the desired state of KeycloakRealmKeyRotation.
+type KeycloakRealmKeyRotationSpec struct {
+	// Name is a prefix of the key provider component names in Keycloak.
+	// Each key gets a name in format <name>-<creation unix time>.
+	Name string `json:"name"`
+
+	// RealmRef is reference to Realm custom resource.
+	RealmRef common.RealmRef `json:"realmRef"`
+
+	// ProviderID is a key provider.
+	// rsa provider imports the key and certificate from the kubernetes.io/tls Secret, e.g. issued by cert-manager.
+	// +kubebuilder:validation:Enum=rsa-generated;rsa-enc-generated;ecdsa-generated;hmac-generated;aes-generated;rsa
+	ProviderID string `json:"providerId"`
+
+	// Algorithm is an algorithm of the key, e.g. RS256, ES256, HS256 or RSA-OAEP.
+	// If not specified, Keycloak default for the provider is used.
+	// +optional
+	Algorithm string `json:"algorithm,omitempty"`
+
+	// KeySize is a size of the generated key.
+	// It is a key size in bits for rsa-generated and rsa-enc-generated providers
+	// and a secret size in bytes for hmac-generated and aes-generated providers.
+	// +optional
+	KeySize int `json:"keySize,omitempty"`
+
+	// EllipticCurve is an elliptic curve of the ecdsa-generated key.
+	// +kubebuilder:validation:Enum=P-256;P-384;P-521
+	// +optional
+	EllipticCurve string `json:"ellipticCurve,omitempty"`
+
+	// Priority is a priority of the first key. Each rotated key gets higher priority than the previous one.
+	// +kubebuilder:default=100
+	// +optional
+	Priority int `json:"priority,omitempty"`
+
+	// RotationPeriod is a period after which a new key is created.
+	// If not specified, a new key is created only when the key configuration or the imported Secret is changed.
+	// +optional
+	RotationPeriod *metav1.Duration `json:"rotationPeriod,omitempty"`
+
+	// GracePeriod is a period after the rotation during which the previous key stays active.
+	// After it, the previous key becomes passive.
+	// +kubebuilder:default="24h"
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+
+	// RetentionPeriod is a period during which the passive key is kept for verification of issued tokens.
+	// After it, the key is deleted.
+	// +kubebuilder:default="168h"
+	// +optional
+	RetentionPeriod *metav1.Duration `json:"retentionPeriod,omitempty"`
+
+	// SecretRef is a reference to the kubernetes.io/tls Secret with the key and certificate.
+	// Required for rsa provider. Renewal of the Secret triggers the key rotation.
+	// +nullable
+	// +optional
+	SecretRef *TLSSecretRef `json:"secretRef,omitempty"`
+}
+
+// TLSSecretRef is a reference to the kubernetes.io/tls Secret.
+type TLSSecretRef struct {
+	// Name is a name of the Secret. The Secret should contain tls.key and tls.crt keys.
+	Name string `json:"name"`
+}
+
+// KeycloakRealmKeyRotationStatus defines the observed state of KeycloakRealmKeyRotation.
+type KeycloakRealmKeyRotationStatus struct {
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// +optional
+	FailureCount int64 `json:"failureCount,omitempty"`
+
+	// Keys are the key provider components managed by the rotation, the newest key is the last.
+	// +nullable
+	// +optional
+	Keys []RotatedKey `json:"keys,omitempty"`
+
+	// NextRotation is a time of the next scheduled rotation.
+	// +nullable
+	// +optional
+	NextRotation *metav1.Time `json:"nextRotation,omitempty"`
+}
+
+// RotatedKey defines the key provider component managed by the rotation.
+type RotatedKey struct {
+	// Name is a name of the key provider component.
+	Name string `json:"name"`
+
+	// ID is an ID of the key provider component.
+	// +optional
+	ID string `json:"id,omitempty"`
+
+	// Priority is a priority of the key.
+	Priority int `json:"priority"`
+
+	// State is a state of the key: active or passive.
+	State string `json:"state"`
+
+	// CreatedAt is a time of the key creation.
+	CreatedAt metav1.Time `json:"createdAt"`
+
+	// PassiveSince is a time when the key became passive.
+	// +nullable
+	// +optional
+	PassiveSince *metav1.Time `json:"passiveSince,omitempty"`
+
+	// ConfigHash is a hash of the key configuration. Change of the configuration triggers the key rotation.
+	// +optional
+	ConfigHash string `json:"configHash,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.value",description="Reconcilation status"
+// +kubebuilder:printcolumn:name="Next Rotation",type="string",JSONPath=".status.nextRotation",description="Next scheduled rotation"
+
+// KeycloakRealmKeyRotation is the Schema for the keycloak realm key rotation API.
+type KeycloakRealmKeyRotation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KeycloakRealmKeyRotationSpec   `json:"spec,omitempty"`
+	Status KeycloakRealmKeyRotationStatus `json:"status,omitempty"`
+}
+
+func (in *KeycloakRealmKeyRotation) GetPriority() int {
+	if in.Spec.Priority == 0 {
+		return defaultKeyPriority
+	}
+
+	return in.Spec.Priority
+}
+
+func (in *KeycloakRealmKeyRotation) GetGracePeriod() time.Duration {
+	if in.Spec.GracePeriod == nil {
+		return defaultKeyGracePeriod
+	}
+
+	return in.Spec.GracePeriod.Duration
+}
+
+func (in *KeycloakRealmKeyRotation) GetRetentionPeriod() time.Duration {
+	if in.Spec.RetentionPeriod == nil {
+		return defaultKeyRetentionPeriod
+	}
+
+	return in.Spec.RetentionPeriod.Duration
+}
+
+func (in *KeycloakRealmKeyRotation) GetFailureCount() int64 {
+	return in.Status.FailureCount
+}
+
+func (in *KeycloakRealmKeyRotation) SetFailureCount(count int64) {
+	in.Status.FailureCount = count
+}
+
+func (in *KeycloakRealmKeyRotation) GetStatus() string {
+	return in.Status.Value
+}
+
+func (in *KeycloakRealmKeyRotation) SetStatus(value string) {
+	in.Status.Value = value
+}
+
+func (in *KeycloakRealmKeyRotation) GetRealmRef() common.RealmRef {
+	return in.Spec.RealmRef
+}
+
+// +kubebuilder:object:root=true
+
+// KeycloakRealmKeyRotationList contains a list of KeycloakRealmKeyRotation.
+type KeycloakRealmKeyRotationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []KeycloakRealmKeyRotation `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KeycloakRealmKeyRotation{}, &KeycloakRealmKeyRotationList{})
+}
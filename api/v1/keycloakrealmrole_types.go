@@ -40,6 +40,19 @@ type KeycloakRealmRoleSpec struct {
 	// +optional
 	Composites []Composite `json:"composites,omitempty"`
 
+	// CompositesClientRoles is a map of client id to the list of client roles assigned to role as composites.
+	// +nullable
+	// +optional
+	CompositesClientRoles map[string][]Composite `json:"compositesClientRoles,omitempty"`
+
+	// CompositesReconciliationStrategy is a strategy to reconcile role composites.
+	// full - composites which are not listed in composites and compositesClientRoles are removed from the role.
+	// addOnly - composites are only added to the role, so composites added by KeycloakClient or manually are kept.
+	// +kubebuilder:validation:Enum=full;addOnly
+	// +kubebuilder:default=addOnly
+	// +optional
+	CompositesReconciliationStrategy string `json:"compositesReconciliationStrategy,omitempty"`
+
 	// IsDefault is a flag if role is default.
 	// +optional
 	IsDefault bool `json:"isDefault,omitempty"`
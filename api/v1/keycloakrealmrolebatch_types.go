@@ -21,8 +21,24 @@ type KeycloakRealmRoleBatchSpec struct {
 
 	// Roles is a list of roles to be created.
 	Roles []BatchRole `json:"roles"`
+
+	// DeletionPolicy defines what happens with child KeycloakRealmRole resources of roles removed from the roles list.
+	// delete - child resource is deleted together with the keycloak role.
+	// orphan - child resource is left in the cluster and is no longer managed by the batch.
+	// +kubebuilder:validation:Enum=delete;orphan
+	// +kubebuilder:default=delete
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
 }
 
+const (
+	// RoleBatchDeletionPolicyDelete deletes child roles removed from the batch.
+	RoleBatchDeletionPolicyDelete = "delete"
+
+	// RoleBatchDeletionPolicyOrphan leaves child roles removed from the batch in the cluster.
+	RoleBatchDeletionPolicyOrphan = "orphan"
+)
+
 type BatchRole struct {
 	// Name of keycloak role.
 	Name string `json:"name"`
@@ -45,6 +61,19 @@ type BatchRole struct {
 	// +optional
 	Composites []Composite `json:"composites,omitempty"`
 
+	// CompositesClientRoles is a map of client id to the list of client roles assigned to role as composites.
+	// +nullable
+	// +optional
+	CompositesClientRoles map[string][]Composite `json:"compositesClientRoles,omitempty"`
+
+	// CompositesReconciliationStrategy is a strategy to reconcile role composites.
+	// full - composites which are not listed in composites and compositesClientRoles are removed from the role.
+	// addOnly - composites are only added to the role, so composites added by KeycloakClient or manually are kept.
+	// +kubebuilder:validation:Enum=full;addOnly
+	// +kubebuilder:default=addOnly
+	// +optional
+	CompositesReconciliationStrategy string `json:"compositesReconciliationStrategy,omitempty"`
+
 	// IsDefault is a flag if role is default.
 	// +optional
 	IsDefault bool `json:"isDefault,omitempty"`
@@ -57,6 +86,28 @@ type KeycloakRealmRoleBatchStatus struct {
 
 	// +optional
 	FailureCount int64 `json:"failureCount,omitempty"`
+
+	// Roles contains status of each role from the batch.
+	// +nullable
+	// +optional
+	Roles []BatchRoleStatus `json:"roles,omitempty"`
+}
+
+// BatchRoleStatus defines the observed state of the role from the batch.
+type BatchRoleStatus struct {
+	// Name of keycloak role.
+	Name string `json:"name"`
+
+	// ResourceName is a name of child KeycloakRealmRole resource.
+	// +optional
+	ResourceName string `json:"resourceName,omitempty"`
+
+	// Ready is true if child KeycloakRealmRole resource is reconciled successfully.
+	Ready bool `json:"ready"`
+
+	// Message is an error message of the role reconciliation.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -89,6 +140,15 @@ func (in *KeycloakRealmRoleBatch) SetFailureCount(count int64) {
 	in.Status.FailureCount = count
 }
 
+// GetDeletionPolicy returns deletion policy of child roles removed from the batch.
+func (in *KeycloakRealmRoleBatch) GetDeletionPolicy() string {
+	if in.Spec.DeletionPolicy == "" {
+		return RoleBatchDeletionPolicyDelete
+	}
+
+	return in.Spec.DeletionPolicy
+}
+
 func (in *KeycloakRealmRoleBatch) FormattedRoleName(baseRoleName string) string {
 	return fmt.Sprintf("%s-%s", in.Name, baseRoleName)
 }
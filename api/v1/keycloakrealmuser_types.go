@@ -36,6 +36,13 @@ type KeycloakRealmUserSpec struct {
 	// +optional
 	Enabled bool `json:"enabled,omitempty"`
 
+	// RevokeSessionsOnDisable is a flag to revoke all user sessions and tokens
+	// when the user is disabled or deleted from Keycloak.
+	// If it is set, the enabled flag is also applied to the existing user,
+	// otherwise the enabled flag is set only when the user is created.
+	// +optional
+	RevokeSessionsOnDisable bool `json:"revokeSessionsOnDisable,omitempty"`
+
 	// EmailVerified is a user email verified flag.
 	// +optional
 	EmailVerified bool `json:"emailVerified,omitempty"`
@@ -50,16 +57,35 @@ type KeycloakRealmUserSpec struct {
 	// +optional
 	Roles []string `json:"roles,omitempty"`
 
+	// ClientRoles is a list of client roles assigned to user.
+	// If the list is empty, user client roles are not changed.
+	// +nullable
+	// +optional
+	ClientRoles []ClientRole `json:"clientRoles,omitempty"`
+
 	// Groups is a list of groups assigned to user.
 	// +nullable
 	// +optional
 	Groups []string `json:"groups,omitempty"`
 
+	// FederatedIdentities is a list of links to the external identity providers.
+	// If the list is empty, user federated identities are not changed.
+	// +nullable
+	// +optional
+	FederatedIdentities []FederatedIdentity `json:"federatedIdentities,omitempty"`
+
+	// Deprecated: use AttributesV2 instead.
 	// Attributes is a map of user attributes.
 	// +nullable
 	// +optional
 	Attributes map[string]string `json:"attributes,omitempty"`
 
+	// AttributesV2 is a map of user attributes with multiple values.
+	// If the same attribute is set in Attributes and AttributesV2, AttributesV2 value is used.
+	// +nullable
+	// +optional
+	AttributesV2 map[string][]string `json:"attributesV2,omitempty"`
+
 	// ReconciliationStrategy is a strategy for reconciliation. Possible values: full, create-only.
 	// Default value: full. If set to create-only, user will be created only if it does not exist. If user exists, it will not be updated.
 	// If set to full, user will be created if it does not exist, or updated if it exists.
@@ -75,11 +101,24 @@ type KeycloakRealmUserSpec struct {
 	KeepResource bool `json:"keepResource,omitempty"`
 
 	// PasswordSecret defines Kubernetes secret Name and Key, which holds User secret.
+	// The secret is watched by the operator, and the user password is reset when the secret value changes.
 	// +nullable
 	// +optional
 	PasswordSecret PasswordSecret `json:"passwordSecret,omitempty"`
 }
 
+// FederatedIdentity defines a link of the user to the external identity provider.
+type FederatedIdentity struct {
+	// IdentityProvider is an alias of the identity provider.
+	IdentityProvider string `json:"identityProvider"`
+
+	// UserID is a user id in the identity provider.
+	UserID string `json:"userId"`
+
+	// UserName is a username in the identity provider.
+	UserName string `json:"userName"`
+}
+
 // PasswordSecret defines struct which contains reference to secret name and key.
 type PasswordSecret struct {
 	// Name is the name of the secret.
@@ -87,6 +126,10 @@ type PasswordSecret struct {
 
 	// Key is the key in the secret.
 	Key string `json:"key"`
+
+	// Temporary indicates that the user must change the password on the next login.
+	// +optional
+	Temporary bool `json:"temporary,omitempty"`
 }
 
 // KeycloakRealmUserStatus defines the observed state of KeycloakRealmUser.
@@ -96,6 +139,15 @@ type KeycloakRealmUserStatus struct {
 
 	// +optional
 	FailureCount int64 `json:"failureCount,omitempty"`
+
+	// PasswordSecretHash is the hash of the last applied password from PasswordSecret.
+	// The password is reset only when the hash changes, so other changes of the secret are ignored.
+	// +optional
+	PasswordSecretHash string `json:"passwordSecretHash,omitempty"`
+
+	// SessionsRevoked is true if the sessions of the disabled user are revoked.
+	// +optional
+	SessionsRevoked bool `json:"sessionsRevoked,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -120,6 +172,30 @@ func (in *KeycloakRealmUser) GetReconciliationStrategy() string {
 	return in.Spec.ReconciliationStrategy
 }
 
+// HasPasswordSecret returns true if the user password is taken from the secret.
+func (in *KeycloakRealmUser) HasPasswordSecret() bool {
+	return in.Spec.PasswordSecret.Name != "" && in.Spec.PasswordSecret.Key != ""
+}
+
+// GetAttributes returns user attributes from Attributes and AttributesV2 fields.
+func (in *KeycloakRealmUser) GetAttributes() map[string][]string {
+	if len(in.Spec.Attributes) == 0 && len(in.Spec.AttributesV2) == 0 {
+		return nil
+	}
+
+	attrs := make(map[string][]string, len(in.Spec.Attributes)+len(in.Spec.AttributesV2))
+
+	for k, v := range in.Spec.Attributes {
+		attrs[k] = []string{v}
+	}
+
+	for k, v := range in.Spec.AttributesV2 {
+		attrs[k] = v
+	}
+
+	return attrs
+}
+
 func (in *KeycloakRealmUser) GetFailureCount() int64 {
 	return in.Status.FailureCount
 }
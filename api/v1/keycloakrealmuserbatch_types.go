@@ -0,0 +1,207 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/epam/edp-keycloak-operator/api/common"
+)
+
+const (
+	// UsersFormatCSV is a CSV format of users list.
+	UsersFormatCSV = "csv"
+
+	// UsersFormatJSON is a JSON format of users list.
+	UsersFormatJSON = "json"
+)
+
+// KeycloakRealmUserBatchSpec defines the desired state of KeycloakRealmUserBatch.
+type KeycloakRealmUserBatchSpec struct {
+	// RealmRef is reference to Realm custom resource.
+	RealmRef common.RealmRef `json:"realmRef"`
+
+	// Users is a list of users to be created.
+	// Users created by the batch are deleted from keycloak when they are removed from the batch
+	// or the batch is deleted. Users which existed before are only updated and are never deleted by the batch.
+	// +nullable
+	// +optional
+	Users []BatchUser `json:"users,omitempty"`
+
+	// UsersFrom is a reference to ConfigMap with the list of users.
+	// Users from ConfigMap are added to the users from the Users field.
+	// +nullable
+	// +optional
+	UsersFrom *UsersFromConfigMap `json:"usersFrom,omitempty"`
+
+	// PasswordSecretName is a name of the secret where generated initial passwords are stored.
+	// Secret keys are usernames with characters not allowed in secret keys replaced with "_".
+	// Users which have the same secret key are rejected.
+	// Initial password is set only when the user is created.
+	// Default value: <batch name>-passwords.
+	// +optional
+	PasswordSecretName string `json:"passwordSecretName,omitempty"`
+
+	// ReconciliationStrategy is a strategy to reconcile users.
+	// full - user roles and groups which are not in the spec are removed.
+	// addOnly - user roles and groups are only added.
+	// +kubebuilder:validation:Enum=full;addOnly
+	// +kubebuilder:default=full
+	// +optional
+	ReconciliationStrategy string `json:"reconciliationStrategy,omitempty"`
+}
+
+// BatchUser defines the user from the batch.
+type BatchUser struct {
+	// Username is a username in keycloak.
+	Username string `json:"username"`
+
+	// Email is a user email.
+	// +optional
+	Email string `json:"email,omitempty"`
+
+	// FirstName is a user first name.
+	// +optional
+	FirstName string `json:"firstName,omitempty"`
+
+	// LastName is a user last name.
+	// +optional
+	LastName string `json:"lastName,omitempty"`
+
+	// Enabled is a user enabled flag.
+	// +kubebuilder:default=true
+	// +optional
+	Enabled bool `json:"enabled"`
+
+	// EmailVerified is a user email verified flag.
+	// +optional
+	EmailVerified bool `json:"emailVerified,omitempty"`
+
+	// Roles is a list of realm roles assigned to user.
+	// +nullable
+	// +optional
+	Roles []string `json:"roles,omitempty"`
+
+	// Groups is a list of groups assigned to user.
+	// +nullable
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+
+	// Attributes is a map of user attributes.
+	// +nullable
+	// +optional
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// UsersFromConfigMap defines the reference to ConfigMap with the list of users.
+type UsersFromConfigMap struct {
+	// Name is the name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Key is the key in the ConfigMap.
+	Key string `json:"key"`
+
+	// Format is the format of the users list.
+	// csv - comma separated values with the header row. Supported columns: username, email, firstName, lastName,
+	// enabled, emailVerified, roles, groups. Roles and groups are separated by ";". Other columns are user attributes.
+	// json - array of users with the same fields as in the users field.
+	// +kubebuilder:validation:Enum=csv;json
+	// +kubebuilder:default=csv
+	// +optional
+	Format string `json:"format,omitempty"`
+}
+
+// KeycloakRealmUserBatchStatus defines the observed state of KeycloakRealmUserBatch.
+type KeycloakRealmUserBatchStatus struct {
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// +optional
+	FailureCount int64 `json:"failureCount,omitempty"`
+
+	// Users contains status of each user from the batch.
+	// +nullable
+	// +optional
+	Users []BatchUserStatus `json:"users,omitempty"`
+}
+
+// BatchUserStatus defines the observed state of the user from the batch.
+type BatchUserStatus struct {
+	// Username is a username in keycloak.
+	Username string `json:"username"`
+
+	// Ready is true if user is synced successfully.
+	Ready bool `json:"ready"`
+
+	// Created is true if the user was created by the batch.
+	// Only created users are deleted when they are removed from the batch or the batch is deleted.
+	// +optional
+	Created bool `json:"created,omitempty"`
+
+	// Message is an error message of the user sync.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.value",description="Reconcilation status"
+
+// KeycloakRealmUserBatch is the Schema for the keycloak realm user batches API.
+type KeycloakRealmUserBatch struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KeycloakRealmUserBatchSpec   `json:"spec,omitempty"`
+	Status KeycloakRealmUserBatchStatus `json:"status,omitempty"`
+}
+
+func (in *KeycloakRealmUserBatch) GetReconciliationStrategy() string {
+	if in.Spec.ReconciliationStrategy == "" {
+		return ReconciliationStrategyFull
+	}
+
+	return in.Spec.ReconciliationStrategy
+}
+
+// GetPasswordSecretName returns name of the secret with generated initial passwords.
+func (in *KeycloakRealmUserBatch) GetPasswordSecretName() string {
+	if in.Spec.PasswordSecretName == "" {
+		return in.Name + "-passwords"
+	}
+
+	return in.Spec.PasswordSecretName
+}
+
+func (in *KeycloakRealmUserBatch) GetFailureCount() int64 {
+	return in.Status.FailureCount
+}
+
+func (in *KeycloakRealmUserBatch) SetFailureCount(count int64) {
+	in.Status.FailureCount = count
+}
+
+func (in *KeycloakRealmUserBatch) GetStatus() string {
+	return in.Status.Value
+}
+
+func (in *KeycloakRealmUserBatch) SetStatus(value string) {
+	in.Status.Value = value
+}
+
+func (in *KeycloakRealmUserBatch) GetRealmRef() common.RealmRef {
+	return in.Spec.RealmRef
+}
+
+// +kubebuilder:object:root=true
+
+// KeycloakRealmUserBatchList contains a list of KeycloakRealmUserBatch.
+type KeycloakRealmUserBatchList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []KeycloakRealmUserBatch `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KeycloakRealmUserBatch{}, &KeycloakRealmUserBatchList{})
+}
@@ -0,0 +1,355 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/epam/edp-keycloak-operator/api/common"
+)
+
+const (
+	// UserFederationProviderLDAP is an LDAP user federation provider.
+	UserFederationProviderLDAP = "ldap"
+
+	// UserFederationProviderKerberos is a Kerberos user federation provider.
+	UserFederationProviderKerberos = "kerberos"
+
+	// UserFederationSyncAnnotation is an annotation to trigger synchronization of the user federation users.
+	// Value should be UserFederationSyncFull or UserFederationSyncChanged.
+	// The annotation is removed after the synchronization, it is kept if the synchronization fails, so it is retried.
+	UserFederationSyncAnnotation = "edp.epam.com/user-federation-sync"
+
+	// UserFederationSyncFull is a value of UserFederationSyncAnnotation to synchronize all users.
+	UserFederationSyncFull = "full"
+
+	// UserFederationSyncChanged is a value of UserFederationSyncAnnotation to synchronize changed users only.
+	UserFederationSyncChanged = "changed"
+)
+
+// KeycloakRealmUserFederationSpec defines the desired state of KeycloakRealmUserFederation.
+type KeycloakRealmUserFederationSpec struct {
+	// Name is a name of the user federation provider in Keycloak.
+	Name string `json:"name"`
+
+	// RealmRef is reference to Realm custom resource.
+	RealmRef common.RealmRef `json:"realmRef"`
+
+	// ProviderID is a provider of the user federation.
+	// +kubebuilder:validation:Enum=ldap;kerberos
+	// +kubebuilder:default=ldap
+	// +optional
+	ProviderID string `json:"providerId,omitempty"`
+
+	// Enabled is a flag to enable the user federation provider.
+	// +kubebuilder:default=true
+	// +optional
+	Enabled bool `json:"enabled"`
+
+	// Priority of the provider when doing user lookups. Lowest first.
+	// +optional
+	Priority int `json:"priority,omitempty"`
+
+	// LDAP is a configuration of the LDAP provider. Required for ldap provider.
+	// +nullable
+	// +optional
+	LDAP *UserFederationLDAP `json:"ldap,omitempty"`
+
+	// Kerberos is a configuration of the Kerberos authentication.
+	// It is used by kerberos provider or by ldap provider with Kerberos authentication.
+	// +nullable
+	// +optional
+	Kerberos *UserFederationKerberos `json:"kerberos,omitempty"`
+
+	// Config is an additional configuration of the user federation provider.
+	// It overrides properties set by typed fields.
+	// Any configuration property can be a reference to k8s secret, in this case the property should be in format $secretName:secretKey.
+	// +nullable
+	// +optional
+	Config map[string][]string `json:"config,omitempty"`
+
+	// Mappers is a list of LDAP mappers. Mappers are matched by name.
+	// If the list is empty, mappers of the user federation are not managed.
+	// +nullable
+	// +optional
+	Mappers []UserFederationMapper `json:"mappers,omitempty"`
+
+	// MappersReconciliationStrategy is a strategy to reconcile user federation mappers.
+	// full - mappers which are not in the Mappers list are removed, including default mappers created by Keycloak.
+	// addOnly - mappers are only created and updated.
+	// Default is addOnly, because Keycloak creates default mappers for a new LDAP provider.
+	// +kubebuilder:validation:Enum=full;addOnly
+	// +optional
+	MappersReconciliationStrategy string `json:"mappersReconciliationStrategy,omitempty"`
+
+	// SkipConnectionTest disables testing of LDAP connection and authentication before applying the configuration.
+	// +optional
+	SkipConnectionTest bool `json:"skipConnectionTest,omitempty"`
+}
+
+// UserFederationLDAP defines the LDAP user federation provider configuration.
+type UserFederationLDAP struct {
+	// Vendor is an LDAP vendor.
+	// +kubebuilder:validation:Enum=other;ad;rhds;tivoli;edirectory
+	// +kubebuilder:default=other
+	// +optional
+	Vendor string `json:"vendor,omitempty"`
+
+	// ConnectionURL is a connection URL to the LDAP server.
+	// +kubebuilder:example="ldap://openldap:389"
+	ConnectionURL string `json:"connectionUrl"`
+
+	// BindDN is a DN of the LDAP admin, which is used by Keycloak to access the LDAP server.
+	// If empty, anonymous authentication is used.
+	// +optional
+	BindDN string `json:"bindDn,omitempty"`
+
+	// BindCredential is a reference to the secret with the password of the LDAP admin.
+	// +nullable
+	// +optional
+	BindCredential *SecretKeyRef `json:"bindCredential,omitempty"`
+
+	// UsersDN is a full DN of LDAP tree where users are.
+	// +kubebuilder:example="ou=users,dc=example,dc=com"
+	UsersDN string `json:"usersDn"`
+
+	// UsernameLDAPAttribute is a name of the LDAP attribute, which is mapped as Keycloak username.
+	// +kubebuilder:example="uid"
+	UsernameLDAPAttribute string `json:"usernameLdapAttribute"`
+
+	// RDNLDAPAttribute is a name of the LDAP attribute, which is used as RDN of typical user DN.
+	// By default, it is the same as UsernameLDAPAttribute.
+	// +optional
+	RDNLDAPAttribute string `json:"rdnLdapAttribute,omitempty"`
+
+	// UUIDLDAPAttribute is a name of the LDAP attribute, which is used as unique object identifier.
+	// +kubebuilder:example="entryUUID"
+	UUIDLDAPAttribute string `json:"uuidLdapAttribute"`
+
+	// UserObjectClasses are object classes of the LDAP users.
+	// +kubebuilder:example={"inetOrgPerson", "organizationalPerson"}
+	UserObjectClasses []string `json:"userObjectClasses"`
+
+	// CustomUserSearchFilter is an additional LDAP filter for filtering searched users.
+	// +optional
+	CustomUserSearchFilter string `json:"customUserSearchFilter,omitempty"`
+
+	// SearchScope is a scope of the users search.
+	// +kubebuilder:validation:Enum=one;subtree
+	// +kubebuilder:default=one
+	// +optional
+	SearchScope string `json:"searchScope,omitempty"`
+
+	// EditMode defines how Keycloak updates LDAP users.
+	// +kubebuilder:validation:Enum=READ_ONLY;WRITABLE;UNSYNCED
+	// +kubebuilder:default=READ_ONLY
+	// +optional
+	EditMode string `json:"editMode,omitempty"`
+
+	// ImportEnabled enables importing of LDAP users into the Keycloak database.
+	// +kubebuilder:default=true
+	// +optional
+	ImportEnabled *bool `json:"importEnabled,omitempty"`
+
+	// SyncRegistrations enables creating of newly registered users in LDAP.
+	// +optional
+	SyncRegistrations *bool `json:"syncRegistrations,omitempty"`
+
+	// StartTLS enables encryption of the connection to LDAP using STARTTLS.
+	// +optional
+	StartTLS *bool `json:"startTls,omitempty"`
+
+	// UseTruststoreSPI defines whether LDAP connection uses the truststore SPI with the truststore configured in Keycloak.
+	// +kubebuilder:validation:Enum=always;ldapsOnly;never
+	// +optional
+	UseTruststoreSPI string `json:"useTruststoreSpi,omitempty"`
+
+	// ConnectionTimeout is an LDAP connection timeout.
+	// +optional
+	ConnectionTimeout *metav1.Duration `json:"connectionTimeout,omitempty"`
+
+	// ReadTimeout is an LDAP read timeout.
+	// +optional
+	ReadTimeout *metav1.Duration `json:"readTimeout,omitempty"`
+
+	// Pagination enables LDAP pagination.
+	// +optional
+	Pagination *bool `json:"pagination,omitempty"`
+
+	// BatchSizeForSync is a count of LDAP users to be imported from LDAP to Keycloak within a single transaction.
+	// +optional
+	BatchSizeForSync *int `json:"batchSizeForSync,omitempty"`
+
+	// FullSyncPeriod is a period of the periodic full synchronization of LDAP users.
+	// If empty, the periodic full synchronization is disabled.
+	// +optional
+	FullSyncPeriod *metav1.Duration `json:"fullSyncPeriod,omitempty"`
+
+	// ChangedSyncPeriod is a period of the periodic synchronization of changed or newly created LDAP users.
+	// If empty, the periodic synchronization of changed users is disabled.
+	// +optional
+	ChangedSyncPeriod *metav1.Duration `json:"changedSyncPeriod,omitempty"`
+}
+
+// UserFederationKerberos defines the Kerberos authentication configuration.
+type UserFederationKerberos struct {
+	// KerberosRealm is a name of the Kerberos realm.
+	// +kubebuilder:example="EXAMPLE.COM"
+	KerberosRealm string `json:"kerberosRealm"`
+
+	// ServerPrincipal is a full name of the server principal for the HTTP service.
+	// +kubebuilder:example="HTTP/host.example.com@EXAMPLE.COM"
+	ServerPrincipal string `json:"serverPrincipal"`
+
+	// KeyTab is a location of the Kerberos KeyTab file containing the credentials of the server principal.
+	// +kubebuilder:example="/etc/krb5.keytab"
+	KeyTab string `json:"keyTab"`
+
+	// UseKerberosForPasswordAuthentication enables using Kerberos login module for the password authentication.
+	// It is used only with ldap provider.
+	// +optional
+	UseKerberosForPasswordAuthentication bool `json:"useKerberosForPasswordAuthentication,omitempty"`
+
+	// AllowPasswordAuthentication enables the username/password authentication against the Kerberos database.
+	// It is used only with kerberos provider.
+	// +optional
+	AllowPasswordAuthentication bool `json:"allowPasswordAuthentication,omitempty"`
+
+	// Debug enables Kerberos debug logging.
+	// +optional
+	Debug bool `json:"debug,omitempty"`
+}
+
+// UserFederationMapper defines the LDAP user federation mapper.
+type UserFederationMapper struct {
+	// Name is a name of the mapper.
+	Name string `json:"name"`
+
+	// ProviderID is a type of the mapper.
+	// +kubebuilder:example="user-attribute-ldap-mapper"
+	ProviderID string `json:"providerId"`
+
+	// Config is a configuration of the mapper.
+	// +kubebuilder:example={"ldap.attribute": {"mail"}, "user.model.attribute": {"email"}}
+	// +nullable
+	// +optional
+	Config map[string][]string `json:"config,omitempty"`
+}
+
+// SecretKeyRef is a reference to the key of the secret.
+type SecretKeyRef struct {
+	// Name is a name of the secret.
+	Name string `json:"name"`
+
+	// Key is a key in the secret.
+	Key string `json:"key"`
+}
+
+// KeycloakRealmUserFederationStatus defines the observed state of KeycloakRealmUserFederation.
+type KeycloakRealmUserFederationStatus struct {
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// +optional
+	FailureCount int64 `json:"failureCount,omitempty"`
+
+	// ID is an ID of the user federation provider in Keycloak.
+	// +optional
+	ID string `json:"id,omitempty"`
+
+	// LastSync is a result of the last users synchronization triggered by the annotation.
+	// +nullable
+	// +optional
+	LastSync *UserFederationSyncStatus `json:"lastSync,omitempty"`
+}
+
+// UserFederationSyncStatus defines the result of the user federation synchronization.
+type UserFederationSyncStatus struct {
+	// Type is a type of the synchronization: full or changed.
+	Type string `json:"type"`
+
+	// Time is a time of the synchronization.
+	Time metav1.Time `json:"time"`
+
+	// Added is a count of the added users.
+	// +optional
+	Added int `json:"added,omitempty"`
+
+	// Updated is a count of the updated users.
+	// +optional
+	Updated int `json:"updated,omitempty"`
+
+	// Removed is a count of the removed users.
+	// +optional
+	Removed int `json:"removed,omitempty"`
+
+	// Failed is a count of the users failed to synchronize.
+	// +optional
+	Failed int `json:"failed,omitempty"`
+
+	// Message is a status message of the synchronization or an error.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.value",description="Reconcilation status"
+
+// KeycloakRealmUserFederation is the Schema for the keycloak realm user federation API.
+type KeycloakRealmUserFederation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KeycloakRealmUserFederationSpec   `json:"spec,omitempty"`
+	Status KeycloakRealmUserFederationStatus `json:"status,omitempty"`
+}
+
+func (in *KeycloakRealmUserFederation) GetProviderID() string {
+	if in.Spec.ProviderID == "" {
+		return UserFederationProviderLDAP
+	}
+
+	return in.Spec.ProviderID
+}
+
+func (in *KeycloakRealmUserFederation) GetMappersReconciliationStrategy() string {
+	if in.Spec.MappersReconciliationStrategy == "" {
+		return ReconciliationStrategyAddOnly
+	}
+
+	return in.Spec.MappersReconciliationStrategy
+}
+
+func (in *KeycloakRealmUserFederation) GetFailureCount() int64 {
+	return in.Status.FailureCount
+}
+
+func (in *KeycloakRealmUserFederation) SetFailureCount(count int64) {
+	in.Status.FailureCount = count
+}
+
+func (in *KeycloakRealmUserFederation) GetStatus() string {
+	return in.Status.Value
+}
+
+func (in *KeycloakRealmUserFederation) SetStatus(value string) {
+	in.Status.Value = value
+}
+
+func (in *KeycloakRealmUserFederation) GetRealmRef() common.RealmRef {
+	return in.Spec.RealmRef
+}
+
+// +kubebuilder:object:root=true
+
+// KeycloakRealmUserFederationList contains a list of KeycloakRealmUserFederation.
+type KeycloakRealmUserFederationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []KeycloakRealmUserFederation `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KeycloakRealmUserFederation{}, &KeycloakRealmUserFederationList{})
+}
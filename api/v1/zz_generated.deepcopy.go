@@ -6,9 +6,106 @@
 package v1
 
 import (
+	"github.com/epam/edp-keycloak-operator/api/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdminPermissionPolicy) DeepCopyInto(out *AdminPermissionPolicy) {
+	*out = *in
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]AdminPermissionPolicyGroup, len(*in))
+		copy(*out, *in)
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]AdminPermissionPolicyRole, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdminPermissionPolicy.
+func (in *AdminPermissionPolicy) DeepCopy() *AdminPermissionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AdminPermissionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdminPermissionPolicyGroup) DeepCopyInto(out *AdminPermissionPolicyGroup) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdminPermissionPolicyGroup.
+func (in *AdminPermissionPolicyGroup) DeepCopy() *AdminPermissionPolicyGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(AdminPermissionPolicyGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdminPermissionPolicyRole) DeepCopyInto(out *AdminPermissionPolicyRole) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdminPermissionPolicyRole.
+func (in *AdminPermissionPolicyRole) DeepCopy() *AdminPermissionPolicyRole {
+	if in == nil {
+		return nil
+	}
+	out := new(AdminPermissionPolicyRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdminPermissionTarget) DeepCopyInto(out *AdminPermissionTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdminPermissionTarget.
+func (in *AdminPermissionTarget) DeepCopy() *AdminPermissionTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(AdminPermissionTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdminScopePermission) DeepCopyInto(out *AdminScopePermission) {
+	*out = *in
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdminScopePermission.
+func (in *AdminScopePermission) DeepCopy() *AdminScopePermission {
+	if in == nil {
+		return nil
+	}
+	out := new(AdminScopePermission)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuthenticationExecution) DeepCopyInto(out *AuthenticationExecution) {
 	*out = *in
@@ -29,6 +126,33 @@ func (in *AuthenticationExecution) DeepCopy() *AuthenticationExecution {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthenticationSubFlow) DeepCopyInto(out *AuthenticationSubFlow) {
+	*out = *in
+	if in.LevelOfAuthentication != nil {
+		in, out := &in.LevelOfAuthentication, &out.LevelOfAuthentication
+		*out = new(LevelOfAuthentication)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AuthenticationExecutions != nil {
+		in, out := &in.AuthenticationExecutions, &out.AuthenticationExecutions
+		*out = make([]AuthenticationExecution, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthenticationSubFlow.
+func (in *AuthenticationSubFlow) DeepCopy() *AuthenticationSubFlow {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthenticationSubFlow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuthenticatorConfig) DeepCopyInto(out *AuthenticatorConfig) {
 	*out = *in
@@ -74,6 +198,21 @@ func (in *BatchRole) DeepCopyInto(out *BatchRole) {
 		*out = make([]Composite, len(*in))
 		copy(*out, *in)
 	}
+	if in.CompositesClientRoles != nil {
+		in, out := &in.CompositesClientRoles, &out.CompositesClientRoles
+		*out = make(map[string][]Composite, len(*in))
+		for key, val := range *in {
+			var outVal []Composite
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]Composite, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BatchRole.
@@ -87,239 +226,287 @@ func (in *BatchRole) DeepCopy() *BatchRole {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClientRole) DeepCopyInto(out *ClientRole) {
+func (in *BatchRoleStatus) DeepCopyInto(out *BatchRoleStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BatchRoleStatus.
+func (in *BatchRoleStatus) DeepCopy() *BatchRoleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BatchRoleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BatchUser) DeepCopyInto(out *BatchUser) {
 	*out = *in
 	if in.Roles != nil {
 		in, out := &in.Roles, &out.Roles
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientRole.
-func (in *ClientRole) DeepCopy() *ClientRole {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BatchUser.
+func (in *BatchUser) DeepCopy() *BatchUser {
 	if in == nil {
 		return nil
 	}
-	out := new(ClientRole)
+	out := new(BatchUser)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Composite) DeepCopyInto(out *Composite) {
+func (in *BatchUserStatus) DeepCopyInto(out *BatchUserStatus) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Composite.
-func (in *Composite) DeepCopy() *Composite {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BatchUserStatus.
+func (in *BatchUserStatus) DeepCopy() *BatchUserStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(Composite)
+	out := new(BatchUserStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IdentityProviderMapper) DeepCopyInto(out *IdentityProviderMapper) {
+func (in *ClientRef) DeepCopyInto(out *ClientRef) {
 	*out = *in
-	if in.Config != nil {
-		in, out := &in.Config, &out.Config
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdentityProviderMapper.
-func (in *IdentityProviderMapper) DeepCopy() *IdentityProviderMapper {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientRef.
+func (in *ClientRef) DeepCopy() *ClientRef {
 	if in == nil {
 		return nil
 	}
-	out := new(IdentityProviderMapper)
+	out := new(ClientRef)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Keycloak) DeepCopyInto(out *Keycloak) {
+func (in *ClientRole) DeepCopyInto(out *ClientRole) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Keycloak.
-func (in *Keycloak) DeepCopy() *Keycloak {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientRole.
+func (in *ClientRole) DeepCopy() *ClientRole {
 	if in == nil {
 		return nil
 	}
-	out := new(Keycloak)
+	out := new(ClientRole)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Keycloak) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakAuthFlow) DeepCopyInto(out *KeycloakAuthFlow) {
+func (in *Composite) DeepCopyInto(out *Composite) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakAuthFlow.
-func (in *KeycloakAuthFlow) DeepCopy() *KeycloakAuthFlow {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Composite.
+func (in *Composite) DeepCopy() *Composite {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakAuthFlow)
+	out := new(Composite)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakAuthFlow) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakAuthFlowList) DeepCopyInto(out *KeycloakAuthFlowList) {
+func (in *ConfigMapKeyRef) DeepCopyInto(out *ConfigMapKeyRef) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]KeycloakAuthFlow, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakAuthFlowList.
-func (in *KeycloakAuthFlowList) DeepCopy() *KeycloakAuthFlowList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapKeyRef.
+func (in *ConfigMapKeyRef) DeepCopy() *ConfigMapKeyRef {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakAuthFlowList)
+	out := new(ConfigMapKeyRef)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakAuthFlowList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FederatedIdentity) DeepCopyInto(out *FederatedIdentity) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FederatedIdentity.
+func (in *FederatedIdentity) DeepCopy() *FederatedIdentity {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(FederatedIdentity)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakAuthFlowSpec) DeepCopyInto(out *KeycloakAuthFlowSpec) {
+func (in *GroupMember) DeepCopyInto(out *GroupMember) {
 	*out = *in
-	out.RealmRef = in.RealmRef
-	if in.AuthenticationExecutions != nil {
-		in, out := &in.AuthenticationExecutions, &out.AuthenticationExecutions
-		*out = make([]AuthenticationExecution, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.AttributeSelector != nil {
+		in, out := &in.AttributeSelector, &out.AttributeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakAuthFlowSpec.
-func (in *KeycloakAuthFlowSpec) DeepCopy() *KeycloakAuthFlowSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupMember.
+func (in *GroupMember) DeepCopy() *GroupMember {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakAuthFlowSpec)
+	out := new(GroupMember)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakAuthFlowStatus) DeepCopyInto(out *KeycloakAuthFlowStatus) {
+func (in *GroupRef) DeepCopyInto(out *GroupRef) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakAuthFlowStatus.
-func (in *KeycloakAuthFlowStatus) DeepCopy() *KeycloakAuthFlowStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupRef.
+func (in *GroupRef) DeepCopy() *GroupRef {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakAuthFlowStatus)
+	out := new(GroupRef)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakClient) DeepCopyInto(out *KeycloakClient) {
+func (in *IdentityProviderMapper) DeepCopyInto(out *IdentityProviderMapper) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClient.
-func (in *KeycloakClient) DeepCopy() *KeycloakClient {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdentityProviderMapper.
+func (in *IdentityProviderMapper) DeepCopy() *IdentityProviderMapper {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakClient)
+	out := new(IdentityProviderMapper)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakClient) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IdentityProviderOIDC) DeepCopyInto(out *IdentityProviderOIDC) {
+	*out = *in
+	if in.DefaultScopes != nil {
+		in, out := &in.DefaultScopes, &out.DefaultScopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ValidateSignature != nil {
+		in, out := &in.ValidateSignature, &out.ValidateSignature
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdentityProviderOIDC.
+func (in *IdentityProviderOIDC) DeepCopy() *IdentityProviderOIDC {
+	if in == nil {
+		return nil
+	}
+	out := new(IdentityProviderOIDC)
+	in.DeepCopyInto(out)
+	return out
+}
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakClientList) DeepCopyInto(out *KeycloakClientList) {
+func (in *IdentityProviderSAML) DeepCopyInto(out *IdentityProviderSAML) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]KeycloakClient, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.MetadataConfigMapRef != nil {
+		in, out := &in.MetadataConfigMapRef, &out.MetadataConfigMapRef
+		*out = new(ConfigMapKeyRef)
+		**out = **in
+	}
+	if in.MetadataRefreshInterval != nil {
+		in, out := &in.MetadataRefreshInterval, &out.MetadataRefreshInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.WantAuthnRequestsSigned != nil {
+		in, out := &in.WantAuthnRequestsSigned, &out.WantAuthnRequestsSigned
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ValidateSignature != nil {
+		in, out := &in.ValidateSignature, &out.ValidateSignature
+		*out = new(bool)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientList.
-func (in *KeycloakClientList) DeepCopy() *KeycloakClientList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdentityProviderSAML.
+func (in *IdentityProviderSAML) DeepCopy() *IdentityProviderSAML {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakClientList)
+	out := new(IdentityProviderSAML)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Keycloak) DeepCopyInto(out *Keycloak) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Keycloak.
+func (in *Keycloak) DeepCopy() *Keycloak {
+	if in == nil {
+		return nil
+	}
+	out := new(Keycloak)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakClientList) DeepCopyObject() runtime.Object {
+func (in *Keycloak) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -327,26 +514,26 @@ func (in *KeycloakClientList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakClientScope) DeepCopyInto(out *KeycloakClientScope) {
+func (in *KeycloakAdminPermission) DeepCopyInto(out *KeycloakAdminPermission) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientScope.
-func (in *KeycloakClientScope) DeepCopy() *KeycloakClientScope {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakAdminPermission.
+func (in *KeycloakAdminPermission) DeepCopy() *KeycloakAdminPermission {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakClientScope)
+	out := new(KeycloakAdminPermission)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakClientScope) DeepCopyObject() runtime.Object {
+func (in *KeycloakAdminPermission) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -354,31 +541,31 @@ func (in *KeycloakClientScope) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakClientScopeList) DeepCopyInto(out *KeycloakClientScopeList) {
+func (in *KeycloakAdminPermissionList) DeepCopyInto(out *KeycloakAdminPermissionList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]KeycloakClientScope, len(*in))
+		*out = make([]KeycloakAdminPermission, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientScopeList.
-func (in *KeycloakClientScopeList) DeepCopy() *KeycloakClientScopeList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakAdminPermissionList.
+func (in *KeycloakAdminPermissionList) DeepCopy() *KeycloakAdminPermissionList {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakClientScopeList)
+	out := new(KeycloakAdminPermissionList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakClientScopeList) DeepCopyObject() runtime.Object {
+func (in *KeycloakAdminPermissionList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -386,269 +573,188 @@ func (in *KeycloakClientScopeList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakClientScopeSpec) DeepCopyInto(out *KeycloakClientScopeSpec) {
+func (in *KeycloakAdminPermissionSpec) DeepCopyInto(out *KeycloakAdminPermissionSpec) {
 	*out = *in
 	out.RealmRef = in.RealmRef
-	if in.Attributes != nil {
-		in, out := &in.Attributes, &out.Attributes
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+	out.Target = in.Target
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]AdminPermissionPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.ProtocolMappers != nil {
-		in, out := &in.ProtocolMappers, &out.ProtocolMappers
-		*out = make([]ProtocolMapper, len(*in))
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]AdminScopePermission, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientScopeSpec.
-func (in *KeycloakClientScopeSpec) DeepCopy() *KeycloakClientScopeSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakAdminPermissionSpec.
+func (in *KeycloakAdminPermissionSpec) DeepCopy() *KeycloakAdminPermissionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakClientScopeSpec)
+	out := new(KeycloakAdminPermissionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakClientScopeStatus) DeepCopyInto(out *KeycloakClientScopeStatus) {
+func (in *KeycloakAdminPermissionStatus) DeepCopyInto(out *KeycloakAdminPermissionStatus) {
 	*out = *in
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ScopePermissions != nil {
+		in, out := &in.ScopePermissions, &out.ScopePermissions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientScopeStatus.
-func (in *KeycloakClientScopeStatus) DeepCopy() *KeycloakClientScopeStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakAdminPermissionStatus.
+func (in *KeycloakAdminPermissionStatus) DeepCopy() *KeycloakAdminPermissionStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakClientScopeStatus)
+	out := new(KeycloakAdminPermissionStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakClientSpec) DeepCopyInto(out *KeycloakClientSpec) {
+func (in *KeycloakAuthFlow) DeepCopyInto(out *KeycloakAuthFlow) {
 	*out = *in
-	out.RealmRef = in.RealmRef
-	if in.RealmRoles != nil {
-		in, out := &in.RealmRoles, &out.RealmRoles
-		*out = new([]RealmRole)
-		if **in != nil {
-			in, out := *in, *out
-			*out = make([]RealmRole, len(*in))
-			copy(*out, *in)
-		}
-	}
-	if in.Protocol != nil {
-		in, out := &in.Protocol, &out.Protocol
-		*out = new(string)
-		**out = **in
-	}
-	if in.Attributes != nil {
-		in, out := &in.Attributes, &out.Attributes
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.ClientRoles != nil {
-		in, out := &in.ClientRoles, &out.ClientRoles
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.ProtocolMappers != nil {
-		in, out := &in.ProtocolMappers, &out.ProtocolMappers
-		*out = new([]ProtocolMapper)
-		if **in != nil {
-			in, out := *in, *out
-			*out = make([]ProtocolMapper, len(*in))
-			for i := range *in {
-				(*in)[i].DeepCopyInto(&(*out)[i])
-			}
-		}
-	}
-	if in.ServiceAccount != nil {
-		in, out := &in.ServiceAccount, &out.ServiceAccount
-		*out = new(ServiceAccount)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.DefaultClientScopes != nil {
-		in, out := &in.DefaultClientScopes, &out.DefaultClientScopes
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.RedirectUris != nil {
-		in, out := &in.RedirectUris, &out.RedirectUris
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.WebOrigins != nil {
-		in, out := &in.WebOrigins, &out.WebOrigins
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientSpec.
-func (in *KeycloakClientSpec) DeepCopy() *KeycloakClientSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakAuthFlow.
+func (in *KeycloakAuthFlow) DeepCopy() *KeycloakAuthFlow {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakClientSpec)
+	out := new(KeycloakAuthFlow)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakAuthFlow) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakClientStatus) DeepCopyInto(out *KeycloakClientStatus) {
+func (in *KeycloakAuthFlowList) DeepCopyInto(out *KeycloakAuthFlowList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeycloakAuthFlow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientStatus.
-func (in *KeycloakClientStatus) DeepCopy() *KeycloakClientStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakAuthFlowList.
+func (in *KeycloakAuthFlowList) DeepCopy() *KeycloakAuthFlowList {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakClientStatus)
+	out := new(KeycloakAuthFlowList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakAuthFlowList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakComponentSpec) DeepCopyInto(out *KeycloakComponentSpec) {
+func (in *KeycloakAuthFlowSpec) DeepCopyInto(out *KeycloakAuthFlowSpec) {
 	*out = *in
 	out.RealmRef = in.RealmRef
-	if in.ParentRef != nil {
-		in, out := &in.ParentRef, &out.ParentRef
-		*out = new(ParentComponent)
-		**out = **in
+	if in.AuthenticationExecutions != nil {
+		in, out := &in.AuthenticationExecutions, &out.AuthenticationExecutions
+		*out = make([]AuthenticationExecution, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.Config != nil {
-		in, out := &in.Config, &out.Config
-		*out = make(map[string][]string, len(*in))
-		for key, val := range *in {
-			var outVal []string
-			if val == nil {
-				(*out)[key] = nil
-			} else {
-				in, out := &val, &outVal
-				*out = make([]string, len(*in))
-				copy(*out, *in)
-			}
-			(*out)[key] = outVal
+	if in.SubFlows != nil {
+		in, out := &in.SubFlows, &out.SubFlows
+		*out = make([]AuthenticationSubFlow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakComponentSpec.
-func (in *KeycloakComponentSpec) DeepCopy() *KeycloakComponentSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakAuthFlowSpec.
+func (in *KeycloakAuthFlowSpec) DeepCopy() *KeycloakAuthFlowSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakComponentSpec)
+	out := new(KeycloakAuthFlowSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakComponentStatus) DeepCopyInto(out *KeycloakComponentStatus) {
+func (in *KeycloakAuthFlowStatus) DeepCopyInto(out *KeycloakAuthFlowStatus) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakComponentStatus.
-func (in *KeycloakComponentStatus) DeepCopy() *KeycloakComponentStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakAuthFlowStatus.
+func (in *KeycloakAuthFlowStatus) DeepCopy() *KeycloakAuthFlowStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakComponentStatus)
+	out := new(KeycloakAuthFlowStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakList) DeepCopyInto(out *KeycloakList) {
+func (in *KeycloakClient) DeepCopyInto(out *KeycloakClient) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Keycloak, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakList.
-func (in *KeycloakList) DeepCopy() *KeycloakList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClient.
+func (in *KeycloakClient) DeepCopy() *KeycloakClient {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakList)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealm) DeepCopyInto(out *KeycloakRealm) {
-	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealm.
-func (in *KeycloakRealm) DeepCopy() *KeycloakRealm {
-	if in == nil {
-		return nil
-	}
-	out := new(KeycloakRealm)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakRealm) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmComponent) DeepCopyInto(out *KeycloakRealmComponent) {
-	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmComponent.
-func (in *KeycloakRealmComponent) DeepCopy() *KeycloakRealmComponent {
-	if in == nil {
-		return nil
-	}
-	out := new(KeycloakRealmComponent)
+	out := new(KeycloakClient)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakRealmComponent) DeepCopyObject() runtime.Object {
+func (in *KeycloakClient) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -656,31 +762,31 @@ func (in *KeycloakRealmComponent) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmComponentList) DeepCopyInto(out *KeycloakRealmComponentList) {
+func (in *KeycloakClientList) DeepCopyInto(out *KeycloakClientList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]KeycloakRealmComponent, len(*in))
+		*out = make([]KeycloakClient, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmComponentList.
-func (in *KeycloakRealmComponentList) DeepCopy() *KeycloakRealmComponentList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientList.
+func (in *KeycloakClientList) DeepCopy() *KeycloakClientList {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmComponentList)
+	out := new(KeycloakClientList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakRealmComponentList) DeepCopyObject() runtime.Object {
+func (in *KeycloakClientList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -688,7 +794,7 @@ func (in *KeycloakRealmComponentList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmGroup) DeepCopyInto(out *KeycloakRealmGroup) {
+func (in *KeycloakClientRole) DeepCopyInto(out *KeycloakClientRole) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -696,18 +802,18 @@ func (in *KeycloakRealmGroup) DeepCopyInto(out *KeycloakRealmGroup) {
 	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmGroup.
-func (in *KeycloakRealmGroup) DeepCopy() *KeycloakRealmGroup {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientRole.
+func (in *KeycloakClientRole) DeepCopy() *KeycloakClientRole {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmGroup)
+	out := new(KeycloakClientRole)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakRealmGroup) DeepCopyObject() runtime.Object {
+func (in *KeycloakClientRole) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -715,31 +821,31 @@ func (in *KeycloakRealmGroup) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmGroupList) DeepCopyInto(out *KeycloakRealmGroupList) {
+func (in *KeycloakClientRoleList) DeepCopyInto(out *KeycloakClientRoleList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]KeycloakRealmGroup, len(*in))
+		*out = make([]KeycloakClientRole, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmGroupList.
-func (in *KeycloakRealmGroupList) DeepCopy() *KeycloakRealmGroupList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientRoleList.
+func (in *KeycloakClientRoleList) DeepCopy() *KeycloakClientRoleList {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmGroupList)
+	out := new(KeycloakClientRoleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakRealmGroupList) DeepCopyObject() runtime.Object {
+func (in *KeycloakClientRoleList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -747,9 +853,9 @@ func (in *KeycloakRealmGroupList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmGroupSpec) DeepCopyInto(out *KeycloakRealmGroupSpec) {
+func (in *KeycloakClientRoleSpec) DeepCopyInto(out *KeycloakClientRoleSpec) {
 	*out = *in
-	out.RealmRef = in.RealmRef
+	out.ClientRef = in.ClientRef
 	if in.Attributes != nil {
 		in, out := &in.Attributes, &out.Attributes
 		*out = make(map[string][]string, len(*in))
@@ -765,59 +871,55 @@ func (in *KeycloakRealmGroupSpec) DeepCopyInto(out *KeycloakRealmGroupSpec) {
 			(*out)[key] = outVal
 		}
 	}
-	if in.Access != nil {
-		in, out := &in.Access, &out.Access
-		*out = make(map[string]bool, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.RealmRoles != nil {
-		in, out := &in.RealmRoles, &out.RealmRoles
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.SubGroups != nil {
-		in, out := &in.SubGroups, &out.SubGroups
-		*out = make([]string, len(*in))
+	if in.Composites != nil {
+		in, out := &in.Composites, &out.Composites
+		*out = make([]Composite, len(*in))
 		copy(*out, *in)
 	}
-	if in.ClientRoles != nil {
-		in, out := &in.ClientRoles, &out.ClientRoles
-		*out = make([]ClientRole, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.CompositesClientRoles != nil {
+		in, out := &in.CompositesClientRoles, &out.CompositesClientRoles
+		*out = make(map[string][]Composite, len(*in))
+		for key, val := range *in {
+			var outVal []Composite
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]Composite, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmGroupSpec.
-func (in *KeycloakRealmGroupSpec) DeepCopy() *KeycloakRealmGroupSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientRoleSpec.
+func (in *KeycloakClientRoleSpec) DeepCopy() *KeycloakClientRoleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmGroupSpec)
+	out := new(KeycloakClientRoleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmGroupStatus) DeepCopyInto(out *KeycloakRealmGroupStatus) {
+func (in *KeycloakClientRoleStatus) DeepCopyInto(out *KeycloakClientRoleStatus) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmGroupStatus.
-func (in *KeycloakRealmGroupStatus) DeepCopy() *KeycloakRealmGroupStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientRoleStatus.
+func (in *KeycloakClientRoleStatus) DeepCopy() *KeycloakClientRoleStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmGroupStatus)
+	out := new(KeycloakClientRoleStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmIdentityProvider) DeepCopyInto(out *KeycloakRealmIdentityProvider) {
+func (in *KeycloakClientScope) DeepCopyInto(out *KeycloakClientScope) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -825,18 +927,18 @@ func (in *KeycloakRealmIdentityProvider) DeepCopyInto(out *KeycloakRealmIdentity
 	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmIdentityProvider.
-func (in *KeycloakRealmIdentityProvider) DeepCopy() *KeycloakRealmIdentityProvider {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientScope.
+func (in *KeycloakClientScope) DeepCopy() *KeycloakClientScope {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmIdentityProvider)
+	out := new(KeycloakClientScope)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakRealmIdentityProvider) DeepCopyObject() runtime.Object {
+func (in *KeycloakClientScope) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -844,31 +946,31 @@ func (in *KeycloakRealmIdentityProvider) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmIdentityProviderList) DeepCopyInto(out *KeycloakRealmIdentityProviderList) {
+func (in *KeycloakClientScopeList) DeepCopyInto(out *KeycloakClientScopeList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]KeycloakRealmIdentityProvider, len(*in))
+		*out = make([]KeycloakClientScope, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmIdentityProviderList.
-func (in *KeycloakRealmIdentityProviderList) DeepCopy() *KeycloakRealmIdentityProviderList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientScopeList.
+func (in *KeycloakClientScopeList) DeepCopy() *KeycloakClientScopeList {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmIdentityProviderList)
+	out := new(KeycloakClientScopeList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakRealmIdentityProviderList) DeepCopyObject() runtime.Object {
+func (in *KeycloakClientScopeList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -876,103 +978,254 @@ func (in *KeycloakRealmIdentityProviderList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmIdentityProviderSpec) DeepCopyInto(out *KeycloakRealmIdentityProviderSpec) {
+func (in *KeycloakClientScopeSpec) DeepCopyInto(out *KeycloakClientScopeSpec) {
 	*out = *in
 	out.RealmRef = in.RealmRef
-	if in.Config != nil {
-		in, out := &in.Config, &out.Config
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
-	if in.Mappers != nil {
-		in, out := &in.Mappers, &out.Mappers
-		*out = make([]IdentityProviderMapper, len(*in))
+	if in.ProtocolMappers != nil {
+		in, out := &in.ProtocolMappers, &out.ProtocolMappers
+		*out = make([]ProtocolMapper, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ScopeMappings != nil {
+		in, out := &in.ScopeMappings, &out.ScopeMappings
+		*out = new(ScopeMappings)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmIdentityProviderSpec.
-func (in *KeycloakRealmIdentityProviderSpec) DeepCopy() *KeycloakRealmIdentityProviderSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientScopeSpec.
+func (in *KeycloakClientScopeSpec) DeepCopy() *KeycloakClientScopeSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmIdentityProviderSpec)
+	out := new(KeycloakClientScopeSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmIdentityProviderStatus) DeepCopyInto(out *KeycloakRealmIdentityProviderStatus) {
+func (in *KeycloakClientScopeStatus) DeepCopyInto(out *KeycloakClientScopeStatus) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmIdentityProviderStatus.
-func (in *KeycloakRealmIdentityProviderStatus) DeepCopy() *KeycloakRealmIdentityProviderStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientScopeStatus.
+func (in *KeycloakClientScopeStatus) DeepCopy() *KeycloakClientScopeStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmIdentityProviderStatus)
+	out := new(KeycloakClientScopeStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmList) DeepCopyInto(out *KeycloakRealmList) {
+func (in *KeycloakClientSpec) DeepCopyInto(out *KeycloakClientSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]KeycloakRealm, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	out.RealmRef = in.RealmRef
+	if in.RealmRoles != nil {
+		in, out := &in.RealmRoles, &out.RealmRoles
+		*out = new([]RealmRole)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]RealmRole, len(*in))
+			copy(*out, *in)
+		}
+	}
+	if in.Protocol != nil {
+		in, out := &in.Protocol, &out.Protocol
+		*out = new(string)
+		**out = **in
+	}
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ClientRoles != nil {
+		in, out := &in.ClientRoles, &out.ClientRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ProtocolMappers != nil {
+		in, out := &in.ProtocolMappers, &out.ProtocolMappers
+		*out = new([]ProtocolMapper)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]ProtocolMapper, len(*in))
+			for i := range *in {
+				(*in)[i].DeepCopyInto(&(*out)[i])
+			}
 		}
 	}
+	if in.ServiceAccount != nil {
+		in, out := &in.ServiceAccount, &out.ServiceAccount
+		*out = new(ServiceAccount)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DefaultClientScopes != nil {
+		in, out := &in.DefaultClientScopes, &out.DefaultClientScopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DefaultClientScopeRefs != nil {
+		in, out := &in.DefaultClientScopeRefs, &out.DefaultClientScopeRefs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OptionalClientScopes != nil {
+		in, out := &in.OptionalClientScopes, &out.OptionalClientScopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OptionalClientScopeRefs != nil {
+		in, out := &in.OptionalClientScopeRefs, &out.OptionalClientScopeRefs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RedirectUris != nil {
+		in, out := &in.RedirectUris, &out.RedirectUris
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.WebOrigins != nil {
+		in, out := &in.WebOrigins, &out.WebOrigins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ScopeMappings != nil {
+		in, out := &in.ScopeMappings, &out.ScopeMappings
+		*out = new(ScopeMappings)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmList.
-func (in *KeycloakRealmList) DeepCopy() *KeycloakRealmList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientSpec.
+func (in *KeycloakClientSpec) DeepCopy() *KeycloakClientSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmList)
+	out := new(KeycloakClientSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakRealmList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakClientStatus) DeepCopyInto(out *KeycloakClientStatus) {
+	*out = *in
+	if in.SecretHashes != nil {
+		in, out := &in.SecretHashes, &out.SecretHashes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	return nil
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientStatus.
+func (in *KeycloakClientStatus) DeepCopy() *KeycloakClientStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakClientStatus)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmRole) DeepCopyInto(out *KeycloakRealmRole) {
+func (in *KeycloakComponentSpec) DeepCopyInto(out *KeycloakComponentSpec) {
+	*out = *in
+	out.RealmRef = in.RealmRef
+	if in.ParentRef != nil {
+		in, out := &in.ParentRef, &out.ParentRef
+		*out = new(ParentComponent)
+		**out = **in
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakComponentSpec.
+func (in *KeycloakComponentSpec) DeepCopy() *KeycloakComponentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakComponentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakComponentStatus) DeepCopyInto(out *KeycloakComponentStatus) {
+	*out = *in
+	if in.SecretHashes != nil {
+		in, out := &in.SecretHashes, &out.SecretHashes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakComponentStatus.
+func (in *KeycloakComponentStatus) DeepCopy() *KeycloakComponentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakComponentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakList) DeepCopyInto(out *KeycloakList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Keycloak, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmRole.
-func (in *KeycloakRealmRole) DeepCopy() *KeycloakRealmRole {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakList.
+func (in *KeycloakList) DeepCopy() *KeycloakList {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmRole)
+	out := new(KeycloakList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakRealmRole) DeepCopyObject() runtime.Object {
+func (in *KeycloakList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -980,7 +1233,7 @@ func (in *KeycloakRealmRole) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmRoleBatch) DeepCopyInto(out *KeycloakRealmRoleBatch) {
+func (in *KeycloakRealm) DeepCopyInto(out *KeycloakRealm) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -988,18 +1241,18 @@ func (in *KeycloakRealmRoleBatch) DeepCopyInto(out *KeycloakRealmRoleBatch) {
 	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmRoleBatch.
-func (in *KeycloakRealmRoleBatch) DeepCopy() *KeycloakRealmRoleBatch {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealm.
+func (in *KeycloakRealm) DeepCopy() *KeycloakRealm {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmRoleBatch)
+	out := new(KeycloakRealm)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakRealmRoleBatch) DeepCopyObject() runtime.Object {
+func (in *KeycloakRealm) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1007,31 +1260,26 @@ func (in *KeycloakRealmRoleBatch) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmRoleBatchList) DeepCopyInto(out *KeycloakRealmRoleBatchList) {
+func (in *KeycloakRealmComponent) DeepCopyInto(out *KeycloakRealmComponent) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]KeycloakRealmRoleBatch, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmRoleBatchList.
-func (in *KeycloakRealmRoleBatchList) DeepCopy() *KeycloakRealmRoleBatchList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmComponent.
+func (in *KeycloakRealmComponent) DeepCopy() *KeycloakRealmComponent {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmRoleBatchList)
+	out := new(KeycloakRealmComponent)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakRealmRoleBatchList) DeepCopyObject() runtime.Object {
+func (in *KeycloakRealmComponent) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1039,69 +1287,90 @@ func (in *KeycloakRealmRoleBatchList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmRoleBatchSpec) DeepCopyInto(out *KeycloakRealmRoleBatchSpec) {
+func (in *KeycloakRealmComponentList) DeepCopyInto(out *KeycloakRealmComponentList) {
 	*out = *in
-	out.RealmRef = in.RealmRef
-	if in.Roles != nil {
-		in, out := &in.Roles, &out.Roles
-		*out = make([]BatchRole, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeycloakRealmComponent, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmRoleBatchSpec.
-func (in *KeycloakRealmRoleBatchSpec) DeepCopy() *KeycloakRealmRoleBatchSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmComponentList.
+func (in *KeycloakRealmComponentList) DeepCopy() *KeycloakRealmComponentList {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmRoleBatchSpec)
+	out := new(KeycloakRealmComponentList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmComponentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmRoleBatchStatus) DeepCopyInto(out *KeycloakRealmRoleBatchStatus) {
+func (in *KeycloakRealmGroup) DeepCopyInto(out *KeycloakRealmGroup) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmRoleBatchStatus.
-func (in *KeycloakRealmRoleBatchStatus) DeepCopy() *KeycloakRealmRoleBatchStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmGroup.
+func (in *KeycloakRealmGroup) DeepCopy() *KeycloakRealmGroup {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmRoleBatchStatus)
+	out := new(KeycloakRealmGroup)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmRoleList) DeepCopyInto(out *KeycloakRealmRoleList) {
+func (in *KeycloakRealmGroupList) DeepCopyInto(out *KeycloakRealmGroupList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]KeycloakRealmRole, len(*in))
+		*out = make([]KeycloakRealmGroup, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmRoleList.
-func (in *KeycloakRealmRoleList) DeepCopy() *KeycloakRealmRoleList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmGroupList.
+func (in *KeycloakRealmGroupList) DeepCopy() *KeycloakRealmGroupList {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmRoleList)
+	out := new(KeycloakRealmGroupList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakRealmRoleList) DeepCopyObject() runtime.Object {
+func (in *KeycloakRealmGroupList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1109,7 +1378,7 @@ func (in *KeycloakRealmRoleList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmRoleSpec) DeepCopyInto(out *KeycloakRealmRoleSpec) {
+func (in *KeycloakRealmGroupSpec) DeepCopyInto(out *KeycloakRealmGroupSpec) {
 	*out = *in
 	out.RealmRef = in.RealmRef
 	if in.Attributes != nil {
@@ -1127,135 +1396,350 @@ func (in *KeycloakRealmRoleSpec) DeepCopyInto(out *KeycloakRealmRoleSpec) {
 			(*out)[key] = outVal
 		}
 	}
-	if in.Composites != nil {
-		in, out := &in.Composites, &out.Composites
-		*out = make([]Composite, len(*in))
+	if in.Access != nil {
+		in, out := &in.Access, &out.Access
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RealmRoles != nil {
+		in, out := &in.RealmRoles, &out.RealmRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SubGroups != nil {
+		in, out := &in.SubGroups, &out.SubGroups
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ParentGroupRef != nil {
+		in, out := &in.ParentGroupRef, &out.ParentGroupRef
+		*out = new(GroupRef)
+		**out = **in
+	}
+	if in.ClientRoles != nil {
+		in, out := &in.ClientRoles, &out.ClientRoles
+		*out = make([]ClientRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]GroupMember, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmRoleSpec.
-func (in *KeycloakRealmRoleSpec) DeepCopy() *KeycloakRealmRoleSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmGroupSpec.
+func (in *KeycloakRealmGroupSpec) DeepCopy() *KeycloakRealmGroupSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmRoleSpec)
+	out := new(KeycloakRealmGroupSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmRoleStatus) DeepCopyInto(out *KeycloakRealmRoleStatus) {
+func (in *KeycloakRealmGroupStatus) DeepCopyInto(out *KeycloakRealmGroupStatus) {
 	*out = *in
+	if in.MissingMembers != nil {
+		in, out := &in.MissingMembers, &out.MissingMembers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmRoleStatus.
-func (in *KeycloakRealmRoleStatus) DeepCopy() *KeycloakRealmRoleStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmGroupStatus.
+func (in *KeycloakRealmGroupStatus) DeepCopy() *KeycloakRealmGroupStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmRoleStatus)
+	out := new(KeycloakRealmGroupStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmSpec) DeepCopyInto(out *KeycloakRealmSpec) {
+func (in *KeycloakRealmIdentityProvider) DeepCopyInto(out *KeycloakRealmIdentityProvider) {
 	*out = *in
-	out.KeycloakRef = in.KeycloakRef
-	if in.SsoRealmEnabled != nil {
-		in, out := &in.SsoRealmEnabled, &out.SsoRealmEnabled
-		*out = new(bool)
-		**out = **in
-	}
-	if in.SsoAutoRedirectEnabled != nil {
-		in, out := &in.SsoAutoRedirectEnabled, &out.SsoAutoRedirectEnabled
-		*out = new(bool)
-		**out = **in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmIdentityProvider.
+func (in *KeycloakRealmIdentityProvider) DeepCopy() *KeycloakRealmIdentityProvider {
+	if in == nil {
+		return nil
 	}
-	if in.Users != nil {
-		in, out := &in.Users, &out.Users
-		*out = make([]User, len(*in))
-		for i := range *in {
+	out := new(KeycloakRealmIdentityProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmIdentityProvider) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmIdentityProviderList) DeepCopyInto(out *KeycloakRealmIdentityProviderList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeycloakRealmIdentityProvider, len(*in))
+		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.SSORealmMappers != nil {
-		in, out := &in.SSORealmMappers, &out.SSORealmMappers
-		*out = new([]SSORealmMapper)
-		if **in != nil {
-			in, out := *in, *out
-			*out = make([]SSORealmMapper, len(*in))
-			for i := range *in {
-				(*in)[i].DeepCopyInto(&(*out)[i])
-			}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmIdentityProviderList.
+func (in *KeycloakRealmIdentityProviderList) DeepCopy() *KeycloakRealmIdentityProviderList {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmIdentityProviderList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmIdentityProviderList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmIdentityProviderSpec) DeepCopyInto(out *KeycloakRealmIdentityProviderSpec) {
+	*out = *in
+	out.RealmRef = in.RealmRef
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
-	if in.BrowserFlow != nil {
-		in, out := &in.BrowserFlow, &out.BrowserFlow
-		*out = new(string)
-		**out = **in
+	if in.OIDC != nil {
+		in, out := &in.OIDC, &out.OIDC
+		*out = new(IdentityProviderOIDC)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.Themes != nil {
-		in, out := &in.Themes, &out.Themes
-		*out = new(RealmThemes)
+	if in.SAML != nil {
+		in, out := &in.SAML, &out.SAML
+		*out = new(IdentityProviderSAML)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.BrowserSecurityHeaders != nil {
-		in, out := &in.BrowserSecurityHeaders, &out.BrowserSecurityHeaders
-		*out = new(map[string]string)
-		if **in != nil {
-			in, out := *in, *out
-			*out = make(map[string]string, len(*in))
-			for key, val := range *in {
-				(*out)[key] = val
-			}
+	if in.Mappers != nil {
+		in, out := &in.Mappers, &out.Mappers
+		*out = make([]IdentityProviderMapper, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.ID != nil {
-		in, out := &in.ID, &out.ID
-		*out = new(string)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmIdentityProviderSpec.
+func (in *KeycloakRealmIdentityProviderSpec) DeepCopy() *KeycloakRealmIdentityProviderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmIdentityProviderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmIdentityProviderStatus) DeepCopyInto(out *KeycloakRealmIdentityProviderStatus) {
+	*out = *in
+	if in.SecretHashes != nil {
+		in, out := &in.SecretHashes, &out.SecretHashes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmIdentityProviderStatus.
+func (in *KeycloakRealmIdentityProviderStatus) DeepCopy() *KeycloakRealmIdentityProviderStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmIdentityProviderStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmKeyRotation) DeepCopyInto(out *KeycloakRealmKeyRotation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmKeyRotation.
+func (in *KeycloakRealmKeyRotation) DeepCopy() *KeycloakRealmKeyRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmKeyRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmKeyRotation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmKeyRotationList) DeepCopyInto(out *KeycloakRealmKeyRotationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeycloakRealmKeyRotation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmKeyRotationList.
+func (in *KeycloakRealmKeyRotationList) DeepCopy() *KeycloakRealmKeyRotationList {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmKeyRotationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmKeyRotationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmKeyRotationSpec) DeepCopyInto(out *KeycloakRealmKeyRotationSpec) {
+	*out = *in
+	out.RealmRef = in.RealmRef
+	if in.RotationPeriod != nil {
+		in, out := &in.RotationPeriod, &out.RotationPeriod
+		*out = new(metav1.Duration)
 		**out = **in
 	}
-	if in.RealmEventConfig != nil {
-		in, out := &in.RealmEventConfig, &out.RealmEventConfig
-		*out = new(RealmEventConfig)
-		(*in).DeepCopyInto(*out)
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
 	}
-	if in.PasswordPolicies != nil {
-		in, out := &in.PasswordPolicies, &out.PasswordPolicies
-		*out = make([]PasswordPolicy, len(*in))
-		copy(*out, *in)
+	if in.RetentionPeriod != nil {
+		in, out := &in.RetentionPeriod, &out.RetentionPeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(TLSSecretRef)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmSpec.
-func (in *KeycloakRealmSpec) DeepCopy() *KeycloakRealmSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmKeyRotationSpec.
+func (in *KeycloakRealmKeyRotationSpec) DeepCopy() *KeycloakRealmKeyRotationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmSpec)
+	out := new(KeycloakRealmKeyRotationSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmStatus) DeepCopyInto(out *KeycloakRealmStatus) {
+func (in *KeycloakRealmKeyRotationStatus) DeepCopyInto(out *KeycloakRealmKeyRotationStatus) {
 	*out = *in
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]RotatedKey, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NextRotation != nil {
+		in, out := &in.NextRotation, &out.NextRotation
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmStatus.
-func (in *KeycloakRealmStatus) DeepCopy() *KeycloakRealmStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmKeyRotationStatus.
+func (in *KeycloakRealmKeyRotationStatus) DeepCopy() *KeycloakRealmKeyRotationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmStatus)
+	out := new(KeycloakRealmKeyRotationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmUser) DeepCopyInto(out *KeycloakRealmUser) {
+func (in *KeycloakRealmList) DeepCopyInto(out *KeycloakRealmList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeycloakRealm, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmList.
+func (in *KeycloakRealmList) DeepCopy() *KeycloakRealmList {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmRole) DeepCopyInto(out *KeycloakRealmRole) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -1263,18 +1747,18 @@ func (in *KeycloakRealmUser) DeepCopyInto(out *KeycloakRealmUser) {
 	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmUser.
-func (in *KeycloakRealmUser) DeepCopy() *KeycloakRealmUser {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmRole.
+func (in *KeycloakRealmRole) DeepCopy() *KeycloakRealmRole {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmUser)
+	out := new(KeycloakRealmRole)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakRealmUser) DeepCopyObject() runtime.Object {
+func (in *KeycloakRealmRole) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1282,55 +1766,997 @@ func (in *KeycloakRealmUser) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmUserList) DeepCopyInto(out *KeycloakRealmUserList) {
+func (in *KeycloakRealmRoleBatch) DeepCopyInto(out *KeycloakRealmRoleBatch) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmRoleBatch.
+func (in *KeycloakRealmRoleBatch) DeepCopy() *KeycloakRealmRoleBatch {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmRoleBatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmRoleBatch) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmRoleBatchList) DeepCopyInto(out *KeycloakRealmRoleBatchList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeycloakRealmRoleBatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmRoleBatchList.
+func (in *KeycloakRealmRoleBatchList) DeepCopy() *KeycloakRealmRoleBatchList {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmRoleBatchList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmRoleBatchList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmRoleBatchSpec) DeepCopyInto(out *KeycloakRealmRoleBatchSpec) {
+	*out = *in
+	out.RealmRef = in.RealmRef
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]BatchRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmRoleBatchSpec.
+func (in *KeycloakRealmRoleBatchSpec) DeepCopy() *KeycloakRealmRoleBatchSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmRoleBatchSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmRoleBatchStatus) DeepCopyInto(out *KeycloakRealmRoleBatchStatus) {
+	*out = *in
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]BatchRoleStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmRoleBatchStatus.
+func (in *KeycloakRealmRoleBatchStatus) DeepCopy() *KeycloakRealmRoleBatchStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmRoleBatchStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmRoleList) DeepCopyInto(out *KeycloakRealmRoleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeycloakRealmRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmRoleList.
+func (in *KeycloakRealmRoleList) DeepCopy() *KeycloakRealmRoleList {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmRoleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmRoleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmRoleSpec) DeepCopyInto(out *KeycloakRealmRoleSpec) {
+	*out = *in
+	out.RealmRef = in.RealmRef
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Composites != nil {
+		in, out := &in.Composites, &out.Composites
+		*out = make([]Composite, len(*in))
+		copy(*out, *in)
+	}
+	if in.CompositesClientRoles != nil {
+		in, out := &in.CompositesClientRoles, &out.CompositesClientRoles
+		*out = make(map[string][]Composite, len(*in))
+		for key, val := range *in {
+			var outVal []Composite
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]Composite, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmRoleSpec.
+func (in *KeycloakRealmRoleSpec) DeepCopy() *KeycloakRealmRoleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmRoleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmRoleStatus) DeepCopyInto(out *KeycloakRealmRoleStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmRoleStatus.
+func (in *KeycloakRealmRoleStatus) DeepCopy() *KeycloakRealmRoleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmRoleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmSpec) DeepCopyInto(out *KeycloakRealmSpec) {
+	*out = *in
+	out.KeycloakRef = in.KeycloakRef
+	if in.SsoRealmEnabled != nil {
+		in, out := &in.SsoRealmEnabled, &out.SsoRealmEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SsoAutoRedirectEnabled != nil {
+		in, out := &in.SsoAutoRedirectEnabled, &out.SsoAutoRedirectEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]User, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SSORealmMappers != nil {
+		in, out := &in.SSORealmMappers, &out.SSORealmMappers
+		*out = new([]SSORealmMapper)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]SSORealmMapper, len(*in))
+			for i := range *in {
+				(*in)[i].DeepCopyInto(&(*out)[i])
+			}
+		}
+	}
+	if in.BrowserFlow != nil {
+		in, out := &in.BrowserFlow, &out.BrowserFlow
+		*out = new(string)
+		**out = **in
+	}
+	if in.Themes != nil {
+		in, out := &in.Themes, &out.Themes
+		*out = new(RealmThemes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BrowserSecurityHeaders != nil {
+		in, out := &in.BrowserSecurityHeaders, &out.BrowserSecurityHeaders
+		*out = new(map[string]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make(map[string]string, len(*in))
+			for key, val := range *in {
+				(*out)[key] = val
+			}
+		}
+	}
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(string)
+		**out = **in
+	}
+	if in.RealmEventConfig != nil {
+		in, out := &in.RealmEventConfig, &out.RealmEventConfig
+		*out = new(RealmEventConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PasswordPolicies != nil {
+		in, out := &in.PasswordPolicies, &out.PasswordPolicies
+		*out = make([]PasswordPolicy, len(*in))
+		copy(*out, *in)
+	}
+	if in.RevokeTokensBefore != nil {
+		in, out := &in.RevokeTokensBefore, &out.RevokeTokensBefore
+		*out = (*in).DeepCopy()
+	}
+	if in.UserProfileConfig != nil {
+		in, out := &in.UserProfileConfig, &out.UserProfileConfig
+		*out = new(UserProfileConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmSpec.
+func (in *KeycloakRealmSpec) DeepCopy() *KeycloakRealmSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmStatus) DeepCopyInto(out *KeycloakRealmStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmStatus.
+func (in *KeycloakRealmStatus) DeepCopy() *KeycloakRealmStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmUser) DeepCopyInto(out *KeycloakRealmUser) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmUser.
+func (in *KeycloakRealmUser) DeepCopy() *KeycloakRealmUser {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmUser) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmUserBatch) DeepCopyInto(out *KeycloakRealmUserBatch) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmUserBatch.
+func (in *KeycloakRealmUserBatch) DeepCopy() *KeycloakRealmUserBatch {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmUserBatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmUserBatch) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmUserBatchList) DeepCopyInto(out *KeycloakRealmUserBatchList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeycloakRealmUserBatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmUserBatchList.
+func (in *KeycloakRealmUserBatchList) DeepCopy() *KeycloakRealmUserBatchList {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmUserBatchList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmUserBatchList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmUserBatchSpec) DeepCopyInto(out *KeycloakRealmUserBatchSpec) {
+	*out = *in
+	out.RealmRef = in.RealmRef
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]BatchUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UsersFrom != nil {
+		in, out := &in.UsersFrom, &out.UsersFrom
+		*out = new(UsersFromConfigMap)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmUserBatchSpec.
+func (in *KeycloakRealmUserBatchSpec) DeepCopy() *KeycloakRealmUserBatchSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmUserBatchSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmUserBatchStatus) DeepCopyInto(out *KeycloakRealmUserBatchStatus) {
+	*out = *in
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]BatchUserStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmUserBatchStatus.
+func (in *KeycloakRealmUserBatchStatus) DeepCopy() *KeycloakRealmUserBatchStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmUserBatchStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmUserFederation) DeepCopyInto(out *KeycloakRealmUserFederation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmUserFederation.
+func (in *KeycloakRealmUserFederation) DeepCopy() *KeycloakRealmUserFederation {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmUserFederation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmUserFederation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmUserFederationList) DeepCopyInto(out *KeycloakRealmUserFederationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeycloakRealmUserFederation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmUserFederationList.
+func (in *KeycloakRealmUserFederationList) DeepCopy() *KeycloakRealmUserFederationList {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmUserFederationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmUserFederationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmUserFederationSpec) DeepCopyInto(out *KeycloakRealmUserFederationSpec) {
+	*out = *in
+	out.RealmRef = in.RealmRef
+	if in.LDAP != nil {
+		in, out := &in.LDAP, &out.LDAP
+		*out = new(UserFederationLDAP)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Kerberos != nil {
+		in, out := &in.Kerberos, &out.Kerberos
+		*out = new(UserFederationKerberos)
+		**out = **in
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Mappers != nil {
+		in, out := &in.Mappers, &out.Mappers
+		*out = make([]UserFederationMapper, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmUserFederationSpec.
+func (in *KeycloakRealmUserFederationSpec) DeepCopy() *KeycloakRealmUserFederationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmUserFederationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmUserFederationStatus) DeepCopyInto(out *KeycloakRealmUserFederationStatus) {
+	*out = *in
+	if in.LastSync != nil {
+		in, out := &in.LastSync, &out.LastSync
+		*out = new(UserFederationSyncStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmUserFederationStatus.
+func (in *KeycloakRealmUserFederationStatus) DeepCopy() *KeycloakRealmUserFederationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmUserFederationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmUserList) DeepCopyInto(out *KeycloakRealmUserList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeycloakRealmUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmUserList.
+func (in *KeycloakRealmUserList) DeepCopy() *KeycloakRealmUserList {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmUserList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmUserList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmUserSpec) DeepCopyInto(out *KeycloakRealmUserSpec) {
+	*out = *in
+	out.RealmRef = in.RealmRef
+	if in.RequiredUserActions != nil {
+		in, out := &in.RequiredUserActions, &out.RequiredUserActions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClientRoles != nil {
+		in, out := &in.ClientRoles, &out.ClientRoles
+		*out = make([]ClientRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FederatedIdentities != nil {
+		in, out := &in.FederatedIdentities, &out.FederatedIdentities
+		*out = make([]FederatedIdentity, len(*in))
+		copy(*out, *in)
+	}
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AttributesV2 != nil {
+		in, out := &in.AttributesV2, &out.AttributesV2
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	out.PasswordSecret = in.PasswordSecret
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmUserSpec.
+func (in *KeycloakRealmUserSpec) DeepCopy() *KeycloakRealmUserSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmUserSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmUserStatus) DeepCopyInto(out *KeycloakRealmUserStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmUserStatus.
+func (in *KeycloakRealmUserStatus) DeepCopy() *KeycloakRealmUserStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmUserStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakSpec) DeepCopyInto(out *KeycloakSpec) {
+	*out = *in
+	if in.FallbackUrls != nil {
+		in, out := &in.FallbackUrls, &out.FallbackUrls
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakSpec.
+func (in *KeycloakSpec) DeepCopy() *KeycloakSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakStatus) DeepCopyInto(out *KeycloakStatus) {
+	*out = *in
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = new(common.KeycloakProviders)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastConnected != nil {
+		in, out := &in.LastConnected, &out.LastConnected
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakStatus.
+func (in *KeycloakStatus) DeepCopy() *KeycloakStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LevelOfAuthentication) DeepCopyInto(out *LevelOfAuthentication) {
+	*out = *in
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LevelOfAuthentication.
+func (in *LevelOfAuthentication) DeepCopy() *LevelOfAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(LevelOfAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParentComponent) DeepCopyInto(out *ParentComponent) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParentComponent.
+func (in *ParentComponent) DeepCopy() *ParentComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(ParentComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordPolicy) DeepCopyInto(out *PasswordPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordPolicy.
+func (in *PasswordPolicy) DeepCopy() *PasswordPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordSecret) DeepCopyInto(out *PasswordSecret) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordSecret.
+func (in *PasswordSecret) DeepCopy() *PasswordSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProtocolMapper) DeepCopyInto(out *ProtocolMapper) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProtocolMapper.
+func (in *ProtocolMapper) DeepCopy() *ProtocolMapper {
+	if in == nil {
+		return nil
+	}
+	out := new(ProtocolMapper)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RealmEventConfig) DeepCopyInto(out *RealmEventConfig) {
+	*out = *in
+	if in.EnabledEventTypes != nil {
+		in, out := &in.EnabledEventTypes, &out.EnabledEventTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EventsListeners != nil {
+		in, out := &in.EventsListeners, &out.EventsListeners
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmEventConfig.
+func (in *RealmEventConfig) DeepCopy() *RealmEventConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RealmEventConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RealmRole) DeepCopyInto(out *RealmRole) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmRole.
+func (in *RealmRole) DeepCopy() *RealmRole {
+	if in == nil {
+		return nil
+	}
+	out := new(RealmRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RealmThemes) DeepCopyInto(out *RealmThemes) {
+	*out = *in
+	if in.LoginTheme != nil {
+		in, out := &in.LoginTheme, &out.LoginTheme
+		*out = new(string)
+		**out = **in
+	}
+	if in.AccountTheme != nil {
+		in, out := &in.AccountTheme, &out.AccountTheme
+		*out = new(string)
+		**out = **in
+	}
+	if in.AdminConsoleTheme != nil {
+		in, out := &in.AdminConsoleTheme, &out.AdminConsoleTheme
+		*out = new(string)
+		**out = **in
+	}
+	if in.EmailTheme != nil {
+		in, out := &in.EmailTheme, &out.EmailTheme
+		*out = new(string)
+		**out = **in
+	}
+	if in.InternationalizationEnabled != nil {
+		in, out := &in.InternationalizationEnabled, &out.InternationalizationEnabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmThemes.
+func (in *RealmThemes) DeepCopy() *RealmThemes {
+	if in == nil {
+		return nil
+	}
+	out := new(RealmThemes)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RotatedKey) DeepCopyInto(out *RotatedKey) {
+	*out = *in
+	in.CreatedAt.DeepCopyInto(&out.CreatedAt)
+	if in.PassiveSince != nil {
+		in, out := &in.PassiveSince, &out.PassiveSince
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RotatedKey.
+func (in *RotatedKey) DeepCopy() *RotatedKey {
+	if in == nil {
+		return nil
+	}
+	out := new(RotatedKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSORealmMapper) DeepCopyInto(out *SSORealmMapper) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSORealmMapper.
+func (in *SSORealmMapper) DeepCopy() *SSORealmMapper {
+	if in == nil {
+		return nil
+	}
+	out := new(SSORealmMapper)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScopeMappings) DeepCopyInto(out *ScopeMappings) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]KeycloakRealmUser, len(*in))
+	if in.RealmRoles != nil {
+		in, out := &in.RealmRoles, &out.RealmRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClientRoles != nil {
+		in, out := &in.ClientRoles, &out.ClientRoles
+		*out = make([]ClientRole, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmUserList.
-func (in *KeycloakRealmUserList) DeepCopy() *KeycloakRealmUserList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScopeMappings.
+func (in *ScopeMappings) DeepCopy() *ScopeMappings {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmUserList)
+	out := new(ScopeMappings)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakRealmUserList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretKeyRef) DeepCopyInto(out *SecretKeyRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyRef.
+func (in *SecretKeyRef) DeepCopy() *SecretKeyRef {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(SecretKeyRef)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmUserSpec) DeepCopyInto(out *KeycloakRealmUserSpec) {
+func (in *ServiceAccount) DeepCopyInto(out *ServiceAccount) {
 	*out = *in
-	out.RealmRef = in.RealmRef
-	if in.RequiredUserActions != nil {
-		in, out := &in.RequiredUserActions, &out.RequiredUserActions
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Roles != nil {
-		in, out := &in.Roles, &out.Roles
+	if in.RealmRoles != nil {
+		in, out := &in.RealmRoles, &out.RealmRoles
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Groups != nil {
-		in, out := &in.Groups, &out.Groups
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.ClientRoles != nil {
+		in, out := &in.ClientRoles, &out.ClientRoles
+		*out = make([]ClientRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Attributes != nil {
 		in, out := &in.Attributes, &out.Attributes
@@ -1339,250 +2765,369 @@ func (in *KeycloakRealmUserSpec) DeepCopyInto(out *KeycloakRealmUserSpec) {
 			(*out)[key] = val
 		}
 	}
-	out.PasswordSecret = in.PasswordSecret
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmUserSpec.
-func (in *KeycloakRealmUserSpec) DeepCopy() *KeycloakRealmUserSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccount.
+func (in *ServiceAccount) DeepCopy() *ServiceAccount {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmUserSpec)
+	out := new(ServiceAccount)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmUserStatus) DeepCopyInto(out *KeycloakRealmUserStatus) {
+func (in *TLSSecretRef) DeepCopyInto(out *TLSSecretRef) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmUserStatus.
-func (in *KeycloakRealmUserStatus) DeepCopy() *KeycloakRealmUserStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSSecretRef.
+func (in *TLSSecretRef) DeepCopy() *TLSSecretRef {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmUserStatus)
+	out := new(TLSSecretRef)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakSpec) DeepCopyInto(out *KeycloakSpec) {
+func (in *User) DeepCopyInto(out *User) {
 	*out = *in
+	if in.RealmRoles != nil {
+		in, out := &in.RealmRoles, &out.RealmRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakSpec.
-func (in *KeycloakSpec) DeepCopy() *KeycloakSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new User.
+func (in *User) DeepCopy() *User {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakSpec)
+	out := new(User)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakStatus) DeepCopyInto(out *KeycloakStatus) {
+func (in *UserFederationKerberos) DeepCopyInto(out *UserFederationKerberos) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakStatus.
-func (in *KeycloakStatus) DeepCopy() *KeycloakStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserFederationKerberos.
+func (in *UserFederationKerberos) DeepCopy() *UserFederationKerberos {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakStatus)
+	out := new(UserFederationKerberos)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ParentComponent) DeepCopyInto(out *ParentComponent) {
+func (in *UserFederationLDAP) DeepCopyInto(out *UserFederationLDAP) {
 	*out = *in
+	if in.BindCredential != nil {
+		in, out := &in.BindCredential, &out.BindCredential
+		*out = new(SecretKeyRef)
+		**out = **in
+	}
+	if in.UserObjectClasses != nil {
+		in, out := &in.UserObjectClasses, &out.UserObjectClasses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ImportEnabled != nil {
+		in, out := &in.ImportEnabled, &out.ImportEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SyncRegistrations != nil {
+		in, out := &in.SyncRegistrations, &out.SyncRegistrations
+		*out = new(bool)
+		**out = **in
+	}
+	if in.StartTLS != nil {
+		in, out := &in.StartTLS, &out.StartTLS
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ConnectionTimeout != nil {
+		in, out := &in.ConnectionTimeout, &out.ConnectionTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ReadTimeout != nil {
+		in, out := &in.ReadTimeout, &out.ReadTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Pagination != nil {
+		in, out := &in.Pagination, &out.Pagination
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BatchSizeForSync != nil {
+		in, out := &in.BatchSizeForSync, &out.BatchSizeForSync
+		*out = new(int)
+		**out = **in
+	}
+	if in.FullSyncPeriod != nil {
+		in, out := &in.FullSyncPeriod, &out.FullSyncPeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ChangedSyncPeriod != nil {
+		in, out := &in.ChangedSyncPeriod, &out.ChangedSyncPeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParentComponent.
-func (in *ParentComponent) DeepCopy() *ParentComponent {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserFederationLDAP.
+func (in *UserFederationLDAP) DeepCopy() *UserFederationLDAP {
 	if in == nil {
 		return nil
 	}
-	out := new(ParentComponent)
+	out := new(UserFederationLDAP)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PasswordPolicy) DeepCopyInto(out *PasswordPolicy) {
+func (in *UserFederationMapper) DeepCopyInto(out *UserFederationMapper) {
 	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordPolicy.
-func (in *PasswordPolicy) DeepCopy() *PasswordPolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserFederationMapper.
+func (in *UserFederationMapper) DeepCopy() *UserFederationMapper {
 	if in == nil {
 		return nil
 	}
-	out := new(PasswordPolicy)
+	out := new(UserFederationMapper)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PasswordSecret) DeepCopyInto(out *PasswordSecret) {
+func (in *UserFederationSyncStatus) DeepCopyInto(out *UserFederationSyncStatus) {
 	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordSecret.
-func (in *PasswordSecret) DeepCopy() *PasswordSecret {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserFederationSyncStatus.
+func (in *UserFederationSyncStatus) DeepCopy() *UserFederationSyncStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PasswordSecret)
+	out := new(UserFederationSyncStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProtocolMapper) DeepCopyInto(out *ProtocolMapper) {
+func (in *UserProfileAttribute) DeepCopyInto(out *UserProfileAttribute) {
 	*out = *in
-	if in.Config != nil {
-		in, out := &in.Config, &out.Config
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = new(UserProfileAttributePermissions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Required != nil {
+		in, out := &in.Required, &out.Required
+		*out = new(UserProfileAttributeRequired)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(UserProfileAttributeSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
+	if in.Validations != nil {
+		in, out := &in.Validations, &out.Validations
+		*out = make(map[string]map[string]UserProfileAttributeValidation, len(*in))
+		for key, val := range *in {
+			var outVal map[string]UserProfileAttributeValidation
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(map[string]UserProfileAttributeValidation, len(*in))
+				for key, val := range *in {
+					(*out)[key] = *val.DeepCopy()
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProtocolMapper.
-func (in *ProtocolMapper) DeepCopy() *ProtocolMapper {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserProfileAttribute.
+func (in *UserProfileAttribute) DeepCopy() *UserProfileAttribute {
 	if in == nil {
 		return nil
 	}
-	out := new(ProtocolMapper)
+	out := new(UserProfileAttribute)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RealmEventConfig) DeepCopyInto(out *RealmEventConfig) {
+func (in *UserProfileAttributePermissions) DeepCopyInto(out *UserProfileAttributePermissions) {
 	*out = *in
-	if in.EnabledEventTypes != nil {
-		in, out := &in.EnabledEventTypes, &out.EnabledEventTypes
+	if in.Edit != nil {
+		in, out := &in.Edit, &out.Edit
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.EventsListeners != nil {
-		in, out := &in.EventsListeners, &out.EventsListeners
+	if in.View != nil {
+		in, out := &in.View, &out.View
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmEventConfig.
-func (in *RealmEventConfig) DeepCopy() *RealmEventConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserProfileAttributePermissions.
+func (in *UserProfileAttributePermissions) DeepCopy() *UserProfileAttributePermissions {
 	if in == nil {
 		return nil
 	}
-	out := new(RealmEventConfig)
+	out := new(UserProfileAttributePermissions)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RealmRole) DeepCopyInto(out *RealmRole) {
+func (in *UserProfileAttributeRequired) DeepCopyInto(out *UserProfileAttributeRequired) {
 	*out = *in
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmRole.
-func (in *RealmRole) DeepCopy() *RealmRole {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserProfileAttributeRequired.
+func (in *UserProfileAttributeRequired) DeepCopy() *UserProfileAttributeRequired {
 	if in == nil {
 		return nil
 	}
-	out := new(RealmRole)
+	out := new(UserProfileAttributeRequired)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RealmThemes) DeepCopyInto(out *RealmThemes) {
+func (in *UserProfileAttributeSelector) DeepCopyInto(out *UserProfileAttributeSelector) {
 	*out = *in
-	if in.LoginTheme != nil {
-		in, out := &in.LoginTheme, &out.LoginTheme
-		*out = new(string)
-		**out = **in
-	}
-	if in.AccountTheme != nil {
-		in, out := &in.AccountTheme, &out.AccountTheme
-		*out = new(string)
-		**out = **in
-	}
-	if in.AdminConsoleTheme != nil {
-		in, out := &in.AdminConsoleTheme, &out.AdminConsoleTheme
-		*out = new(string)
-		**out = **in
-	}
-	if in.EmailTheme != nil {
-		in, out := &in.EmailTheme, &out.EmailTheme
-		*out = new(string)
-		**out = **in
-	}
-	if in.InternationalizationEnabled != nil {
-		in, out := &in.InternationalizationEnabled, &out.InternationalizationEnabled
-		*out = new(bool)
-		**out = **in
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmThemes.
-func (in *RealmThemes) DeepCopy() *RealmThemes {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserProfileAttributeSelector.
+func (in *UserProfileAttributeSelector) DeepCopy() *UserProfileAttributeSelector {
 	if in == nil {
 		return nil
 	}
-	out := new(RealmThemes)
+	out := new(UserProfileAttributeSelector)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SSORealmMapper) DeepCopyInto(out *SSORealmMapper) {
+func (in *UserProfileAttributeValidation) DeepCopyInto(out *UserProfileAttributeValidation) {
 	*out = *in
-	if in.Config != nil {
-		in, out := &in.Config, &out.Config
+	if in.MapVal != nil {
+		in, out := &in.MapVal, &out.MapVal
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
+	if in.SliceVal != nil {
+		in, out := &in.SliceVal, &out.SliceVal
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSORealmMapper.
-func (in *SSORealmMapper) DeepCopy() *SSORealmMapper {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserProfileAttributeValidation.
+func (in *UserProfileAttributeValidation) DeepCopy() *UserProfileAttributeValidation {
 	if in == nil {
 		return nil
 	}
-	out := new(SSORealmMapper)
+	out := new(UserProfileAttributeValidation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServiceAccount) DeepCopyInto(out *ServiceAccount) {
+func (in *UserProfileConfig) DeepCopyInto(out *UserProfileConfig) {
 	*out = *in
-	if in.RealmRoles != nil {
-		in, out := &in.RealmRoles, &out.RealmRoles
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make([]UserProfileAttribute, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.ClientRoles != nil {
-		in, out := &in.ClientRoles, &out.ClientRoles
-		*out = make([]ClientRole, len(*in))
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]UserProfileGroup, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Attributes != nil {
-		in, out := &in.Attributes, &out.Attributes
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserProfileConfig.
+func (in *UserProfileConfig) DeepCopy() *UserProfileConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(UserProfileConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserProfileGroup) DeepCopyInto(out *UserProfileGroup) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
@@ -1590,32 +3135,27 @@ func (in *ServiceAccount) DeepCopyInto(out *ServiceAccount) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccount.
-func (in *ServiceAccount) DeepCopy() *ServiceAccount {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserProfileGroup.
+func (in *UserProfileGroup) DeepCopy() *UserProfileGroup {
 	if in == nil {
 		return nil
 	}
-	out := new(ServiceAccount)
+	out := new(UserProfileGroup)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *User) DeepCopyInto(out *User) {
+func (in *UsersFromConfigMap) DeepCopyInto(out *UsersFromConfigMap) {
 	*out = *in
-	if in.RealmRoles != nil {
-		in, out := &in.RealmRoles, &out.RealmRoles
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new User.
-func (in *User) DeepCopy() *User {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsersFromConfigMap.
+func (in *UsersFromConfigMap) DeepCopy() *UsersFromConfigMap {
 	if in == nil {
 		return nil
 	}
-	out := new(User)
+	out := new(UsersFromConfigMap)
 	in.DeepCopyInto(out)
 	return out
 }
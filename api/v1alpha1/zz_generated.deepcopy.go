@@ -6,6 +6,7 @@
 package v1alpha1
 
 import (
+	"github.com/epam/edp-keycloak-operator/api/common"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -111,8 +112,8 @@ func (in *ClusterKeycloak) DeepCopyInto(out *ClusterKeycloak) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterKeycloak.
@@ -288,6 +289,11 @@ func (in *ClusterKeycloakRealmStatus) DeepCopy() *ClusterKeycloakRealmStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterKeycloakSpec) DeepCopyInto(out *ClusterKeycloakSpec) {
 	*out = *in
+	if in.FallbackUrls != nil {
+		in, out := &in.FallbackUrls, &out.FallbackUrls
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterKeycloakSpec.
@@ -303,6 +309,20 @@ func (in *ClusterKeycloakSpec) DeepCopy() *ClusterKeycloakSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterKeycloakStatus) DeepCopyInto(out *ClusterKeycloakStatus) {
 	*out = *in
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = new(common.KeycloakProviders)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastConnected != nil {
+		in, out := &in.LastConnected, &out.LastConnected
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterKeycloakStatus.
@@ -3,9 +3,11 @@ package clusterkeycloak
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"time"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -21,6 +23,7 @@ import (
 
 type keycloakClientProvider interface {
 	CreateKeycloakClientFomAuthData(ctx context.Context, authData *helper.KeycloakAuthData) (keycloak.Client, error)
+	GetHealthyKeycloakUrl(ctx context.Context, urls []string) (string, error)
 }
 
 func NewReconcile(
@@ -48,6 +51,8 @@ type Reconciler struct {
 const (
 	failedConnectionRetryPeriod  = time.Second * 10
 	successConnectionRetryPeriod = time.Minute * 30
+	// healthProbePeriod is a period of keycloak urls health probing if fallback urls are configured.
+	healthProbePeriod = time.Minute
 )
 
 //+kubebuilder:rbac:groups=v1.edp.epam.com,resources=clusterkeycloaks,verbs=get;list;watch;create;update;patch;delete
@@ -81,6 +86,10 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 
 	log.Info("Reconciling ClusterKeycloak has been finished")
 
+	if len(clusterKeycloak.Spec.FallbackUrls) > 0 {
+		return reconcile.Result{RequeueAfter: healthProbePeriod}, nil
+	}
+
 	return reconcile.Result{
 		RequeueAfter: successConnectionRetryPeriod,
 	}, nil
@@ -109,25 +118,39 @@ func (r *Reconciler) updateConnectionStatusToKeycloak(ctx context.Context, insta
 	log := ctrl.LoggerFrom(ctx)
 	log.Info("Start updating connection status to ClusterKeycloak")
 
-	_, err := r.helper.CreateKeycloakClientFomAuthData(
-		ctx,
-		helper.MakeKeycloakAuthDataFromClusterKeycloak(instance, r.operatorNamespace),
-	)
+	kClient, activeUrl, err := r.connectToKeycloak(ctx, instance)
 	if err != nil {
 		log.Error(err, "Unable to connect to Keycloak")
 	}
 
-	connected := err == nil
+	status := instance.Status.DeepCopy()
+	status.Connected = err == nil
+	status.ActiveUrl = activeUrl
+
+	if status.Connected {
+		status.PathLayout = helper.KeycloakPathLayout(kClient.LegacyMode())
+
+		if serverStatus, serverErr := helper.GetKeycloakServerStatus(ctx, kClient); serverErr != nil {
+			log.Error(serverErr, "Unable to get Keycloak server info")
+		} else {
+			status.Version = serverStatus.Version
+			status.Features = serverStatus.Features
+			status.Providers = serverStatus.Providers
+		}
+
+		now := metav1.Now()
+		status.LastConnected = &now
+	}
 
-	if instance.Status.Connected == connected {
+	if reflect.DeepEqual(status, &instance.Status) {
 		log.Info("Connection status hasn't been changed", "status", instance.Status.Connected)
 
 		return nil
 	}
 
-	log.Info("Connection status has been changed", "from", instance.Status.Connected, "to", connected)
+	log.Info("Connection status has been changed", "from", instance.Status.Connected, "to", status.Connected)
 
-	instance.Status.Connected = connected
+	instance.Status = *status
 
 	err = r.client.Status().Update(ctx, instance)
 	if err != nil {
@@ -138,3 +161,21 @@ func (r *Reconciler) updateConnectionStatusToKeycloak(ctx context.Context, insta
 
 	return nil
 }
+
+// connectToKeycloak creates keycloak client for the first healthy keycloak url and returns the client with the url.
+func (r *Reconciler) connectToKeycloak(ctx context.Context, instance *keycloakApi.ClusterKeycloak) (keycloak.Client, string, error) {
+	url, err := r.helper.GetHealthyKeycloakUrl(ctx, instance.GetUrls())
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to find healthy keycloak url: %w", err)
+	}
+
+	authData := helper.MakeKeycloakAuthDataFromClusterKeycloak(instance, r.operatorNamespace)
+	authData.Url = url
+
+	kClient, err := r.helper.CreateKeycloakClientFomAuthData(ctx, authData)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to create keycloak client: %w", err)
+	}
+
+	return kClient, url, nil
+}
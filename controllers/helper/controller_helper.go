@@ -43,13 +43,55 @@ type ObjectWithKeycloakRef interface {
 	client.Object
 }
 
+// adapterBuilder creates keycloak client. Path layout is detected by probing requests to the server if it is empty.
 type adapterBuilder func(
 	ctx context.Context,
-	url, user, password, adminType string,
+	url, user, password, adminType, pathLayout string,
 	log logr.Logger,
 	restyClient *resty.Client,
 ) (keycloak.Client, error)
 
+func buildKeycloakAdapter(
+	ctx context.Context,
+	url, user, password, adminType, pathLayout string,
+	log logr.Logger,
+	restyClient *resty.Client,
+) (keycloak.Client, error) {
+	var (
+		goKeycloakAdapter *adapter.GoCloakAdapter
+		err               error
+	)
+
+	legacyMode := pathLayout == keycloakApi.KeycloakPathLayoutLegacy
+
+	if adminType == keycloakApi.KeycloakAdminTypeServiceAccount {
+		if pathLayout != "" {
+			goKeycloakAdapter, err = adapter.MakeFromServiceAccountWithLegacyMode(
+				ctx, url, user, password, "master", legacyMode, log, restyClient)
+		} else {
+			goKeycloakAdapter, err = adapter.MakeFromServiceAccount(ctx, url, user, password, "master", log, restyClient)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to make go keycloak adapter from seviceaccount: %w", err)
+		}
+
+		return goKeycloakAdapter, nil
+	}
+
+	if pathLayout != "" {
+		goKeycloakAdapter, err = adapter.MakeWithLegacyMode(ctx, url, user, password, legacyMode, log, restyClient)
+	} else {
+		goKeycloakAdapter, err = adapter.Make(ctx, url, user, password, log, restyClient)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to make go keycloak adapter: %w", err)
+	}
+
+	return goKeycloakAdapter, nil
+}
+
 // ControllerHelper interface defines methods for working with keycloak client and owner references.
 //
 //go:generate mockery --name ControllerHelper --filename helper_mock.go
@@ -82,31 +124,7 @@ func MakeHelper(client client.Client, scheme *runtime.Scheme, operatorNamespace
 		client:            client,
 		scheme:            scheme,
 		operatorNamespace: operatorNamespace,
-		adapterBuilder: func(
-			ctx context.Context,
-			url,
-			user,
-			password,
-			adminType string,
-			log logr.Logger,
-			restyClient *resty.Client,
-		) (keycloak.Client, error) {
-			if adminType == keycloakApi.KeycloakAdminTypeServiceAccount {
-				goKeycloakAdapter, err := adapter.MakeFromServiceAccount(ctx, url, user, password, "master", log, restyClient)
-				if err != nil {
-					return nil, fmt.Errorf("failed to make go keycloak adapter from seviceaccount: %w", err)
-				}
-
-				return goKeycloakAdapter, nil
-			}
-
-			goKeycloakAdapter, err := adapter.Make(ctx, url, user, password, log, restyClient)
-			if err != nil {
-				return nil, fmt.Errorf("failed to make go keycloak adapter: %w", err)
-			}
-
-			return goKeycloakAdapter, nil
-		},
+		adapterBuilder:    buildKeycloakAdapter,
 	}
 }
 
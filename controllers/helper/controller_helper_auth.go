@@ -2,6 +2,7 @@ package helper
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
 
 	"github.com/pkg/errors"
@@ -24,6 +25,9 @@ const (
 
 var ErrKeycloakIsNotAvailable = errors.New("keycloak is not available")
 
+// errTokenNotFound is returned when the token secret doesn't contain the token for the keycloak url.
+var errTokenNotFound = errors.New("token for the keycloak url is not found")
+
 // KeycloakAuthData contains data for keycloak authentication.
 type KeycloakAuthData struct {
 	// Url is keycloak url.
@@ -40,6 +44,15 @@ type KeycloakAuthData struct {
 
 	// KeycloakCRName is name of keycloak CR.
 	KeycloakCRName string
+
+	// PathLayout is keycloak API path layout detected by keycloak controller.
+	// If it is empty, the layout is detected during client creation.
+	PathLayout string
+
+	// ServerVersion and ServerFeatures are keycloak server version and enabled features detected by keycloak controller.
+	// They are used by the client to skip requests which are not supported by the server.
+	ServerVersion  string
+	ServerFeatures []string
 }
 
 func (h *Helper) CreateKeycloakClientFromRealmRef(ctx context.Context, object ObjectWithRealmRef) (keycloak.Client, error) {
@@ -70,7 +83,7 @@ func (h *Helper) CreateKeycloakClientFromClusterRealm(ctx context.Context, realm
 }
 
 func (h *Helper) CreateKeycloakClient(ctx context.Context, url, user, password, adminType string) (keycloak.Client, error) {
-	clientAdapter, err := h.adapterBuilder(ctx, url, user, password, adminType, ctrl.LoggerFrom(ctx), h.restyClient)
+	clientAdapter, err := h.adapterBuilder(ctx, url, user, password, adminType, "", ctrl.LoggerFrom(ctx), h.restyClient)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to init kc client adapter")
 	}
@@ -78,6 +91,27 @@ func (h *Helper) CreateKeycloakClient(ctx context.Context, url, user, password,
 	return clientAdapter, nil
 }
 
+// GetHealthyKeycloakUrl returns the first available keycloak url from the ordered list of urls.
+// Health probing is skipped if only one url is configured.
+func (h *Helper) GetHealthyKeycloakUrl(ctx context.Context, urls []string) (string, error) {
+	if len(urls) == 1 {
+		return urls[0], nil
+	}
+
+	log := ctrl.LoggerFrom(ctx)
+
+	for _, url := range urls {
+		err := adapter.CheckHealth(ctx, url, h.restyClient)
+		if err == nil {
+			return url, nil
+		}
+
+		log.Info("Keycloak url is not available", "url", url, "reason", err.Error())
+	}
+
+	return "", ErrKeycloakIsNotAvailable
+}
+
 func (h *Helper) InvalidateKeycloakClientTokenSecret(ctx context.Context, namespace, rootKeycloakName string) error {
 	var secret coreV1.Secret
 	if err := h.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: tokenSecretName(rootKeycloakName)},
@@ -101,7 +135,7 @@ func (h *Helper) CreateKeycloakClientFomAuthData(ctx context.Context, authData *
 		return clientAdapter, nil
 	}
 
-	if !k8sErrors.IsNotFound(err) && !adapter.IsErrTokenExpired(err) {
+	if !k8sErrors.IsNotFound(err) && !adapter.IsErrTokenExpired(err) && !errors.Is(err, errTokenNotFound) {
 		return nil, fmt.Errorf("unable to create kc client from token secret: %w", err)
 	}
 
@@ -122,18 +156,29 @@ func (h *Helper) createKeycloakClientFromLoginPassword(ctx context.Context, auth
 		return nil, errors.Wrap(err, "authData login password secret not found")
 	}
 
-	clientAdapter, err := h.CreateKeycloakClient(ctx, authData.Url, string(secret.Data["username"]),
-		string(secret.Data["password"]), authData.AdminType)
+	clientAdapter, err := h.adapterBuilder(
+		ctx,
+		authData.Url,
+		string(secret.Data["username"]),
+		string(secret.Data["password"]),
+		authData.AdminType,
+		authData.PathLayout,
+		ctrl.LoggerFrom(ctx),
+		h.restyClient,
+	)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to init authData client adapter")
 	}
 
+	clientAdapter.SetServerFeatures(authData.ServerVersion, authData.ServerFeatures)
+
 	jwtToken, err := clientAdapter.ExportToken()
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to export authData client token")
 	}
 
-	if err := h.saveKeycloakClientTokenSecret(ctx, tokenSecretName(authData.SecretName), secret.Namespace, jwtToken); err != nil {
+	if err := h.saveKeycloakClientTokenSecret(ctx, tokenSecretName(authData.SecretName), secret.Namespace,
+		authData.Url, jwtToken); err != nil {
 		return nil, errors.Wrap(err, "unable to save authData token to secret")
 	}
 
@@ -149,23 +194,55 @@ func (h *Helper) createKeycloakClientFromTokenSecret(ctx context.Context, authDa
 		return nil, errors.Wrap(err, "unable to get token secret")
 	}
 
-	clientAdapter, err := adapter.MakeFromToken(authData.Url, tokenSecret.Data[keycloakTokenSecretKey], ctrl.LoggerFrom(ctx))
+	tokenData, ok := tokenSecret.Data[tokenSecretKey(authData.Url)]
+	if !ok {
+		return nil, errTokenNotFound
+	}
+
+	var (
+		clientAdapter *adapter.GoCloakAdapter
+		err           error
+	)
+
+	if authData.PathLayout != "" {
+		clientAdapter, err = adapter.MakeFromTokenWithLegacyMode(
+			authData.Url,
+			tokenData,
+			authData.PathLayout == keycloakApi.KeycloakPathLayoutLegacy,
+			ctrl.LoggerFrom(ctx),
+		)
+	} else {
+		clientAdapter, err = adapter.MakeFromToken(authData.Url, tokenData, ctrl.LoggerFrom(ctx))
+	}
+
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to make authData client from token")
 	}
 
+	clientAdapter.SetServerFeatures(authData.ServerVersion, authData.ServerFeatures)
+
 	return clientAdapter, nil
 }
 
-func (h *Helper) saveKeycloakClientTokenSecret(ctx context.Context, secretName, secretNamespace string, token []byte) error {
+// saveKeycloakClientTokenSecret saves the token issued by the keycloak url to the token secret.
+// Tokens issued by other keycloak urls are kept in the secret.
+func (h *Helper) saveKeycloakClientTokenSecret(
+	ctx context.Context,
+	secretName, secretNamespace, url string,
+	token []byte,
+) error {
 	var secret coreV1.Secret
 
 	err := h.client.Get(ctx, types.NamespacedName{Namespace: secretNamespace, Name: secretName}, &secret)
 	if err == nil {
-		secret.Data = map[string][]byte{
-			keycloakTokenSecretKey: token,
+		if secret.Data == nil {
+			secret.Data = make(map[string][]byte)
 		}
 
+		// Token which is not bound to the url is stored by the previous operator versions.
+		delete(secret.Data, keycloakTokenSecretKey)
+		secret.Data[tokenSecretKey(url)] = token
+
 		if err = h.client.Update(ctx, &secret); err != nil {
 			return errors.Wrap(err, "unable to update token secret")
 		}
@@ -178,7 +255,7 @@ func (h *Helper) saveKeycloakClientTokenSecret(ctx context.Context, secretName,
 			Namespace: secretNamespace,
 			Name:      secretName,
 		}, Data: map[string][]byte{
-			keycloakTokenSecretKey: token,
+			tokenSecretKey(url): token,
 		}}
 
 		if err = h.client.Create(ctx, &secret); err != nil {
@@ -230,7 +307,12 @@ func (h *Helper) getKeycloakAuthDataFromRealm(ctx context.Context, realm *keyclo
 			return nil, ErrKeycloakIsNotAvailable
 		}
 
-		return MakeKeycloakAuthDataFromKeycloak(kc), nil
+		authData := MakeKeycloakAuthDataFromKeycloak(kc)
+		authData.PathLayout = kc.Status.PathLayout
+		authData.ServerVersion = kc.Status.Version
+		authData.ServerFeatures = kc.Status.Features
+
+		return authData, nil
 	case keycloakAlpha.ClusterKeycloakKind:
 		kc := &keycloakAlpha.ClusterKeycloak{}
 		if err := h.client.Get(ctx, types.NamespacedName{Name: name}, kc); err != nil {
@@ -241,7 +323,12 @@ func (h *Helper) getKeycloakAuthDataFromRealm(ctx context.Context, realm *keyclo
 			return nil, ErrKeycloakIsNotAvailable
 		}
 
-		return MakeKeycloakAuthDataFromClusterKeycloak(kc, h.operatorNamespace), nil
+		authData := MakeKeycloakAuthDataFromClusterKeycloak(kc, h.operatorNamespace)
+		authData.PathLayout = kc.Status.PathLayout
+		authData.ServerVersion = kc.Status.Version
+		authData.ServerFeatures = kc.Status.Features
+
+		return authData, nil
 	default:
 		return nil, fmt.Errorf("unknown keycloak kind: %s", kind)
 	}
@@ -257,12 +344,17 @@ func (h *Helper) getKeycloakAuthDataFromClusterRealm(ctx context.Context, realm
 		return nil, ErrKeycloakIsNotAvailable
 	}
 
-	return MakeKeycloakAuthDataFromClusterKeycloak(kc, h.operatorNamespace), nil
+	authData := MakeKeycloakAuthDataFromClusterKeycloak(kc, h.operatorNamespace)
+	authData.PathLayout = kc.Status.PathLayout
+	authData.ServerVersion = kc.Status.Version
+	authData.ServerFeatures = kc.Status.Features
+
+	return authData, nil
 }
 
 func MakeKeycloakAuthDataFromKeycloak(keycloak *keycloakApi.Keycloak) *KeycloakAuthData {
 	return &KeycloakAuthData{
-		Url:             keycloak.Spec.Url,
+		Url:             activeKeycloakUrl(keycloak.Spec.Url, keycloak.Status.ActiveUrl),
 		SecretName:      keycloak.Spec.Secret,
 		SecretNamespace: keycloak.Namespace,
 		AdminType:       keycloak.Spec.AdminType,
@@ -272,7 +364,7 @@ func MakeKeycloakAuthDataFromKeycloak(keycloak *keycloakApi.Keycloak) *KeycloakA
 
 func MakeKeycloakAuthDataFromClusterKeycloak(keycloak *keycloakAlpha.ClusterKeycloak, secretNamespace string) *KeycloakAuthData {
 	return &KeycloakAuthData{
-		Url:             keycloak.Spec.Url,
+		Url:             activeKeycloakUrl(keycloak.Spec.Url, keycloak.Status.ActiveUrl),
 		SecretName:      keycloak.Spec.Secret,
 		SecretNamespace: secretNamespace,
 		AdminType:       keycloak.Spec.AdminType,
@@ -280,6 +372,30 @@ func MakeKeycloakAuthDataFromClusterKeycloak(keycloak *keycloakAlpha.ClusterKeyc
 	}
 }
 
+// activeKeycloakUrl returns url which was selected by the health probing or the main url if it wasn't selected yet.
+func activeKeycloakUrl(url, activeUrl string) string {
+	if activeUrl != "" {
+		return activeUrl
+	}
+
+	return url
+}
+
+// KeycloakPathLayout returns keycloak API path layout for the status.
+func KeycloakPathLayout(legacyMode bool) string {
+	if legacyMode {
+		return keycloakApi.KeycloakPathLayoutLegacy
+	}
+
+	return keycloakApi.KeycloakPathLayoutCurrent
+}
+
 func tokenSecretName(keycloakName string) string {
 	return fmt.Sprintf("%s%s", keycloakTokenSecretPrefix, keycloakName)
 }
+
+// tokenSecretKey returns the token secret key for the keycloak url.
+// Token issuer depends on the url, so tokens are stored separately for each url.
+func tokenSecretKey(url string) string {
+	return fmt.Sprintf("%s-%x", keycloakTokenSecretKey, sha256.Sum256([]byte(url)))[:len(keycloakTokenSecretKey)+17]
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"net/http"
 	"strings"
 	"testing"
 	"time"
@@ -53,8 +54,8 @@ func TestCreateKeycloakClientFromLoginPassword_FailureExportToken(t *testing.T)
 	adapterMock := adapter.Mock{
 		ExportTokenErr: errors.New("export token fatal"),
 	}
-	helper.adapterBuilder = func(ctx context.Context, url, user, password, adminType string, log logr.Logger,
-		restyClient *resty.Client) (keycloak.Client, error) {
+	helper.adapterBuilder = func(ctx context.Context, url, user, password, adminType, pathLayout string,
+		log logr.Logger, restyClient *resty.Client) (keycloak.Client, error) {
 		return &adapterMock, nil
 	}
 
@@ -68,6 +69,52 @@ func TestCreateKeycloakClientFromLoginPassword_FailureExportToken(t *testing.T)
 	}
 }
 
+func TestCreateKeycloakClientFromLoginPassword_ServerFeatures(t *testing.T) {
+	s := scheme.Scheme
+	utilruntime.Must(keycloakApi.AddToScheme(s))
+
+	kc := keycloakApi.Keycloak{
+		Spec: keycloakApi.KeycloakSpec{
+			Secret: "test",
+		},
+	}
+	lpSecret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: kc.Spec.Secret,
+		},
+		Data: map[string][]byte{
+			"username": []byte("username"),
+			"password": []byte("password"),
+		},
+	}
+
+	cl := fake.NewClientBuilder().WithRuntimeObjects(&kc, &lpSecret).Build()
+
+	helper := MakeHelper(cl, s, "default")
+	adapterMock := adapter.Mock{}
+
+	var builtPathLayout string
+
+	helper.adapterBuilder = func(ctx context.Context, url, user, password, adminType, pathLayout string,
+		log logr.Logger, restyClient *resty.Client) (keycloak.Client, error) {
+		builtPathLayout = pathLayout
+
+		return &adapterMock, nil
+	}
+
+	authData := MakeKeycloakAuthDataFromKeycloak(&kc)
+	authData.PathLayout = keycloakApi.KeycloakPathLayoutLegacy
+	authData.ServerVersion = "21.1.1"
+	authData.ServerFeatures = []string{"ADMIN_FINE_GRAINED_AUTHZ"}
+
+	_, err := helper.createKeycloakClientFromLoginPassword(context.Background(), authData)
+	require.NoError(t, err)
+
+	require.Equal(t, keycloakApi.KeycloakPathLayoutLegacy, builtPathLayout)
+	require.Equal(t, "21.1.1", adapterMock.ServerVersion)
+	require.Equal(t, []string{"ADMIN_FINE_GRAINED_AUTHZ"}, adapterMock.ServerFeatures)
+}
+
 func TestCreateKeycloakClientFromLoginPassword(t *testing.T) {
 	s := scheme.Scheme
 	utilruntime.Must(keycloakApi.AddToScheme(s))
@@ -127,10 +174,41 @@ func TestHelper_SaveKeycloakClientTokenSecret(t *testing.T) {
 		client: cl,
 	}
 
-	err := h.saveKeycloakClientTokenSecret(context.Background(), "secret", "default", []byte("token"))
+	err := h.saveKeycloakClientTokenSecret(context.Background(), "secret", "default", "url", []byte("token"))
 	require.NoError(t, err)
 }
 
+func TestHelper_SaveKeycloakClientTokenSecret_PerUrl(t *testing.T) {
+	secret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "kc-token-kc"},
+		Data: map[string][]byte{
+			keycloakTokenSecretKey: []byte("legacy-token"),
+		},
+	}
+
+	h := Helper{client: fake.NewClientBuilder().WithObjects(&secret).Build()}
+
+	require.NoError(t, h.saveKeycloakClientTokenSecret(context.Background(), secret.Name, secret.Namespace,
+		"https://external", []byte("external-token")))
+	require.NoError(t, h.saveKeycloakClientTokenSecret(context.Background(), secret.Name, secret.Namespace,
+		"http://internal", []byte("internal-token")))
+
+	updated := corev1.Secret{}
+	require.NoError(t, h.client.Get(context.Background(), types.NamespacedName{Namespace: "ns", Name: secret.Name}, &updated))
+
+	require.Equal(t, map[string][]byte{
+		tokenSecretKey("https://external"): []byte("external-token"),
+		tokenSecretKey("http://internal"):  []byte("internal-token"),
+	}, updated.Data)
+
+	_, err := h.createKeycloakClientFromTokenSecret(context.Background(), &KeycloakAuthData{
+		Url:             "http://other",
+		SecretNamespace: "ns",
+		KeycloakCRName:  "kc",
+	})
+	require.ErrorIs(t, err, errTokenNotFound)
+}
+
 func TestHelper_CreateKeycloakClientFromTokenSecret(t *testing.T) {
 	s := scheme.Scheme
 	utilruntime.Must(keycloakApi.AddToScheme(s))
@@ -158,7 +236,7 @@ func TestHelper_CreateKeycloakClientFromTokenSecret(t *testing.T) {
 			Name: tokenSecretName(kc.Name),
 		},
 		Data: map[string][]byte{
-			keycloakTokenSecretKey: bts,
+			tokenSecretKey(kc.Spec.Url): bts,
 		},
 	}
 	cl := fake.NewClientBuilder().WithRuntimeObjects(&kc, &secret).Build()
@@ -206,7 +284,7 @@ func TestHelper_CreateKeycloakClientFromTokenSecret(t *testing.T) {
 			Name: tokenSecretName(kc.Name),
 		},
 		Data: map[string][]byte{
-			keycloakTokenSecretKey: bts,
+			tokenSecretKey(kc.Spec.Url): bts,
 		},
 	}
 	cl = fake.NewClientBuilder().WithRuntimeObjects(&kc, &secret).Build()
@@ -219,6 +297,34 @@ func TestHelper_CreateKeycloakClientFromTokenSecret(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestHelper_GetHealthyKeycloakUrl(t *testing.T) {
+	t.Parallel()
+
+	healthyServer := fakehttp.NewServerBuilder().
+		AddStringResponder("/realms/master", "{}").
+		BuildAndStart()
+	defer healthyServer.Close()
+
+	unhealthyServer := fakehttp.NewServerBuilder().
+		AddStringResponderWithCode(http.StatusBadGateway, "/realms/master", "").
+		AddStringResponderWithCode(http.StatusBadGateway, "/auth/realms/master", "").
+		BuildAndStart()
+	defer unhealthyServer.Close()
+
+	h := Helper{}
+
+	url, err := h.GetHealthyKeycloakUrl(context.Background(), []string{unhealthyServer.GetURL()})
+	require.NoError(t, err)
+	require.Equal(t, unhealthyServer.GetURL(), url, "single url should not be probed")
+
+	url, err = h.GetHealthyKeycloakUrl(context.Background(), []string{unhealthyServer.GetURL(), healthyServer.GetURL()})
+	require.NoError(t, err)
+	require.Equal(t, healthyServer.GetURL(), url)
+
+	_, err = h.GetHealthyKeycloakUrl(context.Background(), []string{unhealthyServer.GetURL(), unhealthyServer.GetURL() + "/"})
+	require.ErrorIs(t, err, ErrKeycloakIsNotAvailable)
+}
+
 func TestHelper_InvalidateKeycloakClientTokenSecret(t *testing.T) {
 	sec := corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: tokenSecretName("kc-name")},
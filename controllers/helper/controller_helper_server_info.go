@@ -0,0 +1,56 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/epam/edp-keycloak-operator/api/common"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+// KeycloakServerStatus is a keycloak server state which is reported in Keycloak and ClusterKeycloak statuses.
+type KeycloakServerStatus struct {
+	Version   string
+	Features  []string
+	Providers *common.KeycloakProviders
+}
+
+// GetKeycloakServerStatus returns keycloak server version, enabled features and providers.
+func GetKeycloakServerStatus(ctx context.Context, kClient keycloak.Client) (*KeycloakServerStatus, error) {
+	serverInfo, err := kClient.GetServerInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get server info: %w", err)
+	}
+
+	return &KeycloakServerStatus{
+		Version:   serverInfo.SystemInfo.Version,
+		Features:  serverInfo.EnabledFeatures(),
+		Providers: MakeKeycloakProviders(serverInfo),
+	}, nil
+}
+
+// statusComponentTypes are component provider types which are managed by the operator resources.
+var statusComponentTypes = []string{
+	adapter.KeyProviderType,
+	adapter.UserStorageProviderType,
+	adapter.LDAPStorageMapperType,
+}
+
+// MakeKeycloakProviders returns providers of the keycloak server which can be used in the operator resources.
+func MakeKeycloakProviders(serverInfo *adapter.ServerInfo) *common.KeycloakProviders {
+	components := make(map[string][]string, len(statusComponentTypes))
+
+	for _, providerType := range statusComponentTypes {
+		if ids := serverInfo.ComponentProviderIDs(providerType); len(ids) > 0 {
+			components[providerType] = ids
+		}
+	}
+
+	return &common.KeycloakProviders{
+		Authenticators:          serverInfo.AuthenticatorIDs(),
+		IdentityProviders:       serverInfo.IdentityProviderIDs(),
+		IdentityProviderMappers: serverInfo.IdentityProviderMapperIDs(),
+		Components:              components,
+	}
+}
@@ -151,8 +151,13 @@ func TestMakeHelper(t *testing.T) {
 	logger := mock.NewLogr()
 	h := MakeHelper(nil, nil, "default")
 	_, err := h.adapterBuilder(context.Background(), mockServer.GetURL(), "foo", "bar",
-		keycloakApi.KeycloakAdminTypeServiceAccount, logger, rCl)
+		keycloakApi.KeycloakAdminTypeServiceAccount, "", logger, rCl)
 	require.NoError(t, err)
+
+	kClient, err := h.adapterBuilder(context.Background(), mockServer.GetURL(), "foo", "bar",
+		keycloakApi.KeycloakAdminTypeServiceAccount, keycloakApi.KeycloakPathLayoutLegacy, logger, rCl)
+	require.NoError(t, err)
+	require.True(t, kClient.LegacyMode())
 }
 
 type testTerminator struct {
@@ -3,9 +3,11 @@ package keycloak
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"time"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -21,6 +23,7 @@ import (
 
 type Helper interface {
 	CreateKeycloakClientFomAuthData(ctx context.Context, authData *helper.KeycloakAuthData) (keycloak.Client, error)
+	GetHealthyKeycloakUrl(ctx context.Context, urls []string) (string, error)
 }
 
 func NewReconcileKeycloak(client client.Client, scheme *runtime.Scheme, helper Helper) *ReconcileKeycloak {
@@ -39,7 +42,11 @@ type ReconcileKeycloak struct {
 	successReconcileTimeout time.Duration
 }
 
-const connectionRetryPeriod = time.Second * 10
+const (
+	connectionRetryPeriod = time.Second * 10
+	// healthProbePeriod is a period of keycloak urls health probing if fallback urls are configured.
+	healthProbePeriod = time.Minute
+)
 
 //+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloaks,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloaks/status,verbs=get;update;patch
@@ -72,6 +79,10 @@ func (r *ReconcileKeycloak) Reconcile(ctx context.Context, req reconcile.Request
 
 	log.Info("Reconciling Keycloak has been finished")
 
+	if len(instance.Spec.FallbackUrls) > 0 {
+		return reconcile.Result{RequeueAfter: healthProbePeriod}, nil
+	}
+
 	return reconcile.Result{}, nil
 }
 
@@ -98,22 +109,39 @@ func (r *ReconcileKeycloak) updateConnectionStatusToKeycloak(ctx context.Context
 	log := ctrl.LoggerFrom(ctx)
 	log.Info("Start updating connection status to Keycloak")
 
-	_, err := r.helper.CreateKeycloakClientFomAuthData(ctx, helper.MakeKeycloakAuthDataFromKeycloak(instance))
+	kClient, activeUrl, err := r.connectToKeycloak(ctx, instance)
 	if err != nil {
 		log.Error(err, "Unable to connect to Keycloak")
 	}
 
-	connected := err == nil
+	status := instance.Status.DeepCopy()
+	status.Connected = err == nil
+	status.ActiveUrl = activeUrl
 
-	if instance.Status.Connected == connected {
+	if status.Connected {
+		status.PathLayout = helper.KeycloakPathLayout(kClient.LegacyMode())
+
+		if serverStatus, serverErr := helper.GetKeycloakServerStatus(ctx, kClient); serverErr != nil {
+			log.Error(serverErr, "Unable to get Keycloak server info")
+		} else {
+			status.Version = serverStatus.Version
+			status.Features = serverStatus.Features
+			status.Providers = serverStatus.Providers
+		}
+
+		now := metav1.Now()
+		status.LastConnected = &now
+	}
+
+	if reflect.DeepEqual(status, &instance.Status) {
 		log.Info("Connection status hasn't been changed", "status", instance.Status.Connected)
 
 		return nil
 	}
 
-	log.Info("Connection status has been changed", "from", instance.Status.Connected, "to", connected)
+	log.Info("Connection status has been changed", "from", instance.Status.Connected, "to", status.Connected)
 
-	instance.Status.Connected = connected
+	instance.Status = *status
 
 	err = r.client.Status().Update(ctx, instance)
 	if err != nil {
@@ -124,3 +152,21 @@ func (r *ReconcileKeycloak) updateConnectionStatusToKeycloak(ctx context.Context
 
 	return nil
 }
+
+// connectToKeycloak creates keycloak client for the first healthy keycloak url and returns the client with the url.
+func (r *ReconcileKeycloak) connectToKeycloak(ctx context.Context, instance *keycloakApi.Keycloak) (keycloak.Client, string, error) {
+	url, err := r.helper.GetHealthyKeycloakUrl(ctx, instance.GetUrls())
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to find healthy keycloak url: %w", err)
+	}
+
+	authData := helper.MakeKeycloakAuthDataFromKeycloak(instance)
+	authData.Url = url
+
+	kClient, err := r.helper.CreateKeycloakClientFomAuthData(ctx, authData)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to create keycloak client: %w", err)
+	}
+
+	return kClient, url, nil
+}
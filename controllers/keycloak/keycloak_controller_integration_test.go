@@ -76,7 +76,9 @@ var _ = Describe("Keycloak controller", func() {
 			if err != nil {
 				return false
 			}
-			return createdKeycloak.Status.Connected
+			return createdKeycloak.Status.Connected &&
+				createdKeycloak.Status.Version != "" &&
+				createdKeycloak.Status.LastConnected != nil
 		}, timeout, interval).Should(BeTrue())
 	})
 })
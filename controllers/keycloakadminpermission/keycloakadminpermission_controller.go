@@ -0,0 +1,193 @@
+package keycloakadminpermission
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/Nerzal/gocloak/v12"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/objectmeta"
+)
+
+const finalizerName = "keycloak.adminpermission.operator.finalizer.name"
+
+type Helper interface {
+	SetFailureCount(fc helper.FailureCountable) time.Duration
+	TryToDelete(ctx context.Context, obj client.Object, terminator helper.Terminator, finalizer string) (isDeleted bool, resultErr error)
+	SetRealmOwnerRef(ctx context.Context, object helper.ObjectWithRealmRef) error
+	GetKeycloakRealmFromRef(ctx context.Context, object helper.ObjectWithRealmRef, kcClient keycloak.Client) (*gocloak.RealmRepresentation, error)
+	CreateKeycloakClientFromRealmRef(ctx context.Context, object helper.ObjectWithRealmRef) (keycloak.Client, error)
+}
+
+type Reconcile struct {
+	client                  client.Client
+	helper                  Helper
+	successReconcileTimeout time.Duration
+}
+
+func NewReconcile(client client.Client, helper Helper) *Reconcile {
+	return &Reconcile{
+		client: client,
+		helper: helper,
+	}
+}
+
+func (r *Reconcile) SetupWithManager(mgr ctrl.Manager, successReconcileTimeout time.Duration) error {
+	r.successReconcileTimeout = successReconcileTimeout
+
+	pred := predicate.Funcs{
+		UpdateFunc: isSpecUpdated,
+	}
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&keycloakApi.KeycloakAdminPermission{}, builder.WithPredicates(pred)).
+		Complete(r)
+	if err != nil {
+		return fmt.Errorf("failed to setup KeycloakAdminPermission controller: %w", err)
+	}
+
+	return nil
+}
+
+func isSpecUpdated(e event.UpdateEvent) bool {
+	oo, ok := e.ObjectOld.(*keycloakApi.KeycloakAdminPermission)
+	if !ok {
+		return false
+	}
+
+	no, ok := e.ObjectNew.(*keycloakApi.KeycloakAdminPermission)
+	if !ok {
+		return false
+	}
+
+	return !reflect.DeepEqual(oo.Spec, no.Spec) ||
+		(oo.GetDeletionTimestamp().IsZero() && !no.GetDeletionTimestamp().IsZero())
+}
+
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakadminpermissions,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakadminpermissions/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakadminpermissions/finalizers,verbs=update
+
+// Reconcile is a loop for reconciling KeycloakAdminPermission object.
+func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (result reconcile.Result, resultErr error) {
+	log := ctrl.LoggerFrom(ctx)
+	log.Info("Reconciling KeycloakAdminPermission")
+
+	var instance keycloakApi.KeycloakAdminPermission
+	if err := r.client.Get(ctx, request.NamespacedName, &instance); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			log.Info("instance not found")
+			return
+		}
+
+		resultErr = fmt.Errorf("unable to get keycloak admin permission from k8s: %w", err)
+
+		return
+	}
+
+	if err := r.tryReconcile(ctx, &instance); err != nil {
+		if errors.Is(err, helper.ErrKeycloakIsNotAvailable) {
+			return ctrl.Result{
+				RequeueAfter: helper.RequeueOnKeycloakNotAvailablePeriod,
+			}, nil
+		}
+
+		instance.Status.Value = err.Error()
+		result.RequeueAfter = r.helper.SetFailureCount(&instance)
+
+		log.Error(err, "an error has occurred while handling keycloak admin permission", "name", request.Name)
+	} else {
+		helper.SetSuccessStatus(&instance)
+		result.RequeueAfter = r.successReconcileTimeout
+	}
+
+	instanceDeleted := !controllerutil.ContainsFinalizer(&instance, finalizerName) &&
+		instance.GetDeletionTimestamp() != nil
+
+	if !instanceDeleted {
+		if err := r.client.Status().Update(ctx, &instance); err != nil {
+			resultErr = fmt.Errorf("unable to update status: %w", err)
+		}
+	}
+
+	log.Info("Reconciling KeycloakAdminPermission done")
+
+	return
+}
+
+func (r *Reconcile) tryReconcile(ctx context.Context, permission *keycloakApi.KeycloakAdminPermission) error {
+	if err := r.helper.SetRealmOwnerRef(ctx, permission); err != nil {
+		return fmt.Errorf("unable to set realm owner ref: %w", err)
+	}
+
+	kClient, err := r.helper.CreateKeycloakClientFromRealmRef(ctx, permission)
+	if err != nil {
+		return fmt.Errorf("unable to create keycloak client from realm ref: %w", err)
+	}
+
+	realm, err := r.helper.GetKeycloakRealmFromRef(ctx, permission, kClient)
+	if err != nil {
+		return fmt.Errorf("unable to get keycloak realm from ref: %w", err)
+	}
+
+	realmName := gocloak.PString(realm.Realm)
+
+	if deleted, err := r.helper.TryToDelete(
+		ctx,
+		permission,
+		makeTerminator(
+			realmName,
+			permission.Spec.Target,
+			permission.Status.Policies,
+			kClient,
+			objectmeta.PreserveResourcesOnDeletion(permission),
+		),
+		finalizerName,
+	); err != nil {
+		return fmt.Errorf("unable to delete admin permission: %w", err)
+	} else if deleted {
+		return nil
+	}
+
+	if err = validateAdminPermission(&permission.Spec); err != nil {
+		return fmt.Errorf("invalid admin permission: %w", err)
+	}
+
+	managementPermissions, err := kClient.SetManagementPermissions(
+		ctx,
+		realmName,
+		permission.Spec.Target.Type,
+		permission.Spec.Target.Name,
+		true,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to enable management permissions: %w", err)
+	}
+
+	permission.Status.ScopePermissions = managementPermissions.ScopePermissions
+
+	policyIDs, err := syncPolicies(ctx, kClient, realmName, permission)
+	if err != nil {
+		return fmt.Errorf("unable to sync policies: %w", err)
+	}
+
+	if err = bindScopes(ctx, kClient, realmName, permission, policyIDs); err != nil {
+		return fmt.Errorf("unable to bind scopes to policies: %w", err)
+	}
+
+	return nil
+}
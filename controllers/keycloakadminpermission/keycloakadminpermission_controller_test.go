@@ -0,0 +1,315 @@
+package keycloakadminpermission
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Nerzal/gocloak/v12"
+	testifymock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/epam/edp-keycloak-operator/api/common"
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	helpermock "github.com/epam/edp-keycloak-operator/controllers/helper/mocks"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+const ns = "default"
+
+func getTestPermission() *keycloakApi.KeycloakAdminPermission {
+	return &keycloakApi.KeycloakAdminPermission{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-a", Namespace: ns},
+		Spec: keycloakApi.KeycloakAdminPermissionSpec{
+			RealmRef: common.RealmRef{Kind: keycloakApi.KeycloakRealmKind, Name: "realm"},
+			Target: keycloakApi.AdminPermissionTarget{
+				Type: adapter.AdminPermissionTargetGroup,
+				Name: "team-a",
+			},
+			Policies: []keycloakApi.AdminPermissionPolicy{
+				{
+					Name:   "team-a-leads",
+					Type:   adapter.AdminPermissionPolicyTypeGroup,
+					Groups: []keycloakApi.AdminPermissionPolicyGroup{{Name: "/team-a/leads"}},
+				},
+			},
+			Permissions: []keycloakApi.AdminScopePermission{
+				{Scope: "manage-members", Policies: []string{"team-a-leads"}},
+				{Scope: "view", Policies: []string{"team-a-leads", "admins"}, DecisionStrategy: "AFFIRMATIVE"},
+			},
+		},
+	}
+}
+
+func groupManagementPermissions() *adapter.ManagementPermissions {
+	return &adapter.ManagementPermissions{
+		Enabled:  true,
+		Resource: "resource-id",
+		ScopePermissions: map[string]string{
+			"manage-members": "manage-members-id",
+			"view":           "view-id",
+		},
+	}
+}
+
+func TestReconcile_Reconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(scheme))
+	utilruntime.Must(corev1.AddToScheme(scheme))
+
+	realmMocks := func(h *helpermock.ControllerHelper, kClient *adapter.Mock) {
+		h.On("SetRealmOwnerRef", testifymock.Anything, testifymock.Anything).Return(nil)
+		h.On("CreateKeycloakClientFromRealmRef", testifymock.Anything, testifymock.Anything).Return(kClient, nil)
+		h.On("GetKeycloakRealmFromRef", testifymock.Anything, testifymock.Anything, kClient).
+			Return(&gocloak.RealmRepresentation{Realm: gocloak.StringP("realm"), ID: gocloak.StringP("realm-id")}, nil)
+		h.On("TryToDelete", testifymock.Anything, testifymock.Anything, testifymock.Anything, finalizerName).
+			Return(false, nil)
+	}
+
+	tests := []struct {
+		name        string
+		permission  func() *keycloakApi.KeycloakAdminPermission
+		setupMocks  func(h *helpermock.ControllerHelper, kClient *adapter.Mock)
+		wantResult  reconcile.Result
+		checkStatus func(t *testing.T, status *keycloakApi.KeycloakAdminPermissionStatus)
+	}{
+		{
+			name:       "permissions are enabled and scopes are bound",
+			permission: getTestPermission,
+			setupMocks: func(h *helpermock.ControllerHelper, kClient *adapter.Mock) {
+				realmMocks(h, kClient)
+				kClient.On("SetManagementPermissions", "realm", adapter.AdminPermissionTargetGroup, "team-a", true).
+					Return(groupManagementPermissions(), nil)
+				kClient.On("SyncAdminPermissionPolicy", "realm", &adapter.AdminPermissionPolicy{
+					Name:   "team-a-leads",
+					Type:   adapter.AdminPermissionPolicyTypeGroup,
+					Groups: []adapter.AdminPermissionPolicyGroup{{Group: "/team-a/leads"}},
+				}).Return("team-a-leads-id", nil)
+				kClient.On("GetAdminPermissionPolicyID", "realm", "admins").Return("admins-id", nil)
+				kClient.On("SetScopePermissionPolicies", "realm", "manage-members-id", []string{"team-a-leads-id"}, "").
+					Return(nil)
+				kClient.On("SetScopePermissionPolicies", "realm", "view-id", []string{"team-a-leads-id", "admins-id"}, "AFFIRMATIVE").
+					Return(nil)
+			},
+			wantResult: reconcile.Result{RequeueAfter: time.Hour},
+			checkStatus: func(t *testing.T, status *keycloakApi.KeycloakAdminPermissionStatus) {
+				require.Equal(t, helper.StatusOK, status.Value)
+				require.Equal(t, []string{"team-a-leads"}, status.Policies)
+				require.Equal(t, groupManagementPermissions().ScopePermissions, status.ScopePermissions)
+			},
+		},
+		{
+			name: "policy removed from spec is deleted",
+			permission: func() *keycloakApi.KeycloakAdminPermission {
+				p := getTestPermission()
+				p.Spec.Permissions = p.Spec.Permissions[:1]
+				p.Status.Policies = []string{"team-a-leads", "removed"}
+
+				return p
+			},
+			setupMocks: func(h *helpermock.ControllerHelper, kClient *adapter.Mock) {
+				realmMocks(h, kClient)
+				kClient.On("SetManagementPermissions", "realm", adapter.AdminPermissionTargetGroup, "team-a", true).
+					Return(groupManagementPermissions(), nil)
+				kClient.On("SyncAdminPermissionPolicy", "realm", testifymock.Anything).Return("team-a-leads-id", nil)
+				kClient.On("DeleteAdminPermissionPolicy", "realm", "removed").Return(nil)
+				kClient.On("SetScopePermissionPolicies", "realm", "manage-members-id", []string{"team-a-leads-id"}, "").
+					Return(nil)
+			},
+			wantResult: reconcile.Result{RequeueAfter: time.Hour},
+			checkStatus: func(t *testing.T, status *keycloakApi.KeycloakAdminPermissionStatus) {
+				require.Equal(t, helper.StatusOK, status.Value)
+				require.Equal(t, []string{"team-a-leads"}, status.Policies)
+			},
+		},
+		{
+			name: "unknown scope",
+			permission: func() *keycloakApi.KeycloakAdminPermission {
+				p := getTestPermission()
+				p.Spec.Permissions = []keycloakApi.AdminScopePermission{{Scope: "impersonate"}}
+
+				return p
+			},
+			setupMocks: func(h *helpermock.ControllerHelper, kClient *adapter.Mock) {
+				realmMocks(h, kClient)
+				kClient.On("SetManagementPermissions", "realm", adapter.AdminPermissionTargetGroup, "team-a", true).
+					Return(groupManagementPermissions(), nil)
+				kClient.On("SyncAdminPermissionPolicy", "realm", testifymock.Anything).Return("team-a-leads-id", nil)
+				h.On("SetFailureCount", testifymock.Anything).Return(time.Minute)
+			},
+			wantResult: reconcile.Result{RequeueAfter: time.Minute},
+			checkStatus: func(t *testing.T, status *keycloakApi.KeycloakAdminPermissionStatus) {
+				require.Contains(t, status.Value,
+					"scope impersonate is not available for group target, available scopes: manage-members, view")
+			},
+		},
+		{
+			name: "referenced policy not found",
+			permission: func() *keycloakApi.KeycloakAdminPermission {
+				p := getTestPermission()
+				p.Spec.Policies = nil
+				p.Spec.Permissions = []keycloakApi.AdminScopePermission{{Scope: "view", Policies: []string{"admins"}}}
+
+				return p
+			},
+			setupMocks: func(h *helpermock.ControllerHelper, kClient *adapter.Mock) {
+				realmMocks(h, kClient)
+				kClient.On("SetManagementPermissions", "realm", adapter.AdminPermissionTargetGroup, "team-a", true).
+					Return(groupManagementPermissions(), nil)
+				kClient.On("GetAdminPermissionPolicyID", "realm", "admins").
+					Return("", adapter.NotFoundError("policy not found"))
+				h.On("SetFailureCount", testifymock.Anything).Return(time.Minute)
+			},
+			wantResult: reconcile.Result{RequeueAfter: time.Minute},
+			checkStatus: func(t *testing.T, status *keycloakApi.KeycloakAdminPermissionStatus) {
+				require.Contains(t, status.Value, "unable to get policy admins")
+			},
+		},
+		{
+			name: "target name is required",
+			permission: func() *keycloakApi.KeycloakAdminPermission {
+				p := getTestPermission()
+				p.Spec.Target.Name = ""
+
+				return p
+			},
+			setupMocks: func(h *helpermock.ControllerHelper, kClient *adapter.Mock) {
+				realmMocks(h, kClient)
+				h.On("SetFailureCount", testifymock.Anything).Return(time.Minute)
+			},
+			wantResult: reconcile.Result{RequeueAfter: time.Minute},
+			checkStatus: func(t *testing.T, status *keycloakApi.KeycloakAdminPermissionStatus) {
+				require.Contains(t, status.Value, "target name is required for group target")
+			},
+		},
+		{
+			name:       "failed to enable management permissions",
+			permission: getTestPermission,
+			setupMocks: func(h *helpermock.ControllerHelper, kClient *adapter.Mock) {
+				realmMocks(h, kClient)
+				kClient.On("SetManagementPermissions", "realm", adapter.AdminPermissionTargetGroup, "team-a", true).
+					Return(nil, errors.New("admin fine-grained permissions feature is disabled"))
+				h.On("SetFailureCount", testifymock.Anything).Return(time.Minute)
+			},
+			wantResult: reconcile.Result{RequeueAfter: time.Minute},
+			checkStatus: func(t *testing.T, status *keycloakApi.KeycloakAdminPermissionStatus) {
+				require.Contains(t, status.Value, "unable to enable management permissions")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			permission := tt.permission()
+			cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(permission).Build()
+			h := helpermock.NewControllerHelper(t)
+			kClient := new(adapter.Mock)
+			tt.setupMocks(h, kClient)
+
+			r := NewReconcile(cl, h)
+			r.successReconcileTimeout = time.Hour
+
+			res, err := r.Reconcile(context.Background(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: permission.Name, Namespace: ns},
+			})
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantResult, res)
+
+			got := &keycloakApi.KeycloakAdminPermission{}
+			require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Name: permission.Name, Namespace: ns}, got))
+			tt.checkStatus(t, &got.Status)
+			kClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestValidateAdminPermission(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    func() *keycloakApi.KeycloakAdminPermissionSpec
+		wantErr string
+	}{
+		{
+			name: "valid",
+			spec: func() *keycloakApi.KeycloakAdminPermissionSpec {
+				return &getTestPermission().Spec
+			},
+		},
+		{
+			name: "name is not allowed for users",
+			spec: func() *keycloakApi.KeycloakAdminPermissionSpec {
+				s := &getTestPermission().Spec
+				s.Target.Type = adapter.AdminPermissionTargetUsers
+
+				return s
+			},
+			wantErr: "target name is not allowed for users target",
+		},
+		{
+			name: "duplicated policy",
+			spec: func() *keycloakApi.KeycloakAdminPermissionSpec {
+				s := &getTestPermission().Spec
+				s.Policies = append(s.Policies, s.Policies[0])
+
+				return s
+			},
+			wantErr: "policy team-a-leads is duplicated",
+		},
+		{
+			name: "empty role policy",
+			spec: func() *keycloakApi.KeycloakAdminPermissionSpec {
+				s := &getTestPermission().Spec
+				s.Policies[0].Type = adapter.AdminPermissionPolicyTypeRole
+
+				return s
+			},
+			wantErr: "role policy team-a-leads should contain at least one role",
+		},
+		{
+			name: "duplicated scope",
+			spec: func() *keycloakApi.KeycloakAdminPermissionSpec {
+				s := &getTestPermission().Spec
+				s.Permissions = append(s.Permissions, s.Permissions[0])
+
+				return s
+			},
+			wantErr: "permission for scope manage-members is duplicated",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAdminPermission(tt.spec())
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestIsSpecUpdated(t *testing.T) {
+	old := getTestPermission()
+	updated := getTestPermission()
+
+	require.False(t, isSpecUpdated(event.UpdateEvent{ObjectOld: old, ObjectNew: updated}))
+
+	updated.Spec.Permissions[0].Scope = "view-members"
+
+	require.True(t, isSpecUpdated(event.UpdateEvent{ObjectOld: old, ObjectNew: updated}))
+}
@@ -0,0 +1,170 @@
+package keycloakadminpermission
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/exp/maps"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+func validateAdminPermission(spec *keycloakApi.KeycloakAdminPermissionSpec) error {
+	if spec.Target.Type == adapter.AdminPermissionTargetUsers && spec.Target.Name != "" {
+		return errors.New("target name is not allowed for users target")
+	}
+
+	if spec.Target.Type != adapter.AdminPermissionTargetUsers && spec.Target.Name == "" {
+		return fmt.Errorf("target name is required for %s target", spec.Target.Type)
+	}
+
+	policies := make(map[string]struct{}, len(spec.Policies))
+
+	for i := range spec.Policies {
+		p := &spec.Policies[i]
+
+		if _, ok := policies[p.Name]; ok {
+			return fmt.Errorf("policy %s is duplicated", p.Name)
+		}
+
+		policies[p.Name] = struct{}{}
+
+		if (p.Type == adapter.AdminPermissionPolicyTypeUser && len(p.Users) == 0) ||
+			(p.Type == adapter.AdminPermissionPolicyTypeGroup && len(p.Groups) == 0) ||
+			(p.Type == adapter.AdminPermissionPolicyTypeRole && len(p.Roles) == 0) {
+			return fmt.Errorf("%s policy %s should contain at least one %s", p.Type, p.Name, p.Type)
+		}
+	}
+
+	scopes := make(map[string]struct{}, len(spec.Permissions))
+
+	for _, p := range spec.Permissions {
+		if _, ok := scopes[p.Scope]; ok {
+			return fmt.Errorf("permission for scope %s is duplicated", p.Scope)
+		}
+
+		scopes[p.Scope] = struct{}{}
+	}
+
+	return nil
+}
+
+func toAdapterPolicy(policy *keycloakApi.AdminPermissionPolicy) *adapter.AdminPermissionPolicy {
+	p := &adapter.AdminPermissionPolicy{
+		Name:        policy.Name,
+		Type:        policy.Type,
+		Description: policy.Description,
+		Logic:       policy.Logic,
+		Users:       policy.Users,
+	}
+
+	for _, g := range policy.Groups {
+		p.Groups = append(p.Groups, adapter.AdminPermissionPolicyGroup{
+			Group:          g.Name,
+			ExtendChildren: g.ExtendChildren,
+		})
+	}
+
+	for _, r := range policy.Roles {
+		p.Roles = append(p.Roles, adapter.AdminPermissionPolicyRole{
+			Role:     r.Name,
+			ClientID: r.ClientID,
+			Required: r.Required,
+		})
+	}
+
+	return p
+}
+
+// syncPolicies creates or updates the spec policies and deletes the policies which were removed from the spec.
+// It returns the map of the policy name to its ID.
+func syncPolicies(
+	ctx context.Context,
+	kClient keycloak.Client,
+	realmName string,
+	permission *keycloakApi.KeycloakAdminPermission,
+) (map[string]string, error) {
+	policyIDs := make(map[string]string, len(permission.Spec.Policies))
+	managed := make([]string, 0, len(permission.Spec.Policies))
+
+	for i := range permission.Spec.Policies {
+		p := &permission.Spec.Policies[i]
+
+		id, err := kClient.SyncAdminPermissionPolicy(ctx, realmName, toAdapterPolicy(p))
+		if err != nil {
+			return nil, fmt.Errorf("unable to sync policy %s: %w", p.Name, err)
+		}
+
+		policyIDs[p.Name] = id
+		managed = append(managed, p.Name)
+	}
+
+	var errs []error
+
+	for _, name := range permission.Status.Policies {
+		if _, ok := policyIDs[name]; ok {
+			continue
+		}
+
+		if err := kClient.DeleteAdminPermissionPolicy(ctx, realmName, name); err != nil {
+			errs = append(errs, fmt.Errorf("unable to delete policy %s: %w", name, err))
+			managed = append(managed, name)
+		}
+	}
+
+	permission.Status.Policies = managed
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return policyIDs, nil
+}
+
+// bindScopes sets policies of the scope permissions of the target.
+// Policies which are not managed by the resource are looked up in realm-management by name.
+func bindScopes(
+	ctx context.Context,
+	kClient keycloak.Client,
+	realmName string,
+	permission *keycloakApi.KeycloakAdminPermission,
+	policyIDs map[string]string,
+) error {
+	for _, p := range permission.Spec.Permissions {
+		permissionID, ok := permission.Status.ScopePermissions[p.Scope]
+		if !ok {
+			scopes := maps.Keys(permission.Status.ScopePermissions)
+			sort.Strings(scopes)
+
+			return fmt.Errorf("scope %s is not available for %s target, available scopes: %s",
+				p.Scope, permission.Spec.Target.Type, strings.Join(scopes, ", "))
+		}
+
+		ids := make([]string, 0, len(p.Policies))
+
+		for _, name := range p.Policies {
+			id, ok := policyIDs[name]
+			if !ok {
+				var err error
+
+				id, err = kClient.GetAdminPermissionPolicyID(ctx, realmName, name)
+				if err != nil {
+					return fmt.Errorf("unable to get policy %s: %w", name, err)
+				}
+			}
+
+			ids = append(ids, id)
+		}
+
+		if err := kClient.SetScopePermissionPolicies(ctx, realmName, permissionID, ids, p.DecisionStrategy); err != nil {
+			return fmt.Errorf("unable to set policies of scope %s: %w", p.Scope, err)
+		}
+	}
+
+	return nil
+}
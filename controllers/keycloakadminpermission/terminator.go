@@ -0,0 +1,72 @@
+package keycloakadminpermission
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+type terminator struct {
+	realmName                   string
+	target                      keycloakApi.AdminPermissionTarget
+	policies                    []string
+	kClient                     keycloak.Client
+	preserveResourcesOnDeletion bool
+}
+
+func makeTerminator(
+	realmName string,
+	target keycloakApi.AdminPermissionTarget,
+	policies []string,
+	kClient keycloak.Client,
+	preserveResourcesOnDeletion bool,
+) *terminator {
+	return &terminator{
+		realmName:                   realmName,
+		target:                      target,
+		policies:                    policies,
+		kClient:                     kClient,
+		preserveResourcesOnDeletion: preserveResourcesOnDeletion,
+	}
+}
+
+// DeleteResource disables management permissions of the target and deletes the policies created by the resource.
+func (t *terminator) DeleteResource(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx)
+	if t.preserveResourcesOnDeletion {
+		log.Info("PreserveResourcesOnDeletion is enabled, skipping deletion.")
+		return nil
+	}
+
+	log.Info("Start deleting KeycloakAdminPermission")
+
+	if _, err := t.kClient.SetManagementPermissions(ctx, t.realmName, t.target.Type, t.target.Name, false); err != nil {
+		if !adapter.IsErrNotFound(err) && !adapter.IsErrFeatureDisabled(err) {
+			return fmt.Errorf("unable to disable management permissions: %w", err)
+		}
+
+		log.Info("Admin permission target not found or feature is disabled, skipping disabling of management permissions.")
+	}
+
+	var errs []error
+
+	for _, name := range t.policies {
+		if err := t.kClient.DeleteAdminPermissionPolicy(ctx, t.realmName, name); err != nil {
+			errs = append(errs, fmt.Errorf("unable to delete policy %s: %w", name, err))
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	log.Info("KeycloakAdminPermission deletion done")
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package keycloakadminpermission
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+func TestTerminator_DeleteResource(t *testing.T) {
+	target := keycloakApi.AdminPermissionTarget{Type: adapter.AdminPermissionTargetGroup, Name: "team-a"}
+
+	tests := []struct {
+		name       string
+		setupMocks func(kClient *adapter.Mock)
+		wantErr    string
+	}{
+		{
+			name: "permissions are disabled and policies are deleted",
+			setupMocks: func(kClient *adapter.Mock) {
+				kClient.On("SetManagementPermissions", "realm", adapter.AdminPermissionTargetGroup, "team-a", false).
+					Return(&adapter.ManagementPermissions{}, nil)
+				kClient.On("DeleteAdminPermissionPolicy", "realm", "policy-1").Return(nil)
+				kClient.On("DeleteAdminPermissionPolicy", "realm", "policy-2").Return(nil)
+			},
+		},
+		{
+			name: "target is not found",
+			setupMocks: func(kClient *adapter.Mock) {
+				kClient.On("SetManagementPermissions", "realm", adapter.AdminPermissionTargetGroup, "team-a", false).
+					Return(nil, adapter.NotFoundError("group not found"))
+				kClient.On("DeleteAdminPermissionPolicy", "realm", "policy-1").Return(nil)
+				kClient.On("DeleteAdminPermissionPolicy", "realm", "policy-2").Return(errors.New("fatal"))
+			},
+			wantErr: "unable to delete policy policy-2",
+		},
+		{
+			name: "failed to disable permissions",
+			setupMocks: func(kClient *adapter.Mock) {
+				kClient.On("SetManagementPermissions", "realm", adapter.AdminPermissionTargetGroup, "team-a", false).
+					Return(nil, errors.New("fatal"))
+			},
+			wantErr: "unable to disable management permissions",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			kClient := new(adapter.Mock)
+			tt.setupMocks(kClient)
+
+			err := makeTerminator("realm", target, []string{"policy-1", "policy-2"}, kClient, false).
+				DeleteResource(context.Background())
+
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.wantErr)
+			}
+
+			kClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTerminatorSkipDeletion(t *testing.T) {
+	term := makeTerminator("realm", keycloakApi.AdminPermissionTarget{Type: adapter.AdminPermissionTargetUsers},
+		[]string{"policy"}, nil, true)
+
+	require.NoError(t, term.DeleteResource(context.Background()))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strconv"
 	"time"
 
 	"github.com/Nerzal/gocloak/v12"
@@ -24,7 +25,15 @@ import (
 	"github.com/epam/edp-keycloak-operator/pkg/objectmeta"
 )
 
-const finalizerName = "keycloak.authflow.operator.finalizer.name"
+const (
+	finalizerName = "keycloak.authflow.operator.finalizer.name"
+
+	loaConditionAuthenticator = "conditional-level-of-authentication"
+	loaConditionLevel         = "loa-condition-level"
+	loaMaxAge                 = "loa-max-age"
+
+	providerKindAuthenticator = "authenticator"
+)
 
 type Helper interface {
 	SetFailureCount(fc helper.FailureCountable) time.Duration
@@ -169,6 +178,18 @@ func (r *Reconcile) tryReconcile(ctx context.Context, instance *keycloakApi.Keyc
 		return nil
 	}
 
+	if err := addSubFlows(keycloakAuthFlow, instance.Spec.SubFlows); err != nil {
+		return fmt.Errorf("unable to build sub-flows: %w", err)
+	}
+
+	if err := r.addExternalChildFlows(ctx, instance, keycloakAuthFlow); err != nil {
+		return err
+	}
+
+	if err := validateAuthenticators(ctx, kClient, keycloakAuthFlow); err != nil {
+		return err
+	}
+
 	if err := kClient.SyncAuthFlow(gocloak.PString(realm.Realm), keycloakAuthFlow); err != nil {
 		return fmt.Errorf("unable to sync auth flow: %w", err)
 	}
@@ -194,18 +215,22 @@ func (r *Reconcile) applyDefaults(ctx context.Context, instance *keycloakApi.Key
 }
 
 func authFlowSpecToAdapterAuthFlow(spec *keycloakApi.KeycloakAuthFlowSpec) *adapter.KeycloakAuthFlow {
-	flow := adapter.KeycloakAuthFlow{
+	return &adapter.KeycloakAuthFlow{
 		Alias:                    spec.Alias,
 		Description:              spec.Description,
 		BuiltIn:                  spec.BuiltIn,
 		ProviderID:               spec.ProviderID,
 		TopLevel:                 spec.TopLevel,
-		AuthenticationExecutions: make([]adapter.AuthenticationExecution, 0, len(spec.AuthenticationExecutions)),
+		AuthenticationExecutions: authExecutionsToAdapterAuthExecutions(spec.AuthenticationExecutions),
 		ParentName:               spec.ParentName,
 		ChildType:                spec.ChildType,
 	}
+}
+
+func authExecutionsToAdapterAuthExecutions(execs []keycloakApi.AuthenticationExecution) []adapter.AuthenticationExecution {
+	authExecs := make([]adapter.AuthenticationExecution, 0, len(execs))
 
-	for _, ae := range spec.AuthenticationExecutions {
+	for _, ae := range execs {
 		exec := adapter.AuthenticationExecution{
 			Authenticator:    ae.Authenticator,
 			Requirement:      ae.Requirement,
@@ -221,8 +246,193 @@ func authFlowSpecToAdapterAuthFlow(spec *keycloakApi.KeycloakAuthFlowSpec) *adap
 			}
 		}
 
-		flow.AuthenticationExecutions = append(flow.AuthenticationExecutions, exec)
+		authExecs = append(authExecs, exec)
+	}
+
+	return authExecs
+}
+
+// addSubFlows converts inline sub-flows to adapter sub-flows and attaches them to their parent flows.
+func addSubFlows(flow *adapter.KeycloakAuthFlow, subFlows []keycloakApi.AuthenticationSubFlow) error {
+	flows := map[string]*adapter.KeycloakAuthFlow{flow.Alias: flow}
+	parents := make(map[string]string, len(subFlows))
+
+	for i := range subFlows {
+		sf := &subFlows[i]
+
+		if _, ok := flows[sf.Alias]; ok {
+			return fmt.Errorf("duplicated sub-flow alias: %s", sf.Alias)
+		}
+
+		parent := sf.Parent
+		if parent == "" {
+			parent = flow.Alias
+		}
+
+		parents[sf.Alias] = parent
+		flows[sf.Alias] = &adapter.KeycloakAuthFlow{
+			Alias:                    sf.Alias,
+			Description:              sf.Description,
+			ProviderID:               sf.Provider,
+			ParentName:               parent,
+			ChildType:                sf.Type,
+			AuthenticationExecutions: subFlowAuthExecutions(sf),
+		}
+	}
+
+	for i := range subFlows {
+		sf := &subFlows[i]
+
+		if err := checkSubFlowParents(sf.Alias, flow.Alias, parents); err != nil {
+			return err
+		}
+
+		parent := flows[parents[sf.Alias]]
+		parent.AuthenticationExecutions = append(parent.AuthenticationExecutions, adapter.AuthenticationExecution{
+			Requirement:      sf.Requirement,
+			Priority:         sf.Priority,
+			AutheticatorFlow: true,
+			Alias:            sf.Alias,
+			SubFlow:          flows[sf.Alias],
+		})
+	}
+
+	return nil
+}
+
+// addExternalChildFlows adds child flows which are managed by other KeycloakAuthFlow resources
+// to the flow or to its inline sub-flow, so they are not deleted during the sync.
+func (r *Reconcile) addExternalChildFlows(
+	ctx context.Context,
+	instance *keycloakApi.KeycloakAuthFlow,
+	flow *adapter.KeycloakAuthFlow,
+) error {
+	var authFlowList keycloakApi.KeycloakAuthFlowList
+	if err := r.client.List(ctx, &authFlowList, client.InNamespace(instance.Namespace)); err != nil {
+		return fmt.Errorf("unable to get auth flow list: %w", err)
+	}
+
+	flows := map[string]*adapter.KeycloakAuthFlow{flow.Alias: flow}
+
+	for i := range flow.AuthenticationExecutions {
+		collectSubFlows(flow.AuthenticationExecutions[i].SubFlow, flows)
+	}
+
+	for i := range authFlowList.Items {
+		child := &authFlowList.Items[i]
+		if child.Spec.RealmRef.Name != instance.Spec.RealmRef.Name || child.Spec.ParentName == "" {
+			continue
+		}
+
+		if parent, ok := flows[child.Spec.ParentName]; ok {
+			parent.ExternalChildFlows = append(parent.ExternalChildFlows, child.Spec.Alias)
+		}
+	}
+
+	return nil
+}
+
+func collectSubFlows(flow *adapter.KeycloakAuthFlow, flows map[string]*adapter.KeycloakAuthFlow) {
+	if flow == nil {
+		return
+	}
+
+	flows[flow.Alias] = flow
+
+	for i := range flow.AuthenticationExecutions {
+		collectSubFlows(flow.AuthenticationExecutions[i].SubFlow, flows)
+	}
+}
+
+// checkSubFlowParents checks that sub-flow belongs to the root flow through the chain of its parents.
+func checkSubFlowParents(alias, rootAlias string, parents map[string]string) error {
+	current := alias
+
+	for i := 0; i <= len(parents); i++ {
+		parent, ok := parents[current]
+		if !ok {
+			return fmt.Errorf("parent flow %s of sub-flow %s not found", current, alias)
+		}
+
+		if parent == rootAlias {
+			return nil
+		}
+
+		current = parent
+	}
+
+	return fmt.Errorf("sub-flow %s has cyclic parent reference", alias)
+}
+
+// subFlowAuthExecutions converts sub-flow executions to adapter executions.
+// If level of authentication is set, conditional-level-of-authentication condition is added as the first execution.
+func subFlowAuthExecutions(sf *keycloakApi.AuthenticationSubFlow) []adapter.AuthenticationExecution {
+	authExecs := authExecutionsToAdapterAuthExecutions(sf.AuthenticationExecutions)
+
+	if sf.LevelOfAuthentication == nil {
+		return authExecs
+	}
+
+	priority := 0
+
+	for _, ae := range authExecs {
+		if ae.Priority <= priority {
+			priority = ae.Priority - 1
+		}
+	}
+
+	config := map[string]string{
+		loaConditionLevel: strconv.Itoa(sf.LevelOfAuthentication.Level),
+	}
+
+	if sf.LevelOfAuthentication.MaxAge != nil {
+		config[loaMaxAge] = strconv.Itoa(*sf.LevelOfAuthentication.MaxAge)
+	}
+
+	return append([]adapter.AuthenticationExecution{{
+		Authenticator: loaConditionAuthenticator,
+		Requirement:   "REQUIRED",
+		Priority:      priority,
+		AuthenticatorConfig: &adapter.AuthenticatorConfig{
+			Alias:  fmt.Sprintf("%s-loa", sf.Alias),
+			Config: config,
+		},
+	}}, authExecs...)
+}
+
+// validateAuthenticators checks that authenticators of the flow and its sub-flows are available on the server.
+// Validation is skipped if server info can't be retrieved, e.g. admin doesn't have enough permissions.
+func validateAuthenticators(ctx context.Context, kClient keycloak.Client, flow *adapter.KeycloakAuthFlow) error {
+	serverInfo, err := kClient.GetServerInfo(ctx)
+	if err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Unable to get server info, skipping authenticators validation")
+
+		return nil
+	}
+
+	return checkFlowAuthenticators(flow, serverInfo.AuthenticatorIDs())
+}
+
+func checkFlowAuthenticators(flow *adapter.KeycloakAuthFlow, available []string) error {
+	for i := range flow.AuthenticationExecutions {
+		exec := &flow.AuthenticationExecutions[i]
+
+		if exec.SubFlow != nil {
+			if err := checkFlowAuthenticators(exec.SubFlow, available); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if exec.AutheticatorFlow {
+			continue
+		}
+
+		if err := adapter.ValidateProviderID(providerKindAuthenticator, exec.Authenticator, available); err != nil {
+			return fmt.Errorf("invalid execution in flow %s: %w", flow.Alias, err)
+		}
 	}
 
-	return &flow
+	return nil
 }
@@ -56,7 +56,19 @@ func TestNewReconcile(t *testing.T) {
 		},
 	}
 
-	client := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(&flow).Build()
+	childFlow := keycloakApi.KeycloakAuthFlow{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "child-flow",
+			Namespace: ns,
+		},
+		Spec: keycloakApi.KeycloakAuthFlowSpec{
+			Alias:      "child-flow",
+			ParentName: flow.Spec.Alias,
+			RealmRef:   flow.Spec.RealmRef,
+		},
+	}
+
+	client := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(&flow, &childFlow).Build()
 	h := helpermock.NewControllerHelper(t)
 	realm := &keycloakApi.KeycloakRealm{
 		Spec: keycloakApi.KeycloakRealmSpec{
@@ -72,9 +84,11 @@ func TestNewReconcile(t *testing.T) {
 			Realm: gocloak.StringP("realm11"),
 		}, nil)
 
+	kClient.On("GetServerInfo", testifymock.Anything).Return(&adapter.ServerInfo{}, nil)
 	kClient.On("SyncAuthFlow", realm.Spec.RealmName, &adapter.KeycloakAuthFlow{
 		Alias:                    flow.Spec.Alias,
 		AuthenticationExecutions: []adapter.AuthenticationExecution{},
+		ExternalChildFlows:       []string{childFlow.Spec.Alias},
 	}).Return(nil)
 
 	h.On("TryToDelete", testifymock.Anything, testifymock.Anything, testifymock.Anything, testifymock.Anything).
@@ -143,6 +157,7 @@ func TestReconcile_Reconcile_Failure(t *testing.T) {
 		Return(false, nil)
 
 	mockErr := errors.New("fatal")
+	kClient.On("GetServerInfo", testifymock.Anything).Return(&adapter.ServerInfo{}, nil)
 	kClient.On("SyncAuthFlow", realm.Spec.RealmName, &adapter.KeycloakAuthFlow{
 		Alias:                    flow.Spec.Alias,
 		AuthenticationExecutions: []adapter.AuthenticationExecution{},
@@ -210,6 +225,7 @@ func TestReconcile_Reconcile_FailureToGetParentRealm(t *testing.T) {
 		Return(false, nil)
 
 	mockErr := errors.New("fatal")
+	kClient.On("GetServerInfo", testifymock.Anything).Return(&adapter.ServerInfo{}, nil)
 	kClient.On("SyncAuthFlow", realm.Spec.RealmName, &adapter.KeycloakAuthFlow{
 		Alias:                    flow.Spec.Alias,
 		AuthenticationExecutions: []adapter.AuthenticationExecution{},
@@ -230,3 +246,144 @@ func TestReconcile_Reconcile_FailureToGetParentRealm(t *testing.T) {
 		t.Fatal("RequeueAfter is not set")
 	}
 }
+
+func TestAddSubFlows(t *testing.T) {
+	t.Parallel()
+
+	maxAge := 300
+
+	tests := []struct {
+		name     string
+		subFlows []keycloakApi.AuthenticationSubFlow
+		wantErr  require.ErrorAssertionFunc
+		check    func(t *testing.T, flow *adapter.KeycloakAuthFlow)
+	}{
+		{
+			name: "nested sub-flows with level of authentication",
+			subFlows: []keycloakApi.AuthenticationSubFlow{
+				{
+					Alias:       "2fa",
+					Parent:      "forms",
+					Type:        "basic-flow",
+					Requirement: "CONDITIONAL",
+					Priority:    1,
+					LevelOfAuthentication: &keycloakApi.LevelOfAuthentication{
+						Level:  2,
+						MaxAge: &maxAge,
+					},
+					AuthenticationExecutions: []keycloakApi.AuthenticationExecution{
+						{Authenticator: "auth-otp-form", Requirement: "REQUIRED"},
+					},
+				},
+				{
+					Alias:       "forms",
+					Type:        "basic-flow",
+					Requirement: "ALTERNATIVE",
+				},
+			},
+			wantErr: require.NoError,
+			check: func(t *testing.T, flow *adapter.KeycloakAuthFlow) {
+				require.Len(t, flow.AuthenticationExecutions, 1)
+
+				forms := flow.AuthenticationExecutions[0]
+				require.True(t, forms.AutheticatorFlow)
+				require.Equal(t, "forms", forms.Alias)
+				require.Equal(t, "ALTERNATIVE", forms.Requirement)
+				require.NotNil(t, forms.SubFlow)
+				require.Equal(t, "flow", forms.SubFlow.ParentName)
+				require.Len(t, forms.SubFlow.AuthenticationExecutions, 1)
+
+				twoFA := forms.SubFlow.AuthenticationExecutions[0]
+				require.Equal(t, "CONDITIONAL", twoFA.Requirement)
+				require.Equal(t, 1, twoFA.Priority)
+				require.Equal(t, "forms", twoFA.SubFlow.ParentName)
+				require.Len(t, twoFA.SubFlow.AuthenticationExecutions, 2)
+
+				loa := twoFA.SubFlow.AuthenticationExecutions[0]
+				require.Equal(t, "conditional-level-of-authentication", loa.Authenticator)
+				require.Equal(t, -1, loa.Priority)
+				require.Equal(t, map[string]string{
+					"loa-condition-level": "2",
+					"loa-max-age":         "300",
+				}, loa.AuthenticatorConfig.Config)
+			},
+		},
+		{
+			name: "parent not found",
+			subFlows: []keycloakApi.AuthenticationSubFlow{
+				{Alias: "2fa", Parent: "unknown"},
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "parent flow unknown of sub-flow 2fa not found")
+			},
+		},
+		{
+			name: "cyclic parent reference",
+			subFlows: []keycloakApi.AuthenticationSubFlow{
+				{Alias: "a", Parent: "b"},
+				{Alias: "b", Parent: "a"},
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "cyclic parent reference")
+			},
+		},
+		{
+			name: "duplicated alias",
+			subFlows: []keycloakApi.AuthenticationSubFlow{
+				{Alias: "flow"},
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "duplicated sub-flow alias: flow")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			flow := authFlowSpecToAdapterAuthFlow(&keycloakApi.KeycloakAuthFlowSpec{Alias: "flow"})
+
+			err := addSubFlows(flow, tt.subFlows)
+			tt.wantErr(t, err)
+
+			if tt.check != nil {
+				tt.check(t, flow)
+			}
+		})
+	}
+}
+
+func TestCheckFlowAuthenticators(t *testing.T) {
+	t.Parallel()
+
+	flow := &adapter.KeycloakAuthFlow{
+		Alias: "browser",
+		AuthenticationExecutions: []adapter.AuthenticationExecution{
+			{Authenticator: "auth-cookie"},
+			{Alias: "child", AutheticatorFlow: true},
+			{
+				Alias:            "forms",
+				AutheticatorFlow: true,
+				SubFlow: &adapter.KeycloakAuthFlow{
+					Alias: "forms",
+					AuthenticationExecutions: []adapter.AuthenticationExecution{
+						{Authenticator: "auth-otp-from"},
+					},
+				},
+			},
+		},
+	}
+
+	err := checkFlowAuthenticators(flow, []string{"auth-cookie", "auth-otp-form"})
+	require.EqualError(t, err,
+		`invalid execution in flow forms: unknown authenticator "auth-otp-from", available: auth-cookie, auth-otp-form`,
+	)
+
+	flow.AuthenticationExecutions[2].SubFlow.AuthenticationExecutions[0].Authenticator = "auth-otp-form"
+	require.NoError(t, checkFlowAuthenticators(flow, []string{"auth-cookie", "auth-otp-form"}))
+}
@@ -24,10 +24,13 @@ func Make(scheme *runtime.Scheme, client client.Client, logger logr.Logger) Elem
 				BaseElement: baseElement,
 				next: &PutClientScope{
 					BaseElement: baseElement,
-					next: &PutProtocolMappers{
+					next: &PutScopeMappings{
 						BaseElement: baseElement,
-						next: &ServiceAccount{
+						next: &PutProtocolMappers{
 							BaseElement: baseElement,
+							next: &ServiceAccount{
+								BaseElement: baseElement,
+							},
 						},
 					},
 				},
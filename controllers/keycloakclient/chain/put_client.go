@@ -70,6 +70,8 @@ func (el *PutClient) putKeycloakClient(ctx context.Context, keycloakClient *keyc
 			return "", fmt.Errorf("unable to update keycloak client: %w", updErr)
 		}
 
+		setSecretHashes(keycloakClient, clientDto.ClientSecret)
+
 		return clientID, nil
 	}
 
@@ -78,6 +80,8 @@ func (el *PutClient) putKeycloakClient(ctx context.Context, keycloakClient *keyc
 		return "", fmt.Errorf("unable to create client: %w", err)
 	}
 
+	setSecretHashes(keycloakClient, clientDto.ClientSecret)
+
 	log.Info("End put keycloak client")
 
 	id, err := adapterClient.GetClientID(clientDto.ClientId, clientDto.RealmName)
@@ -88,6 +92,17 @@ func (el *PutClient) putKeycloakClient(ctx context.Context, keycloakClient *keyc
 	return id, nil
 }
 
+// setSecretHashes records hash of the applied client secret in the status.
+func setSecretHashes(keycloakClient *keycloakApi.KeycloakClient, secret string) {
+	keycloakClient.Status.SecretHashes = nil
+
+	if _, _, ok := secretref.ParseSecretRef(keycloakClient.Spec.Secret); ok && !keycloakClient.Spec.Public {
+		keycloakClient.Status.SecretHashes = map[string]string{
+			keycloakClient.Spec.Secret[1:]: secretref.HashSecretValue(secret),
+		}
+	}
+}
+
 func (el *PutClient) convertCrToDto(ctx context.Context, keycloakClient *keycloakApi.KeycloakClient, realmName string) (*dto.Client, error) {
 	if keycloakClient.Spec.Public {
 		res := dto.ConvertSpecToClient(&keycloakClient.Spec, "", realmName)
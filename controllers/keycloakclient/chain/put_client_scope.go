@@ -5,9 +5,11 @@ import (
 	"fmt"
 
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
 
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
 )
 
 type PutClientScope struct {
@@ -25,19 +27,82 @@ func (el *PutClientScope) Serve(ctx context.Context, keycloakClient *keycloakApi
 
 func (el *PutClientScope) putClientScope(ctx context.Context, keycloakClient *keycloakApi.KeycloakClient, adapterClient keycloak.Client, realmName string) error {
 	kCloakSpec := keycloakClient.Spec
-	if len(kCloakSpec.DefaultClientScopes) == 0 {
-		return nil
+
+	defaultScopes, err := el.getClientScopes(
+		ctx,
+		adapterClient,
+		realmName,
+		keycloakClient.Namespace,
+		kCloakSpec.DefaultClientScopes,
+		kCloakSpec.DefaultClientScopeRefs,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to get default client scopes: %w", err)
 	}
 
-	scopes, err := adapterClient.GetClientScopesByNames(ctx, realmName, kCloakSpec.DefaultClientScopes)
+	optionalScopes, err := el.getClientScopes(
+		ctx,
+		adapterClient,
+		realmName,
+		keycloakClient.Namespace,
+		kCloakSpec.OptionalClientScopes,
+		kCloakSpec.OptionalClientScopeRefs,
+	)
 	if err != nil {
-		return errors.Wrap(err, "error during GetClientScope")
+		return fmt.Errorf("unable to get optional client scopes: %w", err)
 	}
 
-	err = adapterClient.AddDefaultScopeToClient(ctx, realmName, kCloakSpec.ClientId, scopes)
+	if defaultScopes == nil && optionalScopes == nil {
+		return nil
+	}
+
+	err = adapterClient.SyncClientScopes(
+		ctx,
+		realmName,
+		kCloakSpec.ClientId,
+		defaultScopes,
+		optionalScopes,
+		keycloakClient.GetReconciliationStrategy() == keycloakApi.ReconciliationStrategyAddOnly,
+	)
 	if err != nil {
-		return fmt.Errorf("failed to add default scope to client %s: %w", keycloakClient.Name, err)
+		return fmt.Errorf("failed to sync client scopes of client %s: %w", keycloakClient.Name, err)
 	}
 
 	return nil
 }
+
+// getClientScopes returns client scopes by names and by KeycloakClientScope references.
+// It returns nil if there are no scopes, so the client scopes are not changed.
+func (el *PutClientScope) getClientScopes(
+	ctx context.Context,
+	adapterClient keycloak.Client,
+	realmName, namespace string,
+	scopeNames, scopeRefs []string,
+) ([]adapter.ClientScope, error) {
+	if len(scopeNames) == 0 && len(scopeRefs) == 0 {
+		return nil, nil
+	}
+
+	names := make([]string, 0, len(scopeNames)+len(scopeRefs))
+	names = append(names, scopeNames...)
+
+	for _, ref := range scopeRefs {
+		scope := &keycloakApi.KeycloakClientScope{}
+		if err := el.Client.Get(ctx, types.NamespacedName{Name: ref, Namespace: namespace}, scope); err != nil {
+			return nil, fmt.Errorf("unable to get KeycloakClientScope %s: %w", ref, err)
+		}
+
+		if scope.Status.ID == "" {
+			return nil, fmt.Errorf("KeycloakClientScope %s is not created yet", ref)
+		}
+
+		names = append(names, scope.Spec.Name)
+	}
+
+	scopes, err := adapterClient.GetClientScopesByNames(ctx, realmName, names)
+	if err != nil {
+		return nil, errors.Wrap(err, "error during GetClientScope")
+	}
+
+	return scopes, nil
+}
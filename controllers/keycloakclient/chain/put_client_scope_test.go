@@ -0,0 +1,147 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	testifymock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+func TestPutClientScope_Serve(t *testing.T) {
+	t.Parallel()
+
+	s := runtime.NewScheme()
+	require.NoError(t, keycloakApi.AddToScheme(s))
+
+	readyScope := &keycloakApi.KeycloakClientScope{
+		ObjectMeta: metav1.ObjectMeta{Name: "ready-scope", Namespace: "default"},
+		Spec:       keycloakApi.KeycloakClientScopeSpec{Name: "ready"},
+		Status:     keycloakApi.KeycloakClientScopeStatus{ID: "ready-id"},
+	}
+	notReadyScope := &keycloakApi.KeycloakClientScope{
+		ObjectMeta: metav1.ObjectMeta{Name: "not-ready-scope", Namespace: "default"},
+		Spec:       keycloakApi.KeycloakClientScopeSpec{Name: "not-ready"},
+	}
+
+	tests := []struct {
+		name          string
+		spec          keycloakApi.KeycloakClientSpec
+		adapterClient func(t *testing.T) *adapter.Mock
+		wantErr       require.ErrorAssertionFunc
+	}{
+		{
+			name: "scopes are not set",
+			spec: keycloakApi.KeycloakClientSpec{ClientId: "client"},
+			adapterClient: func(t *testing.T) *adapter.Mock {
+				return &adapter.Mock{}
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "default and optional scopes",
+			spec: keycloakApi.KeycloakClientSpec{
+				ClientId:               "client",
+				DefaultClientScopes:    []string{"profile"},
+				DefaultClientScopeRefs: []string{"ready-scope"},
+				OptionalClientScopes:   []string{"phone"},
+				ReconciliationStrategy: keycloakApi.ReconciliationStrategyFull,
+			},
+			adapterClient: func(t *testing.T) *adapter.Mock {
+				m := &adapter.Mock{}
+				defaultScopes := []adapter.ClientScope{{ID: "profile-id", Name: "profile"}, {ID: "ready-id", Name: "ready"}}
+				optionalScopes := []adapter.ClientScope{{ID: "phone-id", Name: "phone"}}
+
+				m.On("GetClientScopesByNames", testifymock.Anything, "realm", []string{"profile", "ready"}).Return(defaultScopes, nil)
+				m.On("GetClientScopesByNames", testifymock.Anything, "realm", []string{"phone"}).Return(optionalScopes, nil)
+				m.On("SyncClientScopes", "realm", "client", defaultScopes, optionalScopes, false).Return(nil)
+
+				return m
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "optional scopes with add only strategy",
+			spec: keycloakApi.KeycloakClientSpec{
+				ClientId:               "client",
+				OptionalClientScopes:   []string{"phone"},
+				ReconciliationStrategy: keycloakApi.ReconciliationStrategyAddOnly,
+			},
+			adapterClient: func(t *testing.T) *adapter.Mock {
+				m := &adapter.Mock{}
+				optionalScopes := []adapter.ClientScope{{ID: "phone-id", Name: "phone"}}
+
+				m.On("GetClientScopesByNames", testifymock.Anything, "realm", []string{"phone"}).Return(optionalScopes, nil)
+				m.On("SyncClientScopes", "realm", "client", []adapter.ClientScope(nil), optionalScopes, true).Return(nil)
+
+				return m
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "referenced scope is not ready",
+			spec: keycloakApi.KeycloakClientSpec{
+				ClientId:                "client",
+				OptionalClientScopeRefs: []string{"not-ready-scope"},
+			},
+			adapterClient: func(t *testing.T) *adapter.Mock {
+				return &adapter.Mock{}
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "KeycloakClientScope not-ready-scope is not created yet")
+			},
+		},
+		{
+			name: "referenced scope is not found",
+			spec: keycloakApi.KeycloakClientSpec{
+				ClientId:               "client",
+				DefaultClientScopeRefs: []string{"missing-scope"},
+			},
+			adapterClient: func(t *testing.T) *adapter.Mock {
+				return &adapter.Mock{}
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "unable to get KeycloakClientScope missing-scope")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			el := &PutClientScope{
+				BaseElement: BaseElement{
+					Client: fake.NewClientBuilder().WithScheme(s).WithObjects(readyScope.DeepCopy(), notReadyScope.DeepCopy()).Build(),
+					Logger: logr.Discard(),
+				},
+			}
+
+			kClient := tt.adapterClient(t)
+
+			err := el.Serve(
+				context.Background(),
+				&keycloakApi.KeycloakClient{
+					ObjectMeta: metav1.ObjectMeta{Name: "client", Namespace: "default"},
+					Spec:       tt.spec,
+				},
+				kClient,
+				"realm",
+			)
+
+			tt.wantErr(t, err)
+			kClient.AssertExpectations(t)
+		})
+	}
+}
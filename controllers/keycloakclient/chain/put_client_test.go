@@ -340,3 +340,24 @@ func TestPutClient_Serve(t *testing.T) {
 		})
 	}
 }
+
+func TestSetSecretHashes(t *testing.T) {
+	t.Parallel()
+
+	keycloakClient := &keycloakApi.KeycloakClient{
+		Spec: keycloakApi.KeycloakClientSpec{
+			Secret: secretref.GenerateSecretRef("client-secret", "secret"),
+		},
+	}
+
+	setSecretHashes(keycloakClient, "secret-value")
+	require.Equal(t,
+		map[string]string{"client-secret:secret": secretref.HashSecretValue("secret-value")},
+		keycloakClient.Status.SecretHashes,
+	)
+
+	keycloakClient.Spec.Public = true
+
+	setSecretHashes(keycloakClient, "")
+	require.Nil(t, keycloakClient.Status.SecretHashes)
+}
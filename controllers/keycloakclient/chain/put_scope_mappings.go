@@ -0,0 +1,44 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+)
+
+type PutScopeMappings struct {
+	BaseElement
+	next Element
+}
+
+func (el *PutScopeMappings) Serve(ctx context.Context, keycloakClient *keycloakApi.KeycloakClient, adapterClient keycloak.Client, realmName string) error {
+	if err := el.putScopeMappings(ctx, keycloakClient, adapterClient, realmName); err != nil {
+		return errors.Wrap(err, "unable to put scope mappings")
+	}
+
+	return el.NextServeOrNil(ctx, el.next, keycloakClient, adapterClient, realmName)
+}
+
+func (el *PutScopeMappings) putScopeMappings(ctx context.Context, keycloakClient *keycloakApi.KeycloakClient, adapterClient keycloak.Client, realmName string) error {
+	scopeMappings := keycloakClient.Spec.ScopeMappings
+	if scopeMappings == nil {
+		return nil
+	}
+
+	if err := adapterClient.SyncClientScopeMappings(
+		ctx,
+		realmName,
+		keycloakClient.Status.ClientID,
+		scopeMappings.RealmRoles,
+		scopeMappings.GetClientRoles(),
+		keycloakClient.GetReconciliationStrategy() == keycloakApi.ReconciliationStrategyAddOnly,
+	); err != nil {
+		return fmt.Errorf("failed to sync scope mappings of client %s: %w", keycloakClient.Name, err)
+	}
+
+	return nil
+}
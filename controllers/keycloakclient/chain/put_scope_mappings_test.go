@@ -0,0 +1,101 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+func TestPutScopeMappings_Serve(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		client        *keycloakApi.KeycloakClient
+		adapterClient func(t *testing.T) *adapter.Mock
+		wantErr       require.ErrorAssertionFunc
+	}{
+		{
+			name: "scope mappings are not set",
+			client: &keycloakApi.KeycloakClient{
+				ObjectMeta: metav1.ObjectMeta{Name: "client"},
+				Spec:       keycloakApi.KeycloakClientSpec{ClientId: "client"},
+			},
+			adapterClient: func(t *testing.T) *adapter.Mock {
+				return &adapter.Mock{}
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "scope mappings are synced",
+			client: &keycloakApi.KeycloakClient{
+				ObjectMeta: metav1.ObjectMeta{Name: "client"},
+				Spec: keycloakApi.KeycloakClientSpec{
+					ClientId: "client",
+					ScopeMappings: &keycloakApi.ScopeMappings{
+						RealmRoles: []string{"realm-role"},
+						ClientRoles: []keycloakApi.ClientRole{
+							{ClientID: "client1", Roles: []string{"role1"}},
+							{ClientID: "client1", Roles: []string{"role2"}},
+						},
+					},
+					ReconciliationStrategy: keycloakApi.ReconciliationStrategyAddOnly,
+				},
+				Status: keycloakApi.KeycloakClientStatus{ClientID: "client-id"},
+			},
+			adapterClient: func(t *testing.T) *adapter.Mock {
+				m := &adapter.Mock{}
+				m.On("SyncClientScopeMappings", "realm", "client-id", []string{"realm-role"},
+					map[string][]string{"client1": {"role1", "role2"}}, true).Return(nil)
+
+				return m
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "failed to sync scope mappings",
+			client: &keycloakApi.KeycloakClient{
+				ObjectMeta: metav1.ObjectMeta{Name: "client"},
+				Spec: keycloakApi.KeycloakClientSpec{
+					ClientId:      "client",
+					ScopeMappings: &keycloakApi.ScopeMappings{},
+				},
+				Status: keycloakApi.KeycloakClientStatus{ClientID: "client-id"},
+			},
+			adapterClient: func(t *testing.T) *adapter.Mock {
+				m := &adapter.Mock{}
+				m.On("SyncClientScopeMappings", "realm", "client-id", []string(nil),
+					map[string][]string{}, false).Return(errors.New("failed"))
+
+				return m
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "failed to sync scope mappings of client")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			adapterClient := tt.adapterClient(t)
+			el := &PutScopeMappings{
+				BaseElement: BaseElement{Logger: logr.Discard()},
+			}
+
+			tt.wantErr(t, el.Serve(context.Background(), tt.client, adapterClient, "realm"))
+			adapterClient.AssertExpectations(t)
+		})
+	}
+}
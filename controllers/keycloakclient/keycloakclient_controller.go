@@ -8,13 +8,17 @@ import (
 
 	"github.com/Nerzal/gocloak/v12"
 	pkgErrors "github.com/pkg/errors"
+	coreV1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/epam/edp-keycloak-operator/api/common"
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
@@ -22,6 +26,7 @@ import (
 	"github.com/epam/edp-keycloak-operator/controllers/keycloakclient/chain"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
 	"github.com/epam/edp-keycloak-operator/pkg/objectmeta"
+	"github.com/epam/edp-keycloak-operator/pkg/secretref"
 )
 
 type Helper interface {
@@ -36,6 +41,7 @@ const (
 	keyCloakClientOperatorFinalizerName       = "keycloak.client.operator.finalizer.name"
 	clientAttributeLogoutRedirectUris         = "post.logout.redirect.uris"
 	clientAttributeLogoutRedirectUrisDefValue = "+"
+	eventReasonSessionsRevoked                = "SessionsRevoked"
 )
 
 func NewReconcileKeycloakClient(client client.Client, helper Helper, scheme *runtime.Scheme) *ReconcileKeycloakClient {
@@ -52,17 +58,34 @@ type ReconcileKeycloakClient struct {
 	helper                  Helper
 	chain                   chain.Element
 	successReconcileTimeout time.Duration
+	recorder                record.EventRecorder
 }
 
 func (r *ReconcileKeycloakClient) SetupWithManager(mgr ctrl.Manager, successReconcileTimeout time.Duration) error {
 	r.successReconcileTimeout = successReconcileTimeout
+	r.recorder = mgr.GetEventRecorderFor("keycloakclient-controller")
 
 	pred := predicate.Funcs{
 		UpdateFunc: helper.IsFailuresUpdated,
 	}
 
+	if err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&keycloakApi.KeycloakClient{},
+		secretref.SecretNamesIndexField,
+		indexSecretNames,
+	); err != nil {
+		return fmt.Errorf("failed to index KeycloakClient by secrets: %w", err)
+	}
+
 	err := ctrl.NewControllerManagedBy(mgr).
 		For(&keycloakApi.KeycloakClient{}, builder.WithPredicates(pred)).
+		Watches(
+			&source.Kind{Type: &coreV1.Secret{}},
+			handler.EnqueueRequestsFromMapFunc(secretref.MapSecretToRequests(r.client, func() client.ObjectList {
+				return &keycloakApi.KeycloakClientList{}
+			})),
+		).
 		Complete(r)
 	if err != nil {
 		return fmt.Errorf("failed to setup KeycloakClient controller: %w", err)
@@ -71,9 +94,20 @@ func (r *ReconcileKeycloakClient) SetupWithManager(mgr ctrl.Manager, successReco
 	return nil
 }
 
+// indexSecretNames returns name of the secret referenced as the client secret.
+func indexSecretNames(obj client.Object) []string {
+	keycloakClient, ok := obj.(*keycloakApi.KeycloakClient)
+	if !ok || keycloakClient.Spec.Public {
+		return nil
+	}
+
+	return secretref.SecretNamesFromRefs(keycloakClient.Spec.Secret)
+}
+
 //+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakclients,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakclients/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakclients/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",namespace=placeholder,resources=events,verbs=create;patch
 
 // Reconcile is a loop for reconciling KeycloakClient object.
 func (r *ReconcileKeycloakClient) Reconcile(ctx context.Context, request reconcile.Request) (result reconcile.Result, resultErr error) {
@@ -140,10 +174,14 @@ func (r *ReconcileKeycloakClient) tryReconcile(ctx context.Context, keycloakClie
 		return fmt.Errorf("unable to serve keycloak client: %w", err)
 	}
 
+	if err := r.revokeSessions(ctx, keycloakClient, kClient, realm); err != nil {
+		return err
+	}
+
 	if _, err := r.helper.TryToDelete(
 		ctx,
 		keycloakClient,
-		makeTerminator(keycloakClient.Status.ClientID, realm, kClient, objectmeta.PreserveResourcesOnDeletion(keycloakClient)),
+		r.makeTerminator(keycloakClient, realm, kClient),
 		keyCloakClientOperatorFinalizerName,
 	); err != nil {
 		return pkgErrors.Wrap(err, "unable to delete kc client")
@@ -152,6 +190,53 @@ func (r *ReconcileKeycloakClient) tryReconcile(ctx context.Context, keycloakClie
 	return nil
 }
 
+// revokeSessions revokes all sessions of the disabled client.
+// Sessions are revoked once after the client is disabled.
+func (r *ReconcileKeycloakClient) revokeSessions(
+	ctx context.Context,
+	keycloakClient *keycloakApi.KeycloakClient,
+	kClient keycloak.Client,
+	realmName string,
+) error {
+	if keycloakClient.Spec.Enabled || !keycloakClient.Spec.RevokeSessionsOnDisable {
+		keycloakClient.Status.SessionsRevoked = false
+		return nil
+	}
+
+	if keycloakClient.Status.SessionsRevoked {
+		return nil
+	}
+
+	if err := kClient.RevokeClientSessions(ctx, realmName, keycloakClient.Status.ClientID); err != nil {
+		return fmt.Errorf("unable to revoke client sessions: %w", err)
+	}
+
+	r.recorder.Event(keycloakClient, coreV1.EventTypeNormal, eventReasonSessionsRevoked, "Client is disabled, client sessions are revoked")
+
+	keycloakClient.Status.SessionsRevoked = true
+
+	return nil
+}
+
+func (r *ReconcileKeycloakClient) makeTerminator(
+	keycloakClient *keycloakApi.KeycloakClient,
+	realmName string,
+	kClient keycloak.Client,
+) *terminator {
+	t := makeTerminator(
+		keycloakClient.Status.ClientID,
+		realmName,
+		kClient,
+		objectmeta.PreserveResourcesOnDeletion(keycloakClient),
+	)
+
+	if keycloakClient.Spec.RevokeSessionsOnDisable {
+		t.withSessionsRevocation(keycloakClient, r.recorder)
+	}
+
+	return t
+}
+
 // applyDefaults applies default values to KeycloakClient.
 func (r *ReconcileKeycloakClient) applyDefaults(ctx context.Context, keycloakClient *keycloakApi.KeycloakClient) (bool, error) {
 	if keycloakClient.Spec.Attributes == nil {
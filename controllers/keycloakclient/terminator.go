@@ -4,15 +4,24 @@ import (
 	"context"
 	"fmt"
 
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
 )
 
 type terminator struct {
 	clientID, realmName         string
 	kClient                     keycloak.Client
 	preserveResourcesOnDeletion bool
+
+	// revokeSessions enables revocation of the client sessions before the client deletion.
+	revokeSessions bool
+	object         runtime.Object
+	recorder       record.EventRecorder
 }
 
 func makeTerminator(clientID, realmName string, kClient keycloak.Client, preserveResourcesOnDeletion bool) *terminator {
@@ -33,6 +42,14 @@ func (t *terminator) DeleteResource(ctx context.Context) error {
 
 	log.Info("Start deleting keycloak client")
 
+	if t.revokeSessions && t.clientID != "" {
+		if err := t.kClient.RevokeClientSessions(ctx, t.realmName, t.clientID); err != nil && !adapter.IsErrNotFound(err) {
+			return fmt.Errorf("unable to revoke keycloak client sessions: %w", err)
+		}
+
+		t.recorder.Event(t.object, coreV1.EventTypeNormal, eventReasonSessionsRevoked, "Client is deleted, client sessions are revoked")
+	}
+
 	if err := t.kClient.DeleteClient(ctx, t.clientID, t.realmName); err != nil {
 		return fmt.Errorf("failed to delete keycloak client: %w", err)
 	}
@@ -41,3 +58,9 @@ func (t *terminator) DeleteResource(ctx context.Context) error {
 
 	return nil
 }
+
+func (t *terminator) withSessionsRevocation(object runtime.Object, recorder record.EventRecorder) {
+	t.revokeSessions = true
+	t.object = object
+	t.recorder = recorder
+}
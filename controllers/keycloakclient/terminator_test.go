@@ -6,7 +6,9 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/record"
 
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
 )
 
@@ -38,3 +40,26 @@ func TestTerminatorSkipDeletion(t *testing.T) {
 	err := term.DeleteResource(context.Background())
 	require.NoError(t, err)
 }
+
+func TestTerminatorRevokeSessions(t *testing.T) {
+	kClient := new(adapter.Mock)
+	recorder := record.NewFakeRecorder(10)
+
+	term := makeTerminator("client-uuid", "realm", kClient, false)
+	term.withSessionsRevocation(&keycloakApi.KeycloakClient{}, recorder)
+
+	kClient.On("RevokeClientSessions", "realm", "client-uuid").Return(nil).Once()
+	kClient.On("DeleteClient", "client-uuid", "realm").Return(nil).Once()
+
+	err := term.DeleteResource(context.Background())
+	require.NoError(t, err)
+	require.Len(t, recorder.Events, 1)
+
+	kClient.On("RevokeClientSessions", "realm", "client-uuid").Return(errors.New("fatal")).Once()
+
+	err = term.DeleteResource(context.Background())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unable to revoke keycloak client sessions")
+
+	kClient.AssertExpectations(t)
+}
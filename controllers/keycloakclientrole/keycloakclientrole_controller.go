@@ -0,0 +1,225 @@
+package keycloakclientrole
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/Nerzal/gocloak/v12"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/dto"
+	"github.com/epam/edp-keycloak-operator/pkg/objectmeta"
+)
+
+const finalizerName = "keycloak.clientrole.operator.finalizer.name"
+
+type Helper interface {
+	SetFailureCount(fc helper.FailureCountable) time.Duration
+	TryToDelete(ctx context.Context, obj client.Object, terminator helper.Terminator, finalizer string) (isDeleted bool, resultErr error)
+	GetKeycloakRealmFromRef(ctx context.Context, object helper.ObjectWithRealmRef, kcClient keycloak.Client) (*gocloak.RealmRepresentation, error)
+	CreateKeycloakClientFromRealmRef(ctx context.Context, object helper.ObjectWithRealmRef) (keycloak.Client, error)
+}
+
+type Reconcile struct {
+	client                  client.Client
+	helper                  Helper
+	successReconcileTimeout time.Duration
+}
+
+func NewReconcile(client client.Client, helper Helper) *Reconcile {
+	return &Reconcile{
+		client: client,
+		helper: helper,
+	}
+}
+
+func (r *Reconcile) SetupWithManager(mgr ctrl.Manager, successReconcileTimeout time.Duration) error {
+	r.successReconcileTimeout = successReconcileTimeout
+
+	pred := predicate.Funcs{
+		UpdateFunc: isSpecUpdated,
+	}
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&keycloakApi.KeycloakClientRole{}, builder.WithPredicates(pred)).
+		Complete(r)
+	if err != nil {
+		return fmt.Errorf("failed to setup KeycloakClientRole controller: %w", err)
+	}
+
+	return nil
+}
+
+func isSpecUpdated(e event.UpdateEvent) bool {
+	oo, ok := e.ObjectOld.(*keycloakApi.KeycloakClientRole)
+	if !ok {
+		return false
+	}
+
+	no, ok := e.ObjectNew.(*keycloakApi.KeycloakClientRole)
+	if !ok {
+		return false
+	}
+
+	return !reflect.DeepEqual(oo.Spec, no.Spec) ||
+		(oo.GetDeletionTimestamp().IsZero() && !no.GetDeletionTimestamp().IsZero())
+}
+
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakclientroles,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakclientroles/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakclientroles/finalizers,verbs=update
+
+// Reconcile is a loop for reconciling KeycloakClientRole object.
+func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (result reconcile.Result, resultErr error) {
+	log := ctrl.LoggerFrom(ctx)
+	log.Info("Reconciling KeycloakClientRole")
+
+	var instance keycloakApi.KeycloakClientRole
+	if err := r.client.Get(ctx, request.NamespacedName, &instance); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			log.Info("instance not found")
+			return
+		}
+
+		resultErr = fmt.Errorf("unable to get keycloak client role from k8s: %w", err)
+
+		return
+	}
+
+	roleID, err := r.tryReconcile(ctx, &instance)
+	if err != nil {
+		if errors.Is(err, helper.ErrKeycloakIsNotAvailable) {
+			return ctrl.Result{
+				RequeueAfter: helper.RequeueOnKeycloakNotAvailablePeriod,
+			}, nil
+		}
+
+		if errors.Is(err, errClientIsDeleted) {
+			log.Info("KeycloakClient is deleted, finalizer is removed")
+			return
+		}
+
+		instance.Status.Value = err.Error()
+		result.RequeueAfter = r.helper.SetFailureCount(&instance)
+
+		log.Error(err, "an error has occurred while handling keycloak client role", "name", request.Name)
+	} else {
+		helper.SetSuccessStatus(&instance)
+		instance.Status.ID = roleID
+		result.RequeueAfter = r.successReconcileTimeout
+	}
+
+	if err := r.client.Status().Update(ctx, &instance); err != nil {
+		resultErr = err
+	}
+
+	log.Info("Reconciling KeycloakClientRole done")
+
+	return
+}
+
+var errClientIsDeleted = errors.New("keycloak client is deleted")
+
+func (r *Reconcile) tryReconcile(ctx context.Context, instance *keycloakApi.KeycloakClientRole) (string, error) {
+	keycloakClient, err := r.getKeycloakClient(ctx, instance)
+	if err != nil {
+		return "", err
+	}
+
+	if keycloakClient.Status.ClientID == "" {
+		return "", fmt.Errorf("keycloak client %s is not ready", keycloakClient.Name)
+	}
+
+	kClient, err := r.helper.CreateKeycloakClientFromRealmRef(ctx, keycloakClient)
+	if err != nil {
+		return "", fmt.Errorf("unable to create keycloak client from realm ref: %w", err)
+	}
+
+	realm, err := r.helper.GetKeycloakRealmFromRef(ctx, keycloakClient, kClient)
+	if err != nil {
+		return "", fmt.Errorf("unable to get keycloak realm from ref: %w", err)
+	}
+
+	roleID, err := kClient.SyncClientRole(
+		ctx,
+		gocloak.PString(realm.Realm),
+		keycloakClient.Status.ClientID,
+		dto.ConvertSpecToClientRole(&instance.Spec),
+	)
+	if err != nil {
+		return "", fmt.Errorf("unable to sync client role: %w", err)
+	}
+
+	if _, err := r.helper.TryToDelete(ctx, instance,
+		makeTerminator(
+			kClient,
+			gocloak.PString(realm.Realm),
+			keycloakClient.Status.ClientID,
+			instance.Spec.Name,
+			objectmeta.PreserveResourcesOnDeletion(instance),
+		),
+		finalizerName,
+	); err != nil {
+		return "", fmt.Errorf("unable to delete client role: %w", err)
+	}
+
+	return roleID, nil
+}
+
+// getKeycloakClient returns KeycloakClient referenced by the role and sets it as an owner of the role.
+// If the role is being deleted and KeycloakClient doesn't exist, the finalizer is removed,
+// as the client roles are deleted together with the client.
+func (r *Reconcile) getKeycloakClient(
+	ctx context.Context,
+	instance *keycloakApi.KeycloakClientRole,
+) (*keycloakApi.KeycloakClient, error) {
+	keycloakClient := &keycloakApi.KeycloakClient{}
+
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: instance.Spec.ClientRef.Name}, keycloakClient)
+	if err != nil {
+		if k8sErrors.IsNotFound(err) && !instance.GetDeletionTimestamp().IsZero() {
+			controllerutil.RemoveFinalizer(instance, finalizerName)
+
+			if err = r.client.Update(ctx, instance); err != nil {
+				return nil, fmt.Errorf("unable to remove finalizer: %w", err)
+			}
+
+			return nil, errClientIsDeleted
+		}
+
+		return nil, fmt.Errorf("unable to get keycloak client %s: %w", instance.Spec.ClientRef.Name, err)
+	}
+
+	if !instance.GetDeletionTimestamp().IsZero() {
+		return keycloakClient, nil
+	}
+
+	updated := instance.DeepCopy()
+	if err = controllerutil.SetOwnerReference(keycloakClient, updated, r.client.Scheme()); err != nil {
+		return nil, fmt.Errorf("unable to set keycloak client owner reference: %w", err)
+	}
+
+	if !reflect.DeepEqual(updated.OwnerReferences, instance.OwnerReferences) {
+		instance.OwnerReferences = updated.OwnerReferences
+
+		if err = r.client.Update(ctx, instance); err != nil {
+			return nil, fmt.Errorf("unable to update keycloak client owner reference: %w", err)
+		}
+	}
+
+	return keycloakClient, nil
+}
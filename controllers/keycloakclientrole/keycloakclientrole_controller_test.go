@@ -0,0 +1,155 @@
+package keycloakclientrole
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Nerzal/gocloak/v12"
+	testifymock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	helpermock "github.com/epam/edp-keycloak-operator/controllers/helper/mocks"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/dto"
+)
+
+const ns = "default"
+
+func getTestClientRole() *keycloakApi.KeycloakClientRole {
+	return &keycloakApi.KeycloakClientRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "role", Namespace: ns},
+		Spec: keycloakApi.KeycloakClientRoleSpec{
+			Name:        "editor",
+			ClientRef:   keycloakApi.ClientRef{Name: "client"},
+			Description: "Editor role",
+			Composites:  []keycloakApi.Composite{{Name: "offline_access"}},
+		},
+	}
+}
+
+func getTestKeycloakClient(clientUUID string) *keycloakApi.KeycloakClient {
+	return &keycloakApi.KeycloakClient{
+		ObjectMeta: metav1.ObjectMeta{Name: "client", Namespace: ns, UID: "client-uid"},
+		Spec:       keycloakApi.KeycloakClientSpec{ClientId: "client-id"},
+		Status:     keycloakApi.KeycloakClientStatus{ClientID: clientUUID},
+	}
+}
+
+func TestReconcile_Reconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(scheme))
+
+	tests := []struct {
+		name        string
+		objects     []client.Object
+		setupMocks  func(h *helpermock.ControllerHelper, kClient *adapter.Mock)
+		wantResult  reconcile.Result
+		checkStatus func(t *testing.T, cl client.Client)
+	}{
+		{
+			name:    "client role is synced",
+			objects: []client.Object{getTestClientRole(), getTestKeycloakClient("client-uuid")},
+			setupMocks: func(h *helpermock.ControllerHelper, kClient *adapter.Mock) {
+				h.On("CreateKeycloakClientFromRealmRef", testifymock.Anything, testifymock.Anything).Return(kClient, nil)
+				h.On("GetKeycloakRealmFromRef", testifymock.Anything, testifymock.Anything, kClient).
+					Return(&gocloak.RealmRepresentation{Realm: gocloak.StringP("realm")}, nil)
+				h.On("TryToDelete", testifymock.Anything, testifymock.Anything, testifymock.Anything, finalizerName).
+					Return(false, nil)
+				kClient.On("SyncClientRole", "realm", "client-uuid", &dto.ClientRole{
+					Name:              "editor",
+					Description:       "Editor role",
+					Composites:        []string{"offline_access"},
+					CompositesAddOnly: true,
+				}).Return("role-id", nil)
+			},
+			wantResult: reconcile.Result{RequeueAfter: time.Hour},
+			checkStatus: func(t *testing.T, cl client.Client) {
+				role := &keycloakApi.KeycloakClientRole{}
+				require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Name: "role", Namespace: ns}, role))
+				require.Equal(t, helper.StatusOK, role.Status.Value)
+				require.Equal(t, "role-id", role.Status.ID)
+				require.Len(t, role.OwnerReferences, 1)
+				require.Equal(t, "client", role.OwnerReferences[0].Name)
+			},
+		},
+		{
+			name:    "keycloak client is not ready",
+			objects: []client.Object{getTestClientRole(), getTestKeycloakClient("")},
+			setupMocks: func(h *helpermock.ControllerHelper, kClient *adapter.Mock) {
+				h.On("SetFailureCount", testifymock.Anything).Return(time.Minute)
+			},
+			wantResult: reconcile.Result{RequeueAfter: time.Minute},
+			checkStatus: func(t *testing.T, cl client.Client) {
+				role := &keycloakApi.KeycloakClientRole{}
+				require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Name: "role", Namespace: ns}, role))
+				require.Contains(t, role.Status.Value, "keycloak client client is not ready")
+			},
+		},
+		{
+			name: "keycloak client is deleted",
+			objects: []client.Object{
+				func() client.Object {
+					role := getTestClientRole()
+					role.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+					controllerutil.AddFinalizer(role, finalizerName)
+
+					return role
+				}(),
+			},
+			setupMocks: func(h *helpermock.ControllerHelper, kClient *adapter.Mock) {},
+			wantResult: reconcile.Result{},
+			checkStatus: func(t *testing.T, cl client.Client) {
+				role := &keycloakApi.KeycloakClientRole{}
+				err := cl.Get(context.Background(), types.NamespacedName{Name: "role", Namespace: ns}, role)
+				if err == nil {
+					require.NotContains(t, role.Finalizers, finalizerName)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build()
+			h := helpermock.NewControllerHelper(t)
+			kClient := new(adapter.Mock)
+			tt.setupMocks(h, kClient)
+
+			r := Reconcile{
+				client:                  cl,
+				helper:                  h,
+				successReconcileTimeout: time.Hour,
+			}
+
+			res, err := r.Reconcile(context.Background(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: "role", Namespace: ns},
+			})
+			require.NoError(t, err)
+			require.Equal(t, tt.wantResult, res)
+			tt.checkStatus(t, cl)
+			kClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestIsSpecUpdated(t *testing.T) {
+	role := getTestClientRole()
+	updated := getTestClientRole()
+	updated.Spec.Description = "new description"
+
+	require.False(t, isSpecUpdated(event.UpdateEvent{ObjectOld: role, ObjectNew: role}))
+	require.True(t, isSpecUpdated(event.UpdateEvent{ObjectOld: role, ObjectNew: updated}))
+}
@@ -0,0 +1,44 @@
+package keycloakclientrole
+
+import (
+	"context"
+	"fmt"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+)
+
+type terminator struct {
+	kClient                         keycloak.Client
+	realmName, clientUUID, roleName string
+	preserveResourcesOnDeletion     bool
+}
+
+func (t *terminator) DeleteResource(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx)
+	if t.preserveResourcesOnDeletion {
+		log.Info("PreserveResourcesOnDeletion is enabled, skipping deletion.")
+		return nil
+	}
+
+	log.Info("Start deleting keycloak client role")
+
+	if err := t.kClient.DeleteClientRole(ctx, t.realmName, t.clientUUID, t.roleName); err != nil {
+		return fmt.Errorf("unable to delete client role: %w", err)
+	}
+
+	log.Info("Client role has been deleted")
+
+	return nil
+}
+
+func makeTerminator(kClient keycloak.Client, realmName, clientUUID, roleName string, preserveResourcesOnDeletion bool) *terminator {
+	return &terminator{
+		kClient:                     kClient,
+		realmName:                   realmName,
+		clientUUID:                  clientUUID,
+		roleName:                    roleName,
+		preserveResourcesOnDeletion: preserveResourcesOnDeletion,
+	}
+}
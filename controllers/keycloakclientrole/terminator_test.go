@@ -0,0 +1,28 @@
+package keycloakclientrole
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+func TestTerminator_DeleteResource(t *testing.T) {
+	kClient := new(adapter.Mock)
+	kClient.On("DeleteClientRole", "realm", "client-uuid", "role").Return(nil).Once()
+
+	term := makeTerminator(kClient, "realm", "client-uuid", "role", false)
+	require.NoError(t, term.DeleteResource(context.Background()))
+
+	kClient.On("DeleteClientRole", "realm", "client-uuid", "role").Return(errors.New("fatal")).Once()
+	require.ErrorContains(t, term.DeleteResource(context.Background()), "unable to delete client role")
+}
+
+func TestTerminatorSkipDeletion(t *testing.T) {
+	term := makeTerminator(nil, "realm", "client-uuid", "role", true)
+
+	require.NoError(t, term.DeleteResource(context.Background()))
+}
@@ -199,7 +199,7 @@ func syncClientScope(ctx context.Context, instance *keycloakApi.KeycloakClientSc
 		Protocol:        instance.Spec.Protocol,
 		ProtocolMappers: convertProtocolMappers(instance.Spec.ProtocolMappers),
 		Description:     instance.Spec.Description,
-		Default:         instance.Spec.Default,
+		Type:            instance.Spec.GetType(),
 	}
 
 	if err == nil {
@@ -210,17 +210,28 @@ func syncClientScope(ctx context.Context, instance *keycloakApi.KeycloakClientSc
 		if err = cl.UpdateClientScope(ctx, realmName, instance.Status.ID, &cScope); err != nil {
 			return "", errors.Wrap(err, "unable to update client scope")
 		}
+	} else {
+		id, err := cl.CreateClientScope(ctx, realmName, &cScope)
+		if err != nil {
+			return "", errors.Wrap(err, "unable to create client scope")
+		}
 
-		return instance.Status.ID, nil
+		instance.Status.ID = id
 	}
 
-	id, err := cl.CreateClientScope(ctx, realmName, &cScope)
-	if err != nil {
-		return "", errors.Wrap(err, "unable to create client scope")
+	if instance.Spec.ScopeMappings != nil {
+		if err := cl.SyncClientScopeScopeMappings(
+			ctx,
+			realmName,
+			instance.Status.ID,
+			instance.Spec.ScopeMappings.RealmRoles,
+			instance.Spec.ScopeMappings.GetClientRoles(),
+			instance.Spec.ScopeMappingsReconciliationStrategy == keycloakApi.ReconciliationStrategyAddOnly,
+		); err != nil {
+			return "", errors.Wrap(err, "unable to sync client scope scope mappings")
+		}
 	}
 
-	instance.Status.ID = id
-
 	return instance.Status.ID, nil
 }
 
@@ -231,3 +231,33 @@ func TestReconcile_Reconcile_FailureNoClientForRealm(t *testing.T) {
 	require.Error(t, loggerSink.LastError())
 	assert.Contains(t, loggerSink.LastError().Error(), "unable to create keycloak client")
 }
+
+func TestSyncClientScope_ScopeMappings(t *testing.T) {
+	kClient := new(adapter.Mock)
+	instance := getTestClientScope("test")
+	instance.Spec.ScopeMappings = &keycloakApi.ScopeMappings{
+		RealmRoles: []string{"realm-role"},
+		ClientRoles: []keycloakApi.ClientRole{
+			{ClientID: "client1", Roles: []string{"client-role"}},
+		},
+	}
+
+	kClient.On("GetClientScope", instance.Spec.Name, "realm11").Return(nil, adapter.NotFoundError("not found"))
+	kClient.On("CreateClientScope", "realm11", testifymock.Anything).Return("scope12", nil)
+	kClient.On("SyncClientScopeScopeMappings", "realm11", "scope12", []string{"realm-role"},
+		map[string][]string{"client1": {"client-role"}}, false).Return(nil).Once()
+
+	id, err := syncClientScope(context.Background(), instance, "realm11", kClient)
+	require.NoError(t, err)
+	require.Equal(t, "scope12", id)
+
+	instance.Spec.ScopeMappingsReconciliationStrategy = keycloakApi.ReconciliationStrategyAddOnly
+
+	kClient.On("SyncClientScopeScopeMappings", "realm11", "scope12", []string{"realm-role"},
+		map[string][]string{"client1": {"client-role"}}, true).Return(errors.New("failed")).Once()
+
+	_, err = syncClientScope(context.Background(), instance, "realm11", kClient)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unable to sync client scope scope mappings")
+	kClient.AssertExpectations(t)
+}
@@ -29,7 +29,9 @@ func CreateDefChain(client client.Client, scheme *runtime.Scheme, hlp Helper) ha
 								next: PutIdentityProvider{
 									next: PutDefaultIdP{
 										next: RealmSettings{
-											next: AuthFlow{},
+											next: PutUserProfile{
+												next: AuthFlow{},
+											},
 										},
 									},
 									SecretRef: secretref.NewSecretRef(client),
@@ -42,7 +42,9 @@ func (h PutIdentityProvider) ServeRequest(ctx context.Context, realm *keycloakAp
 	if realm.Spec.SSORealmMappers != nil {
 		if err := kClient.SyncRealmIdentityProviderMappers(realm.Spec.RealmName,
 			dto.ConvertSSOMappersToIdentityProviderMappers(realm.Spec.SsoRealmName,
-				*realm.Spec.SSORealmMappers)); err != nil {
+				*realm.Spec.SSORealmMappers),
+			realm.GetSSORealmMappersReconciliationStrategy() == keycloakApi.ReconciliationStrategyAddOnly,
+		); err != nil {
 			return errors.Wrap(err, "unable to sync idp mappers")
 		}
 	}
@@ -32,6 +32,12 @@ func (h RealmSettings) ServeRequest(ctx context.Context, realm *keycloakApi.Keyc
 		}
 	}
 
+	if realm.Spec.RevokeTokensBefore != nil {
+		if err := kClient.RevokeRealmTokens(ctx, realm.Spec.RealmName, realm.Spec.RevokeTokensBefore.Time); err != nil {
+			return errors.Wrap(err, "unable to revoke realm tokens")
+		}
+	}
+
 	if realm.Spec.BrowserSecurityHeaders == nil && realm.Spec.Themes == nil && len(realm.Spec.PasswordPolicies) == 0 {
 		rLog.Info("Realm settings is not set, exit.")
 		return nextServeOrNil(ctx, h.next, realm, kClient)
@@ -4,9 +4,11 @@ import (
 	"context"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
@@ -75,3 +77,26 @@ func TestRealmSettings_ServeRequest(t *testing.T) {
 
 	kClient.AssertExpectations(t)
 }
+
+func TestRealmSettings_ServeRequest_RevokeTokensBefore(t *testing.T) {
+	kClient := new(adapter.Mock)
+	notBefore := metav1.NewTime(time.Unix(1700000000, 0))
+	realm := keycloakApi.KeycloakRealm{
+		Spec: keycloakApi.KeycloakRealmSpec{
+			RealmName:          "realm1",
+			RevokeTokensBefore: &notBefore,
+		},
+	}
+
+	kClient.On("RevokeRealmTokens", "realm1", notBefore.Time).Return(nil).Once()
+
+	require.NoError(t, RealmSettings{}.ServeRequest(context.Background(), &realm, kClient))
+
+	kClient.On("RevokeRealmTokens", "realm1", notBefore.Time).Return(errors.New("revoke fatal")).Once()
+
+	err := RealmSettings{}.ServeRequest(context.Background(), &realm, kClient)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unable to revoke realm tokens")
+
+	kClient.AssertExpectations(t)
+}
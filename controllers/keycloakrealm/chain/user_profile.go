@@ -0,0 +1,166 @@
+package chain
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealm/chain/handler"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+type PutUserProfile struct {
+	next handler.RealmHandler
+}
+
+func (h PutUserProfile) ServeRequest(ctx context.Context, realm *keycloakApi.KeycloakRealm, kClient keycloak.Client) error {
+	rLog := log.WithValues("realm name", realm.Spec.RealmName)
+
+	if realm.Spec.UserProfileConfig == nil {
+		rLog.Info("User profile is not set, skipping.")
+		return nextServeOrNil(ctx, h.next, realm, kClient)
+	}
+
+	rLog.Info("Start updating of Keycloak realm user profile")
+
+	profile, err := kClient.GetUsersProfile(ctx, realm.Spec.RealmName)
+	if err != nil {
+		return errors.Wrap(err, "unable to get current user profile")
+	}
+
+	mergeUserProfile(profile, realm.Spec.UserProfileConfig)
+
+	if err = kClient.UpdateUsersProfile(ctx, realm.Spec.RealmName, profile); err != nil {
+		return errors.Wrap(err, "unable to update user profile")
+	}
+
+	rLog.Info("Realm user profile is updated.")
+
+	return nextServeOrNil(ctx, h.next, realm, kClient)
+}
+
+// mergeUserProfile merges attributes and groups from the spec to the current profile by name.
+// Attributes and groups which are not in the spec are kept.
+func mergeUserProfile(profile *adapter.UserProfileConfig, spec *keycloakApi.UserProfileConfig) {
+	if spec.UnmanagedAttributePolicy != "" {
+		profile.UnmanagedAttributePolicy = spec.UnmanagedAttributePolicy
+	}
+
+	groups := make(map[string]int, len(profile.Groups))
+	for i := range profile.Groups {
+		groups[profile.Groups[i].Name] = i
+	}
+
+	for i := range spec.Groups {
+		g := userProfileGroupToAdapter(&spec.Groups[i])
+
+		if idx, ok := groups[g.Name]; ok {
+			profile.Groups[idx] = g
+			continue
+		}
+
+		profile.Groups = append(profile.Groups, g)
+	}
+
+	attributes := make(map[string]int, len(profile.Attributes))
+	for i := range profile.Attributes {
+		attributes[profile.Attributes[i].Name] = i
+	}
+
+	for i := range spec.Attributes {
+		a := userProfileAttributeToAdapter(&spec.Attributes[i])
+
+		if idx, ok := attributes[a.Name]; ok {
+			profile.Attributes[idx] = a
+			continue
+		}
+
+		profile.Attributes = append(profile.Attributes, a)
+	}
+}
+
+func userProfileGroupToAdapter(group *keycloakApi.UserProfileGroup) adapter.UserProfileGroup {
+	return adapter.UserProfileGroup{
+		Name:               group.Name,
+		DisplayHeader:      group.DisplayHeader,
+		DisplayDescription: group.DisplayDescription,
+		Annotations:        annotationsToAdapter(group.Annotations),
+	}
+}
+
+func userProfileAttributeToAdapter(attr *keycloakApi.UserProfileAttribute) adapter.UserProfileAttribute {
+	res := adapter.UserProfileAttribute{
+		Name:        attr.Name,
+		DisplayName: attr.DisplayName,
+		Group:       attr.Group,
+		Multivalued: attr.Multivalued,
+		Annotations: annotationsToAdapter(attr.Annotations),
+	}
+
+	if attr.Permissions != nil {
+		res.Permissions = &adapter.UserProfileAttributePermissions{
+			Edit: attr.Permissions.Edit,
+			View: attr.Permissions.View,
+		}
+	}
+
+	if attr.Required != nil {
+		res.Required = &adapter.UserProfileAttributeRequired{
+			Roles:  attr.Required.Roles,
+			Scopes: attr.Required.Scopes,
+		}
+	}
+
+	if attr.Selector != nil {
+		res.Selector = &adapter.UserProfileAttributeSelector{
+			Scopes: attr.Selector.Scopes,
+		}
+	}
+
+	if attr.Validations != nil {
+		res.Validations = make(map[string]map[string]interface{}, len(attr.Validations))
+
+		for name, params := range attr.Validations {
+			validation := make(map[string]interface{}, len(params))
+
+			for k, v := range params {
+				validation[k] = validationValueToAdapter(v)
+			}
+
+			res.Validations[name] = validation
+		}
+	}
+
+	return res
+}
+
+func validationValueToAdapter(v keycloakApi.UserProfileAttributeValidation) interface{} {
+	if v.SliceVal != nil {
+		return v.SliceVal
+	}
+
+	if v.MapVal != nil {
+		return v.MapVal
+	}
+
+	if v.StringVal != "" {
+		return v.StringVal
+	}
+
+	return v.IntVal
+}
+
+func annotationsToAdapter(annotations map[string]string) map[string]interface{} {
+	if annotations == nil {
+		return nil
+	}
+
+	res := make(map[string]interface{}, len(annotations))
+	for k, v := range annotations {
+		res[k] = v
+	}
+
+	return res
+}
@@ -0,0 +1,139 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+func TestPutUserProfile_ServeRequest(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("user profile is not set", func(t *testing.T) {
+		kClient := new(adapter.Mock)
+
+		err := PutUserProfile{}.ServeRequest(ctx, &keycloakApi.KeycloakRealm{}, kClient)
+		require.NoError(t, err)
+
+		kClient.AssertExpectations(t)
+	})
+
+	t.Run("user profile is merged", func(t *testing.T) {
+		kClient := new(adapter.Mock)
+		realm := &keycloakApi.KeycloakRealm{
+			Spec: keycloakApi.KeycloakRealmSpec{
+				RealmName: "realm1",
+				UserProfileConfig: &keycloakApi.UserProfileConfig{
+					UnmanagedAttributePolicy: "ADMIN_EDIT",
+					Attributes: []keycloakApi.UserProfileAttribute{
+						{
+							Name:        "email",
+							DisplayName: "Email",
+							Permissions: &keycloakApi.UserProfileAttributePermissions{
+								Edit: []string{"admin"},
+							},
+							Validations: map[string]map[string]keycloakApi.UserProfileAttributeValidation{
+								"length": {
+									"max": {IntVal: 100},
+								},
+								"options": {
+									"options": {SliceVal: []string{"a", "b"}},
+								},
+							},
+						},
+						{
+							Name:        "department",
+							Group:       "user-metadata",
+							Multivalued: true,
+							Annotations: map[string]string{"inputType": "text"},
+							Required: &keycloakApi.UserProfileAttributeRequired{
+								Roles: []string{"user"},
+							},
+							Selector: &keycloakApi.UserProfileAttributeSelector{
+								Scopes: []string{"profile"},
+							},
+						},
+					},
+					Groups: []keycloakApi.UserProfileGroup{
+						{Name: "user-metadata", DisplayHeader: "Metadata"},
+					},
+				},
+			},
+		}
+
+		kClient.On("GetUsersProfile", "realm1").Return(&adapter.UserProfileConfig{
+			Attributes: []adapter.UserProfileAttribute{
+				{Name: "username"},
+				{Name: "email", DisplayName: "Old email"},
+			},
+			Groups: []adapter.UserProfileGroup{
+				{Name: "user-metadata", DisplayHeader: "User metadata"},
+			},
+		}, nil)
+		kClient.On("UpdateUsersProfile", "realm1", &adapter.UserProfileConfig{
+			UnmanagedAttributePolicy: "ADMIN_EDIT",
+			Attributes: []adapter.UserProfileAttribute{
+				{Name: "username"},
+				{
+					Name:        "email",
+					DisplayName: "Email",
+					Permissions: &adapter.UserProfileAttributePermissions{
+						Edit: []string{"admin"},
+					},
+					Validations: map[string]map[string]interface{}{
+						"length": {
+							"max": 100,
+						},
+						"options": {
+							"options": []string{"a", "b"},
+						},
+					},
+				},
+				{
+					Name:        "department",
+					Group:       "user-metadata",
+					Multivalued: true,
+					Annotations: map[string]interface{}{"inputType": "text"},
+					Required: &adapter.UserProfileAttributeRequired{
+						Roles: []string{"user"},
+					},
+					Selector: &adapter.UserProfileAttributeSelector{
+						Scopes: []string{"profile"},
+					},
+				},
+			},
+			Groups: []adapter.UserProfileGroup{
+				{Name: "user-metadata", DisplayHeader: "Metadata"},
+			},
+		}).Return(nil)
+
+		err := PutUserProfile{}.ServeRequest(ctx, realm, kClient)
+		require.NoError(t, err)
+
+		kClient.AssertExpectations(t)
+	})
+
+	t.Run("failed to get user profile", func(t *testing.T) {
+		kClient := new(adapter.Mock)
+		realm := &keycloakApi.KeycloakRealm{
+			Spec: keycloakApi.KeycloakRealmSpec{
+				RealmName:         "realm1",
+				UserProfileConfig: &keycloakApi.UserProfileConfig{},
+			},
+		}
+
+		kClient.On("GetUsersProfile", "realm1").Return(nil, errors.New("get profile error"))
+
+		err := PutUserProfile{}.ServeRequest(ctx, realm, kClient)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unable to get current user profile")
+
+		kClient.AssertExpectations(t)
+	})
+}
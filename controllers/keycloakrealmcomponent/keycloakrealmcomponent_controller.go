@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/Nerzal/gocloak/v12"
+	corev1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -19,8 +20,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/epam/edp-keycloak-operator/api/common"
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
@@ -28,6 +31,7 @@ import (
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
 	"github.com/epam/edp-keycloak-operator/pkg/objectmeta"
+	"github.com/epam/edp-keycloak-operator/pkg/secretref"
 )
 
 const finalizerName = "keycloak.realmcomponent.operator.finalizer.name"
@@ -68,8 +72,23 @@ func (r *Reconcile) SetupWithManager(mgr ctrl.Manager, successReconcileTimeout t
 		UpdateFunc: isSpecUpdated,
 	}
 
+	if err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&keycloakApi.KeycloakRealmComponent{},
+		secretref.SecretNamesIndexField,
+		indexSecretNames,
+	); err != nil {
+		return fmt.Errorf("failed to index KeycloakRealmComponent by secrets: %w", err)
+	}
+
 	err := ctrl.NewControllerManagedBy(mgr).
 		For(&keycloakApi.KeycloakRealmComponent{}, builder.WithPredicates(pred)).
+		Watches(
+			&source.Kind{Type: &corev1.Secret{}},
+			handler.EnqueueRequestsFromMapFunc(secretref.MapSecretToRequests(r.client, func() client.ObjectList {
+				return &keycloakApi.KeycloakRealmComponentList{}
+			})),
+		).
 		Complete(r)
 	if err != nil {
 		return fmt.Errorf("failed to setup keycloakRealmComponent controller: %w", err)
@@ -78,6 +97,21 @@ func (r *Reconcile) SetupWithManager(mgr ctrl.Manager, successReconcileTimeout t
 	return nil
 }
 
+// indexSecretNames returns names of the secrets referenced in the component config.
+func indexSecretNames(obj client.Object) []string {
+	component, ok := obj.(*keycloakApi.KeycloakRealmComponent)
+	if !ok {
+		return nil
+	}
+
+	var values []string
+	for _, v := range component.Spec.Config {
+		values = append(values, v...)
+	}
+
+	return secretref.SecretNamesFromRefs(values...)
+}
+
 func isSpecUpdated(e event.UpdateEvent) bool {
 	oo, ok := e.ObjectOld.(*keycloakApi.KeycloakRealmComponent)
 	if !ok {
@@ -146,6 +180,8 @@ func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (r
 
 	term := makeTerminator(
 		gocloak.PString(realm.Realm),
+		keycloakRealmComponent.Status.ID,
+		r.terminatorFallbackParentID(ctx, keycloakRealmComponent, realm, kClient),
 		keycloakRealmComponent.Spec.Name,
 		kClient,
 		objectmeta.PreserveResourcesOnDeletion(keycloakRealmComponent),
@@ -157,7 +193,7 @@ func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (r
 		return reconcile.Result{}, nil
 	}
 
-	if err := r.tryReconcile(ctx, keycloakRealmComponent, gocloak.PString(realm.Realm), kClient); err != nil {
+	if err := r.tryReconcile(ctx, keycloakRealmComponent, realm, kClient); err != nil {
 		keycloakRealmComponent.Status.Value = err.Error()
 		requeueAfter := r.helper.SetFailureCount(keycloakRealmComponent)
 
@@ -182,44 +218,171 @@ func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (r
 func (r *Reconcile) tryReconcile(
 	ctx context.Context,
 	keycloakRealmComponent *keycloakApi.KeycloakRealmComponent,
-	realmName string,
+	realm *gocloak.RealmRepresentation,
 	kClient keycloak.Client,
 ) error {
-	keycloakComponent, err := r.createKeycloakComponent(ctx, keycloakRealmComponent, realmName, kClient)
+	realmName := gocloak.PString(realm.Realm)
+
+	if err := validateComponentProvider(ctx, keycloakRealmComponent, kClient); err != nil {
+		return err
+	}
+
+	keycloakComponent, err := r.createKeycloakComponent(
+		ctx,
+		keycloakRealmComponent,
+		realmName,
+		gocloak.PString(realm.ID),
+		kClient,
+	)
 	if err != nil {
 		return fmt.Errorf("unable to create keycloak component: %w", err)
 	}
 
+	refConfig := make(map[string][]string, len(keycloakComponent.Config))
+	for k, v := range keycloakComponent.Config {
+		refConfig[k] = append([]string(nil), v...)
+	}
+
 	if err = r.secretRefClient.MapComponentConfigSecretsRefs(ctx, keycloakComponent.Config, keycloakRealmComponent.Namespace); err != nil {
 		return fmt.Errorf("unable to map config secrets: %w", err)
 	}
 
-	cmp, err := kClient.GetComponent(ctx, realmName, keycloakRealmComponent.Spec.Name)
+	parentID := keycloakComponent.ParentID
+	if parentID == "" {
+		// Keycloak sets realm ID as a parent of the top-level components.
+		parentID = gocloak.PString(realm.ID)
+	}
+
+	if parentID == "" {
+		return errors.New("unable to get parent id: realm id is empty")
+	}
+
+	cmp, err := findComponent(ctx, kClient, realmName, keycloakRealmComponent.Status.ID, parentID, keycloakRealmComponent.Spec.Name)
 	if err != nil {
+		return err
+	}
+
+	if cmp == nil {
+		if err = kClient.CreateComponent(ctx, realmName, keycloakComponent); err != nil {
+			return fmt.Errorf("unable to create component %w", err)
+		}
+
+		if keycloakComponent.ID == "" {
+			created, err := kClient.GetComponentByParent(ctx, realmName, parentID, keycloakComponent.Name)
+			if err != nil {
+				return fmt.Errorf("unable to get created component: %w", err)
+			}
+
+			keycloakComponent.ID = created.ID
+		}
+	} else {
+		keycloakComponent.ID = cmp.ID
+
+		if err = kClient.UpdateComponent(ctx, realmName, keycloakComponent); err != nil {
+			return fmt.Errorf("unable to update component: %w", err)
+		}
+	}
+
+	keycloakRealmComponent.Status.ID = keycloakComponent.ID
+	keycloakRealmComponent.Status.SecretHashes = secretref.ComponentConfigSecretsHashes(refConfig, keycloakComponent.Config)
+
+	return nil
+}
+
+// findComponent looks up the component by ID stored in the status.
+// If the ID is not set or the component doesn't exist anymore, the component is looked up by name
+// among the components of the parent, so the existing component is adopted.
+// It returns nil if the component is not found.
+func findComponent(
+	ctx context.Context,
+	kClient keycloak.Client,
+	realmName, componentID, parentID, componentName string,
+) (*adapter.Component, error) {
+	if componentID != "" {
+		cmp, err := kClient.GetComponentByID(ctx, realmName, componentID)
+		if err == nil {
+			return cmp, nil
+		}
+
 		if !adapter.IsErrNotFound(err) {
-			return fmt.Errorf("unable to get component, unexpected error: %w", err)
+			return nil, fmt.Errorf("unable to get component by id: %w", err)
 		}
 
-		if err := kClient.CreateComponent(ctx, realmName, keycloakComponent); err != nil {
-			return fmt.Errorf("unable to create component %w", err)
+		ctrl.LoggerFrom(ctx).Info("Component not found by id, looking up by name", "id", componentID)
+	}
+
+	cmp, err := kClient.GetComponentByParent(ctx, realmName, parentID, componentName)
+	if err != nil {
+		if adapter.IsErrNotFound(err) {
+			return nil, nil
 		}
 
-		return nil
+		return nil, fmt.Errorf("unable to get component, unexpected error: %w", err)
 	}
 
-	keycloakComponent.ID = cmp.ID
+	return cmp, nil
+}
 
-	if err := kClient.UpdateComponent(ctx, realmName, keycloakComponent); err != nil {
-		return fmt.Errorf("unable to update component: %w", err)
+// terminatorFallbackParentID returns the parent ID to look up the component on deletion if its ID is unknown.
+// Child components without ID are looked up within their parent component.
+func (r *Reconcile) terminatorFallbackParentID(
+	ctx context.Context,
+	component *keycloakApi.KeycloakRealmComponent,
+	realm *gocloak.RealmRepresentation,
+	kClient keycloak.Client,
+) string {
+	if component.Status.ID != "" {
+		return ""
 	}
 
-	return nil
+	parentID, err := r.getParentID(ctx, component, gocloak.PString(realm.Realm), gocloak.PString(realm.ID), kClient)
+	if err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Unable to get component parent id, component can't be deleted by name")
+
+		return ""
+	}
+
+	if parentID == "" {
+		// Keycloak sets realm ID as a parent of the top-level components.
+		return gocloak.PString(realm.ID)
+	}
+
+	return parentID
+}
+
+// validateComponentProvider checks that component provider type and provider id are available on the server.
+// Validation is skipped if server info can't be retrieved, e.g. admin doesn't have enough permissions.
+func validateComponentProvider(
+	ctx context.Context,
+	component *keycloakApi.KeycloakRealmComponent,
+	kClient keycloak.Client,
+) error {
+	serverInfo, err := kClient.GetServerInfo(ctx)
+	if err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Unable to get server info, skipping component provider validation")
+
+		return nil
+	}
+
+	if err := adapter.ValidateProviderID(
+		"component provider type",
+		component.Spec.ProviderType,
+		serverInfo.ComponentProviderTypes(),
+	); err != nil {
+		return err
+	}
+
+	return adapter.ValidateProviderID(
+		"component provider",
+		component.Spec.ProviderID,
+		serverInfo.ComponentProviderIDs(component.Spec.ProviderType),
+	)
 }
 
 func (r *Reconcile) createKeycloakComponent(
 	ctx context.Context,
 	component *keycloakApi.KeycloakRealmComponent,
-	kcRealmName string,
+	kcRealmName, kcRealmID string,
 	kClient keycloak.Client,
 ) (*adapter.Component, error) {
 	ksComponent := &adapter.Component{
@@ -234,7 +397,7 @@ func (r *Reconcile) createKeycloakComponent(
 		copy(ksComponent.Config[k], v)
 	}
 
-	parenID, err := r.getParentID(ctx, component, kcRealmName, kClient)
+	parenID, err := r.getParentID(ctx, component, kcRealmName, kcRealmID, kClient)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get parent id: %w", err)
 	}
@@ -246,11 +409,26 @@ func (r *Reconcile) createKeycloakComponent(
 	return ksComponent, nil
 }
 
+// getParentID returns ID of the component parent. It returns empty string if the parent is not set.
 func (r *Reconcile) getParentID(
 	ctx context.Context,
 	component *keycloakApi.KeycloakRealmComponent,
-	kcRealmName string,
+	kcRealmName, kcRealmID string,
+	kClient keycloak.Client,
+) (string, error) {
+	return r.resolveParentID(ctx, component, kcRealmName, kcRealmID, kClient, map[string]struct{}{})
+}
+
+// resolveParentID returns ID of the component parent.
+// Parent component without ID in the status is looked up by name among the components of its own parent,
+// so a component with the same name elsewhere in the realm is not used.
+// visited contains names of the components which are already resolved to detect cyclic parent references.
+func (r *Reconcile) resolveParentID(
+	ctx context.Context,
+	component *keycloakApi.KeycloakRealmComponent,
+	kcRealmName, kcRealmID string,
 	kClient keycloak.Client,
+	visited map[string]struct{},
 ) (string, error) {
 	if component.Spec.ParentRef == nil {
 		return "", nil
@@ -275,12 +453,32 @@ func (r *Reconcile) getParentID(
 	}
 
 	if component.Spec.ParentRef.Kind == keycloakApi.KeycloakRealmComponentKind {
+		if _, ok := visited[component.Spec.ParentRef.Name]; ok {
+			return "", fmt.Errorf("cyclic parent reference of component %s", component.Spec.ParentRef.Name)
+		}
+
+		visited[component.Name] = struct{}{}
+
 		parentComponent := &keycloakApi.KeycloakRealmComponent{}
 		if err := r.client.Get(ctx, types.NamespacedName{Name: component.Spec.ParentRef.Name, Namespace: component.GetNamespace()}, parentComponent); err != nil {
 			return "", fmt.Errorf("unable to get parent component: %w", err)
 		}
 
-		kcParentComponent, err := kClient.GetComponent(ctx, kcRealmName, parentComponent.Spec.Name)
+		if parentComponent.Status.ID != "" {
+			return parentComponent.Status.ID, nil
+		}
+
+		grandParentID, err := r.resolveParentID(ctx, parentComponent, kcRealmName, kcRealmID, kClient, visited)
+		if err != nil {
+			return "", err
+		}
+
+		if grandParentID == "" {
+			// Keycloak sets realm ID as a parent of the top-level components.
+			grandParentID = kcRealmID
+		}
+
+		kcParentComponent, err := kClient.GetComponentByParent(ctx, kcRealmName, grandParentID, parentComponent.Spec.Name)
 		if err != nil {
 			return "", fmt.Errorf("unable to get parent component: %w", err)
 		}
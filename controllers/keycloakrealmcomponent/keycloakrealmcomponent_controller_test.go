@@ -41,7 +41,9 @@ func TestReconcile_Reconcile(t *testing.T) {
 			ObjectMeta: metav1.ObjectMeta{Name: "test-comp-name", Namespace: "ns"},
 			TypeMeta:   metav1.TypeMeta{Kind: "KeycloakRealmComponent", APIVersion: "v1.edp.epam.com/v1"},
 			Spec: keycloakApi.KeycloakComponentSpec{
-				Name: "test-comp",
+				Name:         "test-comp",
+				ProviderID:   "rsa-generated",
+				ProviderType: "org.keycloak.keys.KeyProvider",
 				RealmRef: common.RealmRef{
 					Kind: keycloakApi.KeycloakRealmKind,
 					Name: "realm",
@@ -55,7 +57,18 @@ func TestReconcile_Reconcile(t *testing.T) {
 			ObjectMeta: metav1.ObjectMeta{Name: "realm1", Namespace: "ns",
 				OwnerReferences: []metav1.OwnerReference{{Name: "keycloak1", Kind: "Keycloak"}}},
 			Spec: keycloakApi.KeycloakRealmSpec{RealmName: "realm11"}}
-		testComp = adapter.Component{ID: "component-id1", Name: comp.Spec.Name, Config: make(map[string][]string)}
+		testComp = adapter.Component{
+			ID:           "component-id1",
+			Name:         comp.Spec.Name,
+			ProviderID:   comp.Spec.ProviderID,
+			ProviderType: comp.Spec.ProviderType,
+			Config:       make(map[string][]string),
+		}
+		serverInfo = adapter.ServerInfo{
+			ComponentTypes: map[string][]adapter.ComponentTypeInfo{
+				"org.keycloak.keys.KeyProvider": {{ID: "rsa-generated"}, {ID: "hmac-generated"}},
+			},
+		}
 	)
 
 	client := fake.NewClientBuilder().WithScheme(sch).WithRuntimeObjects(&comp).Build()
@@ -68,8 +81,10 @@ func TestReconcile_Reconcile(t *testing.T) {
 	h.On("GetKeycloakRealmFromRef", testifymock.Anything, testifymock.Anything, testifymock.Anything).
 		Return(&gocloak.RealmRepresentation{
 			Realm: gocloak.StringP(realm.Spec.RealmName),
+			ID:    gocloak.StringP("realm-id"),
 		}, nil)
-	kcAdapter.On("GetComponent", realm.Spec.RealmName, comp.Spec.Name).Return(&testComp, nil).Once()
+	kcAdapter.On("GetServerInfo", testifymock.Anything).Return(&serverInfo, nil)
+	kcAdapter.On("GetComponentByParent", realm.Spec.RealmName, "realm-id", comp.Spec.Name).Return(&testComp, nil).Once()
 	kcAdapter.On("UpdateComponent", realm.Spec.RealmName, &testComp).Return(nil)
 
 	r := NewReconcile(client, sch, h, secretref.NewSecretRef(client))
@@ -88,7 +103,16 @@ func TestReconcile_Reconcile(t *testing.T) {
 		t.Fatalf("wrong RequeueAfter: %d", res.RequeueAfter)
 	}
 
-	kcAdapter.On("GetComponent", realm.Spec.RealmName, comp.Spec.Name).Return(nil,
+	reconciledComp := &keycloakApi.KeycloakRealmComponent{}
+	require.NoError(t, client.Get(context.Background(), types.NamespacedName{
+		Name:      comp.Name,
+		Namespace: comp.Namespace,
+	}, reconciledComp))
+	require.Equal(t, testComp.ID, reconciledComp.Status.ID)
+
+	kcAdapter.On("GetComponentByID", realm.Spec.RealmName, testComp.ID).Return(nil,
+		adapter.NotFoundError("not found")).Once()
+	kcAdapter.On("GetComponentByParent", realm.Spec.RealmName, "realm-id", comp.Spec.Name).Return(nil,
 		adapter.NotFoundError("not found")).Once()
 	kcAdapter.On("CreateComponent", realm.Spec.RealmName,
 		testifyMock.Anything).Return(errors.New("create fatal"))
@@ -104,6 +128,22 @@ func TestReconcile_Reconcile(t *testing.T) {
 	}})
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "create fatal")
+
+	invalidComp := &keycloakApi.KeycloakRealmComponent{}
+	require.NoError(t, client.Get(context.Background(), types.NamespacedName{
+		Name:      comp.Name,
+		Namespace: comp.Namespace,
+	}, invalidComp))
+
+	invalidComp.Spec.ProviderID = "rsa-generate"
+	require.NoError(t, client.Update(context.Background(), invalidComp))
+
+	_, err = r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{
+		Name:      comp.Name,
+		Namespace: comp.Namespace,
+	}})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `unknown component provider "rsa-generate", available: hmac-generated, rsa-generated`)
 }
 
 func TestIsSpecUpdated(t *testing.T) {
@@ -113,3 +153,199 @@ func TestIsSpecUpdated(t *testing.T) {
 		t.Fatal("spec is updated")
 	}
 }
+
+func TestReconcile_RenameComponent(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(sch))
+	utilruntime.Must(corev1.AddToScheme(sch))
+
+	comp := &keycloakApi.KeycloakRealmComponent{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-comp", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakComponentSpec{
+			Name:         "renamed-comp",
+			ProviderID:   "rsa-generated",
+			ProviderType: "org.keycloak.keys.KeyProvider",
+			RealmRef:     common.RealmRef{Kind: keycloakApi.KeycloakRealmKind, Name: "realm"},
+		},
+		Status: keycloakApi.KeycloakComponentStatus{ID: "component-id"},
+	}
+
+	cl := fake.NewClientBuilder().WithScheme(sch).WithObjects(comp).Build()
+	h := helpermock.NewControllerHelper(t)
+	kcAdapter := &adapter.Mock{}
+
+	h.On("SetRealmOwnerRef", testifymock.Anything, testifymock.Anything).Return(nil)
+	h.On("CreateKeycloakClientFromRealmRef", testifymock.Anything, testifymock.Anything).Return(kcAdapter, nil)
+	h.On("TryToDelete", testifymock.Anything, testifymock.Anything, testifymock.Anything, testifymock.Anything).
+		Return(false, nil)
+	h.On("GetKeycloakRealmFromRef", testifymock.Anything, testifymock.Anything, testifymock.Anything).
+		Return(&gocloak.RealmRepresentation{Realm: gocloak.StringP("realm"), ID: gocloak.StringP("realm-id")}, nil)
+	kcAdapter.On("GetServerInfo", testifymock.Anything).Return(nil, errors.New("forbidden"))
+	kcAdapter.On("GetComponentByID", "realm", "component-id").
+		Return(&adapter.Component{ID: "component-id", Name: "test-comp"}, nil)
+	kcAdapter.On("UpdateComponent", "realm", testifymock.MatchedBy(func(c *adapter.Component) bool {
+		return c.ID == "component-id" && c.Name == "renamed-comp"
+	})).Return(nil)
+
+	r := NewReconcile(cl, sch, h, secretref.NewSecretRef(cl))
+
+	_, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{
+		Name:      comp.Name,
+		Namespace: comp.Namespace,
+	}})
+	require.NoError(t, err)
+	kcAdapter.AssertExpectations(t)
+}
+
+func TestReconcile_getParentID(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(sch))
+
+	parentRef := func(name string) *keycloakApi.ParentComponent {
+		return &keycloakApi.ParentComponent{Kind: keycloakApi.KeycloakRealmComponentKind, Name: name}
+	}
+
+	tests := []struct {
+		name         string
+		component    *keycloakApi.KeycloakRealmComponent
+		objects      []*keycloakApi.KeycloakRealmComponent
+		kClient      func(t *testing.T) *adapter.Mock
+		want         string
+		wantErrorMsg string
+	}{
+		{
+			name: "parent component with id in status",
+			component: &keycloakApi.KeycloakRealmComponent{
+				ObjectMeta: metav1.ObjectMeta{Name: "child", Namespace: "ns"},
+				Spec:       keycloakApi.KeycloakComponentSpec{Name: "child", ParentRef: parentRef("parent")},
+			},
+			objects: []*keycloakApi.KeycloakRealmComponent{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "parent", Namespace: "ns"},
+					Spec:       keycloakApi.KeycloakComponentSpec{Name: "parent-comp"},
+					Status:     keycloakApi.KeycloakComponentStatus{ID: "parent-id"},
+				},
+			},
+			kClient: func(t *testing.T) *adapter.Mock {
+				return &adapter.Mock{}
+			},
+			want: "parent-id",
+		},
+		{
+			name: "parent component is looked up within the realm",
+			component: &keycloakApi.KeycloakRealmComponent{
+				ObjectMeta: metav1.ObjectMeta{Name: "child", Namespace: "ns"},
+				Spec:       keycloakApi.KeycloakComponentSpec{Name: "child", ParentRef: parentRef("parent")},
+			},
+			objects: []*keycloakApi.KeycloakRealmComponent{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "parent", Namespace: "ns"},
+					Spec:       keycloakApi.KeycloakComponentSpec{Name: "parent-comp"},
+				},
+			},
+			kClient: func(t *testing.T) *adapter.Mock {
+				m := &adapter.Mock{}
+				m.On("GetComponentByParent", "realm", "realm-id", "parent-comp").
+					Return(&adapter.Component{ID: "parent-id"}, nil)
+
+				return m
+			},
+			want: "parent-id",
+		},
+		{
+			name: "parent component is looked up within its parent component",
+			component: &keycloakApi.KeycloakRealmComponent{
+				ObjectMeta: metav1.ObjectMeta{Name: "child", Namespace: "ns"},
+				Spec:       keycloakApi.KeycloakComponentSpec{Name: "child", ParentRef: parentRef("parent")},
+			},
+			objects: []*keycloakApi.KeycloakRealmComponent{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "parent", Namespace: "ns"},
+					Spec:       keycloakApi.KeycloakComponentSpec{Name: "parent-comp", ParentRef: parentRef("grandparent")},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "grandparent", Namespace: "ns"},
+					Spec:       keycloakApi.KeycloakComponentSpec{Name: "grandparent-comp"},
+					Status:     keycloakApi.KeycloakComponentStatus{ID: "grandparent-id"},
+				},
+			},
+			kClient: func(t *testing.T) *adapter.Mock {
+				m := &adapter.Mock{}
+				m.On("GetComponentByParent", "realm", "grandparent-id", "parent-comp").
+					Return(&adapter.Component{ID: "parent-id"}, nil)
+
+				return m
+			},
+			want: "parent-id",
+		},
+		{
+			name: "cyclic parent reference",
+			component: &keycloakApi.KeycloakRealmComponent{
+				ObjectMeta: metav1.ObjectMeta{Name: "child", Namespace: "ns"},
+				Spec:       keycloakApi.KeycloakComponentSpec{Name: "child", ParentRef: parentRef("parent")},
+			},
+			objects: []*keycloakApi.KeycloakRealmComponent{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "parent", Namespace: "ns"},
+					Spec:       keycloakApi.KeycloakComponentSpec{Name: "parent-comp", ParentRef: parentRef("child")},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "child", Namespace: "ns"},
+					Spec:       keycloakApi.KeycloakComponentSpec{Name: "child", ParentRef: parentRef("parent")},
+				},
+			},
+			kClient: func(t *testing.T) *adapter.Mock {
+				return &adapter.Mock{}
+			},
+			wantErrorMsg: "cyclic parent reference",
+		},
+		{
+			name: "parent component not found in keycloak",
+			component: &keycloakApi.KeycloakRealmComponent{
+				ObjectMeta: metav1.ObjectMeta{Name: "child", Namespace: "ns"},
+				Spec:       keycloakApi.KeycloakComponentSpec{Name: "child", ParentRef: parentRef("parent")},
+			},
+			objects: []*keycloakApi.KeycloakRealmComponent{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "parent", Namespace: "ns"},
+					Spec:       keycloakApi.KeycloakComponentSpec{Name: "parent-comp"},
+				},
+			},
+			kClient: func(t *testing.T) *adapter.Mock {
+				m := &adapter.Mock{}
+				m.On("GetComponentByParent", "realm", "realm-id", "parent-comp").
+					Return(nil, adapter.NotFoundError("not found"))
+
+				return m
+			},
+			wantErrorMsg: "unable to get parent component",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			builder := fake.NewClientBuilder().WithScheme(sch)
+			for _, o := range tt.objects {
+				builder = builder.WithObjects(o)
+			}
+
+			kClient := tt.kClient(t)
+			r := NewReconcile(builder.Build(), sch, helpermock.NewControllerHelper(t), nil)
+
+			got, err := r.getParentID(context.Background(), tt.component, "realm", "realm-id", kClient)
+
+			if tt.wantErrorMsg != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.wantErrorMsg)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tt.want, got)
+			kClient.AssertExpectations(t)
+		})
+	}
+}
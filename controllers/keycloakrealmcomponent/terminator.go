@@ -7,18 +7,30 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
 )
 
 type terminator struct {
 	realmName                   string
+	componentID                 string
+	parentID                    string
 	componentName               string
 	kClient                     keycloak.Client
 	preserveResourcesOnDeletion bool
 }
 
-func makeTerminator(realmName, componentName string, kClient keycloak.Client, preserveResourcesOnDeletion bool) *terminator {
+// makeTerminator creates terminator which deletes the component by ID.
+// If the ID is unknown, the component is looked up by name among the components of the parent.
+// If the parent is unknown too, the deletion is skipped, so a component with the same name elsewhere is not removed.
+func makeTerminator(
+	realmName, componentID, parentID, componentName string,
+	kClient keycloak.Client,
+	preserveResourcesOnDeletion bool,
+) *terminator {
 	return &terminator{
 		realmName:                   realmName,
+		componentID:                 componentID,
+		parentID:                    parentID,
 		componentName:               componentName,
 		kClient:                     kClient,
 		preserveResourcesOnDeletion: preserveResourcesOnDeletion,
@@ -34,7 +46,28 @@ func (t *terminator) DeleteResource(ctx context.Context) error {
 
 	log.Info("Start deleting KeycloakRealmComponent")
 
-	if err := t.kClient.DeleteComponent(ctx, t.realmName, t.componentName); err != nil {
+	componentID := t.componentID
+
+	if componentID == "" {
+		if t.parentID == "" {
+			log.Info("Component id is unknown, skipping deletion")
+			return nil
+		}
+
+		cmp, err := t.kClient.GetComponentByParent(ctx, t.realmName, t.parentID, t.componentName)
+		if err != nil {
+			if adapter.IsErrNotFound(err) {
+				log.Info("Component not found, skipping deletion")
+				return nil
+			}
+
+			return fmt.Errorf("unable to get realm component: %w", err)
+		}
+
+		componentID = cmp.ID
+	}
+
+	if err := t.kClient.DeleteComponentByID(ctx, t.realmName, componentID); err != nil {
 		return fmt.Errorf("unable to delete realm component %w", err)
 	}
 
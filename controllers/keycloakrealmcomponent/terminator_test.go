@@ -14,8 +14,32 @@ func TestTerminator_DeleteResource(t *testing.T) {
 		kcAdapter adapter.Mock
 	)
 
-	kcAdapter.On("DeleteComponent", "foo", "bar").Return(nil)
-	term := makeTerminator("foo", "bar", &kcAdapter, false)
+	kcAdapter.On("DeleteComponentByID", "foo", "bar-id").Return(nil)
+	term := makeTerminator("foo", "bar-id", "", "bar", &kcAdapter, false)
 	err := term.DeleteResource(context.Background())
 	require.NoError(t, err)
+	kcAdapter.AssertExpectations(t)
+}
+
+func TestTerminator_DeleteResource_ByParent(t *testing.T) {
+	var kcAdapter adapter.Mock
+
+	kcAdapter.On("GetComponentByParent", "foo", "realm-id", "bar").Return(&adapter.Component{ID: "bar-id"}, nil).Once()
+	kcAdapter.On("DeleteComponentByID", "foo", "bar-id").Return(nil).Once()
+
+	term := makeTerminator("foo", "", "realm-id", "bar", &kcAdapter, false)
+	require.NoError(t, term.DeleteResource(context.Background()))
+
+	kcAdapter.On("GetComponentByParent", "foo", "realm-id", "bar").Return(nil, adapter.NotFoundError("not found")).Once()
+	require.NoError(t, term.DeleteResource(context.Background()))
+
+	kcAdapter.AssertExpectations(t)
+}
+
+func TestTerminator_DeleteResource_UnknownComponent(t *testing.T) {
+	var kcAdapter adapter.Mock
+
+	term := makeTerminator("foo", "", "", "bar", &kcAdapter, false)
+	require.NoError(t, term.DeleteResource(context.Background()))
+	kcAdapter.AssertNotCalled(t, "DeleteComponentByID")
 }
@@ -3,14 +3,19 @@ package keycloakrealmgroup
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"time"
 
 	"github.com/Nerzal/gocloak/v12"
 	"github.com/pkg/errors"
+	"golang.org/x/exp/slices"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -18,10 +23,14 @@ import (
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
 	"github.com/epam/edp-keycloak-operator/controllers/helper"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
 	"github.com/epam/edp-keycloak-operator/pkg/objectmeta"
 )
 
-const keyCloakRealmGroupOperatorFinalizerName = "keycloak.realmgroup.operator.finalizer.name"
+const (
+	keyCloakRealmGroupOperatorFinalizerName = "keycloak.realmgroup.operator.finalizer.name"
+	keycloakRealmGroupKind                  = "KeycloakRealmGroup"
+)
 
 type Helper interface {
 	SetFailureCount(fc helper.FailureCountable) time.Duration
@@ -105,8 +114,13 @@ func (r *ReconcileKeycloakRealmGroup) Reconcile(ctx context.Context, request rec
 		result.RequeueAfter = r.successReconcileTimeout
 	}
 
-	if err := r.client.Status().Update(ctx, &instance); err != nil {
-		resultErr = errors.Wrap(err, "unable to update status")
+	instanceDeleted := !controllerutil.ContainsFinalizer(&instance, keyCloakRealmGroupOperatorFinalizerName) &&
+		instance.GetDeletionTimestamp() != nil
+
+	if !instanceDeleted {
+		if err := r.client.Status().Update(ctx, &instance); err != nil {
+			resultErr = errors.Wrap(err, "unable to update status")
+		}
 	}
 
 	log.Info("Reconciling done")
@@ -130,30 +144,186 @@ func (r *ReconcileKeycloakRealmGroup) tryReconcile(ctx context.Context, keycloak
 		return fmt.Errorf("unable to get keycloak realm from ref: %w", err)
 	}
 
-	id, err := kClient.SyncRealmGroup(gocloak.PString(realm.Realm), &keycloakRealmGroup.Spec)
-	if err != nil {
-		return fmt.Errorf("unable to sync realm group: %w", err)
-	}
-
-	keycloakRealmGroup.Status.ID = id
-
-	if _, err := r.helper.TryToDelete(
+	// Deletion is handled before the parent group is resolved and the group is synced,
+	// so the group deleted together with its parent is not blocked by the missing parent
+	// and the deleted group is not re-created or moved in keycloak.
+	if deleted, err := r.helper.TryToDelete(
 		ctx,
 		keycloakRealmGroup,
 		makeTerminator(
 			kClient,
 			gocloak.PString(realm.Realm),
 			keycloakRealmGroup.Spec.Name,
+			keycloakRealmGroup.Status.ID,
 			objectmeta.PreserveResourcesOnDeletion(keycloakRealmGroup),
 		),
 		keyCloakRealmGroupOperatorFinalizerName,
 	); err != nil {
 		return fmt.Errorf("failed to delete keycloak realm group: %w", err)
+	} else if deleted {
+		return nil
+	}
+
+	groupRef, err := r.makeGroupRef(ctx, keycloakRealmGroup)
+	if err != nil {
+		return err
+	}
+
+	group, err := kClient.SyncRealmGroup(ctx, gocloak.PString(realm.Realm), &keycloakRealmGroup.Spec, groupRef)
+	if err != nil {
+		return fmt.Errorf("unable to sync realm group: %w", err)
+	}
+
+	keycloakRealmGroup.Status.ID = gocloak.PString(group.ID)
+	keycloakRealmGroup.Status.Path = gocloak.PString(group.Path)
+	keycloakRealmGroup.Status.ParentID = groupRef.ParentID
+
+	if err := r.syncMembers(ctx, kClient, gocloak.PString(realm.Realm), keycloakRealmGroup); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// syncMembers syncs group members from the group side. Missing users are reported in the status.
+func (r *ReconcileKeycloakRealmGroup) syncMembers(
+	ctx context.Context,
+	kClient keycloak.Client,
+	realmName string,
+	group *keycloakApi.KeycloakRealmGroup,
+) error {
+	group.Status.MissingMembers = nil
+
+	if len(group.Spec.Members) == 0 {
+		return nil
+	}
+
+	missing, err := kClient.SyncGroupMembers(
+		ctx,
+		realmName,
+		group.Status.ID,
+		group.Spec.Members,
+		group.GetMembersReconciliationStrategy() == keycloakApi.ReconciliationStrategyAddOnly,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to sync group members: %w", err)
+	}
+
+	if len(missing) > 0 {
+		ctrl.LoggerFrom(ctx).Info("Some group members are not found in keycloak", "members", missing)
+	}
+
+	group.Status.MissingMembers = missing
+
+	return nil
+}
+
+// makeGroupRef resolves the parent group and makes reference which is used to find and place the group in keycloak.
+func (r *ReconcileKeycloakRealmGroup) makeGroupRef(
+	ctx context.Context,
+	group *keycloakApi.KeycloakRealmGroup,
+) (adapter.RealmGroupRef, error) {
+	ref := adapter.RealmGroupRef{ID: group.Status.ID}
+
+	if group.Spec.ParentGroupRef == nil {
+		if err := r.syncParentOwnerRef(ctx, group, nil); err != nil {
+			return ref, err
+		}
+
+		// Group is moved to the top level only if it was placed under the parent group by the operator.
+		if group.Status.ParentID == "" {
+			return ref, nil
+		}
+
+		legacySubGroup, err := r.isPlacedBySubGroups(ctx, group)
+		if err != nil {
+			return ref, err
+		}
+
+		ref.ManagePlacement = !legacySubGroup
+
+		return ref, nil
+	}
+
+	parent := &keycloakApi.KeycloakRealmGroup{}
+	if err := r.client.Get(ctx, types.NamespacedName{
+		Namespace: group.Namespace,
+		Name:      group.Spec.ParentGroupRef.Name,
+	}, parent); err != nil {
+		return ref, fmt.Errorf("unable to get parent group: %w", err)
+	}
+
+	if parent.Status.ID == "" {
+		return ref, fmt.Errorf("parent group %s is not created yet", parent.Name)
+	}
+
+	if err := r.syncParentOwnerRef(ctx, group, parent); err != nil {
+		return ref, err
+	}
+
+	ref.ParentID = parent.Status.ID
+	ref.ManagePlacement = true
+
+	return ref, nil
+}
+
+// syncParentOwnerRef sets owner reference to the parent group, so child groups are deleted together with the parent.
+// Owner references to the previous parent groups are removed.
+func (r *ReconcileKeycloakRealmGroup) syncParentOwnerRef(
+	ctx context.Context,
+	group *keycloakApi.KeycloakRealmGroup,
+	parent *keycloakApi.KeycloakRealmGroup,
+) error {
+	ownerRefs := make([]metav1.OwnerReference, 0, len(group.OwnerReferences))
+
+	for _, ref := range group.OwnerReferences {
+		if ref.Kind == keycloakRealmGroupKind && (parent == nil || ref.UID != parent.UID) {
+			continue
+		}
+
+		ownerRefs = append(ownerRefs, ref)
+	}
+
+	updated := group.DeepCopy()
+	updated.OwnerReferences = ownerRefs
+
+	if parent != nil {
+		if err := controllerutil.SetOwnerReference(parent, updated, r.client.Scheme()); err != nil {
+			return fmt.Errorf("unable to set parent group owner reference: %w", err)
+		}
+	}
+
+	if reflect.DeepEqual(updated.OwnerReferences, group.OwnerReferences) {
+		return nil
+	}
+
+	group.OwnerReferences = updated.OwnerReferences
+
+	if err := r.client.Update(ctx, group); err != nil {
+		return fmt.Errorf("unable to update parent group owner reference: %w", err)
 	}
 
 	return nil
 }
 
+// isPlacedBySubGroups checks if the group is listed in the deprecated subGroups field of another group.
+func (r *ReconcileKeycloakRealmGroup) isPlacedBySubGroups(ctx context.Context, group *keycloakApi.KeycloakRealmGroup) (bool, error) {
+	groups := &keycloakApi.KeycloakRealmGroupList{}
+	if err := r.client.List(ctx, groups, client.InNamespace(group.Namespace)); err != nil {
+		return false, fmt.Errorf("unable to list groups: %w", err)
+	}
+
+	for i := range groups.Items {
+		g := &groups.Items[i]
+
+		if g.Name != group.Name && g.Spec.RealmRef == group.Spec.RealmRef && slices.Contains(g.Spec.SubGroups, group.Spec.Name) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 func (r *ReconcileKeycloakRealmGroup) applyDefaults(ctx context.Context, instance *keycloakApi.KeycloakRealmGroup) (bool, error) {
 	if instance.Spec.RealmRef.Name == "" {
 		instance.Spec.RealmRef = common.RealmRef{
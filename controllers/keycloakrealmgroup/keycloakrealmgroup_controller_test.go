@@ -2,6 +2,7 @@ package keycloakrealmgroup
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -56,7 +58,8 @@ func TestReconcileKeycloakRealmGroup_Reconcile(t *testing.T) {
 	client := fake.NewClientBuilder().WithScheme(sch).WithRuntimeObjects(&group, &realm, &keycloak, &secret).Build()
 	kClient := new(adapter.Mock)
 
-	kClient.On("SyncRealmGroup", "ns.realm1", &group.Spec).Return("gid1", nil)
+	kClient.On("SyncRealmGroup", "ns.realm1", &group.Spec, testifymock.Anything).
+		Return(&gocloak.Group{ID: gocloak.StringP("gid1")}, nil)
 	kClient.On("DeleteGroup", "ns.realm1", group.Spec.Name).Return(nil)
 
 	logger := mock.NewLogr()
@@ -71,7 +74,9 @@ func TestReconcileKeycloakRealmGroup_Reconcile(t *testing.T) {
 		}, nil)
 	h.On("TryToDelete", testifymock.Anything, testifymock.Anything, testifymock.Anything, testifymock.Anything).
 		Return(false, nil)
-	kcMock.On("SyncRealmGroup", "ns.realm1", &group.Spec).Return("id11", nil)
+	kcMock.On("SyncRealmGroup", "ns.realm1", testifymock.Anything,
+		adapter.RealmGroupRef{ID: "id11"}).
+		Return(&gocloak.Group{ID: gocloak.StringP("id11"), Path: gocloak.StringP("/group1")}, nil)
 
 	r := ReconcileKeycloakRealmGroup{
 		client:                  client,
@@ -95,3 +100,302 @@ func TestReconcileKeycloakRealmGroup_Reconcile(t *testing.T) {
 		t.Fatal("success reconcile timeout is not set")
 	}
 }
+
+func TestReconcileKeycloakRealmGroup_ReconcileWithParent(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(sch))
+
+	ns := "security"
+	realmRef := common.RealmRef{Kind: keycloakApi.KeycloakRealmKind, Name: "realm1"}
+	parent := keycloakApi.KeycloakRealmGroup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "parent", UID: "parent-uid"},
+		Spec:       keycloakApi.KeycloakRealmGroupSpec{Name: "parent", RealmRef: realmRef},
+	}
+	group := keycloakApi.KeycloakRealmGroup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "child"},
+		Spec: keycloakApi.KeycloakRealmGroupSpec{
+			Name:           "child",
+			RealmRef:       realmRef,
+			ParentGroupRef: &keycloakApi.GroupRef{Name: "parent"},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		parentStatusID string
+		wantErr        require.ErrorAssertionFunc
+		wantStatus     keycloakApi.KeycloakRealmGroupStatus
+	}{
+		{
+			name:           "group is placed under parent group",
+			parentStatusID: "parent-id",
+			wantErr:        require.NoError,
+			wantStatus: keycloakApi.KeycloakRealmGroupStatus{
+				Value:    helper.StatusOK,
+				ID:       "child-id",
+				Path:     "/parent/child",
+				ParentID: "parent-id",
+			},
+		},
+		{
+			name:    "parent group is not created yet",
+			wantErr: require.NoError,
+			wantStatus: keycloakApi.KeycloakRealmGroupStatus{
+				Value: "parent group parent is not created yet",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			p := parent.DeepCopy()
+			p.Status.ID = tt.parentStatusID
+
+			client := fake.NewClientBuilder().WithScheme(sch).WithObjects(p, group.DeepCopy()).Build()
+			h := helpermock.NewControllerHelper(t)
+			kcMock := &adapter.Mock{}
+
+			h.On("SetRealmOwnerRef", testifymock.Anything, testifymock.Anything).Return(nil)
+			h.On("CreateKeycloakClientFromRealmRef", testifymock.Anything, testifymock.Anything).Return(kcMock, nil)
+			h.On("GetKeycloakRealmFromRef", testifymock.Anything, testifymock.Anything, testifymock.Anything).
+				Return(&gocloak.RealmRepresentation{Realm: gocloak.StringP("realm")}, nil)
+			h.On("TryToDelete", testifymock.Anything, testifymock.Anything, testifymock.Anything, testifymock.Anything).
+				Return(false, nil).Maybe()
+			h.On("SetFailureCount", testifymock.Anything).Return(time.Minute).Maybe()
+			kcMock.On("SyncRealmGroup", "realm", testifymock.Anything,
+				adapter.RealmGroupRef{ParentID: "parent-id", ManagePlacement: true}).
+				Return(&gocloak.Group{ID: gocloak.StringP("child-id"), Path: gocloak.StringP("/parent/child")}, nil).
+				Maybe()
+
+			r := ReconcileKeycloakRealmGroup{
+				client:                  client,
+				helper:                  h,
+				successReconcileTimeout: time.Hour,
+			}
+
+			_, err := r.Reconcile(ctrl.LoggerInto(context.Background(), mock.NewLogr()), reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: ns, Name: group.Name},
+			})
+			tt.wantErr(t, err)
+
+			got := &keycloakApi.KeycloakRealmGroup{}
+			require.NoError(t, client.Get(context.Background(), types.NamespacedName{Namespace: ns, Name: group.Name}, got))
+			require.Equal(t, tt.wantStatus, got.Status)
+
+			if tt.parentStatusID != "" {
+				require.Len(t, got.OwnerReferences, 1)
+				require.Equal(t, p.UID, got.OwnerReferences[0].UID)
+			}
+		})
+	}
+}
+
+func TestReconcileKeycloakRealmGroup_ReconcileDeletedWithoutParent(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(sch))
+
+	ns := "security"
+	group := keycloakApi.KeycloakRealmGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         ns,
+			Name:              "child",
+			DeletionTimestamp: &metav1.Time{Time: time.Now()},
+			Finalizers:        []string{keyCloakRealmGroupOperatorFinalizerName},
+		},
+		Spec: keycloakApi.KeycloakRealmGroupSpec{
+			Name:           "child",
+			RealmRef:       common.RealmRef{Kind: keycloakApi.KeycloakRealmKind, Name: "realm1"},
+			ParentGroupRef: &keycloakApi.GroupRef{Name: "parent"},
+			Members:        []keycloakApi.GroupMember{{Username: "user"}},
+		},
+		Status: keycloakApi.KeycloakRealmGroupStatus{ID: "child-id"},
+	}
+
+	client := fake.NewClientBuilder().WithScheme(sch).WithObjects(&group).Build()
+	h := helpermock.NewControllerHelper(t)
+	kcMock := &adapter.Mock{}
+
+	h.On("SetRealmOwnerRef", testifymock.Anything, testifymock.Anything).Return(nil)
+	h.On("CreateKeycloakClientFromRealmRef", testifymock.Anything, testifymock.Anything).Return(kcMock, nil)
+	h.On("GetKeycloakRealmFromRef", testifymock.Anything, testifymock.Anything, testifymock.Anything).
+		Return(&gocloak.RealmRepresentation{Realm: gocloak.StringP("realm")}, nil)
+	h.On("TryToDelete", testifymock.Anything, testifymock.Anything,
+		testifymock.MatchedBy(func(term *terminator) bool {
+			return term.groupID == "child-id"
+		}), keyCloakRealmGroupOperatorFinalizerName).
+		Return(true, nil)
+
+	r := ReconcileKeycloakRealmGroup{
+		client:                  client,
+		helper:                  h,
+		successReconcileTimeout: time.Hour,
+	}
+
+	_, err := r.Reconcile(ctrl.LoggerInto(context.Background(), mock.NewLogr()), reconcile.Request{
+		NamespacedName: types.NamespacedName{Namespace: ns, Name: group.Name},
+	})
+	require.NoError(t, err)
+	kcMock.AssertExpectations(t)
+}
+
+func TestReconcileKeycloakRealmGroup_makeGroupRef(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(sch))
+
+	ns := "security"
+	realmRef := common.RealmRef{Kind: keycloakApi.KeycloakRealmKind, Name: "realm1"}
+
+	tests := []struct {
+		name    string
+		group   *keycloakApi.KeycloakRealmGroup
+		objects []client.Object
+		want    adapter.RealmGroupRef
+	}{
+		{
+			name: "group without parent is not moved",
+			group: &keycloakApi.KeycloakRealmGroup{
+				ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "group"},
+				Spec:       keycloakApi.KeycloakRealmGroupSpec{Name: "group", RealmRef: realmRef},
+				Status:     keycloakApi.KeycloakRealmGroupStatus{ID: "group-id"},
+			},
+			want: adapter.RealmGroupRef{ID: "group-id"},
+		},
+		{
+			name: "group is moved to the top level when parent is removed",
+			group: &keycloakApi.KeycloakRealmGroup{
+				ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "group"},
+				Spec:       keycloakApi.KeycloakRealmGroupSpec{Name: "group", RealmRef: realmRef},
+				Status:     keycloakApi.KeycloakRealmGroupStatus{ID: "group-id", ParentID: "parent-id"},
+			},
+			want: adapter.RealmGroupRef{ID: "group-id", ManagePlacement: true},
+		},
+		{
+			name: "group placed by subgroups of another group is not moved",
+			group: &keycloakApi.KeycloakRealmGroup{
+				ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "group"},
+				Spec:       keycloakApi.KeycloakRealmGroupSpec{Name: "group", RealmRef: realmRef},
+				Status:     keycloakApi.KeycloakRealmGroupStatus{ID: "group-id", ParentID: "parent-id"},
+			},
+			objects: []client.Object{
+				&keycloakApi.KeycloakRealmGroup{
+					ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "legacy-parent"},
+					Spec: keycloakApi.KeycloakRealmGroupSpec{
+						Name:      "legacy-parent",
+						RealmRef:  realmRef,
+						SubGroups: []string{"group"},
+					},
+				},
+			},
+			want: adapter.RealmGroupRef{ID: "group-id"},
+		},
+		{
+			name: "group is placed under declared parent",
+			group: &keycloakApi.KeycloakRealmGroup{
+				ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "group"},
+				Spec: keycloakApi.KeycloakRealmGroupSpec{
+					Name:           "group",
+					RealmRef:       realmRef,
+					ParentGroupRef: &keycloakApi.GroupRef{Name: "parent"},
+				},
+			},
+			objects: []client.Object{
+				&keycloakApi.KeycloakRealmGroup{
+					ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "parent", UID: "parent-uid"},
+					Spec:       keycloakApi.KeycloakRealmGroupSpec{Name: "parent", RealmRef: realmRef},
+					Status:     keycloakApi.KeycloakRealmGroupStatus{ID: "parent-id"},
+				},
+			},
+			want: adapter.RealmGroupRef{ParentID: "parent-id", ManagePlacement: true},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cl := fake.NewClientBuilder().WithScheme(sch).WithObjects(append(tt.objects, tt.group)...).Build()
+			r := ReconcileKeycloakRealmGroup{client: cl}
+
+			got, err := r.makeGroupRef(context.Background(), tt.group)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReconcileKeycloakRealmGroup_syncMembers(t *testing.T) {
+	members := []keycloakApi.GroupMember{{Username: "john"}, {Username: "missing"}}
+
+	tests := []struct {
+		name        string
+		group       keycloakApi.KeycloakRealmGroup
+		setupMock   func(m *adapter.Mock)
+		wantErr     require.ErrorAssertionFunc
+		wantMissing []string
+	}{
+		{
+			name: "missing members are reported in status",
+			group: keycloakApi.KeycloakRealmGroup{
+				Spec:   keycloakApi.KeycloakRealmGroupSpec{Members: members},
+				Status: keycloakApi.KeycloakRealmGroupStatus{ID: "group-id"},
+			},
+			setupMock: func(m *adapter.Mock) {
+				m.On("SyncGroupMembers", "realm", "group-id", members, true).Return([]string{"missing"}, nil)
+			},
+			wantErr:     require.NoError,
+			wantMissing: []string{"missing"},
+		},
+		{
+			name: "full strategy",
+			group: keycloakApi.KeycloakRealmGroup{
+				Spec: keycloakApi.KeycloakRealmGroupSpec{
+					Members:                       members,
+					MembersReconciliationStrategy: keycloakApi.ReconciliationStrategyFull,
+				},
+				Status: keycloakApi.KeycloakRealmGroupStatus{ID: "group-id", MissingMembers: []string{"missing"}},
+			},
+			setupMock: func(m *adapter.Mock) {
+				m.On("SyncGroupMembers", "realm", "group-id", members, false).Return([]string(nil), nil)
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "members are not managed",
+			group: keycloakApi.KeycloakRealmGroup{
+				Status: keycloakApi.KeycloakRealmGroupStatus{ID: "group-id", MissingMembers: []string{"missing"}},
+			},
+			setupMock: func(m *adapter.Mock) {},
+			wantErr:   require.NoError,
+		},
+		{
+			name: "sync error",
+			group: keycloakApi.KeycloakRealmGroup{
+				Spec:   keycloakApi.KeycloakRealmGroupSpec{Members: members},
+				Status: keycloakApi.KeycloakRealmGroupStatus{ID: "group-id"},
+			},
+			setupMock: func(m *adapter.Mock) {
+				m.On("SyncGroupMembers", "realm", "group-id", members, true).Return(nil, errors.New("fatal"))
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.ErrorContains(t, err, "unable to sync group members")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			kClient := &adapter.Mock{}
+			tt.setupMock(kClient)
+
+			r := ReconcileKeycloakRealmGroup{}
+			group := tt.group.DeepCopy()
+
+			tt.wantErr(t, r.syncMembers(context.Background(), kClient, "realm", group))
+			require.Equal(t, tt.wantMissing, group.Status.MissingMembers)
+			kClient.AssertExpectations(t)
+		})
+	}
+}
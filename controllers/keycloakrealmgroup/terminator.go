@@ -12,7 +12,8 @@ import (
 type terminator struct {
 	kClient keycloak.Client
 	realmName,
-	groupName string
+	groupName,
+	groupID string
 	preserveResourcesOnDeletion bool
 }
 
@@ -25,7 +26,11 @@ func (t *terminator) DeleteResource(ctx context.Context) error {
 
 	log.Info("Start deleting group")
 
-	if err := t.kClient.DeleteGroup(ctx, t.realmName, t.groupName); err != nil {
+	if t.groupID != "" {
+		if err := t.kClient.DeleteGroupByID(ctx, t.realmName, t.groupID); err != nil {
+			return fmt.Errorf("unable to delete group %w", err)
+		}
+	} else if err := t.kClient.DeleteGroup(ctx, t.realmName, t.groupName); err != nil {
 		return fmt.Errorf("unable to delete group %w", err)
 	}
 
@@ -34,11 +39,12 @@ func (t *terminator) DeleteResource(ctx context.Context) error {
 	return nil
 }
 
-func makeTerminator(kClient keycloak.Client, realmName, groupName string, preserveResourcesOnDeletion bool) *terminator {
+func makeTerminator(kClient keycloak.Client, realmName, groupName, groupID string, preserveResourcesOnDeletion bool) *terminator {
 	return &terminator{
 		kClient:                     kClient,
 		realmName:                   realmName,
 		groupName:                   groupName,
+		groupID:                     groupID,
 		preserveResourcesOnDeletion: preserveResourcesOnDeletion,
 	}
 }
@@ -17,7 +17,7 @@ func TestTerminator(t *testing.T) {
 	lg := mock.NewLogr()
 	kClient := new(adapter.Mock)
 
-	term := makeTerminator(kClient, "foo", "bar", false)
+	term := makeTerminator(kClient, "foo", "bar", "", false)
 
 	kClient.On("DeleteGroup", "foo", "bar").Return(nil).Once()
 
@@ -40,9 +40,21 @@ func TestTerminatorSkipDeletion(t *testing.T) {
 		nil,
 		"realm",
 		"realmCR",
+		"",
 		true,
 	)
 
 	err := term.DeleteResource(context.Background())
 	require.NoError(t, err)
 }
+
+func TestTerminator_DeleteByID(t *testing.T) {
+	kClient := new(adapter.Mock)
+	term := makeTerminator(kClient, "foo", "bar", "group-id", false)
+
+	kClient.On("DeleteGroupByID", "foo", "group-id").Return(nil).Once()
+
+	err := term.DeleteResource(context.Background())
+	require.NoError(t, err)
+	kClient.AssertExpectations(t)
+}
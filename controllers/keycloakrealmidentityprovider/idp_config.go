@@ -0,0 +1,223 @@
+package keycloakrealmidentityprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+)
+
+const (
+	providerIDOIDC         = "oidc"
+	providerIDKeycloakOIDC = "keycloak-oidc"
+	providerIDSAML         = "saml"
+
+	defaultSAMLMetadataRefreshInterval = time.Hour
+)
+
+// validateIDPConfig checks that typed identity provider configuration is consistent with the provider ID.
+func validateIDPConfig(spec *keycloakApi.KeycloakRealmIdentityProviderSpec) error {
+	if spec.OIDC != nil && spec.SAML != nil {
+		return errors.New("oidc and saml configurations can't be used together")
+	}
+
+	if spec.OIDC != nil {
+		if spec.ProviderID != providerIDOIDC && spec.ProviderID != providerIDKeycloakOIDC {
+			return fmt.Errorf("oidc configuration can't be used with provider %q", spec.ProviderID)
+		}
+
+		if spec.OIDC.DiscoveryURL == "" && (spec.OIDC.AuthorizationURL == "" || spec.OIDC.TokenURL == "") {
+			return errors.New("oidc configuration requires discoveryUrl or both authorizationUrl and tokenUrl")
+		}
+	}
+
+	if spec.SAML != nil {
+		if spec.ProviderID != providerIDSAML {
+			return fmt.Errorf("saml configuration can't be used with provider %q", spec.ProviderID)
+		}
+
+		if spec.SAML.MetadataURL != "" && spec.SAML.MetadataConfigMapRef != nil {
+			return errors.New("saml metadataUrl and metadataConfigMapRef can't be used together")
+		}
+
+		if spec.SAML.MetadataURL == "" && spec.SAML.MetadataConfigMapRef == nil && spec.SAML.SingleSignOnServiceURL == "" {
+			return errors.New("saml configuration requires metadataUrl, metadataConfigMapRef or singleSignOnServiceUrl")
+		}
+	}
+
+	return nil
+}
+
+// makeIDPConfig builds identity provider config from the imported discovery document or metadata,
+// typed OIDC or SAML configuration and raw Config, in order of increasing precedence.
+func (r *Reconcile) makeIDPConfig(
+	ctx context.Context,
+	idp *keycloakApi.KeycloakRealmIdentityProvider,
+	kClient keycloak.Client,
+	realmName string,
+) (map[string]string, error) {
+	config := make(map[string]string, len(idp.Spec.Config))
+
+	switch {
+	case idp.Spec.OIDC != nil:
+		if err := r.setOIDCConfig(ctx, config, idp.Spec.OIDC, idp.Spec.ProviderID, kClient, realmName); err != nil {
+			return nil, err
+		}
+	case idp.Spec.SAML != nil:
+		if err := r.setSAMLConfig(ctx, config, idp.Spec.SAML, idp.Namespace, kClient, realmName); err != nil {
+			return nil, err
+		}
+	}
+
+	for k, v := range idp.Spec.Config {
+		config[k] = v
+	}
+
+	return config, nil
+}
+
+func (r *Reconcile) setOIDCConfig(
+	ctx context.Context,
+	config map[string]string,
+	oidc *keycloakApi.IdentityProviderOIDC,
+	providerID string,
+	kClient keycloak.Client,
+	realmName string,
+) error {
+	if oidc.DiscoveryURL != "" {
+		imported, err := kClient.ImportIdentityProviderConfig(ctx, realmName, providerID, oidc.DiscoveryURL)
+		if err != nil {
+			return fmt.Errorf("unable to import oidc config from %s: %w", oidc.DiscoveryURL, err)
+		}
+
+		for k, v := range imported {
+			config[k] = v
+		}
+	}
+
+	setIfNotEmpty(config, "clientId", oidc.ClientID)
+	setIfNotEmpty(config, "clientSecret", oidc.ClientSecret)
+	setIfNotEmpty(config, "clientAuthMethod", oidc.ClientAuthMethod)
+	setIfNotEmpty(config, "defaultScope", strings.Join(oidc.DefaultScopes, " "))
+	setIfNotEmpty(config, "authorizationUrl", oidc.AuthorizationURL)
+	setIfNotEmpty(config, "tokenUrl", oidc.TokenURL)
+	setIfNotEmpty(config, "userInfoUrl", oidc.UserInfoURL)
+	setIfNotEmpty(config, "logoutUrl", oidc.LogoutURL)
+	setIfNotEmpty(config, "issuer", oidc.Issuer)
+	setIfNotEmpty(config, "syncMode", oidc.SyncMode)
+	setBool(config, "validateSignature", oidc.ValidateSignature)
+
+	if oidc.JwksURL != "" {
+		config["jwksUrl"] = oidc.JwksURL
+		config["useJwksUrl"] = strconv.FormatBool(true)
+	}
+
+	if oidc.PKCEMethod != "" {
+		config["pkceEnabled"] = strconv.FormatBool(true)
+		config["pkceMethod"] = oidc.PKCEMethod
+	}
+
+	return nil
+}
+
+func (r *Reconcile) setSAMLConfig(
+	ctx context.Context,
+	config map[string]string,
+	saml *keycloakApi.IdentityProviderSAML,
+	namespace string,
+	kClient keycloak.Client,
+	realmName string,
+) error {
+	imported, err := r.importSAMLMetadata(ctx, saml, namespace, kClient, realmName)
+	if err != nil {
+		return err
+	}
+
+	for k, v := range imported {
+		config[k] = v
+	}
+
+	setIfNotEmpty(config, "entityId", saml.EntityID)
+	setIfNotEmpty(config, "singleSignOnServiceUrl", saml.SingleSignOnServiceURL)
+	setIfNotEmpty(config, "singleLogoutServiceUrl", saml.SingleLogoutServiceURL)
+	setIfNotEmpty(config, "nameIDPolicyFormat", saml.NameIDPolicyFormat)
+	setIfNotEmpty(config, "principalType", saml.PrincipalType)
+	setIfNotEmpty(config, "principalAttribute", saml.PrincipalAttribute)
+	setIfNotEmpty(config, "syncMode", saml.SyncMode)
+	setBool(config, "wantAuthnRequestsSigned", saml.WantAuthnRequestsSigned)
+	setBool(config, "validateSignature", saml.ValidateSignature)
+
+	return nil
+}
+
+func (r *Reconcile) importSAMLMetadata(
+	ctx context.Context,
+	saml *keycloakApi.IdentityProviderSAML,
+	namespace string,
+	kClient keycloak.Client,
+	realmName string,
+) (map[string]string, error) {
+	if saml.MetadataURL != "" {
+		imported, err := kClient.ImportIdentityProviderConfig(ctx, realmName, providerIDSAML, saml.MetadataURL)
+		if err != nil {
+			return nil, fmt.Errorf("unable to import saml metadata from %s: %w", saml.MetadataURL, err)
+		}
+
+		return imported, nil
+	}
+
+	if saml.MetadataConfigMapRef == nil {
+		return nil, nil
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: saml.MetadataConfigMapRef.Name, Namespace: namespace}, cm); err != nil {
+		return nil, fmt.Errorf("unable to get saml metadata ConfigMap %s: %w", saml.MetadataConfigMapRef.Name, err)
+	}
+
+	metadata, ok := cm.Data[saml.MetadataConfigMapRef.Key]
+	if !ok {
+		return nil, fmt.Errorf("key %s not found in ConfigMap %s", saml.MetadataConfigMapRef.Key, saml.MetadataConfigMapRef.Name)
+	}
+
+	imported, err := kClient.ImportIdentityProviderConfigFromFile(ctx, realmName, providerIDSAML, []byte(metadata))
+	if err != nil {
+		return nil, fmt.Errorf("unable to import saml metadata from ConfigMap %s: %w", saml.MetadataConfigMapRef.Name, err)
+	}
+
+	return imported, nil
+}
+
+// metadataRefreshInterval returns interval of the identity provider metadata refresh.
+// It returns 0 if the identity provider doesn't have metadata source.
+func metadataRefreshInterval(spec *keycloakApi.KeycloakRealmIdentityProviderSpec) time.Duration {
+	if spec.SAML == nil || (spec.SAML.MetadataURL == "" && spec.SAML.MetadataConfigMapRef == nil) {
+		return 0
+	}
+
+	if spec.SAML.MetadataRefreshInterval != nil && spec.SAML.MetadataRefreshInterval.Duration > 0 {
+		return spec.SAML.MetadataRefreshInterval.Duration
+	}
+
+	return defaultSAMLMetadataRefreshInterval
+}
+
+func setIfNotEmpty(config map[string]string, key, value string) {
+	if value != "" {
+		config[key] = value
+	}
+}
+
+func setBool(config map[string]string, key string, value *bool) {
+	if value != nil {
+		config[key] = strconv.FormatBool(*value)
+	}
+}
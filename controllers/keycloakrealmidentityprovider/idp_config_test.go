@@ -0,0 +1,290 @@
+package keycloakrealmidentityprovider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+func TestValidateIDPConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		spec    keycloakApi.KeycloakRealmIdentityProviderSpec
+		wantErr require.ErrorAssertionFunc
+	}{
+		{
+			name:    "raw config",
+			spec:    keycloakApi.KeycloakRealmIdentityProviderSpec{ProviderID: "github"},
+			wantErr: require.NoError,
+		},
+		{
+			name: "oidc with discovery",
+			spec: keycloakApi.KeycloakRealmIdentityProviderSpec{
+				ProviderID: "keycloak-oidc",
+				OIDC:       &keycloakApi.IdentityProviderOIDC{ClientID: "client", DiscoveryURL: "https://idp"},
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "oidc and saml",
+			spec: keycloakApi.KeycloakRealmIdentityProviderSpec{
+				ProviderID: "oidc",
+				OIDC:       &keycloakApi.IdentityProviderOIDC{ClientID: "client", DiscoveryURL: "https://idp"},
+				SAML:       &keycloakApi.IdentityProviderSAML{MetadataURL: "https://idp"},
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.ErrorContains(t, err, "can't be used together")
+			},
+		},
+		{
+			name: "oidc with wrong provider",
+			spec: keycloakApi.KeycloakRealmIdentityProviderSpec{
+				ProviderID: "saml",
+				OIDC:       &keycloakApi.IdentityProviderOIDC{ClientID: "client", DiscoveryURL: "https://idp"},
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.ErrorContains(t, err, `oidc configuration can't be used with provider "saml"`)
+			},
+		},
+		{
+			name: "oidc without endpoints",
+			spec: keycloakApi.KeycloakRealmIdentityProviderSpec{
+				ProviderID: "oidc",
+				OIDC:       &keycloakApi.IdentityProviderOIDC{ClientID: "client", AuthorizationURL: "https://idp/auth"},
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.ErrorContains(t, err, "requires discoveryUrl")
+			},
+		},
+		{
+			name: "saml with both metadata sources",
+			spec: keycloakApi.KeycloakRealmIdentityProviderSpec{
+				ProviderID: "saml",
+				SAML: &keycloakApi.IdentityProviderSAML{
+					MetadataURL:          "https://idp",
+					MetadataConfigMapRef: &keycloakApi.ConfigMapKeyRef{Name: "cm", Key: "key"},
+				},
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.ErrorContains(t, err, "metadataUrl and metadataConfigMapRef can't be used together")
+			},
+		},
+		{
+			name: "saml without metadata",
+			spec: keycloakApi.KeycloakRealmIdentityProviderSpec{
+				ProviderID: "saml",
+				SAML:       &keycloakApi.IdentityProviderSAML{},
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.ErrorContains(t, err, "saml configuration requires")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.wantErr(t, validateIDPConfig(&tt.spec))
+		})
+	}
+}
+
+func TestReconcile_makeIDPConfig(t *testing.T) {
+	t.Parallel()
+
+	s := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(s))
+
+	metadataCM := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "metadata", Namespace: "ns"},
+		Data:       map[string]string{"metadata.xml": "<EntityDescriptor/>"},
+	}
+
+	tests := []struct {
+		name      string
+		spec      keycloakApi.KeycloakRealmIdentityProviderSpec
+		setupMock func(m *adapter.Mock)
+		want      map[string]string
+		wantErr   require.ErrorAssertionFunc
+	}{
+		{
+			name: "oidc with discovery",
+			spec: keycloakApi.KeycloakRealmIdentityProviderSpec{
+				ProviderID: "oidc",
+				OIDC: &keycloakApi.IdentityProviderOIDC{
+					DiscoveryURL:      "https://idp/.well-known/openid-configuration",
+					ClientID:          "client",
+					ClientSecret:      "$secret:key",
+					ClientAuthMethod:  "client_secret_basic",
+					DefaultScopes:     []string{"openid", "email"},
+					TokenURL:          "https://idp/custom-token",
+					ValidateSignature: gocloak.BoolP(true),
+					PKCEMethod:        "S256",
+				},
+				Config: map[string]string{"hideOnLoginPage": "true", "syncMode": "FORCE"},
+			},
+			setupMock: func(m *adapter.Mock) {
+				m.On("ImportIdentityProviderConfig", "realm", "oidc", "https://idp/.well-known/openid-configuration").
+					Return(map[string]string{
+						"authorizationUrl": "https://idp/auth",
+						"tokenUrl":         "https://idp/token",
+						"syncMode":         "IMPORT",
+					}, nil)
+			},
+			want: map[string]string{
+				"authorizationUrl":  "https://idp/auth",
+				"tokenUrl":          "https://idp/custom-token",
+				"clientId":          "client",
+				"clientSecret":      "$secret:key",
+				"clientAuthMethod":  "client_secret_basic",
+				"defaultScope":      "openid email",
+				"validateSignature": "true",
+				"pkceEnabled":       "true",
+				"pkceMethod":        "S256",
+				"hideOnLoginPage":   "true",
+				"syncMode":          "FORCE",
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "oidc discovery failed",
+			spec: keycloakApi.KeycloakRealmIdentityProviderSpec{
+				ProviderID: "oidc",
+				OIDC:       &keycloakApi.IdentityProviderOIDC{DiscoveryURL: "https://idp", ClientID: "client"},
+			},
+			setupMock: func(m *adapter.Mock) {
+				m.On("ImportIdentityProviderConfig", "realm", "oidc", "https://idp").
+					Return(nil, errors.New("not reachable"))
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.ErrorContains(t, err, "unable to import oidc config")
+			},
+		},
+		{
+			name: "saml with metadata url",
+			spec: keycloakApi.KeycloakRealmIdentityProviderSpec{
+				ProviderID: "saml",
+				SAML: &keycloakApi.IdentityProviderSAML{
+					MetadataURL:             "https://idp/metadata",
+					EntityID:                "https://keycloak/realms/realm",
+					PrincipalType:           "ATTRIBUTE",
+					PrincipalAttribute:      "email",
+					WantAuthnRequestsSigned: gocloak.BoolP(false),
+				},
+			},
+			setupMock: func(m *adapter.Mock) {
+				m.On("ImportIdentityProviderConfig", "realm", "saml", "https://idp/metadata").
+					Return(map[string]string{
+						"singleSignOnServiceUrl":  "https://idp/sso",
+						"signingCertificate":      "cert",
+						"wantAuthnRequestsSigned": "true",
+					}, nil)
+			},
+			want: map[string]string{
+				"singleSignOnServiceUrl":  "https://idp/sso",
+				"signingCertificate":      "cert",
+				"wantAuthnRequestsSigned": "false",
+				"entityId":                "https://keycloak/realms/realm",
+				"principalType":           "ATTRIBUTE",
+				"principalAttribute":      "email",
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "saml with metadata from ConfigMap",
+			spec: keycloakApi.KeycloakRealmIdentityProviderSpec{
+				ProviderID: "saml",
+				SAML: &keycloakApi.IdentityProviderSAML{
+					MetadataConfigMapRef: &keycloakApi.ConfigMapKeyRef{Name: "metadata", Key: "metadata.xml"},
+				},
+			},
+			setupMock: func(m *adapter.Mock) {
+				m.On("ImportIdentityProviderConfigFromFile", "realm", "saml", []byte("<EntityDescriptor/>")).
+					Return(map[string]string{"signingCertificate": "cert"}, nil)
+			},
+			want:    map[string]string{"signingCertificate": "cert"},
+			wantErr: require.NoError,
+		},
+		{
+			name: "saml metadata key not found",
+			spec: keycloakApi.KeycloakRealmIdentityProviderSpec{
+				ProviderID: "saml",
+				SAML: &keycloakApi.IdentityProviderSAML{
+					MetadataConfigMapRef: &keycloakApi.ConfigMapKeyRef{Name: "metadata", Key: "idp.xml"},
+				},
+			},
+			setupMock: func(m *adapter.Mock) {},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.ErrorContains(t, err, "key idp.xml not found in ConfigMap metadata")
+			},
+		},
+		{
+			name: "raw config",
+			spec: keycloakApi.KeycloakRealmIdentityProviderSpec{
+				ProviderID: "github",
+				Config:     map[string]string{"clientId": "client"},
+			},
+			setupMock: func(m *adapter.Mock) {},
+			want:      map[string]string{"clientId": "client"},
+			wantErr:   require.NoError,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			kClient := &adapter.Mock{}
+			tt.setupMock(kClient)
+
+			r := &Reconcile{client: fake.NewClientBuilder().WithScheme(s).WithObjects(metadataCM).Build()}
+			idp := &keycloakApi.KeycloakRealmIdentityProvider{
+				ObjectMeta: metav1.ObjectMeta{Name: "idp", Namespace: "ns"},
+				Spec:       tt.spec,
+			}
+
+			got, err := r.makeIDPConfig(context.Background(), idp, kClient, "realm")
+			tt.wantErr(t, err)
+
+			if err == nil {
+				require.Equal(t, tt.want, got)
+			}
+
+			kClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReconcile_successRequeueAfter(t *testing.T) {
+	t.Parallel()
+
+	samlSpec := &keycloakApi.KeycloakRealmIdentityProviderSpec{
+		SAML: &keycloakApi.IdentityProviderSAML{MetadataURL: "https://idp/metadata"},
+	}
+
+	r := &Reconcile{}
+	require.Equal(t, defaultSAMLMetadataRefreshInterval, r.successRequeueAfter(samlSpec))
+	require.Equal(t, time.Duration(0), r.successRequeueAfter(&keycloakApi.KeycloakRealmIdentityProviderSpec{}))
+
+	r.successReconcileTimeout = time.Minute
+	require.Equal(t, time.Minute, r.successRequeueAfter(samlSpec))
+
+	samlSpec.SAML.MetadataRefreshInterval = &metav1.Duration{Duration: time.Second * 30}
+	require.Equal(t, time.Second*30, r.successRequeueAfter(samlSpec))
+}
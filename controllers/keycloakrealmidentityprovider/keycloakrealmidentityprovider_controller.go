@@ -9,13 +9,17 @@ import (
 	"github.com/Nerzal/gocloak/v12"
 	"github.com/pkg/errors"
 	"golang.org/x/exp/maps"
+	corev1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/epam/edp-keycloak-operator/api/common"
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
@@ -23,6 +27,7 @@ import (
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
 	"github.com/epam/edp-keycloak-operator/pkg/objectmeta"
+	"github.com/epam/edp-keycloak-operator/pkg/secretref"
 )
 
 const finalizerName = "keycloak.realmidp.operator.finalizer.name"
@@ -61,8 +66,23 @@ func (r *Reconcile) SetupWithManager(mgr ctrl.Manager, successReconcileTimeout t
 		UpdateFunc: isSpecUpdated,
 	}
 
+	if err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&keycloakApi.KeycloakRealmIdentityProvider{},
+		secretref.SecretNamesIndexField,
+		indexSecretNames,
+	); err != nil {
+		return fmt.Errorf("failed to index KeycloakRealmIdentityProvider by secrets: %w", err)
+	}
+
 	err := ctrl.NewControllerManagedBy(mgr).
 		For(&keycloakApi.KeycloakRealmIdentityProvider{}, builder.WithPredicates(pred)).
+		Watches(
+			&source.Kind{Type: &corev1.Secret{}},
+			handler.EnqueueRequestsFromMapFunc(secretref.MapSecretToRequests(r.client, func() client.ObjectList {
+				return &keycloakApi.KeycloakRealmIdentityProviderList{}
+			})),
+		).
 		Complete(r)
 	if err != nil {
 		return fmt.Errorf("failed to setup KeycloakRealmIdentityProvider controller: %w", err)
@@ -71,6 +91,21 @@ func (r *Reconcile) SetupWithManager(mgr ctrl.Manager, successReconcileTimeout t
 	return nil
 }
 
+// indexSecretNames returns names of the secrets referenced in the identity provider config.
+func indexSecretNames(obj client.Object) []string {
+	idp, ok := obj.(*keycloakApi.KeycloakRealmIdentityProvider)
+	if !ok {
+		return nil
+	}
+
+	values := maps.Values(idp.Spec.Config)
+	if idp.Spec.OIDC != nil {
+		values = append(values, idp.Spec.OIDC.ClientSecret)
+	}
+
+	return secretref.SecretNamesFromRefs(values...)
+}
+
 func isSpecUpdated(e event.UpdateEvent) bool {
 	oo, ok := e.ObjectOld.(*keycloakApi.KeycloakRealmIdentityProvider)
 	if !ok {
@@ -89,6 +124,7 @@ func isSpecUpdated(e event.UpdateEvent) bool {
 //+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealmidentityproviders,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealmidentityproviders/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealmidentityproviders/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",namespace=placeholder,resources=configmaps,verbs=get;list;watch
 
 // Reconcile is a loop for reconciling KeycloakRealmIdentityProvider object.
 func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (result reconcile.Result, resultErr error) {
@@ -128,11 +164,16 @@ func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (r
 		log.Error(err, "an error has occurred while handling keycloak realm idp", "name", request.Name)
 	} else {
 		helper.SetSuccessStatus(&instance)
-		result.RequeueAfter = r.successReconcileTimeout
+		result.RequeueAfter = r.successRequeueAfter(&instance.Spec)
 	}
 
-	if err := r.client.Status().Update(ctx, &instance); err != nil {
-		resultErr = errors.Wrap(err, "unable to update status")
+	instanceDeleted := !controllerutil.ContainsFinalizer(&instance, finalizerName) &&
+		instance.GetDeletionTimestamp() != nil
+
+	if !instanceDeleted {
+		if err := r.client.Status().Update(ctx, &instance); err != nil {
+			resultErr = errors.Wrap(err, "unable to update status")
+		}
 	}
 
 	return
@@ -154,8 +195,37 @@ func (r *Reconcile) tryReconcile(ctx context.Context, keycloakRealmIDP *keycloak
 		return fmt.Errorf("unable to get keycloak realm from ref: %w", err)
 	}
 
+	// Deletion is handled before the config is validated and the metadata is fetched,
+	// so the identity provider with invalid config or unavailable metadata can be deleted.
+	term := makeTerminator(
+		gocloak.PString(realm.Realm),
+		keycloakRealmIDP.Spec.Alias,
+		kClient,
+		objectmeta.PreserveResourcesOnDeletion(keycloakRealmIDP),
+	)
+	if deleted, err := r.helper.TryToDelete(ctx, keycloakRealmIDP, term, finalizerName); err != nil {
+		return errors.Wrap(err, "unable to delete realm idp")
+	} else if deleted {
+		return nil
+	}
+
+	if err = validateIDPProviders(ctx, &keycloakRealmIDP.Spec, kClient); err != nil {
+		return err
+	}
+
+	if err = validateIDPConfig(&keycloakRealmIDP.Spec); err != nil {
+		return fmt.Errorf("invalid identity provider config: %w", err)
+	}
+
 	keycloakIDP := createKeycloakIDPFromSpec(&keycloakRealmIDP.Spec)
 
+	keycloakIDP.Config, err = r.makeIDPConfig(ctx, keycloakRealmIDP, kClient, gocloak.PString(realm.Realm))
+	if err != nil {
+		return fmt.Errorf("unable to make identity provider config: %w", err)
+	}
+
+	refConfig := maps.Clone(keycloakIDP.Config)
+
 	if err = r.secretRefClient.MapConfigSecretsRefs(ctx, keycloakIDP.Config, keycloakRealmIDP.Namespace); err != nil {
 		return fmt.Errorf("unable to map config secrets: %w", err)
 	}
@@ -175,23 +245,26 @@ func (r *Reconcile) tryReconcile(ctx context.Context, keycloakRealmIDP *keycloak
 		}
 	}
 
-	if err := syncIDPMappers(ctx, &keycloakRealmIDP.Spec, kClient, gocloak.PString(realm.Realm)); err != nil {
-		return errors.Wrap(err, "unable to sync idp mappers")
-	}
+	keycloakRealmIDP.Status.SecretHashes = secretref.ConfigSecretsHashes(refConfig, keycloakIDP.Config)
 
-	term := makeTerminator(
-		gocloak.PString(realm.Realm),
-		keycloakRealmIDP.Spec.Alias,
-		kClient,
-		objectmeta.PreserveResourcesOnDeletion(keycloakRealmIDP),
-	)
-	if _, err := r.helper.TryToDelete(ctx, keycloakRealmIDP, term, finalizerName); err != nil {
-		return errors.Wrap(err, "unable to delete realm idp")
+	if err := syncIDPMappers(ctx, keycloakRealmIDP, kClient, gocloak.PString(realm.Realm)); err != nil {
+		return err
 	}
 
 	return nil
 }
 
+// successRequeueAfter returns requeue period after successful reconciliation.
+// Identity providers with metadata source are requeued to refresh the metadata.
+func (r *Reconcile) successRequeueAfter(spec *keycloakApi.KeycloakRealmIdentityProviderSpec) time.Duration {
+	refreshInterval := metadataRefreshInterval(spec)
+	if refreshInterval > 0 && (r.successReconcileTimeout == 0 || refreshInterval < r.successReconcileTimeout) {
+		return refreshInterval
+	}
+
+	return r.successReconcileTimeout
+}
+
 func (r *Reconcile) applyDefaults(ctx context.Context, instance *keycloakApi.KeycloakRealmIdentityProvider) (bool, error) {
 	if instance.Spec.RealmRef.Name == "" {
 		instance.Spec.RealmRef = common.RealmRef{
@@ -209,32 +282,55 @@ func (r *Reconcile) applyDefaults(ctx context.Context, instance *keycloakApi.Key
 	return false, nil
 }
 
-func syncIDPMappers(ctx context.Context, idpSpec *keycloakApi.KeycloakRealmIdentityProviderSpec,
-	kClient keycloak.Client, targetRealm string) error {
-	if len(idpSpec.Mappers) == 0 {
+// validateIDPProviders checks that identity provider and its mappers are available on the server.
+// Validation is skipped if server info can't be retrieved, e.g. admin doesn't have enough permissions.
+func validateIDPProviders(ctx context.Context, idpSpec *keycloakApi.KeycloakRealmIdentityProviderSpec,
+	kClient keycloak.Client) error {
+	serverInfo, err := kClient.GetServerInfo(ctx)
+	if err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Unable to get server info, skipping identity provider validation")
+
 		return nil
 	}
 
-	mappers, err := kClient.GetIDPMappers(ctx, targetRealm, idpSpec.Alias)
-	if err != nil {
-		return errors.Wrap(err, "unable to get idp mappers")
+	if err = adapter.ValidateProviderID("identity provider", idpSpec.ProviderID, serverInfo.IdentityProviderIDs()); err != nil {
+		return err
 	}
 
-	for _, m := range mappers {
-		if err := kClient.DeleteIDPMapper(ctx, targetRealm, idpSpec.Alias, m.ID); err != nil {
-			return errors.Wrap(err, "unable to delete idp mapper")
+	mapperIDs := serverInfo.IdentityProviderMapperIDs()
+
+	for i := range idpSpec.Mappers {
+		if err = adapter.ValidateProviderID(
+			"identity provider mapper",
+			idpSpec.Mappers[i].IdentityProviderMapper,
+			mapperIDs,
+		); err != nil {
+			return fmt.Errorf("invalid mapper %s: %w", idpSpec.Mappers[i].Name, err)
 		}
 	}
 
-	for _, m := range idpSpec.Mappers {
-		if m.IdentityProviderAlias == "" {
-			m.IdentityProviderAlias = idpSpec.Alias
-		}
+	return nil
+}
 
-		if _, err := kClient.CreateIDPMapper(ctx, targetRealm, idpSpec.Alias,
-			createKeycloakIDPMapperFromSpec(&m)); err != nil {
-			return errors.Wrap(err, "unable to create idp mapper")
-		}
+func syncIDPMappers(ctx context.Context, idp *keycloakApi.KeycloakRealmIdentityProvider,
+	kClient keycloak.Client, targetRealm string) error {
+	if len(idp.Spec.Mappers) == 0 {
+		return nil
+	}
+
+	mappers := make([]adapter.IdentityProviderMapper, 0, len(idp.Spec.Mappers))
+	for i := range idp.Spec.Mappers {
+		mappers = append(mappers, *createKeycloakIDPMapperFromSpec(&idp.Spec.Mappers[i]))
+	}
+
+	if err := kClient.SyncIDPMappers(
+		ctx,
+		targetRealm,
+		idp.Spec.Alias,
+		mappers,
+		idp.GetMappersReconciliationStrategy() == keycloakApi.ReconciliationStrategyAddOnly,
+	); err != nil {
+		return fmt.Errorf("unable to sync idp mappers: %w", err)
 	}
 
 	return nil
@@ -250,6 +346,10 @@ func createKeycloakIDPMapperFromSpec(spec *keycloakApi.IdentityProviderMapper) *
 
 	maps.Copy(m.Config, spec.Config)
 
+	if spec.SyncMode != "" {
+		m.Config["syncMode"] = spec.SyncMode
+	}
+
 	return m
 }
 
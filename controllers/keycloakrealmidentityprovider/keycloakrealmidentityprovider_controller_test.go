@@ -0,0 +1,235 @@
+package keycloakrealmidentityprovider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Nerzal/gocloak/v12"
+	testifymock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/epam/edp-keycloak-operator/api/common"
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	helpermock "github.com/epam/edp-keycloak-operator/controllers/helper/mocks"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+func TestValidateIDPProviders(t *testing.T) {
+	t.Parallel()
+
+	serverInfo := &adapter.ServerInfo{
+		IdentityProviders: []adapter.ProviderInfo{{ID: "oidc"}, {ID: "saml"}},
+		SocialProviders:   []adapter.ProviderInfo{{ID: "github"}},
+		Providers: map[string]adapter.SpiInfo{
+			"identity-provider-mapper": {
+				Providers: map[string]adapter.ProviderOrderInfo{
+					"hardcoded-attribute-idp-mapper": {},
+					"oidc-user-attribute-idp-mapper": {},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		spec          keycloakApi.KeycloakRealmIdentityProviderSpec
+		serverInfoErr error
+		wantErr       require.ErrorAssertionFunc
+	}{
+		{
+			name: "valid providers",
+			spec: keycloakApi.KeycloakRealmIdentityProviderSpec{
+				ProviderID: "github",
+				Mappers: []keycloakApi.IdentityProviderMapper{
+					{Name: "mapper", IdentityProviderMapper: "hardcoded-attribute-idp-mapper"},
+				},
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "unknown identity provider",
+			spec: keycloakApi.KeycloakRealmIdentityProviderSpec{
+				ProviderID: "oidc2",
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.EqualError(t, err, `unknown identity provider "oidc2", available: github, oidc, saml`)
+			},
+		},
+		{
+			name: "unknown identity provider mapper",
+			spec: keycloakApi.KeycloakRealmIdentityProviderSpec{
+				ProviderID: "oidc",
+				Mappers: []keycloakApi.IdentityProviderMapper{
+					{Name: "mapper", IdentityProviderMapper: "hardcoded-idp-mapper"},
+				},
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.ErrorContains(t, err, `invalid mapper mapper: unknown identity provider mapper "hardcoded-idp-mapper"`)
+			},
+		},
+		{
+			name: "server info is not available",
+			spec: keycloakApi.KeycloakRealmIdentityProviderSpec{
+				ProviderID: "oidc2",
+			},
+			serverInfoErr: errors.New("forbidden"),
+			wantErr:       require.NoError,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			kClient := &adapter.Mock{}
+			kClient.On("GetServerInfo", testifymock.Anything).Return(serverInfo, tt.serverInfoErr)
+
+			tt.wantErr(t, validateIDPProviders(context.Background(), &tt.spec, kClient))
+		})
+	}
+}
+
+func TestSyncIDPMappers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		idp       *keycloakApi.KeycloakRealmIdentityProvider
+		setupMock func(m *adapter.Mock)
+		wantErr   require.ErrorAssertionFunc
+	}{
+		{
+			name: "mappers are not managed",
+			idp: &keycloakApi.KeycloakRealmIdentityProvider{
+				Spec: keycloakApi.KeycloakRealmIdentityProviderSpec{Alias: "idp"},
+			},
+			setupMock: func(m *adapter.Mock) {},
+			wantErr:   require.NoError,
+		},
+		{
+			name: "add only mappers with sync mode",
+			idp: &keycloakApi.KeycloakRealmIdentityProvider{
+				Spec: keycloakApi.KeycloakRealmIdentityProviderSpec{
+					Alias: "idp",
+					Mappers: []keycloakApi.IdentityProviderMapper{
+						{
+							Name:                   "mapper",
+							IdentityProviderMapper: "hardcoded-attribute-idp-mapper",
+							Config:                 map[string]string{"attribute": "foo"},
+							SyncMode:               "FORCE",
+						},
+					},
+					MappersReconciliationStrategy: keycloakApi.ReconciliationStrategyAddOnly,
+				},
+			},
+			setupMock: func(m *adapter.Mock) {
+				m.On("SyncIDPMappers", "realm", "idp", []adapter.IdentityProviderMapper{
+					{
+						Name:                   "mapper",
+						IdentityProviderMapper: "hardcoded-attribute-idp-mapper",
+						Config:                 map[string]string{"attribute": "foo", "syncMode": "FORCE"},
+					},
+				}, true).Return(nil)
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "failed to sync mappers",
+			idp: &keycloakApi.KeycloakRealmIdentityProvider{
+				Spec: keycloakApi.KeycloakRealmIdentityProviderSpec{
+					Alias:   "idp",
+					Mappers: []keycloakApi.IdentityProviderMapper{{Name: "mapper"}},
+				},
+			},
+			setupMock: func(m *adapter.Mock) {
+				m.On("SyncIDPMappers", "realm", "idp", testifymock.Anything, false).
+					Return(errors.New("unable to sync idp mapper mapper"))
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.ErrorContains(t, err, "unable to sync idp mapper mapper")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			kClient := &adapter.Mock{}
+			tt.setupMock(kClient)
+
+			tt.wantErr(t, syncIDPMappers(context.Background(), tt.idp, kClient, "realm"))
+			kClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestIndexSecretNames(t *testing.T) {
+	t.Parallel()
+
+	idp := &keycloakApi.KeycloakRealmIdentityProvider{
+		Spec: keycloakApi.KeycloakRealmIdentityProviderSpec{
+			Config: map[string]string{
+				"clientId":     "client",
+				"clientSecret": "$idp-secret:clientSecret",
+			},
+			OIDC: &keycloakApi.IdentityProviderOIDC{ClientSecret: "$oidc-secret:clientSecret"},
+		},
+	}
+
+	require.Equal(t, []string{"idp-secret", "oidc-secret"}, indexSecretNames(idp))
+}
+
+func TestReconcile_ReconcileDeletedWithInvalidConfig(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(sch))
+
+	idp := keycloakApi.KeycloakRealmIdentityProvider{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "security",
+			Name:              "saml",
+			DeletionTimestamp: &metav1.Time{Time: time.Now()},
+			Finalizers:        []string{finalizerName},
+		},
+		Spec: keycloakApi.KeycloakRealmIdentityProviderSpec{
+			RealmRef:   common.RealmRef{Kind: keycloakApi.KeycloakRealmKind, Name: "realm1"},
+			ProviderID: "saml",
+			Alias:      "saml",
+			SAML: &keycloakApi.IdentityProviderSAML{
+				MetadataConfigMapRef: &keycloakApi.ConfigMapKeyRef{Name: "missing", Key: "metadata.xml"},
+			},
+		},
+	}
+
+	k8sClient := fake.NewClientBuilder().WithScheme(sch).WithObjects(&idp).Build()
+	h := helpermock.NewControllerHelper(t)
+	kcMock := &adapter.Mock{}
+
+	h.On("SetRealmOwnerRef", testifymock.Anything, testifymock.Anything).Return(nil)
+	h.On("CreateKeycloakClientFromRealmRef", testifymock.Anything, testifymock.Anything).Return(kcMock, nil)
+	h.On("GetKeycloakRealmFromRef", testifymock.Anything, testifymock.Anything, testifymock.Anything).
+		Return(&gocloak.RealmRepresentation{Realm: gocloak.StringP("realm")}, nil)
+	h.On("TryToDelete", testifymock.Anything, testifymock.Anything, testifymock.Anything, finalizerName).
+		Return(true, nil)
+
+	r := Reconcile{
+		client: k8sClient,
+		helper: h,
+	}
+
+	_, err := r.Reconcile(context.Background(), reconcile.Request{
+		NamespacedName: types.NamespacedName{Namespace: idp.Namespace, Name: idp.Name},
+	})
+	require.NoError(t, err)
+	kcMock.AssertExpectations(t)
+}
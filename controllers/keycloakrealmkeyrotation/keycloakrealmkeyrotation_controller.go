@@ -0,0 +1,217 @@
+package keycloakrealmkeyrotation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/Nerzal/gocloak/v12"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/objectmeta"
+	"github.com/epam/edp-keycloak-operator/pkg/secretref"
+)
+
+const finalizerName = "keycloak.realmkeyrotation.operator.finalizer.name"
+
+type Helper interface {
+	SetFailureCount(fc helper.FailureCountable) time.Duration
+	TryToDelete(ctx context.Context, obj client.Object, terminator helper.Terminator, finalizer string) (isDeleted bool, resultErr error)
+	SetRealmOwnerRef(ctx context.Context, object helper.ObjectWithRealmRef) error
+	GetKeycloakRealmFromRef(ctx context.Context, object helper.ObjectWithRealmRef, kcClient keycloak.Client) (*gocloak.RealmRepresentation, error)
+	CreateKeycloakClientFromRealmRef(ctx context.Context, object helper.ObjectWithRealmRef) (keycloak.Client, error)
+}
+
+type Reconcile struct {
+	client                  client.Client
+	helper                  Helper
+	successReconcileTimeout time.Duration
+	now                     func() time.Time
+}
+
+func NewReconcile(client client.Client, helper Helper) *Reconcile {
+	return &Reconcile{
+		client: client,
+		helper: helper,
+		now:    time.Now,
+	}
+}
+
+func (r *Reconcile) SetupWithManager(mgr ctrl.Manager, successReconcileTimeout time.Duration) error {
+	r.successReconcileTimeout = successReconcileTimeout
+
+	pred := predicate.Funcs{
+		UpdateFunc: isSpecUpdated,
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&keycloakApi.KeycloakRealmKeyRotation{},
+		secretref.SecretNamesIndexField,
+		indexSecretNames,
+	); err != nil {
+		return fmt.Errorf("failed to index KeycloakRealmKeyRotation by secrets: %w", err)
+	}
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&keycloakApi.KeycloakRealmKeyRotation{}, builder.WithPredicates(pred)).
+		Watches(
+			&source.Kind{Type: &corev1.Secret{}},
+			handler.EnqueueRequestsFromMapFunc(secretref.MapSecretToRequests(r.client, func() client.ObjectList {
+				return &keycloakApi.KeycloakRealmKeyRotationList{}
+			})),
+		).
+		Complete(r)
+	if err != nil {
+		return fmt.Errorf("failed to setup KeycloakRealmKeyRotation controller: %w", err)
+	}
+
+	return nil
+}
+
+// indexSecretNames returns name of the TLS secret with the imported key.
+func indexSecretNames(obj client.Object) []string {
+	rotation, ok := obj.(*keycloakApi.KeycloakRealmKeyRotation)
+	if !ok || rotation.Spec.SecretRef == nil {
+		return nil
+	}
+
+	return []string{rotation.Spec.SecretRef.Name}
+}
+
+func isSpecUpdated(e event.UpdateEvent) bool {
+	oo, ok := e.ObjectOld.(*keycloakApi.KeycloakRealmKeyRotation)
+	if !ok {
+		return false
+	}
+
+	no, ok := e.ObjectNew.(*keycloakApi.KeycloakRealmKeyRotation)
+	if !ok {
+		return false
+	}
+
+	return !reflect.DeepEqual(oo.Spec, no.Spec) ||
+		(oo.GetDeletionTimestamp().IsZero() && !no.GetDeletionTimestamp().IsZero())
+}
+
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealmkeyrotations,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealmkeyrotations/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealmkeyrotations/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",namespace=placeholder,resources=secrets,verbs=get;list;watch
+
+// Reconcile is a loop for reconciling KeycloakRealmKeyRotation object.
+// Reconciliation is requeued at the time of the next rotation step if it is earlier than the success reconcile timeout.
+func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (result reconcile.Result, resultErr error) {
+	log := ctrl.LoggerFrom(ctx)
+	log.Info("Reconciling KeycloakRealmKeyRotation")
+
+	var instance keycloakApi.KeycloakRealmKeyRotation
+	if err := r.client.Get(ctx, request.NamespacedName, &instance); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			log.Info("instance not found")
+			return
+		}
+
+		resultErr = fmt.Errorf("unable to get keycloak realm key rotation from k8s: %w", err)
+
+		return
+	}
+
+	nextStep, err := r.tryReconcile(ctx, &instance)
+	if err != nil {
+		if errors.Is(err, helper.ErrKeycloakIsNotAvailable) {
+			return ctrl.Result{
+				RequeueAfter: helper.RequeueOnKeycloakNotAvailablePeriod,
+			}, nil
+		}
+
+		instance.Status.Value = err.Error()
+		result.RequeueAfter = r.helper.SetFailureCount(&instance)
+
+		log.Error(err, "an error has occurred while handling keycloak realm key rotation", "name", request.Name)
+	} else {
+		helper.SetSuccessStatus(&instance)
+		result.RequeueAfter = r.successReconcileTimeout
+
+		if nextStep > 0 && nextStep < result.RequeueAfter {
+			result.RequeueAfter = nextStep
+		}
+	}
+
+	instanceDeleted := !controllerutil.ContainsFinalizer(&instance, finalizerName) &&
+		instance.GetDeletionTimestamp() != nil
+
+	if !instanceDeleted {
+		if err := r.client.Status().Update(ctx, &instance); err != nil {
+			resultErr = fmt.Errorf("unable to update status: %w", err)
+		}
+	}
+
+	log.Info("Reconciling KeycloakRealmKeyRotation done")
+
+	return
+}
+
+// tryReconcile applies the key rotation and returns the duration until the next rotation step.
+func (r *Reconcile) tryReconcile(ctx context.Context, rotation *keycloakApi.KeycloakRealmKeyRotation) (time.Duration, error) {
+	if err := r.helper.SetRealmOwnerRef(ctx, rotation); err != nil {
+		return 0, fmt.Errorf("unable to set realm owner ref: %w", err)
+	}
+
+	kClient, err := r.helper.CreateKeycloakClientFromRealmRef(ctx, rotation)
+	if err != nil {
+		return 0, fmt.Errorf("unable to create keycloak client from realm ref: %w", err)
+	}
+
+	realm, err := r.helper.GetKeycloakRealmFromRef(ctx, rotation, kClient)
+	if err != nil {
+		return 0, fmt.Errorf("unable to get keycloak realm from ref: %w", err)
+	}
+
+	realmName := gocloak.PString(realm.Realm)
+	realmID := gocloak.PString(realm.ID)
+
+	if deleted, err := r.helper.TryToDelete(
+		ctx,
+		rotation,
+		makeTerminator(realmName, realmID, rotation.Status.Keys, kClient, objectmeta.PreserveResourcesOnDeletion(rotation)),
+		finalizerName,
+	); err != nil {
+		return 0, fmt.Errorf("unable to delete realm key rotation: %w", err)
+	} else if deleted {
+		return 0, nil
+	}
+
+	if err = validateKeyRotation(&rotation.Spec); err != nil {
+		return 0, fmt.Errorf("invalid key rotation: %w", err)
+	}
+
+	config, err := r.makeKeyConfig(ctx, rotation)
+	if err != nil {
+		return 0, fmt.Errorf("unable to make key config: %w", err)
+	}
+
+	rotator := &keyRotator{
+		kClient:   kClient,
+		realmName: realmName,
+		realmID:   realmID,
+		now:       r.now(),
+	}
+
+	return rotator.rotate(ctx, rotation, config)
+}
@@ -0,0 +1,408 @@
+package keycloakrealmkeyrotation
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Nerzal/gocloak/v12"
+	testifymock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/epam/edp-keycloak-operator/api/common"
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	helpermock "github.com/epam/edp-keycloak-operator/controllers/helper/mocks"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+const ns = "default"
+
+var testNow = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func getTestRotation(providerID string) *keycloakApi.KeycloakRealmKeyRotation {
+	return &keycloakApi.KeycloakRealmKeyRotation{
+		ObjectMeta: metav1.ObjectMeta{Name: "keys", Namespace: ns},
+		Spec: keycloakApi.KeycloakRealmKeyRotationSpec{
+			Name:            "key",
+			RealmRef:        common.RealmRef{Kind: keycloakApi.KeycloakRealmKind, Name: "realm"},
+			ProviderID:      providerID,
+			Priority:        100,
+			GracePeriod:     &metav1.Duration{Duration: 24 * time.Hour},
+			RetentionPeriod: &metav1.Duration{Duration: 7 * 24 * time.Hour},
+		},
+	}
+}
+
+func getTestHMACRotation() *keycloakApi.KeycloakRealmKeyRotation {
+	rotation := getTestRotation(keycloakApi.KeyProviderHMACGenerated)
+	rotation.Spec.KeySize = 64
+	rotation.Spec.RotationPeriod = &metav1.Duration{Duration: 30 * 24 * time.Hour}
+
+	return rotation
+}
+
+func hmacConfigHash(t *testing.T) string {
+	hash, err := hashKeyConfig(map[string][]string{"secretSize": {"64"}})
+	require.NoError(t, err)
+
+	return hash
+}
+
+func key(name string, priority int, state string, createdAt time.Time, hash string) keycloakApi.RotatedKey {
+	k := keycloakApi.RotatedKey{
+		Name:       name,
+		ID:         name + "-id",
+		Priority:   priority,
+		State:      state,
+		CreatedAt:  metav1.Time{Time: createdAt},
+		ConfigHash: hash,
+	}
+
+	return k
+}
+
+func matchKeyComponent(name, providerID, priority string, config map[string]string) interface{} {
+	return testifymock.MatchedBy(func(c *adapter.Component) bool {
+		if c.Name != name || c.ProviderID != providerID || c.ProviderType != adapter.KeyProviderType ||
+			c.ParentID != "realm-id" || c.Config["priority"][0] != priority || c.Config["active"][0] != "true" {
+			return false
+		}
+
+		for k, v := range config {
+			if len(c.Config[k]) != 1 || c.Config[k][0] != v {
+				return false
+			}
+		}
+
+		return true
+	})
+}
+
+func generateTLSSecret(t *testing.T, name string) *corev1.Secret {
+	t.Helper()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "keycloak"},
+		NotBefore:    testNow,
+		NotAfter:     testNow.Add(time.Hour),
+	}
+
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert})
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSPrivateKeyKey: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}),
+			// Chain is added to check that only the leaf certificate is used.
+			corev1.TLSCertKey: append(append([]byte{}, certPEM...), certPEM...),
+		},
+	}
+}
+
+func TestReconcile_Reconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(scheme))
+	utilruntime.Must(corev1.AddToScheme(scheme))
+
+	tlsSecret := generateTLSSecret(t, "tls-secret")
+	hash := hmacConfigHash(t)
+
+	realmMocks := func(h *helpermock.ControllerHelper, kClient *adapter.Mock) {
+		h.On("SetRealmOwnerRef", testifymock.Anything, testifymock.Anything).Return(nil)
+		h.On("CreateKeycloakClientFromRealmRef", testifymock.Anything, testifymock.Anything).Return(kClient, nil)
+		h.On("GetKeycloakRealmFromRef", testifymock.Anything, testifymock.Anything, kClient).
+			Return(&gocloak.RealmRepresentation{Realm: gocloak.StringP("realm"), ID: gocloak.StringP("realm-id")}, nil)
+		h.On("TryToDelete", testifymock.Anything, testifymock.Anything, testifymock.Anything, finalizerName).
+			Return(false, nil)
+	}
+
+	newKeyName := "key-1709294400"
+
+	tests := []struct {
+		name        string
+		rotation    func() *keycloakApi.KeycloakRealmKeyRotation
+		objects     []client.Object
+		setupMocks  func(h *helpermock.ControllerHelper, kClient *adapter.Mock)
+		wantResult  reconcile.Result
+		checkStatus func(t *testing.T, status *keycloakApi.KeycloakRealmKeyRotationStatus)
+	}{
+		{
+			name:     "first key is created",
+			rotation: getTestHMACRotation,
+			setupMocks: func(h *helpermock.ControllerHelper, kClient *adapter.Mock) {
+				realmMocks(h, kClient)
+				kClient.On("CreateComponent", "realm", matchKeyComponent(
+					newKeyName, keycloakApi.KeyProviderHMACGenerated, "100", map[string]string{"secretSize": "64"},
+				)).Run(func(args testifymock.Arguments) {
+					args.Get(1).(*adapter.Component).ID = "new-id"
+				}).Return(nil)
+			},
+			wantResult: reconcile.Result{RequeueAfter: time.Hour},
+			checkStatus: func(t *testing.T, status *keycloakApi.KeycloakRealmKeyRotationStatus) {
+				require.Equal(t, helper.StatusOK, status.Value)
+				require.Len(t, status.Keys, 1)
+				require.Equal(t, "new-id", status.Keys[0].ID)
+				require.Equal(t, keycloakApi.KeyStateActive, status.Keys[0].State)
+				require.Equal(t, hmacConfigHash(t), status.Keys[0].ConfigHash)
+				require.True(t, testNow.Add(30*24*time.Hour).Equal(status.NextRotation.Time))
+			},
+		},
+		{
+			name: "rotation is not due",
+			rotation: func() *keycloakApi.KeycloakRealmKeyRotation {
+				r := getTestHMACRotation()
+				r.Status.Keys = []keycloakApi.RotatedKey{
+					key("key-1", 100, keycloakApi.KeyStateActive, testNow.Add(-time.Minute), hash),
+				}
+
+				return r
+			},
+			setupMocks: realmMocks,
+			wantResult: reconcile.Result{RequeueAfter: time.Hour},
+			checkStatus: func(t *testing.T, status *keycloakApi.KeycloakRealmKeyRotationStatus) {
+				require.Len(t, status.Keys, 1)
+			},
+		},
+		{
+			name: "rotation period passed, previous key stays active during grace period",
+			rotation: func() *keycloakApi.KeycloakRealmKeyRotation {
+				r := getTestHMACRotation()
+				r.Status.Keys = []keycloakApi.RotatedKey{
+					key("key-1", 100, keycloakApi.KeyStateActive, testNow.Add(-31*24*time.Hour), hash),
+				}
+
+				return r
+			},
+			setupMocks: func(h *helpermock.ControllerHelper, kClient *adapter.Mock) {
+				realmMocks(h, kClient)
+				kClient.On("CreateComponent", "realm", matchKeyComponent(
+					newKeyName, keycloakApi.KeyProviderHMACGenerated, "101", nil,
+				)).Return(nil)
+				kClient.On("GetComponentByParent", "realm", "realm-id", newKeyName).Return(&adapter.Component{ID: "new-id"}, nil)
+			},
+			wantResult: reconcile.Result{RequeueAfter: time.Hour},
+			checkStatus: func(t *testing.T, status *keycloakApi.KeycloakRealmKeyRotationStatus) {
+				require.Len(t, status.Keys, 2)
+				require.Equal(t, keycloakApi.KeyStateActive, status.Keys[0].State)
+				require.Equal(t, newKeyName, status.Keys[1].Name)
+			},
+		},
+		{
+			name: "spec change triggers rotation",
+			rotation: func() *keycloakApi.KeycloakRealmKeyRotation {
+				r := getTestHMACRotation()
+				r.Status.Keys = []keycloakApi.RotatedKey{
+					key("key-1", 100, keycloakApi.KeyStateActive, testNow.Add(-time.Minute), "old-hash"),
+				}
+
+				return r
+			},
+			setupMocks: func(h *helpermock.ControllerHelper, kClient *adapter.Mock) {
+				realmMocks(h, kClient)
+				kClient.On("CreateComponent", "realm", testifymock.Anything).Return(nil)
+				kClient.On("GetComponentByParent", "realm", "realm-id", newKeyName).Return(&adapter.Component{ID: "new-id"}, nil)
+			},
+			wantResult: reconcile.Result{RequeueAfter: time.Hour},
+			checkStatus: func(t *testing.T, status *keycloakApi.KeycloakRealmKeyRotationStatus) {
+				require.Len(t, status.Keys, 2)
+			},
+		},
+		{
+			name: "previous key becomes passive after grace period",
+			rotation: func() *keycloakApi.KeycloakRealmKeyRotation {
+				r := getTestHMACRotation()
+				r.Status.Keys = []keycloakApi.RotatedKey{
+					key("key-1", 100, keycloakApi.KeyStateActive, testNow.Add(-40*24*time.Hour), hash),
+					key("key-2", 101, keycloakApi.KeyStateActive, testNow.Add(-25*time.Hour), hash),
+				}
+
+				return r
+			},
+			setupMocks: func(h *helpermock.ControllerHelper, kClient *adapter.Mock) {
+				realmMocks(h, kClient)
+				kClient.On("GetComponentByID", "realm", "key-1-id").Return(&adapter.Component{
+					ID:     "key-1-id",
+					Name:   "key-1",
+					Config: map[string][]string{"active": {"true"}, "priority": {"100"}},
+				}, nil)
+				kClient.On("UpdateComponent", "realm", testifymock.MatchedBy(func(c *adapter.Component) bool {
+					return c.ID == "key-1-id" && c.Config["active"][0] == "false" && c.Config["priority"][0] == "100"
+				})).Return(nil)
+			},
+			wantResult: reconcile.Result{RequeueAfter: time.Hour},
+			checkStatus: func(t *testing.T, status *keycloakApi.KeycloakRealmKeyRotationStatus) {
+				require.Len(t, status.Keys, 2)
+				require.Equal(t, keycloakApi.KeyStatePassive, status.Keys[0].State)
+				require.True(t, testNow.Equal(status.Keys[0].PassiveSince.Time))
+			},
+		},
+		{
+			name: "passive key is deleted after retention period",
+			rotation: func() *keycloakApi.KeycloakRealmKeyRotation {
+				r := getTestHMACRotation()
+				passive := key("key-1", 100, keycloakApi.KeyStatePassive, testNow.Add(-40*24*time.Hour), hash)
+				passive.PassiveSince = &metav1.Time{Time: testNow.Add(-8 * 24 * time.Hour)}
+				r.Status.Keys = []keycloakApi.RotatedKey{
+					passive,
+					key("key-2", 101, keycloakApi.KeyStateActive, testNow.Add(-9*24*time.Hour), hash),
+				}
+
+				return r
+			},
+			setupMocks: func(h *helpermock.ControllerHelper, kClient *adapter.Mock) {
+				realmMocks(h, kClient)
+				kClient.On("DeleteComponentByID", "realm", "key-1-id").Return(nil)
+			},
+			wantResult: reconcile.Result{RequeueAfter: time.Hour},
+			checkStatus: func(t *testing.T, status *keycloakApi.KeycloakRealmKeyRotationStatus) {
+				require.Len(t, status.Keys, 1)
+				require.Equal(t, "key-2", status.Keys[0].Name)
+			},
+		},
+		{
+			name: "rsa key is imported from tls secret",
+			rotation: func() *keycloakApi.KeycloakRealmKeyRotation {
+				r := getTestRotation(keycloakApi.KeyProviderRSA)
+				r.Spec.SecretRef = &keycloakApi.TLSSecretRef{Name: "tls-secret"}
+
+				return r
+			},
+			objects: []client.Object{tlsSecret},
+			setupMocks: func(h *helpermock.ControllerHelper, kClient *adapter.Mock) {
+				realmMocks(h, kClient)
+
+				certBlock, _ := pem.Decode(tlsSecret.Data[corev1.TLSCertKey])
+
+				kClient.On("CreateComponent", "realm", matchKeyComponent(
+					newKeyName, keycloakApi.KeyProviderRSA, "100", map[string]string{
+						"privateKey":  string(tlsSecret.Data[corev1.TLSPrivateKeyKey]),
+						"certificate": string(pem.EncodeToMemory(certBlock)),
+					},
+				)).Return(nil)
+				kClient.On("GetComponentByParent", "realm", "realm-id", newKeyName).Return(&adapter.Component{ID: "new-id"}, nil)
+			},
+			wantResult: reconcile.Result{RequeueAfter: time.Hour},
+			checkStatus: func(t *testing.T, status *keycloakApi.KeycloakRealmKeyRotationStatus) {
+				require.Equal(t, helper.StatusOK, status.Value)
+				require.Len(t, status.Keys, 1)
+				require.Nil(t, status.NextRotation)
+			},
+		},
+		{
+			name: "invalid tls secret",
+			rotation: func() *keycloakApi.KeycloakRealmKeyRotation {
+				r := getTestRotation(keycloakApi.KeyProviderRSA)
+				r.Spec.SecretRef = &keycloakApi.TLSSecretRef{Name: "invalid-secret"}
+
+				return r
+			},
+			objects: []client.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "invalid-secret", Namespace: ns},
+				Data:       map[string][]byte{corev1.TLSPrivateKeyKey: []byte("key")},
+			}},
+			setupMocks: func(h *helpermock.ControllerHelper, kClient *adapter.Mock) {
+				realmMocks(h, kClient)
+				h.On("SetFailureCount", testifymock.Anything).Return(time.Minute)
+			},
+			wantResult: reconcile.Result{RequeueAfter: time.Minute},
+			checkStatus: func(t *testing.T, status *keycloakApi.KeycloakRealmKeyRotationStatus) {
+				require.Contains(t, status.Value, "doesn't contain PEM encoded tls.key")
+			},
+		},
+		{
+			name: "rsa provider requires secret",
+			rotation: func() *keycloakApi.KeycloakRealmKeyRotation {
+				return getTestRotation(keycloakApi.KeyProviderRSA)
+			},
+			setupMocks: func(h *helpermock.ControllerHelper, kClient *adapter.Mock) {
+				realmMocks(h, kClient)
+				h.On("SetFailureCount", testifymock.Anything).Return(time.Minute)
+			},
+			wantResult: reconcile.Result{RequeueAfter: time.Minute},
+			checkStatus: func(t *testing.T, status *keycloakApi.KeycloakRealmKeyRotationStatus) {
+				require.Contains(t, status.Value, "secretRef is required for rsa provider")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			cl := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(append(tt.objects, tt.rotation())...).
+				Build()
+			h := helpermock.NewControllerHelper(t)
+			kClient := new(adapter.Mock)
+			tt.setupMocks(h, kClient)
+
+			r := NewReconcile(cl, h)
+			r.successReconcileTimeout = time.Hour
+			r.now = func() time.Time { return testNow }
+
+			res, err := r.Reconcile(context.Background(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: "keys", Namespace: ns},
+			})
+			require.NoError(t, err)
+			require.Equal(t, tt.wantResult, res)
+
+			rotation := &keycloakApi.KeycloakRealmKeyRotation{}
+			require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Name: "keys", Namespace: ns}, rotation))
+			tt.checkStatus(t, &rotation.Status)
+			kClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestKeyRotator_nextStep(t *testing.T) {
+	t.Parallel()
+
+	rotation := getTestHMACRotation()
+	passive := key("key-1", 100, keycloakApi.KeyStatePassive, testNow.Add(-40*24*time.Hour), "")
+	passive.PassiveSince = &metav1.Time{Time: testNow.Add(-6 * 24 * time.Hour)}
+	rotation.Status.Keys = []keycloakApi.RotatedKey{
+		passive,
+		key("key-2", 101, keycloakApi.KeyStateActive, testNow.Add(-20*time.Hour), ""),
+		key("key-3", 102, keycloakApi.KeyStateActive, testNow.Add(-2*time.Hour), ""),
+	}
+	rotation.Status.NextRotation = &metav1.Time{Time: testNow.Add(30 * time.Hour)}
+
+	k := &keyRotator{now: testNow}
+
+	// key-2 becomes passive when the grace period after key-3 creation passes.
+	require.Equal(t, 22*time.Hour, k.nextStep(rotation))
+
+	// key-1 is deleted when the retention period passes.
+	rotation.Status.Keys = rotation.Status.Keys[:2]
+	require.Equal(t, 24*time.Hour, k.nextStep(rotation))
+
+	rotation.Status.Keys = rotation.Status.Keys[1:]
+	require.Equal(t, 30*time.Hour, k.nextStep(rotation))
+}
@@ -0,0 +1,369 @@
+package keycloakrealmkeyrotation
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/secretref"
+)
+
+// validateKeyRotation checks that the configuration is supported by the provider.
+func validateKeyRotation(spec *keycloakApi.KeycloakRealmKeyRotationSpec) error {
+	if spec.ProviderID == keycloakApi.KeyProviderRSA {
+		if spec.SecretRef == nil {
+			return errors.New("secretRef is required for rsa provider")
+		}
+
+		if spec.RotationPeriod != nil {
+			return errors.New("rotationPeriod is not supported by rsa provider, the key is rotated on the Secret renewal")
+		}
+	} else if spec.SecretRef != nil {
+		return errors.New("secretRef is supported only by rsa provider")
+	}
+
+	if spec.EllipticCurve != "" && spec.ProviderID != keycloakApi.KeyProviderECDSAGenerated {
+		return errors.New("ellipticCurve is supported only by ecdsa-generated provider")
+	}
+
+	return nil
+}
+
+// makeKeyConfig builds key provider config without priority and state properties.
+func (r *Reconcile) makeKeyConfig(ctx context.Context, rotation *keycloakApi.KeycloakRealmKeyRotation) (map[string][]string, error) {
+	spec := &rotation.Spec
+	config := make(map[string][]string)
+
+	setIfNotEmpty(config, "algorithm", spec.Algorithm)
+
+	switch spec.ProviderID {
+	case keycloakApi.KeyProviderRSAGenerated, keycloakApi.KeyProviderRSAEncGenerated:
+		if spec.KeySize > 0 {
+			config["keySize"] = []string{strconv.Itoa(spec.KeySize)}
+		}
+	case keycloakApi.KeyProviderHMACGenerated, keycloakApi.KeyProviderAESGenerated:
+		if spec.KeySize > 0 {
+			config["secretSize"] = []string{strconv.Itoa(spec.KeySize)}
+		}
+	case keycloakApi.KeyProviderECDSAGenerated:
+		setIfNotEmpty(config, "ecdsaEllipticCurveKey", spec.EllipticCurve)
+	case keycloakApi.KeyProviderRSA:
+		privateKey, certificate, err := r.getTLSKeyPair(ctx, spec.SecretRef.Name, rotation.Namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		config["privateKey"] = []string{privateKey}
+		config["certificate"] = []string{certificate}
+	}
+
+	return config, nil
+}
+
+// getTLSKeyPair returns the private key and the leaf certificate from the kubernetes.io/tls Secret.
+func (r *Reconcile) getTLSKeyPair(ctx context.Context, secretName, namespace string) (privateKey, certificate string, err error) {
+	secret := &corev1.Secret{}
+	if err = r.client.Get(ctx, types.NamespacedName{Name: secretName, Namespace: namespace}, secret); err != nil {
+		return "", "", fmt.Errorf("unable to get secret %s: %w", secretName, err)
+	}
+
+	keyPEM := secret.Data[corev1.TLSPrivateKeyKey]
+	if block, _ := pem.Decode(keyPEM); block == nil {
+		return "", "", fmt.Errorf("secret %s doesn't contain PEM encoded %s", secretName, corev1.TLSPrivateKeyKey)
+	}
+
+	// Certificate chain is not supported by Keycloak, so only the leaf certificate is used.
+	certBlock, _ := pem.Decode(secret.Data[corev1.TLSCertKey])
+	if certBlock == nil || certBlock.Type != "CERTIFICATE" {
+		return "", "", fmt.Errorf("secret %s doesn't contain PEM encoded %s", secretName, corev1.TLSCertKey)
+	}
+
+	return string(keyPEM), string(pem.EncodeToMemory(certBlock)), nil
+}
+
+// hashKeyConfig returns hash of the key config. Map keys are sorted by json encoder, so the hash is stable.
+func hashKeyConfig(config map[string][]string) (string, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("unable to marshal key config: %w", err)
+	}
+
+	return secretref.HashSecretValue(string(data)), nil
+}
+
+type keyRotator struct {
+	kClient   keycloak.Client
+	realmName string
+	realmID   string
+	now       time.Time
+}
+
+// rotate creates a new key if it is required and moves the previous keys through the lifecycle:
+// active during the grace period, then passive during the retention period, then deleted.
+// It returns the duration until the next lifecycle step.
+func (k *keyRotator) rotate(
+	ctx context.Context,
+	rotation *keycloakApi.KeycloakRealmKeyRotation,
+	config map[string][]string,
+) (time.Duration, error) {
+	configHash, err := hashKeyConfig(config)
+	if err != nil {
+		return 0, err
+	}
+
+	if k.rotationRequired(rotation, configHash) {
+		key, err := k.createKey(ctx, rotation, config, configHash)
+		if key != nil {
+			rotation.Status.Keys = append(rotation.Status.Keys, *key)
+		}
+
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	newest := rotation.Status.Keys[len(rotation.Status.Keys)-1]
+	keys := make([]keycloakApi.RotatedKey, 0, len(rotation.Status.Keys))
+
+	var errs []error
+
+	for i := range rotation.Status.Keys[:len(rotation.Status.Keys)-1] {
+		key := rotation.Status.Keys[i]
+
+		keep, err := k.retireKey(ctx, &key, newest.CreatedAt.Time, rotation)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("unable to retire key %s: %w", key.Name, err))
+		}
+
+		if keep {
+			keys = append(keys, key)
+		}
+	}
+
+	rotation.Status.Keys = append(keys, newest)
+	rotation.Status.NextRotation = nil
+
+	if rotation.Spec.RotationPeriod != nil {
+		rotation.Status.NextRotation = &metav1.Time{Time: newest.CreatedAt.Add(rotation.Spec.RotationPeriod.Duration)}
+	}
+
+	return k.nextStep(rotation), errors.Join(errs...)
+}
+
+func (k *keyRotator) rotationRequired(rotation *keycloakApi.KeycloakRealmKeyRotation, configHash string) bool {
+	if len(rotation.Status.Keys) == 0 {
+		return true
+	}
+
+	newest := rotation.Status.Keys[len(rotation.Status.Keys)-1]
+	if newest.ConfigHash != configHash {
+		return true
+	}
+
+	return rotation.Spec.RotationPeriod != nil &&
+		!k.now.Before(newest.CreatedAt.Add(rotation.Spec.RotationPeriod.Duration))
+}
+
+func (k *keyRotator) createKey(
+	ctx context.Context,
+	rotation *keycloakApi.KeycloakRealmKeyRotation,
+	config map[string][]string,
+	configHash string,
+) (*keycloakApi.RotatedKey, error) {
+	priority := rotation.GetPriority()
+	if len(rotation.Status.Keys) > 0 && rotation.Status.Keys[len(rotation.Status.Keys)-1].Priority >= priority {
+		priority = rotation.Status.Keys[len(rotation.Status.Keys)-1].Priority + 1
+	}
+
+	name := fmt.Sprintf("%s-%d", rotation.Spec.Name, k.now.Unix())
+
+	componentConfig := make(map[string][]string, len(config)+3)
+	for key, v := range config {
+		componentConfig[key] = v
+	}
+
+	componentConfig["priority"] = []string{strconv.Itoa(priority)}
+	componentConfig["enabled"] = []string{strconv.FormatBool(true)}
+	componentConfig["active"] = []string{strconv.FormatBool(true)}
+
+	ctrl.LoggerFrom(ctx).Info("Creating realm key", "name", name, "priority", priority)
+
+	component := &adapter.Component{
+		Name:         name,
+		ProviderID:   rotation.Spec.ProviderID,
+		ProviderType: adapter.KeyProviderType,
+		ParentID:     k.realmID,
+		Config:       componentConfig,
+	}
+
+	if err := k.kClient.CreateComponent(ctx, k.realmName, component); err != nil {
+		return nil, fmt.Errorf("unable to create key %s: %w", name, err)
+	}
+
+	key := &keycloakApi.RotatedKey{
+		ID:         component.ID,
+		Name:       name,
+		Priority:   priority,
+		State:      keycloakApi.KeyStateActive,
+		CreatedAt:  metav1.Time{Time: k.now},
+		ConfigHash: configHash,
+	}
+
+	if key.ID != "" {
+		return key, nil
+	}
+
+	// The key is returned even if its ID can't be retrieved, so it is tracked in the status.
+	created, err := getKeyComponent(ctx, k.kClient, k.realmName, k.realmID, key)
+	if err != nil {
+		return key, fmt.Errorf("unable to get created key %s: %w", name, err)
+	}
+
+	key.ID = created.ID
+
+	return key, nil
+}
+
+// getKeyComponent returns the key component by ID or by name among the realm components if the ID is unknown.
+func getKeyComponent(
+	ctx context.Context,
+	kClient keycloak.Client,
+	realmName, realmID string,
+	key *keycloakApi.RotatedKey,
+) (*adapter.Component, error) {
+	if key.ID != "" {
+		return kClient.GetComponentByID(ctx, realmName, key.ID)
+	}
+
+	return kClient.GetComponentByParent(ctx, realmName, realmID, key.Name)
+}
+
+// deleteKeyComponent deletes the key component. Missing component is not an error.
+func deleteKeyComponent(
+	ctx context.Context,
+	kClient keycloak.Client,
+	realmName, realmID string,
+	key *keycloakApi.RotatedKey,
+) error {
+	id := key.ID
+
+	if id == "" {
+		component, err := getKeyComponent(ctx, kClient, realmName, realmID, key)
+		if err != nil {
+			if adapter.IsErrNotFound(err) {
+				return nil
+			}
+
+			return err
+		}
+
+		id = component.ID
+	}
+
+	return kClient.DeleteComponentByID(ctx, realmName, id)
+}
+
+// retireKey makes the previous key passive after the grace period and deletes it after the retention period.
+// It returns false if the key is deleted.
+func (k *keyRotator) retireKey(
+	ctx context.Context,
+	key *keycloakApi.RotatedKey,
+	rotatedAt time.Time,
+	rotation *keycloakApi.KeycloakRealmKeyRotation,
+) (bool, error) {
+	log := ctrl.LoggerFrom(ctx).WithValues("name", key.Name)
+
+	if key.State == keycloakApi.KeyStateActive {
+		if k.now.Before(rotatedAt.Add(rotation.GetGracePeriod())) {
+			return true, nil
+		}
+
+		component, err := getKeyComponent(ctx, k.kClient, k.realmName, k.realmID, key)
+		if err != nil {
+			if adapter.IsErrNotFound(err) {
+				log.Info("Realm key not found, removing it from the status")
+				return false, nil
+			}
+
+			return true, fmt.Errorf("unable to get key: %w", err)
+		}
+
+		log.Info("Making realm key passive")
+
+		component.Config["active"] = []string{strconv.FormatBool(false)}
+		if err = k.kClient.UpdateComponent(ctx, k.realmName, component); err != nil {
+			return true, fmt.Errorf("unable to make key passive: %w", err)
+		}
+
+		key.State = keycloakApi.KeyStatePassive
+		key.PassiveSince = &metav1.Time{Time: k.now}
+
+		return true, nil
+	}
+
+	if key.PassiveSince != nil && k.now.Before(key.PassiveSince.Add(rotation.GetRetentionPeriod())) {
+		return true, nil
+	}
+
+	log.Info("Deleting realm key")
+
+	if err := deleteKeyComponent(ctx, k.kClient, k.realmName, k.realmID, key); err != nil {
+		return true, fmt.Errorf("unable to delete key: %w", err)
+	}
+
+	return false, nil
+}
+
+// nextStep returns the duration until the nearest rotation, demotion or deletion of the keys.
+func (k *keyRotator) nextStep(rotation *keycloakApi.KeycloakRealmKeyRotation) time.Duration {
+	keys := rotation.Status.Keys
+	newest := keys[len(keys)-1]
+
+	var steps []time.Time
+
+	if rotation.Status.NextRotation != nil {
+		steps = append(steps, rotation.Status.NextRotation.Time)
+	}
+
+	for _, key := range keys[:len(keys)-1] {
+		if key.State == keycloakApi.KeyStateActive {
+			steps = append(steps, newest.CreatedAt.Add(rotation.GetGracePeriod()))
+		} else if key.PassiveSince != nil {
+			steps = append(steps, key.PassiveSince.Add(rotation.GetRetentionPeriod()))
+		}
+	}
+
+	var next time.Duration
+
+	for _, step := range steps {
+		d := step.Sub(k.now)
+		if d <= 0 {
+			// The step has failed, it is retried by the failure or success requeue.
+			continue
+		}
+
+		if next == 0 || d < next {
+			next = d
+		}
+	}
+
+	return next
+}
+
+func setIfNotEmpty(config map[string][]string, key, value string) {
+	if value != "" {
+		config[key] = []string{value}
+	}
+}
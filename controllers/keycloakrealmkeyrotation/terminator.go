@@ -0,0 +1,62 @@
+package keycloakrealmkeyrotation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+)
+
+type terminator struct {
+	realmName                   string
+	realmID                     string
+	keys                        []keycloakApi.RotatedKey
+	kClient                     keycloak.Client
+	preserveResourcesOnDeletion bool
+}
+
+func makeTerminator(
+	realmName, realmID string,
+	keys []keycloakApi.RotatedKey,
+	kClient keycloak.Client,
+	preserveResourcesOnDeletion bool,
+) *terminator {
+	return &terminator{
+		realmName:                   realmName,
+		realmID:                     realmID,
+		keys:                        keys,
+		kClient:                     kClient,
+		preserveResourcesOnDeletion: preserveResourcesOnDeletion,
+	}
+}
+
+// DeleteResource deletes all keys managed by the rotation.
+func (t *terminator) DeleteResource(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx)
+	if t.preserveResourcesOnDeletion {
+		log.Info("PreserveResourcesOnDeletion is enabled, skipping deletion.")
+		return nil
+	}
+
+	log.Info("Start deleting KeycloakRealmKeyRotation keys")
+
+	var errs []error
+
+	for i := range t.keys {
+		if err := deleteKeyComponent(ctx, t.kClient, t.realmName, t.realmID, &t.keys[i]); err != nil {
+			errs = append(errs, fmt.Errorf("unable to delete key %s: %w", t.keys[i].Name, err))
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	log.Info("KeycloakRealmKeyRotation deletion done")
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package keycloakrealmkeyrotation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+func TestTerminator_DeleteResource(t *testing.T) {
+	kClient := new(adapter.Mock)
+	kClient.On("DeleteComponentByID", "realm", "key-1-id").Return(nil)
+	kClient.On("GetComponentByParent", "realm", "realm-id", "key-2").Return(&adapter.Component{ID: "key-2-id"}, nil)
+	kClient.On("DeleteComponentByID", "realm", "key-2-id").Return(errors.New("fatal"))
+
+	term := makeTerminator("realm", "realm-id", []keycloakApi.RotatedKey{
+		{Name: "key-1", ID: "key-1-id"},
+		{Name: "key-2"},
+	}, kClient, false)
+
+	require.ErrorContains(t, term.DeleteResource(context.Background()), "unable to delete key key-2")
+	kClient.AssertExpectations(t)
+}
+
+func TestTerminatorSkipDeletion(t *testing.T) {
+	term := makeTerminator("realm", "realm-id", []keycloakApi.RotatedKey{{Name: "key-1"}}, nil, true)
+
+	require.NoError(t, term.DeleteResource(context.Background()))
+}
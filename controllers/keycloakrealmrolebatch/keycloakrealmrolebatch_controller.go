@@ -2,7 +2,11 @@ package keycloakrealmrolebatch
 
 import (
 	"context"
+	goerrors "errors"
 	"fmt"
+	"reflect"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/Nerzal/gocloak/v12"
@@ -14,8 +18,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/epam/edp-keycloak-operator/api/common"
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
@@ -23,7 +30,12 @@ import (
 	"github.com/epam/edp-keycloak-operator/pkg/objectmeta"
 )
 
-const keyCloakRealmRoleBatchOperatorFinalizerName = "keycloak.realmrolebatch.operator.finalizer.name"
+const (
+	keyCloakRealmRoleBatchOperatorFinalizerName = "keycloak.realmrolebatch.operator.finalizer.name"
+
+	// maxRoleWorkers is a maximum number of child roles processed in parallel.
+	maxRoleWorkers = 10
+)
 
 type Helper interface {
 	TryToDelete(ctx context.Context, obj client.Object, terminator helper.Terminator, finalizer string) (isDeleted bool, resultErr error)
@@ -51,8 +63,15 @@ func (r *ReconcileKeycloakRealmRoleBatch) SetupWithManager(mgr ctrl.Manager, suc
 		UpdateFunc: helper.IsFailuresUpdated,
 	}
 
+	// Child roles are watched to refresh their readiness in the batch status.
+	// Realm is a controller owner of the child roles, so the batch owner reference is not a controller one.
 	err := ctrl.NewControllerManagedBy(mgr).
 		For(&keycloakApi.KeycloakRealmRoleBatch{}, builder.WithPredicates(pred)).
+		Watches(
+			&source.Kind{Type: &keycloakApi.KeycloakRealmRole{}},
+			&handler.EnqueueRequestForOwner{OwnerType: &keycloakApi.KeycloakRealmRoleBatch{}},
+			builder.WithPredicates(predicate.Funcs{UpdateFunc: isRoleStatusUpdated}),
+		).
 		Complete(r)
 	if err != nil {
 		return fmt.Errorf("failed to setup KeycloakRealmRoleBatch controller: %w", err)
@@ -61,6 +80,21 @@ func (r *ReconcileKeycloakRealmRoleBatch) SetupWithManager(mgr ctrl.Manager, suc
 	return nil
 }
 
+// isRoleStatusUpdated returns true if status value of the child role is changed.
+func isRoleStatusUpdated(e event.UpdateEvent) bool {
+	oldRole, ok := e.ObjectOld.(*keycloakApi.KeycloakRealmRole)
+	if !ok {
+		return false
+	}
+
+	newRole, ok := e.ObjectNew.(*keycloakApi.KeycloakRealmRole)
+	if !ok {
+		return false
+	}
+
+	return oldRole.Status.Value != newRole.Status.Value
+}
+
 //+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealmrolebatches,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealmrolebatches/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealmrolebatches/finalizers,verbs=update
@@ -128,7 +162,7 @@ func (r *ReconcileKeycloakRealmRoleBatch) removeRoles(ctx context.Context, batch
 		specRoles      = make(map[string]struct{})
 	)
 
-	if err := r.client.List(ctx, &namespaceRoles); err != nil {
+	if err := r.client.List(ctx, &namespaceRoles, client.InNamespace(batch.Namespace)); err != nil {
 		return errors.Wrap(err, "unable to get keycloak realm roles")
 	}
 
@@ -137,67 +171,193 @@ func (r *ReconcileKeycloakRealmRoleBatch) removeRoles(ctx context.Context, batch
 	}
 
 	for i := range namespaceRoles.Items {
-		if _, ok := specRoles[namespaceRoles.Items[i].Name]; !ok && r.isOwner(batch, &namespaceRoles.Items[i]) {
-			if err := r.client.Delete(ctx, &namespaceRoles.Items[i]); err != nil {
-				return errors.Wrap(err, "unable to delete keycloak realm role")
+		role := &namespaceRoles.Items[i]
+
+		if _, ok := specRoles[role.Name]; ok || !r.isOwner(batch, role) {
+			continue
+		}
+
+		if batch.GetDeletionPolicy() == keycloakApi.RoleBatchDeletionPolicyOrphan {
+			if err := r.orphanRole(ctx, batch, role); err != nil {
+				return err
 			}
+
+			continue
+		}
+
+		if err := r.client.Delete(ctx, role); err != nil {
+			return errors.Wrap(err, "unable to delete keycloak realm role")
+		}
+	}
+
+	return nil
+}
+
+// orphanRole removes batch owner reference from the child role, so it is no longer managed by the batch.
+func (r *ReconcileKeycloakRealmRoleBatch) orphanRole(
+	ctx context.Context,
+	batch *keycloakApi.KeycloakRealmRoleBatch,
+	role *keycloakApi.KeycloakRealmRole,
+) error {
+	refs := make([]metav1.OwnerReference, 0, len(role.OwnerReferences))
+
+	for _, owner := range role.OwnerReferences {
+		if owner.Kind == batch.Kind && owner.Name == batch.Name && owner.UID == batch.UID {
+			continue
 		}
+
+		refs = append(refs, owner)
+	}
+
+	role.OwnerReferences = refs
+
+	if err := r.client.Update(ctx, role); err != nil {
+		return fmt.Errorf("unable to orphan keycloak realm role %s: %w", role.Name, err)
+	}
+
+	return nil
+}
+
+// updateRoleSpec updates spec of the child role if it is changed in the batch.
+func (r *ReconcileKeycloakRealmRoleBatch) updateRoleSpec(
+	ctx context.Context,
+	role *keycloakApi.KeycloakRealmRole,
+	spec *keycloakApi.KeycloakRealmRoleSpec,
+) error {
+	if reflect.DeepEqual(role.Spec, *spec) {
+		return nil
+	}
+
+	role.Spec = *spec
+
+	if err := r.client.Update(ctx, role); err != nil {
+		return fmt.Errorf("unable to update child role %s: %w", role.Name, err)
 	}
 
 	return nil
 }
 
+// putRoles creates or updates child roles of the batch in parallel.
+// It returns successfully put roles and status of each role from the batch.
 func (r *ReconcileKeycloakRealmRoleBatch) putRoles(
 	ctx context.Context,
 	batch *keycloakApi.KeycloakRealmRoleBatch,
-) (roles []keycloakApi.KeycloakRealmRole, resultErr error) {
+) ([]keycloakApi.KeycloakRealmRole, []keycloakApi.BatchRoleStatus, error) {
 	log := ctrl.LoggerFrom(ctx)
 	log.Info("Start putting keycloak cr role batch")
 
-	for _, role := range batch.Spec.Roles {
-		roleName := batch.FormattedRoleName(role.Name)
+	type putRoleResult struct {
+		role *keycloakApi.KeycloakRealmRole
+		err  error
+	}
+
+	var (
+		results = make([]putRoleResult, len(batch.Spec.Roles))
+		workers = make(chan struct{}, maxRoleWorkers)
+		wg      sync.WaitGroup
+	)
+
+	for i := range batch.Spec.Roles {
+		wg.Add(1)
+
+		workers <- struct{}{}
+
+		go func(i int) {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+
+			results[i].role, results[i].err = r.putRole(ctx, batch, &batch.Spec.Roles[i])
+		}(i)
+	}
+
+	wg.Wait()
+
+	roles := make([]keycloakApi.KeycloakRealmRole, 0, len(results))
+	statuses := make([]keycloakApi.BatchRoleStatus, 0, len(results))
+	errs := make([]string, 0)
+
+	for i, res := range results {
+		status := keycloakApi.BatchRoleStatus{
+			Name:         batch.Spec.Roles[i].Name,
+			ResourceName: batch.FormattedRoleName(batch.Spec.Roles[i].Name),
+		}
 
-		var crRole keycloakApi.KeycloakRealmRole
+		if res.err != nil {
+			status.Message = res.err.Error()
+			errs = append(errs, fmt.Sprintf("role %s: %s", status.Name, res.err))
+		} else {
+			roles = append(roles, *res.role)
+			status.Ready = res.role.Status.Value == helper.StatusOK
 
-		err := r.client.Get(ctx, types.NamespacedName{Namespace: batch.Namespace, Name: roleName}, &crRole)
-		if err != nil && !k8sErrors.IsNotFound(err) {
-			return nil, errors.Wrap(err, "unable to check batch role")
-		} else if err == nil {
-			if r.isOwner(batch, &crRole) {
-				log.Info("Role already created")
-				roles = append(roles, crRole)
-				continue
+			if !status.Ready {
+				status.Message = res.role.Status.Value
 			}
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	if len(errs) > 0 {
+		return roles, statuses, errors.New(strings.Join(errs, "; "))
+	}
+
+	log.Info("Realm role batch put successfully")
+
+	return roles, statuses, nil
+}
+
+// putRole creates child role of the batch or updates it if it is already created.
+func (r *ReconcileKeycloakRealmRoleBatch) putRole(
+	ctx context.Context,
+	batch *keycloakApi.KeycloakRealmRoleBatch,
+	role *keycloakApi.BatchRole,
+) (*keycloakApi.KeycloakRealmRole, error) {
+	roleName := batch.FormattedRoleName(role.Name)
+	roleSpec := keycloakApi.KeycloakRealmRoleSpec{
+		Name:                             role.Name,
+		RealmRef:                         batch.GetRealmRef(),
+		Composite:                        role.Composite,
+		Composites:                       role.Composites,
+		CompositesClientRoles:            role.CompositesClientRoles,
+		CompositesReconciliationStrategy: role.CompositesReconciliationStrategy,
+		Description:                      role.Description,
+		Attributes:                       role.Attributes,
+		IsDefault:                        role.IsDefault,
+	}
 
+	var crRole keycloakApi.KeycloakRealmRole
+
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: batch.Namespace, Name: roleName}, &crRole)
+	if err != nil && !k8sErrors.IsNotFound(err) {
+		return nil, errors.Wrap(err, "unable to check batch role")
+	} else if err == nil {
+		if !r.isOwner(batch, &crRole) {
 			return nil, errors.New("one of batch role already exists")
 		}
 
-		newRole := keycloakApi.KeycloakRealmRole{
-			ObjectMeta: metav1.ObjectMeta{Name: roleName,
-				Namespace: batch.Namespace,
-				OwnerReferences: []metav1.OwnerReference{
-					{Name: batch.Name, Kind: batch.Kind, BlockOwnerDeletion: gocloak.BoolP(true), UID: batch.UID,
-						APIVersion: batch.APIVersion},
-				}},
-			Spec: keycloakApi.KeycloakRealmRoleSpec{
-				Name:        role.Name,
-				RealmRef:    batch.GetRealmRef(),
-				Composite:   role.Composite,
-				Composites:  role.Composites,
-				Description: role.Description,
-				Attributes:  role.Attributes,
-				IsDefault:   role.IsDefault,
-			}}
-		if err := r.client.Create(ctx, &newRole); err != nil {
-			return nil, errors.Wrap(err, "unable to create child role from batch")
+		if err := r.updateRoleSpec(ctx, &crRole, &roleSpec); err != nil {
+			return nil, err
 		}
 
-		roles = append(roles, newRole)
+		return &crRole, nil
 	}
 
-	log.Info("Realm role batch put successfully")
+	newRole := keycloakApi.KeycloakRealmRole{
+		ObjectMeta: metav1.ObjectMeta{Name: roleName,
+			Namespace: batch.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				{Name: batch.Name, Kind: batch.Kind, BlockOwnerDeletion: gocloak.BoolP(true), UID: batch.UID,
+					APIVersion: batch.APIVersion},
+			}},
+		Spec: roleSpec,
+	}
+	if err := r.client.Create(ctx, &newRole); err != nil {
+		return nil, errors.Wrap(err, "unable to create child role from batch")
+	}
 
-	return
+	return &newRole, nil
 }
 
 func (r *ReconcileKeycloakRealmRoleBatch) tryReconcile(ctx context.Context, batch *keycloakApi.KeycloakRealmRoleBatch) error {
@@ -206,13 +366,20 @@ func (r *ReconcileKeycloakRealmRoleBatch) tryReconcile(ctx context.Context, batc
 		return fmt.Errorf("unable to set realm owner ref: %w", err)
 	}
 
-	createdRoles, err := r.putRoles(ctx, batch)
-	if err != nil {
-		return errors.Wrap(err, "unable to put roles batch")
+	createdRoles, statuses, putErr := r.putRoles(ctx, batch)
+	batch.Status.Roles = statuses
+
+	if putErr != nil {
+		putErr = errors.Wrap(putErr, "unable to put roles batch")
+	}
+
+	// Roles removed from the batch are pruned even if some of the batch roles are failed.
+	if err = r.removeRoles(ctx, batch); err != nil {
+		return goerrors.Join(putErr, errors.Wrap(err, "unable to delete roles"))
 	}
 
-	if err := r.removeRoles(ctx, batch); err != nil {
-		return errors.Wrap(err, "unable to delete roles")
+	if putErr != nil {
+		return putErr
 	}
 
 	if _, err := r.helper.TryToDelete(
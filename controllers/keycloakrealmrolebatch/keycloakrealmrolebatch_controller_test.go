@@ -8,8 +8,10 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	testifymock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -17,11 +19,13 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	k8sCLient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/epam/edp-keycloak-operator/api/common"
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
 	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	helpermock "github.com/epam/edp-keycloak-operator/controllers/helper/mocks"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
 )
 
@@ -248,4 +252,157 @@ func TestReconcileKeycloakRealmRoleBatch_ReconcileFailure(t *testing.T) {
 		t.Log(checkBatch.Status.Value)
 		t.Fatal("batch status not updated on failure")
 	}
+
+	require.Equal(t, []keycloakApi.BatchRoleStatus{
+		{Name: "role1", ResourceName: "batch1-role1"},
+		{Name: "role2", ResourceName: "batch1-role2", Message: "one of batch role already exists"},
+	}, checkBatch.Status.Roles)
+}
+
+func TestReconcileKeycloakRealmRoleBatch_ReconcileOrphan(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(scheme))
+
+	ns := "security"
+	batch := keycloakApi.KeycloakRealmRoleBatch{
+		TypeMeta: metav1.TypeMeta{Kind: "KeycloakRealmRoleBatch", APIVersion: "v1.edp.epam.com/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "batch1",
+			Namespace: ns,
+			UID:       "batch-uid",
+		},
+		Spec: keycloakApi.KeycloakRealmRoleBatchSpec{
+			Roles: []keycloakApi.BatchRole{
+				{Name: "role1"},
+			},
+			RealmRef: common.RealmRef{
+				Kind: keycloakApi.KeycloakRealmKind,
+				Name: "realm1",
+			},
+			DeletionPolicy: keycloakApi.RoleBatchDeletionPolicyOrphan,
+		},
+	}
+
+	readyRole := keycloakApi.KeycloakRealmRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "batch1-role1", Namespace: ns,
+			OwnerReferences: []metav1.OwnerReference{{Name: "batch1", Kind: batch.Kind, UID: batch.UID}}},
+		Spec: keycloakApi.KeycloakRealmRoleSpec{
+			Name:     "role1",
+			RealmRef: batch.Spec.RealmRef,
+		},
+		Status: keycloakApi.KeycloakRealmRoleStatus{Value: helper.StatusOK},
+	}
+	removedRole := keycloakApi.KeycloakRealmRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "batch1-role2", Namespace: ns,
+			OwnerReferences: []metav1.OwnerReference{{Name: "batch1", Kind: batch.Kind, UID: batch.UID}}},
+		Spec: keycloakApi.KeycloakRealmRoleSpec{Name: "role2"},
+	}
+
+	client := fake.NewClientBuilder().WithScheme(scheme).
+		WithRuntimeObjects(&batch, &readyRole, &removedRole).Build()
+
+	h := helpermock.NewControllerHelper(t)
+	h.On("SetRealmOwnerRef", testifymock.Anything, testifymock.Anything).Return(nil)
+	h.On("TryToDelete", testifymock.Anything, testifymock.Anything, testifymock.Anything, testifymock.Anything).
+		Return(false, nil)
+
+	rkr := ReconcileKeycloakRealmRoleBatch{
+		client:                  client,
+		helper:                  h,
+		successReconcileTimeout: time.Hour,
+	}
+
+	res, err := rkr.Reconcile(context.Background(), reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "batch1",
+			Namespace: ns,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, time.Hour, res.RequeueAfter)
+
+	var checkRole keycloakApi.KeycloakRealmRole
+	err = client.Get(context.Background(), types.NamespacedName{Namespace: ns, Name: "batch1-role2"}, &checkRole)
+	require.NoError(t, err, "orphaned role must not be deleted")
+	require.Empty(t, checkRole.OwnerReferences)
+
+	var checkBatch keycloakApi.KeycloakRealmRoleBatch
+	err = client.Get(context.Background(), types.NamespacedName{Namespace: ns, Name: "batch1"}, &checkBatch)
+	require.NoError(t, err)
+	require.Equal(t, helper.StatusOK, checkBatch.Status.Value)
+	require.Equal(t, []keycloakApi.BatchRoleStatus{
+		{Name: "role1", ResourceName: "batch1-role1", Ready: true},
+	}, checkBatch.Status.Roles)
+}
+
+func TestReconcileKeycloakRealmRoleBatch_ReconcilePruneOnFailure(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(scheme))
+
+	ns := "security"
+	batch := keycloakApi.KeycloakRealmRoleBatch{
+		TypeMeta: metav1.TypeMeta{Kind: "KeycloakRealmRoleBatch", APIVersion: "v1.edp.epam.com/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "batch1",
+			Namespace: ns,
+			UID:       "batch-uid",
+		},
+		Spec: keycloakApi.KeycloakRealmRoleBatchSpec{
+			Roles: []keycloakApi.BatchRole{
+				{Name: "role1"},
+			},
+			RealmRef: common.RealmRef{
+				Kind: keycloakApi.KeycloakRealmKind,
+				Name: "realm1",
+			},
+		},
+	}
+
+	conflictRole := keycloakApi.KeycloakRealmRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "batch1-role1", Namespace: ns},
+		Spec:       keycloakApi.KeycloakRealmRoleSpec{Name: "role1"},
+	}
+	removedRole := keycloakApi.KeycloakRealmRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "batch1-role2", Namespace: ns,
+			OwnerReferences: []metav1.OwnerReference{{Name: "batch1", Kind: batch.Kind, UID: batch.UID}}},
+		Spec: keycloakApi.KeycloakRealmRoleSpec{Name: "role2"},
+	}
+
+	client := fake.NewClientBuilder().WithScheme(scheme).
+		WithRuntimeObjects(&batch, &conflictRole, &removedRole).Build()
+
+	h := helpermock.NewControllerHelper(t)
+	h.On("SetRealmOwnerRef", testifymock.Anything, testifymock.Anything).Return(nil)
+	h.On("SetFailureCount", testifymock.Anything).Return(time.Minute)
+
+	rkr := ReconcileKeycloakRealmRoleBatch{
+		client: client,
+		helper: h,
+	}
+
+	res, err := rkr.Reconcile(context.Background(), reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "batch1",
+			Namespace: ns,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, res.RequeueAfter)
+
+	var checkRole keycloakApi.KeycloakRealmRole
+	err = client.Get(context.Background(), types.NamespacedName{Namespace: ns, Name: "batch1-role2"}, &checkRole)
+	require.True(t, k8sErrors.IsNotFound(err), "role removed from the batch must be deleted")
+
+	var checkBatch keycloakApi.KeycloakRealmRoleBatch
+	err = client.Get(context.Background(), types.NamespacedName{Namespace: ns, Name: "batch1"}, &checkBatch)
+	require.NoError(t, err)
+	require.Contains(t, checkBatch.Status.Value, "one of batch role already exists")
+}
+
+func Test_isRoleStatusUpdated(t *testing.T) {
+	oldRole := &keycloakApi.KeycloakRealmRole{Status: keycloakApi.KeycloakRealmRoleStatus{Value: "in progress"}}
+	newRole := &keycloakApi.KeycloakRealmRole{Status: keycloakApi.KeycloakRealmRoleStatus{Value: helper.StatusOK}}
+
+	require.True(t, isRoleStatusUpdated(event.UpdateEvent{ObjectOld: oldRole, ObjectNew: newRole}))
+	require.False(t, isRoleStatusUpdated(event.UpdateEvent{ObjectOld: newRole, ObjectNew: newRole}))
 }
@@ -11,12 +11,15 @@ import (
 	coreV1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/epam/edp-keycloak-operator/api/common"
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
@@ -24,9 +27,14 @@ import (
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
 	"github.com/epam/edp-keycloak-operator/pkg/objectmeta"
+	"github.com/epam/edp-keycloak-operator/pkg/secretref"
 )
 
-const finalizer = "keycloak.realmuser.operator.finalizer.name"
+const (
+	finalizer = "keycloak.realmuser.operator.finalizer.name"
+
+	eventReasonSessionsRevoked = "SessionsRevoked"
+)
 
 type Helper interface {
 	SetFailureCount(fc helper.FailureCountable) time.Duration
@@ -37,8 +45,9 @@ type Helper interface {
 }
 
 type Reconcile struct {
-	client client.Client
-	helper Helper
+	client   client.Client
+	helper   Helper
+	recorder record.EventRecorder
 }
 
 func NewReconcile(client client.Client, helper Helper) *Reconcile {
@@ -49,6 +58,8 @@ func NewReconcile(client client.Client, helper Helper) *Reconcile {
 }
 
 func (r *Reconcile) SetupWithManager(mgr ctrl.Manager) error {
+	r.recorder = mgr.GetEventRecorderFor("keycloakrealmuser-controller")
+
 	pred := predicate.Funcs{
 		UpdateFunc: isSpecUpdated,
 		DeleteFunc: func(deleteEvent event.DeleteEvent) bool {
@@ -56,8 +67,23 @@ func (r *Reconcile) SetupWithManager(mgr ctrl.Manager) error {
 		},
 	}
 
+	if err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&keycloakApi.KeycloakRealmUser{},
+		secretref.SecretNamesIndexField,
+		indexPasswordSecretName,
+	); err != nil {
+		return fmt.Errorf("failed to index KeycloakRealmUser by password secret: %w", err)
+	}
+
 	err := ctrl.NewControllerManagedBy(mgr).
 		For(&keycloakApi.KeycloakRealmUser{}, builder.WithPredicates(pred)).
+		Watches(
+			&source.Kind{Type: &coreV1.Secret{}},
+			handler.EnqueueRequestsFromMapFunc(secretref.MapSecretToRequests(r.client, func() client.ObjectList {
+				return &keycloakApi.KeycloakRealmUserList{}
+			})),
+		).
 		Complete(r)
 	if err != nil {
 		return fmt.Errorf("failed to setup KeycloakRealmUser controller: %w", err)
@@ -66,6 +92,15 @@ func (r *Reconcile) SetupWithManager(mgr ctrl.Manager) error {
 	return nil
 }
 
+func indexPasswordSecretName(obj client.Object) []string {
+	user, ok := obj.(*keycloakApi.KeycloakRealmUser)
+	if !ok || !user.HasPasswordSecret() {
+		return nil
+	}
+
+	return []string{user.Spec.PasswordSecret.Name}
+}
+
 func isSpecUpdated(e event.UpdateEvent) bool {
 	oo, ok := e.ObjectOld.(*keycloakApi.KeycloakRealmUser)
 	if !ok {
@@ -84,6 +119,8 @@ func isSpecUpdated(e event.UpdateEvent) bool {
 //+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealmusers,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealmusers/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealmusers/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",namespace=placeholder,resources=secrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",namespace=placeholder,resources=events,verbs=create;patch
 
 // Reconcile is a loop for reconciling KeycloakRealmUser object.
 func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (result reconcile.Result, resultErr error) {
@@ -150,7 +187,7 @@ func (r *Reconcile) tryReconcile(ctx context.Context, instance *keycloakApi.Keyc
 		return fmt.Errorf("unable to get keycloak realm from ref: %w", err)
 	}
 
-	password, getPasswordErr := r.getPassword(ctx, instance)
+	password, passwordSecretHash, getPasswordErr := r.getPassword(ctx, instance)
 	if getPasswordErr != nil {
 		return fmt.Errorf("unable to get password: %w", getPasswordErr)
 	}
@@ -159,25 +196,40 @@ func (r *Reconcile) tryReconcile(ctx context.Context, instance *keycloakApi.Keyc
 		Username:            instance.Spec.Username,
 		Groups:              instance.Spec.Groups,
 		Roles:               instance.Spec.Roles,
+		ClientRoles:         makeClientRoles(instance.Spec.ClientRoles),
 		RequiredUserActions: instance.Spec.RequiredUserActions,
 		LastName:            instance.Spec.LastName,
 		FirstName:           instance.Spec.FirstName,
 		EmailVerified:       instance.Spec.EmailVerified,
 		Enabled:             instance.Spec.Enabled,
 		Email:               instance.Spec.Email,
-		Attributes:          instance.Spec.Attributes,
+		Attributes:          instance.GetAttributes(),
 		Password:            password,
+		FederatedIdentities: makeFederatedIdentities(instance.Spec.FederatedIdentities),
+		PasswordTemporary:   instance.Spec.PasswordSecret.Temporary,
+		// Password is reset only when the password in the secret is changed after it was applied.
+		// The password of the new user is set on creation.
+		ResetPassword: passwordSecretHash != "" &&
+			instance.Status.PasswordSecretHash != "" &&
+			passwordSecretHash != instance.Status.PasswordSecretHash,
+		// Disabling of the existing user is required to revoke its sessions.
+		UpdateEnabled: instance.Spec.RevokeSessionsOnDisable,
 	}, instance.GetReconciliationStrategy() == keycloakApi.ReconciliationStrategyAddOnly); err != nil {
 		return errors.Wrap(err, "unable to sync realm user")
 	}
 
+	instance.Status.PasswordSecretHash = passwordSecretHash
+
+	if err := r.revokeSessions(ctx, instance, kClient, gocloak.PString(realm.Realm)); err != nil {
+		return err
+	}
+
 	if instance.Spec.KeepResource {
 		if _, err := r.helper.TryToDelete(ctx, instance,
-			makeTerminator(
+			r.makeTerminator(
+				instance,
 				gocloak.PString(realm.Realm),
-				instance.Spec.Username,
 				kClient,
-				objectmeta.PreserveResourcesOnDeletion(instance),
 			),
 			finalizer,
 		); err != nil {
@@ -192,32 +244,107 @@ func (r *Reconcile) tryReconcile(ctx context.Context, instance *keycloakApi.Keyc
 	return nil
 }
 
-func (r *Reconcile) getPassword(ctx context.Context, instance *keycloakApi.KeycloakRealmUser) (string, error) {
+// revokeSessions revokes all sessions of the disabled user.
+// Sessions are revoked once after the user is disabled.
+func (r *Reconcile) revokeSessions(
+	ctx context.Context,
+	instance *keycloakApi.KeycloakRealmUser,
+	kClient keycloak.Client,
+	realmName string,
+) error {
+	if instance.Spec.Enabled || !instance.Spec.RevokeSessionsOnDisable {
+		instance.Status.SessionsRevoked = false
+		return nil
+	}
+
+	if instance.Status.SessionsRevoked {
+		return nil
+	}
+
+	if err := kClient.LogoutRealmUser(ctx, realmName, instance.Spec.Username); err != nil {
+		return fmt.Errorf("unable to revoke user sessions: %w", err)
+	}
+
+	r.recorder.Event(instance, coreV1.EventTypeNormal, eventReasonSessionsRevoked, "User is disabled, user sessions are revoked")
+
+	instance.Status.SessionsRevoked = true
+
+	return nil
+}
+
+func (r *Reconcile) makeTerminator(instance *keycloakApi.KeycloakRealmUser, realmName string, kClient keycloak.Client) *terminator {
+	t := makeTerminator(
+		realmName,
+		instance.Spec.Username,
+		kClient,
+		objectmeta.PreserveResourcesOnDeletion(instance),
+	)
+
+	if instance.Spec.RevokeSessionsOnDisable {
+		t.withSessionsRevocation(instance, r.recorder)
+	}
+
+	return t
+}
+
+func makeClientRoles(clientRoles []keycloakApi.ClientRole) map[string][]string {
+	if len(clientRoles) == 0 {
+		return nil
+	}
+
+	res := make(map[string][]string, len(clientRoles))
+	for _, cr := range clientRoles {
+		res[cr.ClientID] = cr.Roles
+	}
+
+	return res
+}
+
+func makeFederatedIdentities(identities []keycloakApi.FederatedIdentity) []adapter.FederatedIdentity {
+	if len(identities) == 0 {
+		return nil
+	}
+
+	res := make([]adapter.FederatedIdentity, 0, len(identities))
+	for _, fi := range identities {
+		res = append(res, adapter.FederatedIdentity{
+			IdentityProvider: fi.IdentityProvider,
+			UserID:           fi.UserID,
+			UserName:         fi.UserName,
+		})
+	}
+
+	return res
+}
+
+// getPassword returns the user password and the hash of the password from the secret.
+// The hash is empty if the password is set in the spec.
+func (r *Reconcile) getPassword(ctx context.Context, instance *keycloakApi.KeycloakRealmUser) (password, secretHash string, err error) {
 	log := ctrl.LoggerFrom(ctx)
 
-	if instance.Spec.PasswordSecret.Name != "" && instance.Spec.PasswordSecret.Key != "" {
+	if instance.HasPasswordSecret() {
 		secret := &coreV1.Secret{}
 		if err := r.client.Get(ctx, types.NamespacedName{Name: instance.Spec.PasswordSecret.Name, Namespace: instance.Namespace}, secret); err != nil {
 			if k8sErrors.IsNotFound(err) {
-				return "", errors.Wrapf(err, "secret %s not found", instance.Spec.PasswordSecret.Name)
+				return "", "", errors.Wrapf(err, "secret %s not found", instance.Spec.PasswordSecret.Name)
 			}
 
-			return "", errors.Wrapf(err, "unable to get secret %s", instance.Spec.PasswordSecret.Name)
+			return "", "", errors.Wrapf(err, "unable to get secret %s", instance.Spec.PasswordSecret.Name)
 		}
 
 		passwordBytes, ok := secret.Data[instance.Spec.PasswordSecret.Key]
 		if !ok {
-			return "", errors.Errorf("key %s not found in secret %s", instance.Spec.PasswordSecret.Key, instance.Spec.PasswordSecret.Name)
+			return "", "", errors.Errorf("key %s not found in secret %s", instance.Spec.PasswordSecret.Key, instance.Spec.PasswordSecret.Name)
 		}
 
 		log.Info("Using password from secret", "secret", instance.Spec.PasswordSecret.Name)
 
-		return string(passwordBytes), nil
+		return string(passwordBytes), secretref.HashSecretValue(string(passwordBytes)), nil
 	}
 
 	log.Info("Using password from instance Spec.password")
 
-	return instance.Spec.Password, nil
+	return instance.Spec.Password, "", nil
 }
 
 func (r *Reconcile) applyDefaults(ctx context.Context, instance *keycloakApi.KeycloakRealmUser) (bool, error) {
@@ -14,6 +14,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -23,6 +24,7 @@ import (
 	"github.com/epam/edp-keycloak-operator/controllers/helper"
 	helpermock "github.com/epam/edp-keycloak-operator/controllers/helper/mocks"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/secretref"
 )
 
 func TestNewReconcile_Init(t *testing.T) {
@@ -164,6 +166,7 @@ func (e *TestControllerSuite) TestGetPassword() {
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "my-secret",
 			Namespace: e.namespace,
+			UID:       "secret-uid",
 		},
 		Data: map[string][]byte{
 			"my-key": []byte("my-secret-password"),
@@ -178,23 +181,200 @@ func (e *TestControllerSuite) TestGetPassword() {
 		client: e.k8sClient,
 	}
 
-	password, err := r.getPassword(context.Background(), e.kcRealmUser)
+	password, hash, err := r.getPassword(context.Background(), e.kcRealmUser)
 	assert.NoError(e.T(), err)
 	assert.Equal(e.T(), "my-secret-password", password)
+	assert.Equal(e.T(), secretref.HashSecretValue("my-secret-password"), hash)
 
 	e.kcRealmUser.Spec.PasswordSecret.Key = "non-existent-key"
-	password, err = r.getPassword(context.Background(), e.kcRealmUser)
+	password, _, err = r.getPassword(context.Background(), e.kcRealmUser)
 	assert.Error(e.T(), err)
 	assert.Equal(e.T(), "", password)
 
 	e.kcRealmUser.Spec.PasswordSecret.Name = "non-existent-secret"
-	password, err = r.getPassword(context.Background(), e.kcRealmUser)
+	password, _, err = r.getPassword(context.Background(), e.kcRealmUser)
 	assert.Error(e.T(), err)
 	assert.Equal(e.T(), "", password)
 
 	e.kcRealmUser.Spec.PasswordSecret.Name = ""
 	e.kcRealmUser.Spec.Password = "spec-password"
-	password, err = r.getPassword(context.Background(), e.kcRealmUser)
+	password, hash, err = r.getPassword(context.Background(), e.kcRealmUser)
 	assert.NoError(e.T(), err)
 	assert.Equal(e.T(), "spec-password", password)
+	assert.Empty(e.T(), hash)
+}
+
+func (e *TestControllerSuite) TestReconcilePasswordSecretRotation() {
+	e.kcRealmUser.Spec.KeepResource = true
+	e.kcRealmUser.Spec.PasswordSecret = keycloakApi.PasswordSecret{
+		Name:      "my-secret",
+		Key:       "my-key",
+		Temporary: true,
+	}
+	e.kcRealmUser.Status.PasswordSecretHash = secretref.HashSecretValue("old-password")
+
+	secret := &coreV1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-secret",
+			Namespace: e.namespace,
+			UID:       "secret-uid",
+		},
+		Data: map[string][]byte{
+			"my-key": []byte("new-password"),
+		},
+	}
+
+	e.scheme.AddKnownTypes(coreV1.SchemeGroupVersion, secret)
+	e.k8sClient = fake.NewClientBuilder().WithScheme(e.scheme).WithRuntimeObjects(e.kcRealmUser, secret).Build()
+
+	e.helper.On("SetRealmOwnerRef", testifymock.Anything, testifymock.Anything).Return(nil)
+	e.helper.On("CreateKeycloakClientFromRealmRef", testifymock.Anything, testifymock.Anything).Return(e.kClient, nil)
+	e.helper.On("GetKeycloakRealmFromRef", testifymock.Anything, testifymock.Anything, testifymock.Anything).
+		Return(&gocloak.RealmRepresentation{
+			Realm: gocloak.StringP(e.realmName),
+		}, nil)
+	e.helper.On("TryToDelete", testifymock.Anything, testifymock.Anything, testifymock.Anything, testifymock.Anything).
+		Return(false, nil)
+
+	e.adapterUser.Password = "new-password"
+	e.adapterUser.PasswordTemporary = true
+	e.adapterUser.ResetPassword = true
+	e.kClient.On("SyncRealmUser", e.realmName, e.adapterUser, false).Return(nil)
+
+	r := Reconcile{
+		helper: e.helper,
+		client: e.k8sClient,
+	}
+
+	_, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{
+		Namespace: e.namespace,
+		Name:      e.kcRealmUser.Name,
+	}})
+	assert.NoError(e.T(), err)
+
+	var checkUser keycloakApi.KeycloakRealmUser
+	err = e.k8sClient.Get(context.Background(),
+		types.NamespacedName{Name: e.kcRealmUser.Name, Namespace: e.kcRealmUser.Namespace}, &checkUser)
+	assert.NoError(e.T(), err)
+	assert.Equal(e.T(), secretref.HashSecretValue("new-password"), checkUser.Status.PasswordSecretHash)
+
+	e.kClient.AssertExpectations(e.T())
+}
+
+func (e *TestControllerSuite) TestReconcilePasswordSecretUnchangedPassword() {
+	e.kcRealmUser.Spec.KeepResource = true
+	e.kcRealmUser.Spec.PasswordSecret = keycloakApi.PasswordSecret{
+		Name:      "my-secret",
+		Key:       "my-key",
+		Temporary: true,
+	}
+	e.kcRealmUser.Status.PasswordSecretHash = secretref.HashSecretValue("new-password")
+
+	secret := &coreV1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-secret",
+			Namespace: e.namespace,
+			UID:       "secret-uid",
+			Labels:    map[string]string{"refreshed": "true"},
+		},
+		Data: map[string][]byte{
+			"my-key": []byte("new-password"),
+		},
+	}
+
+	e.scheme.AddKnownTypes(coreV1.SchemeGroupVersion, secret)
+	e.k8sClient = fake.NewClientBuilder().WithScheme(e.scheme).WithRuntimeObjects(e.kcRealmUser, secret).Build()
+
+	e.helper.On("SetRealmOwnerRef", testifymock.Anything, testifymock.Anything).Return(nil)
+	e.helper.On("CreateKeycloakClientFromRealmRef", testifymock.Anything, testifymock.Anything).Return(e.kClient, nil)
+	e.helper.On("GetKeycloakRealmFromRef", testifymock.Anything, testifymock.Anything, testifymock.Anything).
+		Return(&gocloak.RealmRepresentation{
+			Realm: gocloak.StringP(e.realmName),
+		}, nil)
+	e.helper.On("TryToDelete", testifymock.Anything, testifymock.Anything, testifymock.Anything, testifymock.Anything).
+		Return(false, nil)
+
+	e.adapterUser.Password = "new-password"
+	e.adapterUser.PasswordTemporary = true
+	e.adapterUser.ResetPassword = false
+	e.kClient.On("SyncRealmUser", e.realmName, e.adapterUser, false).Return(nil)
+
+	r := Reconcile{
+		helper: e.helper,
+		client: e.k8sClient,
+	}
+
+	_, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{
+		Namespace: e.namespace,
+		Name:      e.kcRealmUser.Name,
+	}})
+	assert.NoError(e.T(), err)
+
+	var checkUser keycloakApi.KeycloakRealmUser
+	err = e.k8sClient.Get(context.Background(),
+		types.NamespacedName{Name: e.kcRealmUser.Name, Namespace: e.kcRealmUser.Namespace}, &checkUser)
+	assert.NoError(e.T(), err)
+	assert.Equal(e.T(), secretref.HashSecretValue("new-password"), checkUser.Status.PasswordSecretHash)
+
+	e.kClient.AssertExpectations(e.T())
+}
+
+func (e *TestControllerSuite) TestReconcileRevokeSessionsOnDisable() {
+	e.kcRealmUser.Spec.KeepResource = true
+	e.kcRealmUser.Spec.RevokeSessionsOnDisable = true
+	e.k8sClient = fake.NewClientBuilder().WithScheme(e.scheme).WithRuntimeObjects(e.kcRealmUser).Build()
+
+	e.helper.On("SetRealmOwnerRef", testifymock.Anything, testifymock.Anything).Return(nil)
+	e.helper.On("CreateKeycloakClientFromRealmRef", testifymock.Anything, testifymock.Anything).Return(e.kClient, nil)
+	e.helper.On("GetKeycloakRealmFromRef", testifymock.Anything, testifymock.Anything, testifymock.Anything).
+		Return(&gocloak.RealmRepresentation{
+			Realm: gocloak.StringP(e.realmName),
+		}, nil)
+	e.helper.On("TryToDelete", testifymock.Anything, testifymock.Anything, testifymock.Anything, testifymock.Anything).
+		Return(false, nil)
+
+	e.adapterUser.UpdateEnabled = true
+	e.kClient.On("SyncRealmUser", e.realmName, e.adapterUser, false).Return(nil)
+	e.kClient.On("LogoutRealmUser", e.realmName, e.kcRealmUser.Spec.Username).Return(nil).Once()
+
+	recorder := record.NewFakeRecorder(10)
+	r := Reconcile{
+		helper:   e.helper,
+		client:   e.k8sClient,
+		recorder: recorder,
+	}
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{
+		Namespace: e.namespace,
+		Name:      e.kcRealmUser.Name,
+	}}
+
+	_, err := r.Reconcile(context.Background(), req)
+	assert.NoError(e.T(), err)
+
+	var checkUser keycloakApi.KeycloakRealmUser
+	err = e.k8sClient.Get(context.Background(), req.NamespacedName, &checkUser)
+	assert.NoError(e.T(), err)
+	assert.True(e.T(), checkUser.Status.SessionsRevoked)
+	assert.Len(e.T(), recorder.Events, 1)
+
+	// Sessions are revoked only once.
+	_, err = r.Reconcile(context.Background(), req)
+	assert.NoError(e.T(), err)
+
+	e.kClient.AssertExpectations(e.T())
+}
+
+func TestIndexPasswordSecretName(t *testing.T) {
+	user := &keycloakApi.KeycloakRealmUser{
+		Spec: keycloakApi.KeycloakRealmUserSpec{
+			PasswordSecret: keycloakApi.PasswordSecret{
+				Name: "my-secret",
+				Key:  "my-key",
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"my-secret"}, indexPasswordSecretName(user))
+	assert.Nil(t, indexPasswordSecretName(&keycloakApi.KeycloakRealmUser{}))
 }
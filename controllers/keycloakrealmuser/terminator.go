@@ -4,15 +4,24 @@ import (
 	"context"
 	"fmt"
 
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
 )
 
 type terminator struct {
 	kClient                     keycloak.Client
 	realmName, userName         string
 	preserveResourcesOnDeletion bool
+
+	// revokeSessions enables revocation of the user sessions before the user deletion.
+	revokeSessions bool
+	object         runtime.Object
+	recorder       record.EventRecorder
 }
 
 func (t *terminator) DeleteResource(ctx context.Context) error {
@@ -24,6 +33,14 @@ func (t *terminator) DeleteResource(ctx context.Context) error {
 
 	log.Info("Start deleting keycloak realm user")
 
+	if t.revokeSessions {
+		if err := t.kClient.LogoutRealmUser(ctx, t.realmName, t.userName); err != nil && !adapter.IsErrNotFound(err) {
+			return fmt.Errorf("unable to revoke realm user sessions: %w", err)
+		}
+
+		t.recorder.Event(t.object, coreV1.EventTypeNormal, eventReasonSessionsRevoked, "User is deleted, user sessions are revoked")
+	}
+
 	if err := t.kClient.DeleteRealmUser(ctx, t.realmName, t.userName); err != nil {
 		return fmt.Errorf("unable to delete realm user %w", err)
 	}
@@ -41,3 +58,9 @@ func makeTerminator(realmName, userName string, kClient keycloak.Client, preserv
 		preserveResourcesOnDeletion: preserveResourcesOnDeletion,
 	}
 }
+
+func (t *terminator) withSessionsRevocation(object runtime.Object, recorder record.EventRecorder) {
+	t.revokeSessions = true
+	t.object = object
+	t.recorder = recorder
+}
@@ -0,0 +1,527 @@
+package keycloakrealmuserbatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/Nerzal/gocloak/v12"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/dto"
+	"github.com/epam/edp-keycloak-operator/pkg/objectmeta"
+)
+
+const (
+	finalizerName = "keycloak.realmuserbatch.operator.finalizer.name"
+
+	// maxUserWorkers is a maximum number of users synced in parallel.
+	maxUserWorkers = 10
+
+	requiredActionUpdatePassword = "UPDATE_PASSWORD"
+
+	// usersConfigMapIndexField is a field index of batches by name of the ConfigMap with users.
+	usersConfigMapIndexField = "spec.usersFrom.name"
+)
+
+type Helper interface {
+	SetFailureCount(fc helper.FailureCountable) time.Duration
+	TryToDelete(ctx context.Context, obj client.Object, terminator helper.Terminator, finalizer string) (isDeleted bool, resultErr error)
+	SetRealmOwnerRef(ctx context.Context, object helper.ObjectWithRealmRef) error
+	GetKeycloakRealmFromRef(ctx context.Context, object helper.ObjectWithRealmRef, kcClient keycloak.Client) (*gocloak.RealmRepresentation, error)
+	CreateKeycloakClientFromRealmRef(ctx context.Context, object helper.ObjectWithRealmRef) (keycloak.Client, error)
+}
+
+type Reconcile struct {
+	client                  client.Client
+	helper                  Helper
+	successReconcileTimeout time.Duration
+}
+
+func NewReconcile(client client.Client, helper Helper) *Reconcile {
+	return &Reconcile{
+		client: client,
+		helper: helper,
+	}
+}
+
+func (r *Reconcile) SetupWithManager(mgr ctrl.Manager, successReconcileTimeout time.Duration) error {
+	r.successReconcileTimeout = successReconcileTimeout
+
+	pred := predicate.Funcs{
+		UpdateFunc: isSpecUpdated,
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&keycloakApi.KeycloakRealmUserBatch{},
+		usersConfigMapIndexField,
+		indexUsersConfigMap,
+	); err != nil {
+		return fmt.Errorf("failed to index KeycloakRealmUserBatch by users ConfigMap: %w", err)
+	}
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&keycloakApi.KeycloakRealmUserBatch{}, builder.WithPredicates(pred)).
+		Watches(
+			&source.Kind{Type: &corev1.ConfigMap{}},
+			handler.EnqueueRequestsFromMapFunc(r.mapConfigMapToBatches),
+		).
+		Complete(r)
+	if err != nil {
+		return fmt.Errorf("failed to setup KeycloakRealmUserBatch controller: %w", err)
+	}
+
+	return nil
+}
+
+// indexUsersConfigMap returns name of the ConfigMap with the users list of the batch.
+func indexUsersConfigMap(obj client.Object) []string {
+	batch, ok := obj.(*keycloakApi.KeycloakRealmUserBatch)
+	if !ok || batch.Spec.UsersFrom == nil {
+		return nil
+	}
+
+	return []string{batch.Spec.UsersFrom.Name}
+}
+
+// mapConfigMapToBatches returns reconcile requests for the batches which read users from the ConfigMap.
+func (r *Reconcile) mapConfigMapToBatches(cm client.Object) []reconcile.Request {
+	var batches keycloakApi.KeycloakRealmUserBatchList
+	if err := r.client.List(
+		context.Background(),
+		&batches,
+		client.InNamespace(cm.GetNamespace()),
+		client.MatchingFields{usersConfigMapIndexField: cm.GetName()},
+	); err != nil {
+		ctrl.Log.Error(err, "unable to list KeycloakRealmUserBatch for ConfigMap", "configmap", cm.GetName())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(batches.Items))
+	for i := range batches.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: batches.Items[i].Namespace, Name: batches.Items[i].Name},
+		})
+	}
+
+	return requests
+}
+
+func isSpecUpdated(e event.UpdateEvent) bool {
+	oo, ok := e.ObjectOld.(*keycloakApi.KeycloakRealmUserBatch)
+	if !ok {
+		return false
+	}
+
+	no, ok := e.ObjectNew.(*keycloakApi.KeycloakRealmUserBatch)
+	if !ok {
+		return false
+	}
+
+	return !reflect.DeepEqual(oo.Spec, no.Spec) ||
+		(oo.GetDeletionTimestamp().IsZero() && !no.GetDeletionTimestamp().IsZero())
+}
+
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealmuserbatches,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealmuserbatches/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealmuserbatches/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",namespace=placeholder,resources=configmaps,verbs=get;list;watch
+
+// Reconcile is a loop for reconciling KeycloakRealmUserBatch object.
+func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (result reconcile.Result, resultErr error) {
+	log := ctrl.LoggerFrom(ctx)
+	log.Info("Reconciling KeycloakRealmUserBatch")
+
+	var instance keycloakApi.KeycloakRealmUserBatch
+	if err := r.client.Get(ctx, request.NamespacedName, &instance); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			log.Info("instance not found")
+			return
+		}
+
+		resultErr = fmt.Errorf("unable to get keycloak realm user batch from k8s: %w", err)
+
+		return
+	}
+
+	if err := r.tryReconcile(ctx, &instance); err != nil {
+		if errors.Is(err, helper.ErrKeycloakIsNotAvailable) {
+			return ctrl.Result{
+				RequeueAfter: helper.RequeueOnKeycloakNotAvailablePeriod,
+			}, nil
+		}
+
+		instance.Status.Value = err.Error()
+		result.RequeueAfter = r.helper.SetFailureCount(&instance)
+
+		log.Error(err, "an error has occurred while handling keycloak realm user batch", "name", request.Name)
+	} else {
+		helper.SetSuccessStatus(&instance)
+		result.RequeueAfter = r.successReconcileTimeout
+	}
+
+	instanceDeleted := !controllerutil.ContainsFinalizer(&instance, finalizerName) &&
+		instance.GetDeletionTimestamp() != nil
+
+	if !instanceDeleted {
+		if err := r.client.Status().Update(ctx, &instance); err != nil {
+			resultErr = err
+		}
+	}
+
+	log.Info("Reconciling KeycloakRealmUserBatch done")
+
+	return
+}
+
+func (r *Reconcile) tryReconcile(ctx context.Context, batch *keycloakApi.KeycloakRealmUserBatch) error {
+	if err := r.helper.SetRealmOwnerRef(ctx, batch); err != nil {
+		return fmt.Errorf("unable to set realm owner ref: %w", err)
+	}
+
+	kClient, err := r.helper.CreateKeycloakClientFromRealmRef(ctx, batch)
+	if err != nil {
+		return fmt.Errorf("unable to create keycloak client from ref: %w", err)
+	}
+
+	realm, err := r.helper.GetKeycloakRealmFromRef(ctx, batch, kClient)
+	if err != nil {
+		return fmt.Errorf("unable to get keycloak realm from ref: %w", err)
+	}
+
+	realmName := gocloak.PString(realm.Realm)
+
+	// Deletion is handled before users are read, so the missing ConfigMap or failed users don't block it.
+	if deleted, err := r.helper.TryToDelete(ctx, batch,
+		makeTerminator(kClient, realmName, createdUsernames(batch), objectmeta.PreserveResourcesOnDeletion(batch)),
+		finalizerName,
+	); err != nil {
+		return fmt.Errorf("unable to delete realm user batch: %w", err)
+	} else if deleted {
+		return nil
+	}
+
+	users, err := r.getUsers(ctx, batch)
+	if err != nil {
+		return err
+	}
+
+	passwords, err := r.syncPasswordSecret(ctx, batch, users)
+	if err != nil {
+		return err
+	}
+
+	created := createdUsernames(batch)
+
+	statuses, failed := syncUsers(
+		ctx,
+		kClient,
+		realmName,
+		users,
+		passwords,
+		created,
+		batch.GetReconciliationStrategy() == keycloakApi.ReconciliationStrategyAddOnly,
+	)
+
+	pruneStatuses := pruneUsers(ctx, kClient, realmName, created, users,
+		objectmeta.PreserveResourcesOnDeletion(batch))
+	batch.Status.Users = append(statuses, pruneStatuses...)
+
+	if failed > 0 {
+		return fmt.Errorf("unable to sync %d of %d users", failed, len(users))
+	}
+
+	if len(pruneStatuses) > 0 {
+		return fmt.Errorf("unable to delete %d users removed from the batch", len(pruneStatuses))
+	}
+
+	return nil
+}
+
+// createdUsernames returns usernames of the users which were created by the batch.
+func createdUsernames(batch *keycloakApi.KeycloakRealmUserBatch) []string {
+	usernames := make([]string, 0, len(batch.Status.Users))
+
+	for _, u := range batch.Status.Users {
+		if u.Created {
+			usernames = append(usernames, u.Username)
+		}
+	}
+
+	return usernames
+}
+
+// pruneUsers deletes users which were created by the batch but are removed from it.
+// It returns statuses of the users which were not deleted, so the deletion is retried.
+func pruneUsers(
+	ctx context.Context,
+	kClient keycloak.Client,
+	realmName string,
+	created []string,
+	users []keycloakApi.BatchUser,
+	preserveResources bool,
+) []keycloakApi.BatchUserStatus {
+	if preserveResources {
+		return nil
+	}
+
+	current := make(map[string]struct{}, len(users))
+	for i := range users {
+		current[users[i].Username] = struct{}{}
+	}
+
+	var statuses []keycloakApi.BatchUserStatus
+
+	for _, username := range created {
+		if _, ok := current[username]; ok {
+			continue
+		}
+
+		if err := kClient.DeleteRealmUser(ctx, realmName, username); err != nil && !adapter.IsErrNotFound(err) {
+			statuses = append(statuses, keycloakApi.BatchUserStatus{
+				Username: username,
+				Created:  true,
+				Message:  fmt.Sprintf("unable to delete user removed from the batch: %s", err),
+			})
+		}
+	}
+
+	return statuses
+}
+
+// getUsers returns users from the spec and from the referenced ConfigMap.
+func (r *Reconcile) getUsers(ctx context.Context, batch *keycloakApi.KeycloakRealmUserBatch) ([]keycloakApi.BatchUser, error) {
+	users := make([]keycloakApi.BatchUser, 0, len(batch.Spec.Users))
+	users = append(users, batch.Spec.Users...)
+
+	if batch.Spec.UsersFrom != nil {
+		cm := &corev1.ConfigMap{}
+		if err := r.client.Get(ctx, types.NamespacedName{Name: batch.Spec.UsersFrom.Name, Namespace: batch.Namespace}, cm); err != nil {
+			return nil, fmt.Errorf("unable to get users ConfigMap %s: %w", batch.Spec.UsersFrom.Name, err)
+		}
+
+		data, ok := cm.Data[batch.Spec.UsersFrom.Key]
+		if !ok {
+			return nil, fmt.Errorf("key %s not found in ConfigMap %s", batch.Spec.UsersFrom.Key, batch.Spec.UsersFrom.Name)
+		}
+
+		cmUsers, err := parseUsers(data, batch.Spec.UsersFrom.Format)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse users from ConfigMap %s: %w", batch.Spec.UsersFrom.Name, err)
+		}
+
+		users = append(users, cmUsers...)
+	}
+
+	usernames := make(map[string]struct{}, len(users))
+
+	for i := range users {
+		if users[i].Username == "" {
+			return nil, fmt.Errorf("username of user %d is empty", i)
+		}
+
+		if _, ok := usernames[users[i].Username]; ok {
+			return nil, fmt.Errorf("user %s is duplicated", users[i].Username)
+		}
+
+		usernames[users[i].Username] = struct{}{}
+	}
+
+	return users, nil
+}
+
+// syncPasswordSecret generates initial passwords for the users which don't have them in the secret yet.
+// It returns a map of username to password.
+func (r *Reconcile) syncPasswordSecret(
+	ctx context.Context,
+	batch *keycloakApi.KeycloakRealmUserBatch,
+	users []keycloakApi.BatchUser,
+) (map[string]string, error) {
+	secret := &corev1.Secret{}
+
+	err := r.client.Get(ctx, types.NamespacedName{Name: batch.GetPasswordSecretName(), Namespace: batch.Namespace}, secret)
+	if err != nil && !k8sErrors.IsNotFound(err) {
+		return nil, fmt.Errorf("unable to get password secret: %w", err)
+	}
+
+	secretExists := err == nil
+
+	if !secretExists {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      batch.GetPasswordSecretName(),
+				Namespace: batch.Namespace,
+			},
+		}
+
+		if err = controllerutil.SetControllerReference(batch, secret, r.client.Scheme()); err != nil {
+			return nil, fmt.Errorf("unable to set password secret owner reference: %w", err)
+		}
+	}
+
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+
+	passwords := make(map[string]string, len(users))
+	keys := make(map[string]string, len(users))
+	updated := false
+
+	for i := range users {
+		key := passwordSecretKey(users[i].Username)
+
+		if username, ok := keys[key]; ok {
+			return nil, fmt.Errorf("users %s and %s have the same password secret key %s", username, users[i].Username, key)
+		}
+
+		keys[key] = users[i].Username
+
+		if password, ok := secret.Data[key]; ok {
+			passwords[users[i].Username] = string(password)
+			continue
+		}
+
+		password, err := generatePassword()
+		if err != nil {
+			return nil, err
+		}
+
+		secret.Data[key] = []byte(password)
+		passwords[users[i].Username] = password
+		updated = true
+	}
+
+	if !secretExists {
+		if err = r.client.Create(ctx, secret); err != nil {
+			return nil, fmt.Errorf("unable to create password secret: %w", err)
+		}
+
+		return passwords, nil
+	}
+
+	if updated {
+		if err = r.client.Update(ctx, secret); err != nil {
+			return nil, fmt.Errorf("unable to update password secret: %w", err)
+		}
+	}
+
+	return passwords, nil
+}
+
+// syncUsers syncs users in parallel and returns status of each user and the number of failed users.
+// created contains usernames of the users which were created by the batch before,
+// other users are checked for existence to detect if they are created by the batch.
+func syncUsers(
+	ctx context.Context,
+	kClient keycloak.Client,
+	realmName string,
+	users []keycloakApi.BatchUser,
+	passwords map[string]string,
+	created []string,
+	addOnly bool,
+) ([]keycloakApi.BatchUserStatus, int) {
+	var (
+		statuses = make([]keycloakApi.BatchUserStatus, len(users))
+		workers  = make(chan struct{}, maxUserWorkers)
+		wg       sync.WaitGroup
+	)
+
+	createdUsers := make(map[string]struct{}, len(created))
+	for _, username := range created {
+		createdUsers[username] = struct{}{}
+	}
+
+	for i := range users {
+		wg.Add(1)
+
+		workers <- struct{}{}
+
+		go func(i int) {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+
+			_, userCreated := createdUsers[users[i].Username]
+			statuses[i] = keycloakApi.BatchUserStatus{Username: users[i].Username, Ready: true, Created: userCreated}
+
+			if !statuses[i].Created {
+				exists, err := kClient.ExistRealmUser(realmName, &dto.User{Username: users[i].Username})
+				if err != nil {
+					statuses[i].Ready = false
+					statuses[i].Message = fmt.Sprintf("unable to check if user exists: %s", err)
+
+					return
+				}
+
+				statuses[i].Created = !exists
+			}
+
+			if err := kClient.SyncRealmUser(ctx, realmName, makeKeycloakUser(&users[i], passwords[users[i].Username]), addOnly); err != nil {
+				statuses[i].Ready = false
+				statuses[i].Message = err.Error()
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	failed := 0
+
+	for i := range statuses {
+		if !statuses[i].Ready {
+			failed++
+		}
+	}
+
+	return statuses, failed
+}
+
+func makeKeycloakUser(user *keycloakApi.BatchUser, password string) *adapter.KeycloakUser {
+	return &adapter.KeycloakUser{
+		Username:            user.Username,
+		Enabled:             user.Enabled,
+		EmailVerified:       user.EmailVerified,
+		Email:               user.Email,
+		FirstName:           user.FirstName,
+		LastName:            user.LastName,
+		RequiredUserActions: []string{requiredActionUpdatePassword},
+		Roles:               user.Roles,
+		Groups:              user.Groups,
+		Attributes:          makeAttributes(user.Attributes),
+		Password:            password,
+	}
+}
+
+func makeAttributes(attributes map[string]string) map[string][]string {
+	if len(attributes) == 0 {
+		return nil
+	}
+
+	attrs := make(map[string][]string, len(attributes))
+	for k, v := range attributes {
+		attrs[k] = []string{v}
+	}
+
+	return attrs
+}
@@ -0,0 +1,319 @@
+package keycloakrealmuserbatch
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Nerzal/gocloak/v12"
+	testifymock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/epam/edp-keycloak-operator/api/common"
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	helpermock "github.com/epam/edp-keycloak-operator/controllers/helper/mocks"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/dto"
+)
+
+const ns = "default"
+
+func getTestBatch() *keycloakApi.KeycloakRealmUserBatch {
+	return &keycloakApi.KeycloakRealmUserBatch{
+		ObjectMeta: metav1.ObjectMeta{Name: "batch", Namespace: ns},
+		Spec: keycloakApi.KeycloakRealmUserBatchSpec{
+			RealmRef: common.RealmRef{Kind: keycloakApi.KeycloakRealmKind, Name: "realm"},
+			Users: []keycloakApi.BatchUser{
+				{Username: "john@example.com", Enabled: true, Roles: []string{"developer"}},
+			},
+			UsersFrom: &keycloakApi.UsersFromConfigMap{
+				Name:   "users",
+				Key:    "users.csv",
+				Format: keycloakApi.UsersFormatCSV,
+			},
+		},
+	}
+}
+
+func matchUser(username, password string) interface{} {
+	return testifymock.MatchedBy(func(user *adapter.KeycloakUser) bool {
+		return user.Username == username &&
+			(password == "" || user.Password == password) &&
+			len(user.RequiredUserActions) == 1 && user.RequiredUserActions[0] == requiredActionUpdatePassword
+	})
+}
+
+func setupKeycloakClient(h *helpermock.ControllerHelper, kClient *adapter.Mock) {
+	h.On("SetRealmOwnerRef", testifymock.Anything, testifymock.Anything).Return(nil)
+	h.On("CreateKeycloakClientFromRealmRef", testifymock.Anything, testifymock.Anything).Return(kClient, nil)
+	h.On("GetKeycloakRealmFromRef", testifymock.Anything, testifymock.Anything, kClient).
+		Return(&gocloak.RealmRepresentation{Realm: gocloak.StringP("realm")}, nil)
+}
+
+func TestReconcile_Reconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(scheme))
+	utilruntime.Must(corev1.AddToScheme(scheme))
+
+	usersCM := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "users", Namespace: ns},
+		Data:       map[string]string{"users.csv": "username,email\njane,jane@example.com\n"},
+	}
+	passwordSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "batch-passwords", Namespace: ns},
+		Data:       map[string][]byte{"jane": []byte("jane-password")},
+	}
+
+	tests := []struct {
+		name        string
+		objects     []client.Object
+		setupMocks  func(h *helpermock.ControllerHelper, kClient *adapter.Mock)
+		wantResult  reconcile.Result
+		checkStatus func(t *testing.T, cl client.Client)
+	}{
+		{
+			name:    "users are synced",
+			objects: []client.Object{getTestBatch(), usersCM, passwordSecret},
+			setupMocks: func(h *helpermock.ControllerHelper, kClient *adapter.Mock) {
+				h.On("SetRealmOwnerRef", testifymock.Anything, testifymock.Anything).Return(nil)
+				h.On("CreateKeycloakClientFromRealmRef", testifymock.Anything, testifymock.Anything).Return(kClient, nil)
+				h.On("GetKeycloakRealmFromRef", testifymock.Anything, testifymock.Anything, kClient).
+					Return(&gocloak.RealmRepresentation{Realm: gocloak.StringP("realm")}, nil)
+				h.On("TryToDelete", testifymock.Anything, testifymock.Anything, testifymock.Anything, finalizerName).
+					Return(false, nil)
+				kClient.On("ExistRealmUser", "realm", &dto.User{Username: "john@example.com"}).Return(true, nil)
+				kClient.On("ExistRealmUser", "realm", &dto.User{Username: "jane"}).Return(false, nil)
+				kClient.On("SyncRealmUser", "realm", matchUser("john@example.com", ""), false).Return(nil)
+				kClient.On("SyncRealmUser", "realm", matchUser("jane", "jane-password"), false).Return(nil)
+			},
+			wantResult: reconcile.Result{RequeueAfter: time.Hour},
+			checkStatus: func(t *testing.T, cl client.Client) {
+				batch := &keycloakApi.KeycloakRealmUserBatch{}
+				require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Name: "batch", Namespace: ns}, batch))
+				require.Equal(t, helper.StatusOK, batch.Status.Value)
+				require.Equal(t, []keycloakApi.BatchUserStatus{
+					{Username: "john@example.com", Ready: true},
+					{Username: "jane", Ready: true, Created: true},
+				}, batch.Status.Users)
+
+				secret := &corev1.Secret{}
+				require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Name: "batch-passwords", Namespace: ns}, secret))
+				require.Equal(t, "jane-password", string(secret.Data["jane"]))
+				require.Len(t, secret.Data["john_example.com"], passwordLength)
+			},
+		},
+		{
+			name:    "one of users failed",
+			objects: []client.Object{getTestBatch(), usersCM},
+			setupMocks: func(h *helpermock.ControllerHelper, kClient *adapter.Mock) {
+				h.On("SetRealmOwnerRef", testifymock.Anything, testifymock.Anything).Return(nil)
+				h.On("CreateKeycloakClientFromRealmRef", testifymock.Anything, testifymock.Anything).Return(kClient, nil)
+				h.On("GetKeycloakRealmFromRef", testifymock.Anything, testifymock.Anything, kClient).
+					Return(&gocloak.RealmRepresentation{Realm: gocloak.StringP("realm")}, nil)
+				h.On("TryToDelete", testifymock.Anything, testifymock.Anything, testifymock.Anything, finalizerName).
+					Return(false, nil)
+				h.On("SetFailureCount", testifymock.Anything).Return(time.Minute)
+				kClient.On("ExistRealmUser", "realm", &dto.User{Username: "john@example.com"}).Return(true, nil)
+				kClient.On("ExistRealmUser", "realm", &dto.User{Username: "jane"}).Return(false, nil)
+				kClient.On("SyncRealmUser", "realm", matchUser("john@example.com", ""), false).Return(nil)
+				kClient.On("SyncRealmUser", "realm", matchUser("jane", ""), false).Return(errors.New("group testers not found"))
+			},
+			wantResult: reconcile.Result{RequeueAfter: time.Minute},
+			checkStatus: func(t *testing.T, cl client.Client) {
+				batch := &keycloakApi.KeycloakRealmUserBatch{}
+				require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Name: "batch", Namespace: ns}, batch))
+				require.Equal(t, "unable to sync 1 of 2 users", batch.Status.Value)
+				require.Equal(t, []keycloakApi.BatchUserStatus{
+					{Username: "john@example.com", Ready: true},
+					{Username: "jane", Created: true, Message: "group testers not found"},
+				}, batch.Status.Users)
+
+				secret := &corev1.Secret{}
+				require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Name: "batch-passwords", Namespace: ns}, secret))
+				require.Len(t, secret.Data, 2)
+				require.Len(t, secret.OwnerReferences, 1)
+			},
+		},
+		{
+			name:    "users ConfigMap not found",
+			objects: []client.Object{getTestBatch()},
+			setupMocks: func(h *helpermock.ControllerHelper, kClient *adapter.Mock) {
+				setupKeycloakClient(h, kClient)
+				h.On("TryToDelete", testifymock.Anything, testifymock.Anything, testifymock.Anything, finalizerName).
+					Return(false, nil)
+				h.On("SetFailureCount", testifymock.Anything).Return(time.Minute)
+			},
+			wantResult: reconcile.Result{RequeueAfter: time.Minute},
+			checkStatus: func(t *testing.T, cl client.Client) {
+				batch := &keycloakApi.KeycloakRealmUserBatch{}
+				require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Name: "batch", Namespace: ns}, batch))
+				require.Contains(t, batch.Status.Value, "unable to get users ConfigMap users")
+			},
+		},
+		{
+			name: "duplicated users",
+			objects: []client.Object{
+				getTestBatch(),
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "users", Namespace: ns},
+					Data:       map[string]string{"users.csv": "username\njohn@example.com\n"},
+				},
+			},
+			setupMocks: func(h *helpermock.ControllerHelper, kClient *adapter.Mock) {
+				setupKeycloakClient(h, kClient)
+				h.On("TryToDelete", testifymock.Anything, testifymock.Anything, testifymock.Anything, finalizerName).
+					Return(false, nil)
+				h.On("SetFailureCount", testifymock.Anything).Return(time.Minute)
+			},
+			wantResult: reconcile.Result{RequeueAfter: time.Minute},
+			checkStatus: func(t *testing.T, cl client.Client) {
+				batch := &keycloakApi.KeycloakRealmUserBatch{}
+				require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Name: "batch", Namespace: ns}, batch))
+				require.Equal(t, "user john@example.com is duplicated", batch.Status.Value)
+			},
+		},
+		{
+			name: "users created by the batch and removed from it are deleted",
+			objects: []client.Object{
+				func() client.Object {
+					batch := getTestBatch()
+					batch.Status.Users = []keycloakApi.BatchUserStatus{
+						{Username: "john@example.com", Ready: true},
+						{Username: "jane", Ready: true, Created: true},
+						{Username: "removed", Ready: true, Created: true},
+						{Username: "failed", Ready: true, Created: true},
+						{Username: "adopted", Ready: true},
+					}
+
+					return batch
+				}(),
+				usersCM,
+				passwordSecret,
+			},
+			setupMocks: func(h *helpermock.ControllerHelper, kClient *adapter.Mock) {
+				setupKeycloakClient(h, kClient)
+				h.On("TryToDelete", testifymock.Anything, testifymock.Anything, testifymock.Anything, finalizerName).
+					Return(false, nil)
+				h.On("SetFailureCount", testifymock.Anything).Return(time.Minute)
+				kClient.On("ExistRealmUser", "realm", &dto.User{Username: "john@example.com"}).Return(true, nil)
+				kClient.On("SyncRealmUser", "realm", matchUser("john@example.com", ""), false).Return(nil)
+				kClient.On("SyncRealmUser", "realm", matchUser("jane", "jane-password"), false).Return(nil)
+				kClient.On("DeleteRealmUser", "realm", "removed").Return(nil)
+				kClient.On("DeleteRealmUser", "realm", "failed").Return(errors.New("fatal"))
+			},
+			wantResult: reconcile.Result{RequeueAfter: time.Minute},
+			checkStatus: func(t *testing.T, cl client.Client) {
+				batch := &keycloakApi.KeycloakRealmUserBatch{}
+				require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Name: "batch", Namespace: ns}, batch))
+				require.Equal(t, "unable to delete 1 users removed from the batch", batch.Status.Value)
+				require.Equal(t, []keycloakApi.BatchUserStatus{
+					{Username: "john@example.com", Ready: true},
+					{Username: "jane", Ready: true, Created: true},
+					{Username: "failed", Created: true, Message: "unable to delete user removed from the batch: fatal"},
+				}, batch.Status.Users)
+			},
+		},
+		{
+			name: "deletion doesn't require users ConfigMap",
+			objects: []client.Object{
+				func() client.Object {
+					batch := getTestBatch()
+					batch.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+					batch.Finalizers = []string{finalizerName}
+					batch.Status.Users = []keycloakApi.BatchUserStatus{
+						{Username: "created", Ready: true, Created: true},
+						{Username: "adopted", Ready: true},
+					}
+
+					return batch
+				}(),
+			},
+			setupMocks: func(h *helpermock.ControllerHelper, kClient *adapter.Mock) {
+				setupKeycloakClient(h, kClient)
+				h.On("TryToDelete", testifymock.Anything, testifymock.Anything,
+					testifymock.MatchedBy(func(term *terminator) bool {
+						return reflect.DeepEqual(term.usernames, []string{"created"})
+					}), finalizerName).
+					Return(true, nil)
+			},
+			wantResult:  reconcile.Result{RequeueAfter: time.Hour},
+			checkStatus: func(t *testing.T, cl client.Client) {},
+		},
+		{
+			name: "users have the same password secret key",
+			objects: []client.Object{
+				getTestBatch(),
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "users", Namespace: ns},
+					Data:       map[string]string{"users.csv": "username\njohn_example.com\n"},
+				},
+			},
+			setupMocks: func(h *helpermock.ControllerHelper, kClient *adapter.Mock) {
+				setupKeycloakClient(h, kClient)
+				h.On("TryToDelete", testifymock.Anything, testifymock.Anything, testifymock.Anything, finalizerName).
+					Return(false, nil)
+				h.On("SetFailureCount", testifymock.Anything).Return(time.Minute)
+			},
+			wantResult: reconcile.Result{RequeueAfter: time.Minute},
+			checkStatus: func(t *testing.T, cl client.Client) {
+				batch := &keycloakApi.KeycloakRealmUserBatch{}
+				require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Name: "batch", Namespace: ns}, batch))
+				require.Equal(t,
+					"users john@example.com and john_example.com have the same password secret key john_example.com",
+					batch.Status.Value)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build()
+			h := helpermock.NewControllerHelper(t)
+			kClient := new(adapter.Mock)
+			tt.setupMocks(h, kClient)
+
+			r := NewReconcile(cl, h)
+			r.successReconcileTimeout = time.Hour
+
+			res, err := r.Reconcile(context.Background(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: "batch", Namespace: ns},
+			})
+			require.NoError(t, err)
+			require.Equal(t, tt.wantResult, res)
+			tt.checkStatus(t, cl)
+			kClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReconcile_mapConfigMapToBatches(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(scheme))
+
+	otherBatch := getTestBatch()
+	otherBatch.Name = "other"
+	otherBatch.Spec.UsersFrom.Name = "other-users"
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(getTestBatch(), otherBatch).
+		WithIndex(&keycloakApi.KeycloakRealmUserBatch{}, usersConfigMapIndexField, indexUsersConfigMap).
+		Build()
+
+	r := NewReconcile(cl, nil)
+
+	require.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: ns, Name: "batch"}},
+	}, r.mapConfigMapToBatches(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "users", Namespace: ns}}))
+}
@@ -0,0 +1,51 @@
+package keycloakrealmuserbatch
+
+import (
+	"context"
+	"fmt"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+type terminator struct {
+	kClient                     keycloak.Client
+	realmName                   string
+	usernames                   []string
+	preserveResourcesOnDeletion bool
+}
+
+func (t *terminator) DeleteResource(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx)
+	if t.preserveResourcesOnDeletion {
+		log.Info("PreserveResourcesOnDeletion is enabled, skipping deletion.")
+		return nil
+	}
+
+	log.Info("Start deleting keycloak realm user batch")
+
+	for _, username := range t.usernames {
+		if err := t.kClient.DeleteRealmUser(ctx, t.realmName, username); err != nil {
+			if adapter.IsErrNotFound(err) {
+				continue
+			}
+
+			return fmt.Errorf("unable to delete realm user %s: %w", username, err)
+		}
+	}
+
+	log.Info("Realm user batch has been deleted")
+
+	return nil
+}
+
+func makeTerminator(kClient keycloak.Client, realmName string, usernames []string, preserveResourcesOnDeletion bool) *terminator {
+	return &terminator{
+		kClient:                     kClient,
+		realmName:                   realmName,
+		usernames:                   usernames,
+		preserveResourcesOnDeletion: preserveResourcesOnDeletion,
+	}
+}
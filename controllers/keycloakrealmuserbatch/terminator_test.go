@@ -0,0 +1,29 @@
+package keycloakrealmuserbatch
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+func TestTerminator_DeleteResource(t *testing.T) {
+	kClient := new(adapter.Mock)
+	kClient.On("DeleteRealmUser", "realm", "user1").Return(nil).Once()
+	kClient.On("DeleteRealmUser", "realm", "user2").Return(adapter.NotFoundError("user not found")).Once()
+
+	term := makeTerminator(kClient, "realm", []string{"user1", "user2"}, false)
+	require.NoError(t, term.DeleteResource(context.Background()))
+
+	kClient.On("DeleteRealmUser", "realm", "user1").Return(errors.New("fatal")).Once()
+	require.ErrorContains(t, term.DeleteResource(context.Background()), "unable to delete realm user user1")
+}
+
+func TestTerminatorSkipDeletion(t *testing.T) {
+	term := makeTerminator(nil, "realm", []string{"user1"}, true)
+
+	require.NoError(t, term.DeleteResource(context.Background()))
+}
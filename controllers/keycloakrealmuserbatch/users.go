@@ -0,0 +1,167 @@
+package keycloakrealmuserbatch
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+)
+
+const (
+	passwordLength  = 16
+	passwordSymbols = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789!#%*+-_"
+
+	csvListSeparator = ";"
+)
+
+var invalidSecretKeyChars = regexp.MustCompile(`[^-._a-zA-Z0-9]`)
+
+// parseUsers parses users list from ConfigMap data in the given format.
+func parseUsers(data, format string) ([]keycloakApi.BatchUser, error) {
+	switch format {
+	case keycloakApi.UsersFormatJSON:
+		return parseJSONUsers(data)
+	case keycloakApi.UsersFormatCSV, "":
+		return parseCSVUsers(data)
+	default:
+		return nil, fmt.Errorf("unsupported users format %s", format)
+	}
+}
+
+func parseJSONUsers(data string) ([]keycloakApi.BatchUser, error) {
+	var rawUsers []json.RawMessage
+	if err := json.Unmarshal([]byte(data), &rawUsers); err != nil {
+		return nil, fmt.Errorf("unable to parse users json: %w", err)
+	}
+
+	users := make([]keycloakApi.BatchUser, 0, len(rawUsers))
+
+	for _, raw := range rawUsers {
+		user := keycloakApi.BatchUser{Enabled: true}
+		if err := json.Unmarshal(raw, &user); err != nil {
+			return nil, fmt.Errorf("unable to parse user json: %w", err)
+		}
+
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+func parseCSVUsers(data string) ([]keycloakApi.BatchUser, error) {
+	reader := csv.NewReader(bytes.NewBufferString(data))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read users csv header: %w", err)
+	}
+
+	users := make([]keycloakApi.BatchUser, 0)
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to read users csv: %w", err)
+		}
+
+		user, err := csvRecordToUser(header, record)
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+func csvRecordToUser(header, record []string) (keycloakApi.BatchUser, error) {
+	user := keycloakApi.BatchUser{Enabled: true}
+
+	for i, column := range header {
+		value := strings.TrimSpace(record[i])
+		if value == "" {
+			continue
+		}
+
+		var err error
+
+		switch column {
+		case "username":
+			user.Username = value
+		case "email":
+			user.Email = value
+		case "firstName":
+			user.FirstName = value
+		case "lastName":
+			user.LastName = value
+		case "enabled":
+			user.Enabled, err = strconv.ParseBool(value)
+		case "emailVerified":
+			user.EmailVerified, err = strconv.ParseBool(value)
+		case "roles":
+			user.Roles = splitCSVList(value)
+		case "groups":
+			user.Groups = splitCSVList(value)
+		default:
+			if user.Attributes == nil {
+				user.Attributes = make(map[string]string)
+			}
+
+			user.Attributes[column] = value
+		}
+
+		if err != nil {
+			return user, fmt.Errorf("unable to parse column %s of user %s: %w", column, user.Username, err)
+		}
+	}
+
+	return user, nil
+}
+
+func splitCSVList(value string) []string {
+	items := strings.Split(value, csvListSeparator)
+	res := make([]string, 0, len(items))
+
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			res = append(res, item)
+		}
+	}
+
+	return res
+}
+
+// passwordSecretKey converts username to the valid secret key.
+func passwordSecretKey(username string) string {
+	return invalidSecretKeyChars.ReplaceAllString(username, "_")
+}
+
+func generatePassword() (string, error) {
+	password := make([]byte, passwordLength)
+
+	for i := range password {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(passwordSymbols))))
+		if err != nil {
+			return "", fmt.Errorf("unable to generate password: %w", err)
+		}
+
+		password[i] = passwordSymbols[n.Int64()]
+	}
+
+	return string(password), nil
+}
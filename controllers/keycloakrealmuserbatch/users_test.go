@@ -0,0 +1,104 @@
+package keycloakrealmuserbatch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+)
+
+func TestParseUsers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		data    string
+		format  string
+		want    []keycloakApi.BatchUser
+		wantErr require.ErrorAssertionFunc
+	}{
+		{
+			name: "csv",
+			data: "username,email,enabled,roles,groups,department\n" +
+				"john,john@example.com,,developer; qa,testers,qa\n" +
+				"jane,,false,,,\n",
+			format: keycloakApi.UsersFormatCSV,
+			want: []keycloakApi.BatchUser{
+				{
+					Username:   "john",
+					Email:      "john@example.com",
+					Enabled:    true,
+					Roles:      []string{"developer", "qa"},
+					Groups:     []string{"testers"},
+					Attributes: map[string]string{"department": "qa"},
+				},
+				{
+					Username: "jane",
+					Enabled:  false,
+				},
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name:    "csv invalid bool",
+			data:    "username,enabled\njohn,maybe\n",
+			format:  keycloakApi.UsersFormatCSV,
+			wantErr: require.Error,
+		},
+		{
+			name:   "json",
+			data:   `[{"username":"john","roles":["developer"]},{"username":"jane","enabled":false}]`,
+			format: keycloakApi.UsersFormatJSON,
+			want: []keycloakApi.BatchUser{
+				{Username: "john", Enabled: true, Roles: []string{"developer"}},
+				{Username: "jane", Enabled: false},
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name:    "invalid json",
+			data:    `{"username":"john"}`,
+			format:  keycloakApi.UsersFormatJSON,
+			wantErr: require.Error,
+		},
+		{
+			name:    "unsupported format",
+			format:  "yaml",
+			wantErr: require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseUsers(tt.data, tt.format)
+			tt.wantErr(t, err)
+
+			if tt.want != nil {
+				require.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPasswordSecretKey(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "john.doe_example.com", passwordSecretKey("john.doe@example.com"))
+	require.Equal(t, "john-doe_1", passwordSecretKey("john-doe_1"))
+}
+
+func TestGeneratePassword(t *testing.T) {
+	t.Parallel()
+
+	p1, err := generatePassword()
+	require.NoError(t, err)
+	require.Len(t, p1, passwordLength)
+
+	p2, err := generatePassword()
+	require.NoError(t, err)
+	require.NotEqual(t, p1, p2)
+}
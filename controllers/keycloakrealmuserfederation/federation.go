@@ -0,0 +1,258 @@
+package keycloakrealmuserfederation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/maps"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/secretref"
+)
+
+const (
+	ldapSearchScopeOne     = "1"
+	ldapSearchScopeSubtree = "2"
+
+	// disabledSyncPeriod is a Keycloak value of the disabled periodic synchronization.
+	disabledSyncPeriod = "-1"
+)
+
+// validateUserFederation checks that the configuration required by the provider is set.
+func validateUserFederation(spec *keycloakApi.KeycloakRealmUserFederationSpec, providerID string) error {
+	switch providerID {
+	case keycloakApi.UserFederationProviderLDAP:
+		if spec.LDAP == nil {
+			return errors.New("ldap configuration is required for ldap provider")
+		}
+	case keycloakApi.UserFederationProviderKerberos:
+		if spec.Kerberos == nil {
+			return errors.New("kerberos configuration is required for kerberos provider")
+		}
+
+		if len(spec.Mappers) > 0 {
+			return errors.New("mappers are supported only by ldap provider")
+		}
+	default:
+		return fmt.Errorf("unsupported provider %q", providerID)
+	}
+
+	return nil
+}
+
+// makeFederationComponent builds user federation component from typed configuration and raw Config.
+// Raw Config has precedence over typed fields. Secret references are resolved.
+func (r *Reconcile) makeFederationComponent(
+	ctx context.Context,
+	federation *keycloakApi.KeycloakRealmUserFederation,
+) (*adapter.Component, error) {
+	spec := &federation.Spec
+	config := map[string][]string{
+		"enabled":  {strconv.FormatBool(spec.Enabled)},
+		"priority": {strconv.Itoa(spec.Priority)},
+	}
+
+	if spec.LDAP != nil {
+		bindCredential, err := r.getBindCredential(ctx, spec.LDAP, federation.Namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		setLDAPConfig(config, spec.LDAP, bindCredential)
+	}
+
+	if spec.Kerberos != nil {
+		setKerberosConfig(config, spec.Kerberos, federation.GetProviderID())
+	}
+
+	rawConfig := make(map[string][]string, len(spec.Config))
+	for k, v := range spec.Config {
+		rawConfig[k] = append([]string(nil), v...)
+	}
+
+	if err := r.secretRefClient.MapComponentConfigSecretsRefs(ctx, rawConfig, federation.Namespace); err != nil {
+		return nil, fmt.Errorf("unable to map config secrets: %w", err)
+	}
+
+	maps.Copy(config, rawConfig)
+
+	return &adapter.Component{
+		Name:       spec.Name,
+		ProviderID: federation.GetProviderID(),
+		Config:     config,
+	}, nil
+}
+
+func (r *Reconcile) getBindCredential(ctx context.Context, ldap *keycloakApi.UserFederationLDAP, namespace string) (string, error) {
+	if ldap.BindCredential == nil {
+		return "", nil
+	}
+
+	credential, err := r.secretRefClient.GetSecretFromRef(
+		ctx,
+		secretref.GenerateSecretRef(ldap.BindCredential.Name, ldap.BindCredential.Key),
+		namespace,
+	)
+	if err != nil {
+		return "", fmt.Errorf("unable to get bind credential: %w", err)
+	}
+
+	return credential, nil
+}
+
+func setLDAPConfig(config map[string][]string, ldap *keycloakApi.UserFederationLDAP, bindCredential string) {
+	setIfNotEmpty(config, "vendor", ldap.Vendor)
+	setIfNotEmpty(config, "connectionUrl", ldap.ConnectionURL)
+	setIfNotEmpty(config, "usersDn", ldap.UsersDN)
+	setIfNotEmpty(config, "usernameLDAPAttribute", ldap.UsernameLDAPAttribute)
+	setIfNotEmpty(config, "uuidLDAPAttribute", ldap.UUIDLDAPAttribute)
+	setIfNotEmpty(config, "userObjectClasses", strings.Join(ldap.UserObjectClasses, ", "))
+	setIfNotEmpty(config, "customUserSearchFilter", ldap.CustomUserSearchFilter)
+	setIfNotEmpty(config, "editMode", ldap.EditMode)
+	setIfNotEmpty(config, "useTruststoreSpi", ldap.UseTruststoreSPI)
+	setBool(config, "importEnabled", ldap.ImportEnabled)
+	setBool(config, "syncRegistrations", ldap.SyncRegistrations)
+	setBool(config, "startTls", ldap.StartTLS)
+	setBool(config, "pagination", ldap.Pagination)
+
+	if ldap.RDNLDAPAttribute != "" {
+		config["rdnLDAPAttribute"] = []string{ldap.RDNLDAPAttribute}
+	} else {
+		setIfNotEmpty(config, "rdnLDAPAttribute", ldap.UsernameLDAPAttribute)
+	}
+
+	if ldap.BindDN != "" {
+		config["authType"] = []string{"simple"}
+		config["bindDn"] = []string{ldap.BindDN}
+		config["bindCredential"] = []string{bindCredential}
+	} else {
+		config["authType"] = []string{"none"}
+	}
+
+	if ldap.SearchScope == "subtree" {
+		config["searchScope"] = []string{ldapSearchScopeSubtree}
+	} else {
+		config["searchScope"] = []string{ldapSearchScopeOne}
+	}
+
+	if ldap.ConnectionTimeout != nil {
+		config["connectionTimeout"] = []string{strconv.FormatInt(ldap.ConnectionTimeout.Milliseconds(), 10)}
+	}
+
+	if ldap.ReadTimeout != nil {
+		config["readTimeout"] = []string{strconv.FormatInt(ldap.ReadTimeout.Milliseconds(), 10)}
+	}
+
+	if ldap.BatchSizeForSync != nil {
+		config["batchSizeForSync"] = []string{strconv.Itoa(*ldap.BatchSizeForSync)}
+	}
+
+	config["fullSyncPeriod"] = []string{syncPeriod(ldap.FullSyncPeriod)}
+	config["changedSyncPeriod"] = []string{syncPeriod(ldap.ChangedSyncPeriod)}
+}
+
+func setKerberosConfig(config map[string][]string, kerberos *keycloakApi.UserFederationKerberos, providerID string) {
+	config["kerberosRealm"] = []string{kerberos.KerberosRealm}
+	config["serverPrincipal"] = []string{kerberos.ServerPrincipal}
+	config["keyTab"] = []string{kerberos.KeyTab}
+	config["debug"] = []string{strconv.FormatBool(kerberos.Debug)}
+
+	if providerID == keycloakApi.UserFederationProviderKerberos {
+		config["allowPasswordAuthentication"] = []string{strconv.FormatBool(kerberos.AllowPasswordAuthentication)}
+
+		return
+	}
+
+	config["allowKerberosAuthentication"] = []string{strconv.FormatBool(true)}
+	config["useKerberosForPasswordAuthentication"] = []string{
+		strconv.FormatBool(kerberos.UseKerberosForPasswordAuthentication),
+	}
+}
+
+// testLDAPConnection checks connection to the LDAP server and authentication with bind credentials if they are set.
+func testLDAPConnection(ctx context.Context, kClient keycloak.Client, realmName string, component *adapter.Component) error {
+	test := &adapter.LDAPConnectionTest{
+		Action:            adapter.LDAPTestConnection,
+		ConnectionURL:     getConfigValue(component.Config, "connectionUrl"),
+		UseTruststoreSpi:  getConfigValue(component.Config, "useTruststoreSpi"),
+		ConnectionTimeout: getConfigValue(component.Config, "connectionTimeout"),
+		StartTLS:          getConfigValue(component.Config, "startTls"),
+		ComponentID:       component.ID,
+	}
+
+	if err := kClient.TestLDAPConnection(ctx, realmName, test); err != nil {
+		return fmt.Errorf("unable to connect to ldap server: %w", err)
+	}
+
+	if getConfigValue(component.Config, "authType") != "simple" {
+		return nil
+	}
+
+	test.Action = adapter.LDAPTestAuthentication
+	test.AuthType = "simple"
+	test.BindDN = getConfigValue(component.Config, "bindDn")
+	test.BindCredential = getConfigValue(component.Config, "bindCredential")
+
+	if err := kClient.TestLDAPConnection(ctx, realmName, test); err != nil {
+		return fmt.Errorf("unable to authenticate to ldap server: %w", err)
+	}
+
+	return nil
+}
+
+// getUserFederation returns the user federation by ID.
+// If the ID is unknown or the user federation is not found by it,
+// the user federation is looked up by name among the user federations of the realm.
+func getUserFederation(
+	ctx context.Context,
+	kClient keycloak.Client,
+	realmName, realmID, federationID, federationName string,
+) (*adapter.Component, error) {
+	if federationID != "" {
+		federation, err := kClient.GetComponentByID(ctx, realmName, federationID)
+		if err == nil {
+			return federation, nil
+		}
+
+		if !adapter.IsErrNotFound(err) {
+			return nil, err
+		}
+	}
+
+	return kClient.GetUserFederation(ctx, realmName, realmID, federationName)
+}
+
+// syncPeriod converts period to Keycloak sync period in seconds.
+func syncPeriod(period *metav1.Duration) string {
+	if period == nil {
+		return disabledSyncPeriod
+	}
+
+	return strconv.Itoa(int(period.Seconds()))
+}
+
+func getConfigValue(config map[string][]string, key string) string {
+	if len(config[key]) == 0 {
+		return ""
+	}
+
+	return config[key][0]
+}
+
+func setIfNotEmpty(config map[string][]string, key, value string) {
+	if value != "" {
+		config[key] = []string{value}
+	}
+}
+
+func setBool(config map[string][]string, key string, value *bool) {
+	if value != nil {
+		config[key] = []string{strconv.FormatBool(*value)}
+	}
+}
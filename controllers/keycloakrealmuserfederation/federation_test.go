@@ -0,0 +1,52 @@
+package keycloakrealmuserfederation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+)
+
+func TestSetLDAPConfig(t *testing.T) {
+	t.Parallel()
+
+	importEnabled := false
+	config := map[string][]string{}
+
+	setLDAPConfig(config, &keycloakApi.UserFederationLDAP{
+		ConnectionURL:         "ldap://ldap:389",
+		BindDN:                "cn=admin",
+		UsernameLDAPAttribute: "uid",
+		UserObjectClasses:     []string{"inetOrgPerson", "organizationalPerson"},
+		SearchScope:           "subtree",
+		ImportEnabled:         &importEnabled,
+		ConnectionTimeout:     &metav1.Duration{Duration: 5 * time.Second},
+		FullSyncPeriod:        &metav1.Duration{Duration: 24 * time.Hour},
+	}, "secret")
+
+	require.Equal(t, []string{"simple"}, config["authType"])
+	require.Equal(t, []string{"secret"}, config["bindCredential"])
+	require.Equal(t, []string{"uid"}, config["rdnLDAPAttribute"])
+	require.Equal(t, []string{"inetOrgPerson, organizationalPerson"}, config["userObjectClasses"])
+	require.Equal(t, []string{ldapSearchScopeSubtree}, config["searchScope"])
+	require.Equal(t, []string{"false"}, config["importEnabled"])
+	require.Equal(t, []string{"5000"}, config["connectionTimeout"])
+	require.Equal(t, []string{"86400"}, config["fullSyncPeriod"])
+	require.Equal(t, []string{disabledSyncPeriod}, config["changedSyncPeriod"])
+	require.NotContains(t, config, "readTimeout")
+}
+
+func TestValidateUserFederation(t *testing.T) {
+	t.Parallel()
+
+	spec := &keycloakApi.KeycloakRealmUserFederationSpec{}
+	require.ErrorContains(t, validateUserFederation(spec, keycloakApi.UserFederationProviderLDAP), "ldap configuration is required")
+
+	spec.Kerberos = &keycloakApi.UserFederationKerberos{}
+	spec.Mappers = []keycloakApi.UserFederationMapper{{Name: "email"}}
+	require.ErrorContains(t, validateUserFederation(spec, keycloakApi.UserFederationProviderKerberos), "mappers are supported only")
+	require.ErrorContains(t, validateUserFederation(spec, "ad"), "unsupported provider")
+}
@@ -0,0 +1,363 @@
+package keycloakrealmuserfederation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/Nerzal/gocloak/v12"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/objectmeta"
+	"github.com/epam/edp-keycloak-operator/pkg/secretref"
+)
+
+const finalizerName = "keycloak.realmuserfederation.operator.finalizer.name"
+
+type Helper interface {
+	SetFailureCount(fc helper.FailureCountable) time.Duration
+	TryToDelete(ctx context.Context, obj client.Object, terminator helper.Terminator, finalizer string) (isDeleted bool, resultErr error)
+	SetRealmOwnerRef(ctx context.Context, object helper.ObjectWithRealmRef) error
+	GetKeycloakRealmFromRef(ctx context.Context, object helper.ObjectWithRealmRef, kcClient keycloak.Client) (*gocloak.RealmRepresentation, error)
+	CreateKeycloakClientFromRealmRef(ctx context.Context, object helper.ObjectWithRealmRef) (keycloak.Client, error)
+}
+
+type RefClient interface {
+	MapComponentConfigSecretsRefs(ctx context.Context, config map[string][]string, namespace string) error
+	GetSecretFromRef(ctx context.Context, refVal, secretNamespace string) (string, error)
+}
+
+type Reconcile struct {
+	client                  client.Client
+	helper                  Helper
+	secretRefClient         RefClient
+	successReconcileTimeout time.Duration
+}
+
+func NewReconcile(client client.Client, helper Helper, secretRefClient RefClient) *Reconcile {
+	return &Reconcile{
+		client:          client,
+		helper:          helper,
+		secretRefClient: secretRefClient,
+	}
+}
+
+func (r *Reconcile) SetupWithManager(mgr ctrl.Manager, successReconcileTimeout time.Duration) error {
+	r.successReconcileTimeout = successReconcileTimeout
+
+	pred := predicate.Funcs{
+		UpdateFunc: isSpecUpdated,
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&keycloakApi.KeycloakRealmUserFederation{},
+		secretref.SecretNamesIndexField,
+		indexSecretNames,
+	); err != nil {
+		return fmt.Errorf("failed to index KeycloakRealmUserFederation by secrets: %w", err)
+	}
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&keycloakApi.KeycloakRealmUserFederation{}, builder.WithPredicates(pred)).
+		Watches(
+			&source.Kind{Type: &corev1.Secret{}},
+			handler.EnqueueRequestsFromMapFunc(secretref.MapSecretToRequests(r.client, func() client.ObjectList {
+				return &keycloakApi.KeycloakRealmUserFederationList{}
+			})),
+		).
+		Complete(r)
+	if err != nil {
+		return fmt.Errorf("failed to setup KeycloakRealmUserFederation controller: %w", err)
+	}
+
+	return nil
+}
+
+// indexSecretNames returns names of the secrets referenced by the user federation.
+func indexSecretNames(obj client.Object) []string {
+	federation, ok := obj.(*keycloakApi.KeycloakRealmUserFederation)
+	if !ok {
+		return nil
+	}
+
+	var values []string
+	for _, v := range federation.Spec.Config {
+		values = append(values, v...)
+	}
+
+	if federation.Spec.LDAP != nil && federation.Spec.LDAP.BindCredential != nil {
+		values = append(values, secretref.GenerateSecretRef(
+			federation.Spec.LDAP.BindCredential.Name,
+			federation.Spec.LDAP.BindCredential.Key,
+		))
+	}
+
+	return secretref.SecretNamesFromRefs(values...)
+}
+
+// isSpecUpdated also triggers reconciliation when the sync annotation is set.
+func isSpecUpdated(e event.UpdateEvent) bool {
+	oo, ok := e.ObjectOld.(*keycloakApi.KeycloakRealmUserFederation)
+	if !ok {
+		return false
+	}
+
+	no, ok := e.ObjectNew.(*keycloakApi.KeycloakRealmUserFederation)
+	if !ok {
+		return false
+	}
+
+	syncRequested := no.GetAnnotations()[keycloakApi.UserFederationSyncAnnotation] != "" &&
+		oo.GetAnnotations()[keycloakApi.UserFederationSyncAnnotation] != no.GetAnnotations()[keycloakApi.UserFederationSyncAnnotation]
+
+	return !reflect.DeepEqual(oo.Spec, no.Spec) || syncRequested ||
+		(oo.GetDeletionTimestamp().IsZero() && !no.GetDeletionTimestamp().IsZero())
+}
+
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealmuserfederations,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealmuserfederations/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealmuserfederations/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",namespace=placeholder,resources=secrets,verbs=get;list;watch
+
+// Reconcile is a loop for reconciling KeycloakRealmUserFederation object.
+func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (result reconcile.Result, resultErr error) {
+	log := ctrl.LoggerFrom(ctx)
+	log.Info("Reconciling KeycloakRealmUserFederation")
+
+	var instance keycloakApi.KeycloakRealmUserFederation
+	if err := r.client.Get(ctx, request.NamespacedName, &instance); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			log.Info("instance not found")
+			return
+		}
+
+		resultErr = fmt.Errorf("unable to get keycloak realm user federation from k8s: %w", err)
+
+		return
+	}
+
+	if err := r.tryReconcile(ctx, &instance); err != nil {
+		if errors.Is(err, helper.ErrKeycloakIsNotAvailable) {
+			return ctrl.Result{
+				RequeueAfter: helper.RequeueOnKeycloakNotAvailablePeriod,
+			}, nil
+		}
+
+		instance.Status.Value = err.Error()
+		result.RequeueAfter = r.helper.SetFailureCount(&instance)
+
+		log.Error(err, "an error has occurred while handling keycloak realm user federation", "name", request.Name)
+	} else {
+		helper.SetSuccessStatus(&instance)
+		result.RequeueAfter = r.successReconcileTimeout
+	}
+
+	instanceDeleted := !controllerutil.ContainsFinalizer(&instance, finalizerName) &&
+		instance.GetDeletionTimestamp() != nil
+
+	if !instanceDeleted {
+		if err := r.client.Status().Update(ctx, &instance); err != nil {
+			resultErr = fmt.Errorf("unable to update status: %w", err)
+		}
+	}
+
+	log.Info("Reconciling KeycloakRealmUserFederation done")
+
+	return
+}
+
+func (r *Reconcile) tryReconcile(ctx context.Context, federation *keycloakApi.KeycloakRealmUserFederation) error {
+	if err := r.helper.SetRealmOwnerRef(ctx, federation); err != nil {
+		return fmt.Errorf("unable to set realm owner ref: %w", err)
+	}
+
+	kClient, err := r.helper.CreateKeycloakClientFromRealmRef(ctx, federation)
+	if err != nil {
+		return fmt.Errorf("unable to create keycloak client from realm ref: %w", err)
+	}
+
+	realm, err := r.helper.GetKeycloakRealmFromRef(ctx, federation, kClient)
+	if err != nil {
+		return fmt.Errorf("unable to get keycloak realm from ref: %w", err)
+	}
+
+	realmName := gocloak.PString(realm.Realm)
+	realmID := gocloak.PString(realm.ID)
+
+	if realmID == "" {
+		return fmt.Errorf("realm %s id is empty", realmName)
+	}
+
+	if deleted, err := r.helper.TryToDelete(
+		ctx,
+		federation,
+		makeTerminator(
+			realmName,
+			realmID,
+			federation.Status.ID,
+			federation.Spec.Name,
+			kClient,
+			objectmeta.PreserveResourcesOnDeletion(federation),
+		),
+		finalizerName,
+	); err != nil {
+		return fmt.Errorf("unable to delete realm user federation: %w", err)
+	} else if deleted {
+		return nil
+	}
+
+	if err = validateUserFederation(&federation.Spec, federation.GetProviderID()); err != nil {
+		return fmt.Errorf("invalid user federation: %w", err)
+	}
+
+	component, err := r.makeFederationComponent(ctx, federation)
+	if err != nil {
+		return fmt.Errorf("unable to make user federation config: %w", err)
+	}
+
+	current, err := getUserFederation(ctx, kClient, realmName, realmID, federation.Status.ID, federation.Spec.Name)
+	if err != nil && !adapter.IsErrNotFound(err) {
+		return fmt.Errorf("unable to get user federation: %w", err)
+	}
+
+	if current != nil {
+		component.ID = current.ID
+	}
+
+	if component.ProviderID == keycloakApi.UserFederationProviderLDAP && !federation.Spec.SkipConnectionTest {
+		if err = testLDAPConnection(ctx, kClient, realmName, component); err != nil {
+			return err
+		}
+	}
+
+	if current == nil {
+		if component.ID, err = kClient.CreateUserFederation(ctx, realmName, component); err != nil {
+			return fmt.Errorf("unable to create user federation: %w", err)
+		}
+	} else if err = kClient.UpdateUserFederation(ctx, realmName, component); err != nil {
+		return fmt.Errorf("unable to update user federation: %w", err)
+	}
+
+	federation.Status.ID = component.ID
+
+	if err = syncMappers(ctx, federation, kClient, realmName); err != nil {
+		return err
+	}
+
+	return r.syncUsers(ctx, federation, kClient, realmName)
+}
+
+func syncMappers(
+	ctx context.Context,
+	federation *keycloakApi.KeycloakRealmUserFederation,
+	kClient keycloak.Client,
+	realmName string,
+) error {
+	if len(federation.Spec.Mappers) == 0 {
+		return nil
+	}
+
+	mappers := make([]adapter.Component, 0, len(federation.Spec.Mappers))
+	for _, m := range federation.Spec.Mappers {
+		mappers = append(mappers, adapter.Component{
+			Name:       m.Name,
+			ProviderID: m.ProviderID,
+			Config:     m.Config,
+		})
+	}
+
+	if err := kClient.SyncUserFederationMappers(
+		ctx,
+		realmName,
+		federation.Status.ID,
+		mappers,
+		federation.GetMappersReconciliationStrategy() == keycloakApi.ReconciliationStrategyAddOnly,
+	); err != nil {
+		return fmt.Errorf("unable to sync user federation mappers: %w", err)
+	}
+
+	return nil
+}
+
+// syncUsers triggers users synchronization requested by the sync annotation.
+// The result is stored in the status and the annotation is removed, so the synchronization is not repeated.
+// If the synchronization fails, the annotation is kept, so the synchronization is retried.
+func (r *Reconcile) syncUsers(
+	ctx context.Context,
+	federation *keycloakApi.KeycloakRealmUserFederation,
+	kClient keycloak.Client,
+	realmName string,
+) error {
+	syncType, ok := federation.GetAnnotations()[keycloakApi.UserFederationSyncAnnotation]
+	if !ok {
+		return nil
+	}
+
+	lastSync := &keycloakApi.UserFederationSyncStatus{
+		Type: syncType,
+		Time: metav1.Now(),
+	}
+
+	var action string
+
+	switch syncType {
+	case keycloakApi.UserFederationSyncFull:
+		action = adapter.UserFederationSyncFull
+	case keycloakApi.UserFederationSyncChanged:
+		action = adapter.UserFederationSyncChanged
+	default:
+		lastSync.Message = fmt.Sprintf("unknown sync type %q, supported types: %s, %s",
+			syncType, keycloakApi.UserFederationSyncFull, keycloakApi.UserFederationSyncChanged)
+	}
+
+	if action != "" {
+		ctrl.LoggerFrom(ctx).Info("Synchronizing user federation users", "type", syncType)
+
+		res, err := kClient.TriggerUserFederationSync(ctx, realmName, federation.Status.ID, action)
+		if err != nil {
+			lastSync.Message = err.Error()
+			federation.Status.LastSync = lastSync
+
+			return fmt.Errorf("unable to synchronize user federation users: %w", err)
+		}
+
+		lastSync.Added = res.Added
+		lastSync.Updated = res.Updated
+		lastSync.Removed = res.Removed
+		lastSync.Failed = res.Failed
+		lastSync.Message = res.Status
+	}
+
+	// Patch response contains status from the server, so the reconciled status is restored.
+	status := federation.Status
+	patch := client.MergeFrom(federation.DeepCopy())
+
+	delete(federation.Annotations, keycloakApi.UserFederationSyncAnnotation)
+
+	if err := r.client.Patch(ctx, federation, patch); err != nil {
+		return fmt.Errorf("unable to remove sync annotation: %w", err)
+	}
+
+	federation.Status = status
+	federation.Status.LastSync = lastSync
+
+	return nil
+}
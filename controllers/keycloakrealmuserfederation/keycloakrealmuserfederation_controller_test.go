@@ -0,0 +1,295 @@
+package keycloakrealmuserfederation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Nerzal/gocloak/v12"
+	testifymock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/epam/edp-keycloak-operator/api/common"
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	helpermock "github.com/epam/edp-keycloak-operator/controllers/helper/mocks"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/secretref"
+)
+
+const ns = "default"
+
+func getTestFederation() *keycloakApi.KeycloakRealmUserFederation {
+	return &keycloakApi.KeycloakRealmUserFederation{
+		ObjectMeta: metav1.ObjectMeta{Name: "ldap", Namespace: ns},
+		Spec: keycloakApi.KeycloakRealmUserFederationSpec{
+			Name:     "ldap",
+			RealmRef: common.RealmRef{Kind: keycloakApi.KeycloakRealmKind, Name: "realm"},
+			Enabled:  true,
+			LDAP: &keycloakApi.UserFederationLDAP{
+				ConnectionURL:         "ldap://ldap:389",
+				BindDN:                "cn=admin,dc=example,dc=com",
+				BindCredential:        &keycloakApi.SecretKeyRef{Name: "ldap-secret", Key: "password"},
+				UsersDN:               "ou=users,dc=example,dc=com",
+				UsernameLDAPAttribute: "uid",
+				UUIDLDAPAttribute:     "entryUUID",
+				UserObjectClasses:     []string{"inetOrgPerson"},
+			},
+		},
+	}
+}
+
+func matchLDAPTest(action string) interface{} {
+	return testifymock.MatchedBy(func(test *adapter.LDAPConnectionTest) bool {
+		return test.Action == action && test.ConnectionURL == "ldap://ldap:389" &&
+			(action == adapter.LDAPTestConnection || test.BindCredential == "ldap-password")
+	})
+}
+
+func TestReconcile_Reconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(scheme))
+	utilruntime.Must(corev1.AddToScheme(scheme))
+
+	bindSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ldap-secret", Namespace: ns},
+		Data:       map[string][]byte{"password": []byte("ldap-password")},
+	}
+
+	realmMocks := func(h *helpermock.ControllerHelper, kClient *adapter.Mock) {
+		h.On("SetRealmOwnerRef", testifymock.Anything, testifymock.Anything).Return(nil)
+		h.On("CreateKeycloakClientFromRealmRef", testifymock.Anything, testifymock.Anything).Return(kClient, nil)
+		h.On("GetKeycloakRealmFromRef", testifymock.Anything, testifymock.Anything, kClient).
+			Return(&gocloak.RealmRepresentation{Realm: gocloak.StringP("realm"), ID: gocloak.StringP("realm-id")}, nil)
+		h.On("TryToDelete", testifymock.Anything, testifymock.Anything, testifymock.Anything, finalizerName).
+			Return(false, nil)
+	}
+
+	tests := []struct {
+		name        string
+		federation  func() *keycloakApi.KeycloakRealmUserFederation
+		objects     []client.Object
+		setupMocks  func(h *helpermock.ControllerHelper, kClient *adapter.Mock)
+		wantResult  reconcile.Result
+		checkStatus func(t *testing.T, federation *keycloakApi.KeycloakRealmUserFederation)
+	}{
+		{
+			name: "create federation with mappers and full sync",
+			federation: func() *keycloakApi.KeycloakRealmUserFederation {
+				f := getTestFederation()
+				f.Annotations = map[string]string{keycloakApi.UserFederationSyncAnnotation: keycloakApi.UserFederationSyncFull}
+				f.Spec.Mappers = []keycloakApi.UserFederationMapper{
+					{Name: "email", ProviderID: "user-attribute-ldap-mapper", Config: map[string][]string{"ldap.attribute": {"mail"}}},
+				}
+
+				return f
+			},
+			objects: []client.Object{bindSecret},
+			setupMocks: func(h *helpermock.ControllerHelper, kClient *adapter.Mock) {
+				realmMocks(h, kClient)
+				kClient.On("GetUserFederation", "realm", "realm-id", "ldap").Return(nil, adapter.NotFoundError("not found"))
+				kClient.On("TestLDAPConnection", "realm", matchLDAPTest(adapter.LDAPTestConnection)).Return(nil)
+				kClient.On("TestLDAPConnection", "realm", matchLDAPTest(adapter.LDAPTestAuthentication)).Return(nil)
+				kClient.On("CreateUserFederation", "realm", testifymock.MatchedBy(func(c *adapter.Component) bool {
+					return c.Name == "ldap" && c.ProviderID == keycloakApi.UserFederationProviderLDAP &&
+						c.Config["bindCredential"][0] == "ldap-password"
+				})).Return("fed-id", nil)
+				kClient.On("SyncUserFederationMappers", "realm", "fed-id", []adapter.Component{
+					{Name: "email", ProviderID: "user-attribute-ldap-mapper", Config: map[string][]string{"ldap.attribute": {"mail"}}},
+				}, true).Return(nil)
+				kClient.On("TriggerUserFederationSync", "realm", "fed-id", adapter.UserFederationSyncFull).
+					Return(&adapter.UserFederationSyncResult{Added: 3, Status: "3 imported users"}, nil)
+			},
+			wantResult: reconcile.Result{RequeueAfter: time.Hour},
+			checkStatus: func(t *testing.T, federation *keycloakApi.KeycloakRealmUserFederation) {
+				require.Equal(t, helper.StatusOK, federation.Status.Value)
+				require.Equal(t, "fed-id", federation.Status.ID)
+				require.NotNil(t, federation.Status.LastSync)
+				require.Equal(t, keycloakApi.UserFederationSyncFull, federation.Status.LastSync.Type)
+				require.Equal(t, 3, federation.Status.LastSync.Added)
+				require.Equal(t, "3 imported users", federation.Status.LastSync.Message)
+				require.NotContains(t, federation.Annotations, keycloakApi.UserFederationSyncAnnotation)
+			},
+		},
+		{
+			name: "update federation without connection test",
+			federation: func() *keycloakApi.KeycloakRealmUserFederation {
+				f := getTestFederation()
+				f.Spec.SkipConnectionTest = true
+
+				return f
+			},
+			objects: []client.Object{bindSecret},
+			setupMocks: func(h *helpermock.ControllerHelper, kClient *adapter.Mock) {
+				realmMocks(h, kClient)
+				kClient.On("GetUserFederation", "realm", "realm-id", "ldap").Return(&adapter.Component{ID: "fed-id", Name: "ldap"}, nil)
+				kClient.On("UpdateUserFederation", "realm", testifymock.MatchedBy(func(c *adapter.Component) bool {
+					return c.ID == "fed-id"
+				})).Return(nil)
+			},
+			wantResult: reconcile.Result{RequeueAfter: time.Hour},
+			checkStatus: func(t *testing.T, federation *keycloakApi.KeycloakRealmUserFederation) {
+				require.Equal(t, helper.StatusOK, federation.Status.Value)
+				require.Equal(t, "fed-id", federation.Status.ID)
+				require.Nil(t, federation.Status.LastSync)
+			},
+		},
+		{
+			name: "unknown sync type",
+			federation: func() *keycloakApi.KeycloakRealmUserFederation {
+				f := getTestFederation()
+				f.Annotations = map[string]string{keycloakApi.UserFederationSyncAnnotation: "all"}
+				f.Spec.SkipConnectionTest = true
+
+				return f
+			},
+			objects: []client.Object{bindSecret},
+			setupMocks: func(h *helpermock.ControllerHelper, kClient *adapter.Mock) {
+				realmMocks(h, kClient)
+				kClient.On("GetUserFederation", "realm", "realm-id", "ldap").Return(&adapter.Component{ID: "fed-id", Name: "ldap"}, nil)
+				kClient.On("UpdateUserFederation", "realm", testifymock.Anything).Return(nil)
+			},
+			wantResult: reconcile.Result{RequeueAfter: time.Hour},
+			checkStatus: func(t *testing.T, federation *keycloakApi.KeycloakRealmUserFederation) {
+				require.Equal(t, helper.StatusOK, federation.Status.Value)
+				require.Contains(t, federation.Status.LastSync.Message, `unknown sync type "all"`)
+				require.NotContains(t, federation.Annotations, keycloakApi.UserFederationSyncAnnotation)
+			},
+		},
+		{
+			name: "federation is found by status id",
+			federation: func() *keycloakApi.KeycloakRealmUserFederation {
+				f := getTestFederation()
+				f.Spec.SkipConnectionTest = true
+				f.Status.ID = "fed-id"
+
+				return f
+			},
+			objects: []client.Object{bindSecret},
+			setupMocks: func(h *helpermock.ControllerHelper, kClient *adapter.Mock) {
+				realmMocks(h, kClient)
+				kClient.On("GetComponentByID", "realm", "fed-id").Return(&adapter.Component{ID: "fed-id", Name: "ldap"}, nil)
+				kClient.On("UpdateUserFederation", "realm", testifymock.MatchedBy(func(c *adapter.Component) bool {
+					return c.ID == "fed-id"
+				})).Return(nil)
+			},
+			wantResult: reconcile.Result{RequeueAfter: time.Hour},
+			checkStatus: func(t *testing.T, federation *keycloakApi.KeycloakRealmUserFederation) {
+				require.Equal(t, helper.StatusOK, federation.Status.Value)
+				require.Equal(t, "fed-id", federation.Status.ID)
+			},
+		},
+		{
+			name: "users sync failed",
+			federation: func() *keycloakApi.KeycloakRealmUserFederation {
+				f := getTestFederation()
+				f.Annotations = map[string]string{keycloakApi.UserFederationSyncAnnotation: keycloakApi.UserFederationSyncChanged}
+				f.Spec.SkipConnectionTest = true
+
+				return f
+			},
+			objects: []client.Object{bindSecret},
+			setupMocks: func(h *helpermock.ControllerHelper, kClient *adapter.Mock) {
+				realmMocks(h, kClient)
+				h.On("SetFailureCount", testifymock.Anything).Return(time.Minute)
+				kClient.On("GetUserFederation", "realm", "realm-id", "ldap").Return(&adapter.Component{ID: "fed-id", Name: "ldap"}, nil)
+				kClient.On("UpdateUserFederation", "realm", testifymock.Anything).Return(nil)
+				kClient.On("TriggerUserFederationSync", "realm", "fed-id", adapter.UserFederationSyncChanged).
+					Return(nil, errors.New("ldap is not available"))
+			},
+			wantResult: reconcile.Result{RequeueAfter: time.Minute},
+			checkStatus: func(t *testing.T, federation *keycloakApi.KeycloakRealmUserFederation) {
+				require.Contains(t, federation.Status.Value, "unable to synchronize user federation users")
+				require.Equal(t, "ldap is not available", federation.Status.LastSync.Message)
+				require.Equal(t, keycloakApi.UserFederationSyncChanged, federation.Annotations[keycloakApi.UserFederationSyncAnnotation])
+			},
+		},
+		{
+			name:       "ldap connection failed",
+			federation: getTestFederation,
+			objects:    []client.Object{bindSecret},
+			setupMocks: func(h *helpermock.ControllerHelper, kClient *adapter.Mock) {
+				realmMocks(h, kClient)
+				h.On("SetFailureCount", testifymock.Anything).Return(time.Minute)
+				kClient.On("GetUserFederation", "realm", "realm-id", "ldap").Return(nil, adapter.NotFoundError("not found"))
+				kClient.On("TestLDAPConnection", "realm", matchLDAPTest(adapter.LDAPTestConnection)).
+					Return(errors.New("connection refused"))
+			},
+			wantResult: reconcile.Result{RequeueAfter: time.Minute},
+			checkStatus: func(t *testing.T, federation *keycloakApi.KeycloakRealmUserFederation) {
+				require.Contains(t, federation.Status.Value, "unable to connect to ldap server")
+			},
+		},
+		{
+			name:       "bind credential secret not found",
+			federation: getTestFederation,
+			setupMocks: func(h *helpermock.ControllerHelper, kClient *adapter.Mock) {
+				realmMocks(h, kClient)
+				h.On("SetFailureCount", testifymock.Anything).Return(time.Minute)
+			},
+			wantResult: reconcile.Result{RequeueAfter: time.Minute},
+			checkStatus: func(t *testing.T, federation *keycloakApi.KeycloakRealmUserFederation) {
+				require.Contains(t, federation.Status.Value, "unable to get bind credential")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			cl := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(append(tt.objects, tt.federation())...).
+				Build()
+			h := helpermock.NewControllerHelper(t)
+			kClient := new(adapter.Mock)
+			tt.setupMocks(h, kClient)
+
+			r := NewReconcile(cl, h, secretref.NewSecretRef(cl))
+			r.successReconcileTimeout = time.Hour
+
+			res, err := r.Reconcile(context.Background(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: "ldap", Namespace: ns},
+			})
+			require.NoError(t, err)
+			require.Equal(t, tt.wantResult, res)
+
+			federation := &keycloakApi.KeycloakRealmUserFederation{}
+			require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Name: "ldap", Namespace: ns}, federation))
+			tt.checkStatus(t, federation)
+			kClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestIsSpecUpdated(t *testing.T) {
+	t.Parallel()
+
+	old := getTestFederation()
+
+	withAnnotation := getTestFederation()
+	withAnnotation.Annotations = map[string]string{keycloakApi.UserFederationSyncAnnotation: keycloakApi.UserFederationSyncChanged}
+
+	require.False(t, isSpecUpdated(event.UpdateEvent{ObjectOld: old, ObjectNew: getTestFederation()}))
+	require.True(t, isSpecUpdated(event.UpdateEvent{ObjectOld: old, ObjectNew: withAnnotation}))
+	require.False(t, isSpecUpdated(event.UpdateEvent{ObjectOld: withAnnotation, ObjectNew: old}))
+}
+
+func TestIndexSecretNames(t *testing.T) {
+	t.Parallel()
+
+	federation := getTestFederation()
+	federation.Spec.Config = map[string][]string{"krbPrincipalAttribute": {"$krb-secret:principal"}}
+
+	require.Equal(t, []string{"krb-secret", "ldap-secret"}, indexSecretNames(federation))
+}
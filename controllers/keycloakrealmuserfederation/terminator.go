@@ -0,0 +1,65 @@
+package keycloakrealmuserfederation
+
+import (
+	"context"
+	"fmt"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+type terminator struct {
+	realmName                   string
+	realmID                     string
+	federationID                string
+	federationName              string
+	kClient                     keycloak.Client
+	preserveResourcesOnDeletion bool
+}
+
+// makeTerminator creates terminator which deletes the user federation by ID.
+// If the ID is unknown, the user federation is looked up by name among the user federations of the realm.
+func makeTerminator(
+	realmName, realmID, federationID, federationName string,
+	kClient keycloak.Client,
+	preserveResourcesOnDeletion bool,
+) *terminator {
+	return &terminator{
+		realmName:                   realmName,
+		realmID:                     realmID,
+		federationID:                federationID,
+		federationName:              federationName,
+		kClient:                     kClient,
+		preserveResourcesOnDeletion: preserveResourcesOnDeletion,
+	}
+}
+
+func (t *terminator) DeleteResource(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx)
+	if t.preserveResourcesOnDeletion {
+		log.Info("PreserveResourcesOnDeletion is enabled, skipping deletion.")
+		return nil
+	}
+
+	log.Info("Start deleting KeycloakRealmUserFederation")
+
+	federation, err := getUserFederation(ctx, t.kClient, t.realmName, t.realmID, t.federationID, t.federationName)
+	if err != nil {
+		if adapter.IsErrNotFound(err) {
+			log.Info("User federation not found, skipping deletion")
+			return nil
+		}
+
+		return fmt.Errorf("unable to get user federation: %w", err)
+	}
+
+	if err = t.kClient.DeleteUserFederation(ctx, t.realmName, federation.ID); err != nil {
+		return fmt.Errorf("unable to delete user federation: %w", err)
+	}
+
+	log.Info("KeycloakRealmUserFederation deletion done")
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package keycloakrealmuserfederation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+func TestTerminator_DeleteResource(t *testing.T) {
+	kClient := new(adapter.Mock)
+	kClient.On("GetUserFederation", "realm", "realm-id", "ldap").Return(&adapter.Component{ID: "fed-id"}, nil).Once()
+	kClient.On("DeleteUserFederation", "realm", "fed-id").Return(nil).Once()
+
+	term := makeTerminator("realm", "realm-id", "", "ldap", kClient, false)
+	require.NoError(t, term.DeleteResource(context.Background()))
+
+	kClient.On("GetUserFederation", "realm", "realm-id", "ldap").Return(nil, adapter.NotFoundError("not found")).Once()
+	require.NoError(t, term.DeleteResource(context.Background()))
+
+	kClient.On("GetUserFederation", "realm", "realm-id", "ldap").Return(&adapter.Component{ID: "fed-id"}, nil).Once()
+	kClient.On("DeleteUserFederation", "realm", "fed-id").Return(errors.New("fatal")).Once()
+	require.ErrorContains(t, term.DeleteResource(context.Background()), "unable to delete user federation")
+
+	kClient.AssertExpectations(t)
+}
+
+func TestTerminator_DeleteResourceByID(t *testing.T) {
+	kClient := new(adapter.Mock)
+	kClient.On("GetComponentByID", "realm", "fed-id").Return(&adapter.Component{ID: "fed-id"}, nil).Once()
+	kClient.On("DeleteUserFederation", "realm", "fed-id").Return(nil).Once()
+
+	term := makeTerminator("realm", "realm-id", "fed-id", "ldap", kClient, false)
+	require.NoError(t, term.DeleteResource(context.Background()))
+
+	kClient.On("GetComponentByID", "realm", "fed-id").Return(nil, adapter.NotFoundError("not found")).Once()
+	kClient.On("GetUserFederation", "realm", "realm-id", "ldap").Return(nil, adapter.NotFoundError("not found")).Once()
+	require.NoError(t, term.DeleteResource(context.Background()))
+
+	kClient.AssertExpectations(t)
+}
+
+func TestTerminatorSkipDeletion(t *testing.T) {
+	term := makeTerminator("realm", "realm-id", "fed-id", "ldap", nil, true)
+
+	require.NoError(t, term.DeleteResource(context.Background()))
+}
@@ -30,16 +30,21 @@ import (
 	"github.com/epam/edp-keycloak-operator/controllers/clusterkeycloakrealm"
 	"github.com/epam/edp-keycloak-operator/controllers/helper"
 	"github.com/epam/edp-keycloak-operator/controllers/keycloak"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakadminpermission"
 	"github.com/epam/edp-keycloak-operator/controllers/keycloakauthflow"
 	"github.com/epam/edp-keycloak-operator/controllers/keycloakclient"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakclientrole"
 	"github.com/epam/edp-keycloak-operator/controllers/keycloakclientscope"
 	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealm"
 	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealmcomponent"
 	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealmgroup"
 	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealmidentityprovider"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealmkeyrotation"
 	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealmrole"
 	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealmrolebatch"
 	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealmuser"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealmuserbatch"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealmuserfederation"
 	"github.com/epam/edp-keycloak-operator/pkg/secretref"
 	"github.com/epam/edp-keycloak-operator/pkg/util"
 )
@@ -182,12 +187,24 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = keycloakrealmuserbatch.NewReconcile(mgr.GetClient(), h).
+		SetupWithManager(mgr, successReconcileTimeoutValue); err != nil {
+		setupLog.Error(err, "unable to create keycloak-realm-user-batch controller")
+		os.Exit(1)
+	}
+
 	if err = keycloakclientscope.NewReconcile(mgr.GetClient(), h).
 		SetupWithManager(mgr, successReconcileTimeoutValue); err != nil {
 		setupLog.Error(err, "unable to create keycloak-client-scope controller")
 		os.Exit(1)
 	}
 
+	if err = keycloakclientrole.NewReconcile(mgr.GetClient(), h).
+		SetupWithManager(mgr, successReconcileTimeoutValue); err != nil {
+		setupLog.Error(err, "unable to create keycloak-client-role controller")
+		os.Exit(1)
+	}
+
 	if err = keycloakrealmcomponent.NewReconcile(mgr.GetClient(), mgr.GetScheme(), h, secretref.NewSecretRef(mgr.GetClient())).
 		SetupWithManager(mgr, successReconcileTimeoutValue); err != nil {
 		setupLog.Error(err, "unable to create keycloak-realm-component controller")
@@ -200,6 +217,24 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = keycloakrealmuserfederation.NewReconcile(mgr.GetClient(), h, secretref.NewSecretRef(mgr.GetClient())).
+		SetupWithManager(mgr, successReconcileTimeoutValue); err != nil {
+		setupLog.Error(err, "unable to create keycloak-realm-user-federation controller")
+		os.Exit(1)
+	}
+
+	if err = keycloakrealmkeyrotation.NewReconcile(mgr.GetClient(), h).
+		SetupWithManager(mgr, successReconcileTimeoutValue); err != nil {
+		setupLog.Error(err, "unable to create keycloak-realm-key-rotation controller")
+		os.Exit(1)
+	}
+
+	if err = keycloakadminpermission.NewReconcile(mgr.GetClient(), h).
+		SetupWithManager(mgr, successReconcileTimeoutValue); err != nil {
+		setupLog.Error(err, "unable to create keycloak-admin-permission controller")
+		os.Exit(1)
+	}
+
 	if ns == "" {
 		if err = clusterkeycloak.NewReconcile(mgr.GetClient(), mgr.GetScheme(), h, operatorNamespace).
 			SetupWithManager(mgr); err != nil {
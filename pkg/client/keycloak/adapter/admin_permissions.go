@@ -0,0 +1,395 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Nerzal/gocloak/v12"
+)
+
+const (
+	// AdminPermissionTargetGroup is a target of the admin permissions of the group.
+	AdminPermissionTargetGroup = "group"
+
+	// AdminPermissionTargetClient is a target of the admin permissions of the client.
+	AdminPermissionTargetClient = "client"
+
+	// AdminPermissionTargetUsers is a target of the admin permissions of all realm users.
+	AdminPermissionTargetUsers = "users"
+
+	// AdminPermissionTargetIdentityProvider is a target of the admin permissions of the identity provider.
+	AdminPermissionTargetIdentityProvider = "identityProvider"
+
+	// AdminPermissionPolicyTypeUser is a type of the policy which grants access to the listed users.
+	AdminPermissionPolicyTypeUser = "user"
+
+	// AdminPermissionPolicyTypeGroup is a type of the policy which grants access to the members of the listed groups.
+	AdminPermissionPolicyTypeGroup = "group"
+
+	// AdminPermissionPolicyTypeRole is a type of the policy which grants access to the users with the listed roles.
+	AdminPermissionPolicyTypeRole = "role"
+
+	// realmManagementClient is a client which resource server holds the admin permissions of the realm.
+	realmManagementClient = "realm-management"
+)
+
+// ManagementPermissions is a state of the admin permissions of the target.
+type ManagementPermissions struct {
+	Enabled bool `json:"enabled"`
+
+	// Resource is an ID of the target resource in the realm-management resource server.
+	Resource string `json:"resource,omitempty"`
+
+	// ScopePermissions is a map of the scope name to the ID of the scope permission.
+	ScopePermissions map[string]string `json:"scopePermissions,omitempty"`
+}
+
+// AdminPermissionPolicy is a policy of the realm-management resource server.
+// Users, groups and roles are referenced by names and resolved to IDs on sync.
+type AdminPermissionPolicy struct {
+	Name        string
+	Type        string
+	Description string
+	Logic       string
+	Users       []string
+	Groups      []AdminPermissionPolicyGroup
+	Roles       []AdminPermissionPolicyRole
+}
+
+// AdminPermissionPolicyGroup is a group of the group policy.
+type AdminPermissionPolicyGroup struct {
+	// Group is a group name or a group path starting with "/" for subgroups.
+	Group          string
+	ExtendChildren bool
+}
+
+// AdminPermissionPolicyRole is a role of the role policy.
+type AdminPermissionPolicyRole struct {
+	Role string
+
+	// ClientID is a clientId of the client role. Realm role is used if it is empty.
+	ClientID string
+	Required bool
+}
+
+// SetManagementPermissions enables or disables admin permissions of the target and returns their state.
+// Target name is a group name or path, clientId or identity provider alias. It is ignored for users target.
+// Disabling of the permissions removes the target resource and its scope permissions from realm-management.
+func (a GoCloakAdapter) SetManagementPermissions(
+	ctx context.Context,
+	realmName, targetType, targetName string,
+	enabled bool,
+) (*ManagementPermissions, error) {
+	if err := a.checkFeatureEnabled(FeatureAdminFineGrainedAuthz); err != nil {
+		return nil, err
+	}
+
+	path, pathParams, err := a.managementPermissionsPath(ctx, realmName, targetType, targetName)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions := &ManagementPermissions{}
+
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(pathParams).
+		SetBody(ManagementPermissions{Enabled: enabled}).
+		SetResult(permissions).
+		Put(a.buildPath(path))
+	if err == nil && rsp.StatusCode() == http.StatusNotFound {
+		return nil, NotFoundError(fmt.Sprintf("%s %s not found", targetType, targetName))
+	}
+
+	if err = a.checkError(err, rsp); err != nil {
+		return nil, fmt.Errorf("unable to set management permissions: %w", err)
+	}
+
+	return permissions, nil
+}
+
+func (a GoCloakAdapter) managementPermissionsPath(
+	ctx context.Context,
+	realmName, targetType, targetName string,
+) (string, map[string]string, error) {
+	pathParams := map[string]string{
+		keycloakApiParamRealm: realmName,
+	}
+
+	switch targetType {
+	case AdminPermissionTargetGroup:
+		group, err := a.getGroupByNameOrPath(ctx, realmName, targetName)
+		if err != nil {
+			return "", nil, err
+		}
+
+		pathParams[keycloakApiParamId] = *group.ID
+
+		return groupManagementPermissions, pathParams, nil
+	case AdminPermissionTargetClient:
+		clientID, err := a.GetClientID(targetName, realmName)
+		if err != nil {
+			return "", nil, fmt.Errorf("unable to get client %s: %w", targetName, err)
+		}
+
+		pathParams[keycloakApiParamId] = clientID
+
+		return clientManagementPermissions, pathParams, nil
+	case AdminPermissionTargetUsers:
+		return usersManagementPermissions, pathParams, nil
+	case AdminPermissionTargetIdentityProvider:
+		pathParams[keycloakApiParamAlias] = targetName
+
+		return idpManagementPermissions, pathParams, nil
+	default:
+		return "", nil, fmt.Errorf("unsupported admin permission target type %q", targetType)
+	}
+}
+
+// SyncAdminPermissionPolicy creates or updates the policy of the realm-management resource server by name
+// and returns its ID.
+func (a GoCloakAdapter) SyncAdminPermissionPolicy(
+	ctx context.Context,
+	realmName string,
+	policy *AdminPermissionPolicy,
+) (string, error) {
+	idOfClient, err := a.GetClientID(realmManagementClient, realmName)
+	if err != nil {
+		return "", fmt.Errorf("unable to get %s client: %w", realmManagementClient, err)
+	}
+
+	representation, err := a.makeAdminPermissionPolicy(ctx, realmName, policy)
+	if err != nil {
+		return "", err
+	}
+
+	existing, err := a.GetAuthzPolicyByName(ctx, realmName, idOfClient, policy.Name)
+	if err != nil && !IsErrNotFound(err) {
+		return "", err
+	}
+
+	if existing == nil {
+		id, err := a.CreateAuthzPolicy(ctx, realmName, idOfClient, representation)
+		if err != nil {
+			return "", err
+		}
+
+		return id, nil
+	}
+
+	if gocloak.PString(existing.Type) != policy.Type {
+		return "", fmt.Errorf("policy %s already exists with type %s", policy.Name, gocloak.PString(existing.Type))
+	}
+
+	representation.ID = existing.ID
+
+	if err = a.UpdateAuthzPolicy(ctx, realmName, idOfClient, representation); err != nil {
+		return "", err
+	}
+
+	return *existing.ID, nil
+}
+
+func (a GoCloakAdapter) makeAdminPermissionPolicy(
+	ctx context.Context,
+	realmName string,
+	policy *AdminPermissionPolicy,
+) (*gocloak.PolicyRepresentation, error) {
+	representation := &gocloak.PolicyRepresentation{
+		Name:             gocloak.StringP(policy.Name),
+		Type:             gocloak.StringP(policy.Type),
+		Description:      gocloak.StringP(policy.Description),
+		DecisionStrategy: gocloak.UNANIMOUS,
+		Logic:            gocloak.POSITIVE,
+	}
+
+	if policy.Logic != "" {
+		logic := gocloak.Logic(policy.Logic)
+		representation.Logic = &logic
+	}
+
+	switch policy.Type {
+	case AdminPermissionPolicyTypeUser:
+		users := make([]string, 0, len(policy.Users))
+
+		for _, username := range policy.Users {
+			id, err := a.getUserIDByUsername(ctx, realmName, username)
+			if err != nil {
+				return nil, err
+			}
+
+			users = append(users, id)
+		}
+
+		representation.Users = &users
+	case AdminPermissionPolicyTypeGroup:
+		groups := make([]gocloak.GroupDefinition, 0, len(policy.Groups))
+
+		for _, g := range policy.Groups {
+			group, err := a.getGroupByNameOrPath(ctx, realmName, g.Group)
+			if err != nil {
+				return nil, err
+			}
+
+			groups = append(groups, gocloak.GroupDefinition{
+				ID:             group.ID,
+				ExtendChildren: gocloak.BoolP(g.ExtendChildren),
+			})
+		}
+
+		representation.Groups = &groups
+	case AdminPermissionPolicyTypeRole:
+		roles := make([]gocloak.RoleDefinition, 0, len(policy.Roles))
+
+		for _, r := range policy.Roles {
+			id, err := a.getRoleID(ctx, realmName, r.ClientID, r.Role)
+			if err != nil {
+				return nil, err
+			}
+
+			roles = append(roles, gocloak.RoleDefinition{
+				ID:       gocloak.StringP(id),
+				Required: gocloak.BoolP(r.Required),
+			})
+		}
+
+		representation.Roles = &roles
+	default:
+		return nil, fmt.Errorf("unsupported policy type %q", policy.Type)
+	}
+
+	return representation, nil
+}
+
+// GetAdminPermissionPolicyID returns ID of the policy of the realm-management resource server by name.
+func (a GoCloakAdapter) GetAdminPermissionPolicyID(ctx context.Context, realmName, name string) (string, error) {
+	idOfClient, err := a.GetClientID(realmManagementClient, realmName)
+	if err != nil {
+		return "", fmt.Errorf("unable to get %s client: %w", realmManagementClient, err)
+	}
+
+	policy, err := a.GetAuthzPolicyByName(ctx, realmName, idOfClient, name)
+	if err != nil {
+		return "", err
+	}
+
+	return *policy.ID, nil
+}
+
+// DeleteAdminPermissionPolicy deletes the policy of the realm-management resource server by name.
+// Deletion of not existing policy is not an error.
+func (a GoCloakAdapter) DeleteAdminPermissionPolicy(ctx context.Context, realmName, name string) error {
+	idOfClient, err := a.GetClientID(realmManagementClient, realmName)
+	if err != nil {
+		return fmt.Errorf("unable to get %s client: %w", realmManagementClient, err)
+	}
+
+	policy, err := a.GetAuthzPolicyByName(ctx, realmName, idOfClient, name)
+	if err != nil {
+		if IsErrNotFound(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	return a.DeleteAuthzPolicy(ctx, realmName, idOfClient, *policy.ID)
+}
+
+// SetScopePermissionPolicies replaces policies and decision strategy of the scope permission of realm-management.
+// Scope permission is created by Keycloak when the management permissions are enabled.
+func (a GoCloakAdapter) SetScopePermissionPolicies(
+	ctx context.Context,
+	realmName, permissionID string,
+	policyIDs []string,
+	decisionStrategy string,
+) error {
+	idOfClient, err := a.GetClientID(realmManagementClient, realmName)
+	if err != nil {
+		return fmt.Errorf("unable to get %s client: %w", realmManagementClient, err)
+	}
+
+	permission, err := a.GetAuthzScopePermission(ctx, realmName, idOfClient, permissionID)
+	if err != nil {
+		return err
+	}
+
+	policies := make([]string, len(policyIDs))
+	copy(policies, policyIDs)
+
+	permission.Policies = &policies
+
+	if decisionStrategy != "" {
+		strategy := gocloak.DecisionStrategy(decisionStrategy)
+		permission.DecisionStrategy = &strategy
+	}
+
+	return a.UpdateAuthzScopePermission(ctx, realmName, idOfClient, permission)
+}
+
+// getGroupByNameOrPath returns the top-level group by name or any group by path starting with "/".
+func (a GoCloakAdapter) getGroupByNameOrPath(ctx context.Context, realmName, group string) (*gocloak.Group, error) {
+	if !strings.HasPrefix(group, "/") {
+		g, err := a.getGroup(realmName, group)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get group %s: %w", group, err)
+		}
+
+		return g, nil
+	}
+
+	g, err := a.client.GetGroupByPath(ctx, a.token.AccessToken, realmName, group)
+	if err != nil {
+		apiErr := new(gocloak.APIError)
+		if errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound {
+			return nil, NotFoundError(fmt.Sprintf("group %s not found", group))
+		}
+
+		return nil, fmt.Errorf("unable to get group %s: %w", group, err)
+	}
+
+	return g, nil
+}
+
+func (a GoCloakAdapter) getUserIDByUsername(ctx context.Context, realmName, username string) (string, error) {
+	users, err := a.client.GetUsers(ctx, a.token.AccessToken, realmName, gocloak.GetUsersParams{
+		Username: gocloak.StringP(username),
+		Exact:    gocloak.BoolP(true),
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to get user %s: %w", username, err)
+	}
+
+	for _, u := range users {
+		if strings.EqualFold(gocloak.PString(u.Username), username) {
+			return *u.ID, nil
+		}
+	}
+
+	return "", NotFoundError(fmt.Sprintf("user %s not found", username))
+}
+
+// getRoleID returns ID of the realm role or of the client role if clientID is specified.
+func (a GoCloakAdapter) getRoleID(ctx context.Context, realmName, clientID, roleName string) (string, error) {
+	if clientID == "" {
+		role, err := a.client.GetRealmRole(ctx, a.token.AccessToken, realmName, roleName)
+		if err != nil {
+			return "", fmt.Errorf("unable to get realm role %s: %w", roleName, err)
+		}
+
+		return *role.ID, nil
+	}
+
+	idOfClient, err := a.GetClientID(clientID, realmName)
+	if err != nil {
+		return "", fmt.Errorf("unable to get client %s: %w", clientID, err)
+	}
+
+	role, err := a.client.GetClientRole(ctx, a.token.AccessToken, realmName, idOfClient, roleName)
+	if err != nil {
+		return "", fmt.Errorf("unable to get client role %s/%s: %w", clientID, roleName, err)
+	}
+
+	return *role.ID, nil
+}
@@ -0,0 +1,310 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const realmManagementPolicies = "/admin/realms/realm/clients/realm-management-id/authz/resource-server/policy"
+
+func mockRealmManagementClient(mockClient *MockGoCloakClient) {
+	mockClient.On("GetClients", "realm", gocloak.GetClientsParams{
+		ClientID: gocloak.StringP(realmManagementClient),
+	}).Return([]*gocloak.Client{{
+		ID:       gocloak.StringP("realm-management-id"),
+		ClientID: gocloak.StringP(realmManagementClient),
+	}}, nil)
+}
+
+func TestGoCloakAdapter_SetManagementPermissions(t *testing.T) {
+	tests := []struct {
+		name       string
+		targetType string
+		targetName string
+		prepare    func(mockClient *MockGoCloakClient)
+		wantErr    require.ErrorAssertionFunc
+		want       *ManagementPermissions
+	}{
+		{
+			name:       "group by name",
+			targetType: AdminPermissionTargetGroup,
+			targetName: "team-a",
+			prepare: func(mockClient *MockGoCloakClient) {
+				mockClient.On("GetGroups", "realm", gocloak.GetGroupsParams{Search: gocloak.StringP("team-a")}).
+					Return([]*gocloak.Group{{ID: gocloak.StringP("group-id"), Name: gocloak.StringP("team-a")}}, nil)
+
+				httpmock.RegisterResponder(http.MethodPut, "/admin/realms/realm/groups/group-id/management/permissions",
+					httpmock.NewJsonResponderOrPanic(http.StatusOK, ManagementPermissions{
+						Enabled:          true,
+						Resource:         "resource-id",
+						ScopePermissions: map[string]string{"manage-members": "permission-id"},
+					}))
+			},
+			wantErr: require.NoError,
+			want: &ManagementPermissions{
+				Enabled:          true,
+				Resource:         "resource-id",
+				ScopePermissions: map[string]string{"manage-members": "permission-id"},
+			},
+		},
+		{
+			name:       "subgroup by path",
+			targetType: AdminPermissionTargetGroup,
+			targetName: "/team-a/leads",
+			prepare: func(mockClient *MockGoCloakClient) {
+				mockClient.On("GetGroupByPath", "realm", "/team-a/leads").
+					Return(&gocloak.Group{ID: gocloak.StringP("subgroup-id")}, nil)
+
+				httpmock.RegisterResponder(http.MethodPut, "/admin/realms/realm/groups/subgroup-id/management/permissions",
+					httpmock.NewJsonResponderOrPanic(http.StatusOK, ManagementPermissions{Enabled: true}))
+			},
+			wantErr: require.NoError,
+			want:    &ManagementPermissions{Enabled: true},
+		},
+		{
+			name:       "client",
+			targetType: AdminPermissionTargetClient,
+			targetName: "app",
+			prepare: func(mockClient *MockGoCloakClient) {
+				mockClient.On("GetClients", "realm", gocloak.GetClientsParams{ClientID: gocloak.StringP("app")}).
+					Return([]*gocloak.Client{{ID: gocloak.StringP("app-id"), ClientID: gocloak.StringP("app")}}, nil)
+
+				httpmock.RegisterResponder(http.MethodPut, "/admin/realms/realm/clients/app-id/management/permissions",
+					httpmock.NewJsonResponderOrPanic(http.StatusOK, ManagementPermissions{Enabled: true}))
+			},
+			wantErr: require.NoError,
+			want:    &ManagementPermissions{Enabled: true},
+		},
+		{
+			name:       "users",
+			targetType: AdminPermissionTargetUsers,
+			prepare: func(mockClient *MockGoCloakClient) {
+				httpmock.RegisterResponder(http.MethodPut, "/admin/realms/realm/users-management-permissions",
+					httpmock.NewJsonResponderOrPanic(http.StatusOK, ManagementPermissions{Enabled: true}))
+			},
+			wantErr: require.NoError,
+			want:    &ManagementPermissions{Enabled: true},
+		},
+		{
+			name:       "identity provider not found",
+			targetType: AdminPermissionTargetIdentityProvider,
+			targetName: "github",
+			prepare: func(mockClient *MockGoCloakClient) {
+				httpmock.RegisterResponder(http.MethodPut, "/admin/realms/realm/identity-provider/instances/github/management/permissions",
+					httpmock.NewStringResponder(http.StatusNotFound, ""))
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.True(t, IsErrNotFound(err))
+			},
+		},
+		{
+			name:       "group not found",
+			targetType: AdminPermissionTargetGroup,
+			targetName: "team-b",
+			prepare: func(mockClient *MockGoCloakClient) {
+				mockClient.On("GetGroups", "realm", gocloak.GetGroupsParams{Search: gocloak.StringP("team-b")}).
+					Return([]*gocloak.Group{}, nil)
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.True(t, IsErrNotFound(err))
+			},
+		},
+		{
+			name:       "unsupported target",
+			targetType: "realm",
+			prepare:    func(mockClient *MockGoCloakClient) {},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.ErrorContains(t, err, "unsupported admin permission target type")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			a, mockClient, _ := initAdapter()
+			tt.prepare(mockClient)
+
+			got, err := a.SetManagementPermissions(context.Background(), "realm", tt.targetType, tt.targetName, true)
+
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGoCloakAdapter_SyncAdminPermissionPolicy(t *testing.T) {
+	t.Run("create group policy", func(t *testing.T) {
+		a, mockClient, _ := initAdapter()
+		mockRealmManagementClient(mockClient)
+		mockClient.On("GetGroups", "realm", gocloak.GetGroupsParams{Search: gocloak.StringP("team-a-leads")}).
+			Return([]*gocloak.Group{{ID: gocloak.StringP("group-id"), Name: gocloak.StringP("team-a-leads")}}, nil)
+
+		httpmock.RegisterResponder(http.MethodGet, realmManagementPolicies+"/search?name=team-a-leads",
+			httpmock.NewStringResponder(http.StatusNoContent, ""))
+		httpmock.RegisterResponder(http.MethodPost, realmManagementPolicies+"/group",
+			func(req *http.Request) (*http.Response, error) {
+				var policy gocloak.PolicyRepresentation
+				if err := json.NewDecoder(req.Body).Decode(&policy); err != nil {
+					return nil, err
+				}
+
+				if len(*policy.Groups) != 1 || *(*policy.Groups)[0].ID != "group-id" {
+					return httpmock.NewStringResponse(http.StatusBadRequest, "wrong groups"), nil
+				}
+
+				return httpmock.NewJsonResponse(http.StatusCreated, gocloak.PolicyRepresentation{ID: gocloak.StringP("policy-id")})
+			})
+
+		id, err := a.SyncAdminPermissionPolicy(context.Background(), "realm", &AdminPermissionPolicy{
+			Name:   "team-a-leads",
+			Type:   AdminPermissionPolicyTypeGroup,
+			Groups: []AdminPermissionPolicyGroup{{Group: "team-a-leads"}},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "policy-id", id)
+	})
+
+	t.Run("update role policy", func(t *testing.T) {
+		a, mockClient, _ := initAdapter()
+		mockRealmManagementClient(mockClient)
+		mockClient.On("GetRealmRole", "realm", "team-lead").
+			Return(&gocloak.Role{ID: gocloak.StringP("realm-role-id")}, nil)
+		mockClient.On("GetClients", "realm", gocloak.GetClientsParams{ClientID: gocloak.StringP("app")}).
+			Return([]*gocloak.Client{{ID: gocloak.StringP("app-id"), ClientID: gocloak.StringP("app")}}, nil)
+		mockClient.On("GetClientRole", "realm", "app-id", "admin").
+			Return(&gocloak.Role{ID: gocloak.StringP("client-role-id")}, nil)
+
+		httpmock.RegisterResponder(http.MethodGet, realmManagementPolicies+"/search?name=leads",
+			httpmock.NewJsonResponderOrPanic(http.StatusOK, gocloak.PolicyRepresentation{
+				ID:   gocloak.StringP("policy-id"),
+				Type: gocloak.StringP(AdminPermissionPolicyTypeRole),
+			}))
+		httpmock.RegisterResponder(http.MethodPut, realmManagementPolicies+"/role/policy-id",
+			httpmock.NewStringResponder(http.StatusCreated, ""))
+
+		id, err := a.SyncAdminPermissionPolicy(context.Background(), "realm", &AdminPermissionPolicy{
+			Name:  "leads",
+			Type:  AdminPermissionPolicyTypeRole,
+			Logic: "NEGATIVE",
+			Roles: []AdminPermissionPolicyRole{
+				{Role: "team-lead"},
+				{Role: "admin", ClientID: "app", Required: true},
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "policy-id", id)
+	})
+
+	t.Run("policy exists with another type", func(t *testing.T) {
+		a, mockClient, _ := initAdapter()
+		mockRealmManagementClient(mockClient)
+		mockClient.On("GetUsers", "realm", gocloak.GetUsersParams{
+			Username: gocloak.StringP("john"),
+			Exact:    gocloak.BoolP(true),
+		}).Return([]*gocloak.User{{ID: gocloak.StringP("user-id"), Username: gocloak.StringP("john")}}, nil)
+
+		httpmock.RegisterResponder(http.MethodGet, realmManagementPolicies+"/search?name=john",
+			httpmock.NewJsonResponderOrPanic(http.StatusOK, gocloak.PolicyRepresentation{
+				ID:   gocloak.StringP("policy-id"),
+				Type: gocloak.StringP(AdminPermissionPolicyTypeGroup),
+			}))
+
+		_, err := a.SyncAdminPermissionPolicy(context.Background(), "realm", &AdminPermissionPolicy{
+			Name:  "john",
+			Type:  AdminPermissionPolicyTypeUser,
+			Users: []string{"john"},
+		})
+
+		require.ErrorContains(t, err, "policy john already exists with type group")
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		a, mockClient, _ := initAdapter()
+		mockRealmManagementClient(mockClient)
+		mockClient.On("GetUsers", "realm", gocloak.GetUsersParams{
+			Username: gocloak.StringP("jane"),
+			Exact:    gocloak.BoolP(true),
+		}).Return([]*gocloak.User{}, nil)
+
+		_, err := a.SyncAdminPermissionPolicy(context.Background(), "realm", &AdminPermissionPolicy{
+			Name:  "jane",
+			Type:  AdminPermissionPolicyTypeUser,
+			Users: []string{"jane"},
+		})
+
+		require.Error(t, err)
+		require.True(t, IsErrNotFound(err))
+	})
+}
+
+func TestGoCloakAdapter_DeleteAdminPermissionPolicy(t *testing.T) {
+	a, mockClient, _ := initAdapter()
+	mockRealmManagementClient(mockClient)
+
+	httpmock.RegisterResponder(http.MethodGet, realmManagementPolicies+"/search?name=deleted",
+		httpmock.NewStringResponder(http.StatusNoContent, ""))
+	require.NoError(t, a.DeleteAdminPermissionPolicy(context.Background(), "realm", "deleted"))
+
+	httpmock.RegisterResponder(http.MethodGet, realmManagementPolicies+"/search?name=leads",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, gocloak.PolicyRepresentation{ID: gocloak.StringP("policy-id")}))
+	httpmock.RegisterResponder(http.MethodDelete, realmManagementPolicies+"/policy-id",
+		httpmock.NewStringResponder(http.StatusNoContent, ""))
+	require.NoError(t, a.DeleteAdminPermissionPolicy(context.Background(), "realm", "leads"))
+
+	httpmock.RegisterResponder(http.MethodGet, realmManagementPolicies+"/search?name=failed",
+		httpmock.NewStringResponder(http.StatusInternalServerError, "fatal"))
+	require.ErrorContains(t, a.DeleteAdminPermissionPolicy(context.Background(), "realm", "failed"), "unable to search policy")
+}
+
+func TestGoCloakAdapter_SetScopePermissionPolicies(t *testing.T) {
+	a, mockClient, _ := initAdapter()
+	mockRealmManagementClient(mockClient)
+
+	permissionPath := "/admin/realms/realm/clients/realm-management-id/authz/resource-server/permission/scope/permission-id"
+
+	httpmock.RegisterResponder(http.MethodGet, permissionPath,
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, gocloak.PermissionRepresentation{
+			ID:               gocloak.StringP("permission-id"),
+			Name:             gocloak.StringP("manage.members.permission.group.group-id"),
+			DecisionStrategy: gocloak.UNANIMOUS,
+		}))
+
+	var updated gocloak.PermissionRepresentation
+
+	httpmock.RegisterResponder(http.MethodPut, permissionPath,
+		func(req *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(req.Body).Decode(&updated); err != nil {
+				return nil, err
+			}
+
+			return httpmock.NewStringResponse(http.StatusCreated, ""), nil
+		})
+
+	err := a.SetScopePermissionPolicies(context.Background(), "realm", "permission-id",
+		[]string{"policy-1", "policy-2"}, "AFFIRMATIVE")
+	require.NoError(t, err)
+
+	assert.Equal(t, "manage.members.permission.group.group-id", gocloak.PString(updated.Name))
+	assert.Equal(t, []string{"policy-1", "policy-2"}, *updated.Policies)
+	assert.Equal(t, gocloak.AFFIRMATIVE, updated.DecisionStrategy)
+
+	httpmock.RegisterResponder(http.MethodGet,
+		"/admin/realms/realm/clients/realm-management-id/authz/resource-server/permission/scope/missing",
+		httpmock.NewStringResponder(http.StatusNotFound, ""))
+
+	err = a.SetScopePermissionPolicies(context.Background(), "realm", "missing", nil, "")
+	require.Error(t, err)
+	require.True(t, IsErrNotFound(err))
+}
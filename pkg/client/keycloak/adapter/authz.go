@@ -0,0 +1,136 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/Nerzal/gocloak/v12"
+)
+
+// GetAuthzPolicyByName returns policy of the client resource server by name.
+// Client is identified by its ID (idOfClient), not by clientId, in all authorization services methods.
+func (a GoCloakAdapter) GetAuthzPolicyByName(
+	ctx context.Context,
+	realmName, idOfClient, name string,
+) (*gocloak.PolicyRepresentation, error) {
+	policy := &gocloak.PolicyRepresentation{}
+
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realmName,
+		keycloakApiParamId:    idOfClient,
+	}).SetQueryParam("name", name).SetResult(policy).Get(a.buildPath(authzPolicySearch))
+	if err = a.checkError(err, rsp); err != nil {
+		return nil, fmt.Errorf("unable to search policy: %w", err)
+	}
+
+	// Keycloak responds with 204 No Content if the policy doesn't exist.
+	if rsp.StatusCode() == http.StatusNoContent || policy.ID == nil {
+		return nil, NotFoundError("policy not found")
+	}
+
+	return policy, nil
+}
+
+// CreateAuthzPolicy creates policy of the client resource server and returns its ID.
+// Policy type is used as a policy provider, e.g. user, group or role.
+func (a GoCloakAdapter) CreateAuthzPolicy(
+	ctx context.Context,
+	realmName, idOfClient string,
+	policy *gocloak.PolicyRepresentation,
+) (string, error) {
+	created := &gocloak.PolicyRepresentation{}
+
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realmName,
+		keycloakApiParamId:    idOfClient,
+		"type":                gocloak.PString(policy.Type),
+	}).SetBody(policy).SetResult(created).Post(a.buildPath(authzPolicyCreate))
+	if err = a.checkError(err, rsp); err != nil {
+		return "", fmt.Errorf("unable to create policy: %w", err)
+	}
+
+	if created.ID == nil {
+		return "", fmt.Errorf("policy ID is not returned in response")
+	}
+
+	return *created.ID, nil
+}
+
+// UpdateAuthzPolicy updates policy of the client resource server. Policy ID and type are required.
+func (a GoCloakAdapter) UpdateAuthzPolicy(
+	ctx context.Context,
+	realmName, idOfClient string,
+	policy *gocloak.PolicyRepresentation,
+) error {
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realmName,
+		keycloakApiParamId:    idOfClient,
+		"type":                gocloak.PString(policy.Type),
+		"policyID":            gocloak.PString(policy.ID),
+	}).SetBody(policy).Put(a.buildPath(authzPolicyEntity))
+	if err = a.checkError(err, rsp); err != nil {
+		return fmt.Errorf("unable to update policy: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteAuthzPolicy deletes policy of the client resource server. Deletion of not existing policy is not an error.
+func (a GoCloakAdapter) DeleteAuthzPolicy(ctx context.Context, realmName, idOfClient, policyID string) error {
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realmName,
+		keycloakApiParamId:    idOfClient,
+		"policyID":            policyID,
+	}).Delete(a.buildPath(authzPolicyDelete))
+	if err == nil && rsp.StatusCode() == http.StatusNotFound {
+		return nil
+	}
+
+	if err = a.checkError(err, rsp); err != nil {
+		return fmt.Errorf("unable to delete policy: %w", err)
+	}
+
+	return nil
+}
+
+// GetAuthzScopePermission returns scope permission of the client resource server by ID.
+func (a GoCloakAdapter) GetAuthzScopePermission(
+	ctx context.Context,
+	realmName, idOfClient, permissionID string,
+) (*gocloak.PermissionRepresentation, error) {
+	permission := &gocloak.PermissionRepresentation{}
+
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realmName,
+		keycloakApiParamId:    idOfClient,
+		"permissionID":        permissionID,
+	}).SetResult(permission).Get(a.buildPath(authzScopePermission))
+	if err == nil && rsp.StatusCode() == http.StatusNotFound {
+		return nil, NotFoundError("permission not found")
+	}
+
+	if err = a.checkError(err, rsp); err != nil {
+		return nil, fmt.Errorf("unable to get scope permission: %w", err)
+	}
+
+	return permission, nil
+}
+
+// UpdateAuthzScopePermission updates scope permission of the client resource server. Permission ID is required.
+func (a GoCloakAdapter) UpdateAuthzScopePermission(
+	ctx context.Context,
+	realmName, idOfClient string,
+	permission *gocloak.PermissionRepresentation,
+) error {
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realmName,
+		keycloakApiParamId:    idOfClient,
+		"permissionID":        gocloak.PString(permission.ID),
+	}).SetBody(permission).Put(a.buildPath(authzScopePermission))
+	if err = a.checkError(err, rsp); err != nil {
+		return fmt.Errorf("unable to update scope permission: %w", err)
+	}
+
+	return nil
+}
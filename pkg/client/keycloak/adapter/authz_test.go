@@ -0,0 +1,70 @@
+package adapter
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const authzTestPolicies = "/admin/realms/realm/clients/client-id/authz/resource-server/policy"
+
+func TestGoCloakAdapter_GetAuthzPolicyByName(t *testing.T) {
+	a, _, _ := initAdapter()
+
+	httpmock.RegisterResponder(http.MethodGet, authzTestPolicies+"/search?name=policy",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, gocloak.PolicyRepresentation{
+			ID:   gocloak.StringP("policy-id"),
+			Name: gocloak.StringP("policy"),
+		}))
+
+	policy, err := a.GetAuthzPolicyByName(context.Background(), "realm", "client-id", "policy")
+	require.NoError(t, err)
+	assert.Equal(t, "policy-id", gocloak.PString(policy.ID))
+
+	httpmock.RegisterResponder(http.MethodGet, authzTestPolicies+"/search?name=missing",
+		httpmock.NewStringResponder(http.StatusNoContent, ""))
+
+	_, err = a.GetAuthzPolicyByName(context.Background(), "realm", "client-id", "missing")
+	require.Error(t, err)
+	assert.True(t, IsErrNotFound(err))
+}
+
+func TestGoCloakAdapter_CreateAuthzPolicy(t *testing.T) {
+	a, _, _ := initAdapter()
+
+	httpmock.RegisterResponder(http.MethodPost, authzTestPolicies+"/user",
+		httpmock.NewJsonResponderOrPanic(http.StatusCreated, gocloak.PolicyRepresentation{ID: gocloak.StringP("policy-id")}))
+
+	id, err := a.CreateAuthzPolicy(context.Background(), "realm", "client-id", &gocloak.PolicyRepresentation{
+		Name: gocloak.StringP("policy"),
+		Type: gocloak.StringP("user"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "policy-id", id)
+
+	httpmock.RegisterResponder(http.MethodPost, authzTestPolicies+"/group",
+		httpmock.NewStringResponder(http.StatusConflict, "conflict"))
+
+	_, err = a.CreateAuthzPolicy(context.Background(), "realm", "client-id", &gocloak.PolicyRepresentation{
+		Name: gocloak.StringP("policy"),
+		Type: gocloak.StringP("group"),
+	})
+	require.ErrorContains(t, err, "unable to create policy")
+}
+
+func TestGoCloakAdapter_DeleteAuthzPolicy(t *testing.T) {
+	a, _, _ := initAdapter()
+
+	httpmock.RegisterResponder(http.MethodDelete, authzTestPolicies+"/policy-id",
+		httpmock.NewStringResponder(http.StatusNotFound, ""))
+	require.NoError(t, a.DeleteAuthzPolicy(context.Background(), "realm", "client-id", "policy-id"))
+
+	httpmock.RegisterResponder(http.MethodDelete, authzTestPolicies+"/failed",
+		httpmock.NewStringResponder(http.StatusInternalServerError, "fatal"))
+	require.ErrorContains(t, a.DeleteAuthzPolicy(context.Background(), "realm", "client-id", "failed"), "unable to delete policy")
+}
@@ -8,6 +8,9 @@ import (
 	"github.com/pkg/errors"
 )
 
+// KeyProviderType is a component provider type of the realm keys.
+const KeyProviderType = "org.keycloak.keys.KeyProvider"
+
 type Component struct {
 	Name         string              `json:"name"`
 	ParentID     string              `json:"parentId,omitempty"`
@@ -17,6 +20,7 @@ type Component struct {
 	ID           string              `json:"id,omitempty"`
 }
 
+// CreateComponent creates component and sets its ID from the response if Keycloak returns it.
 func (a GoCloakAdapter) CreateComponent(ctx context.Context, realmName string, component *Component) error {
 	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
 		keycloakApiParamRealm: realmName,
@@ -25,6 +29,10 @@ func (a GoCloakAdapter) CreateComponent(ctx context.Context, realmName string, c
 		return errors.Wrap(err, "error during request")
 	}
 
+	if id, err := getIDFromResponseLocation(rsp.RawResponse); err == nil {
+		component.ID = id
+	}
+
 	return nil
 }
 
@@ -94,3 +102,47 @@ func (a GoCloakAdapter) GetComponent(ctx context.Context, realmName, componentNa
 
 	return nil, NotFoundError("component not found")
 }
+
+// GetComponentByID returns component by ID.
+func (a GoCloakAdapter) GetComponentByID(ctx context.Context, realmName, componentID string) (*Component, error) {
+	component := &Component{}
+
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realmName,
+		keycloakApiParamId:    componentID,
+	}).SetResult(component).Get(a.buildPath(realmComponentEntity))
+	if err == nil && rsp.StatusCode() == http.StatusNotFound {
+		return nil, NotFoundError("component not found")
+	}
+
+	if err = a.checkError(err, rsp); err != nil {
+		return nil, fmt.Errorf("unable to get component: %w", err)
+	}
+
+	return component, nil
+}
+
+// GetComponentByParent returns component by name among the components of the given parent.
+// Parent of the top-level components is the realm ID.
+func (a GoCloakAdapter) GetComponentByParent(ctx context.Context, realmName, parentID, componentName string) (*Component, error) {
+	components, err := a.getComponents(ctx, realmName, map[string]string{
+		"parent": parentID,
+		"name":   componentName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range components {
+		if components[i].Name == componentName {
+			return &components[i], nil
+		}
+	}
+
+	return nil, NotFoundError("component not found")
+}
+
+// DeleteComponentByID deletes component by ID. Missing component is not an error.
+func (a GoCloakAdapter) DeleteComponentByID(ctx context.Context, realmName, componentID string) error {
+	return a.deleteComponentByID(ctx, realmName, componentID)
+}
@@ -2,6 +2,7 @@ package adapter
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/Nerzal/gocloak/v12"
@@ -136,3 +137,74 @@ func TestGoCloakAdapter_GetComponent_Failure(t *testing.T) {
 		t.Fatalf("wrong error returned: %s", err.Error())
 	}
 }
+
+func TestGoCloakAdapter_CreateComponent_SetsID(t *testing.T) {
+	kcAdapter, _, _ := initAdapter()
+
+	httpmock.RegisterResponder("POST", "/admin/realms/realm-name/components",
+		func(req *http.Request) (*http.Response, error) {
+			rsp := httpmock.NewStringResponse(http.StatusCreated, "")
+			rsp.Header.Set("Location", "/admin/realms/realm-name/components/new-id")
+
+			return rsp, nil
+		})
+
+	component := testComponent()
+	require.NoError(t, kcAdapter.CreateComponent(context.Background(), "realm-name", component))
+	require.Equal(t, "new-id", component.ID)
+}
+
+func TestGoCloakAdapter_GetComponentByID(t *testing.T) {
+	kcAdapter, _, _ := initAdapter()
+	httpmock.Reset()
+
+	testCmp := testComponent()
+	testCmp.ID = "test-id"
+
+	httpmock.RegisterResponder("GET", "/admin/realms/realm-name/components/test-id",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, testCmp))
+	httpmock.RegisterResponder("GET", "/admin/realms/realm-name/components/missing-id",
+		httpmock.NewStringResponder(http.StatusNotFound, ""))
+	httpmock.RegisterResponder("GET", "/admin/realms/realm-name/components/error-id",
+		httpmock.NewStringResponder(http.StatusInternalServerError, "fatal"))
+
+	component, err := kcAdapter.GetComponentByID(context.Background(), "realm-name", "test-id")
+	require.NoError(t, err)
+	require.Equal(t, testCmp, component)
+
+	_, err = kcAdapter.GetComponentByID(context.Background(), "realm-name", "missing-id")
+	require.True(t, IsErrNotFound(err))
+
+	_, err = kcAdapter.GetComponentByID(context.Background(), "realm-name", "error-id")
+	require.ErrorContains(t, err, "unable to get component")
+}
+
+func TestGoCloakAdapter_GetComponentByParent(t *testing.T) {
+	kcAdapter, _, _ := initAdapter()
+	httpmock.Reset()
+
+	httpmock.RegisterResponder("GET", "/admin/realms/realm-name/components?name=email&parent=ldap-1",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, []Component{
+			{ID: "mapper-1", Name: "email", ParentID: "ldap-1"},
+		}))
+	httpmock.RegisterResponder("GET", "/admin/realms/realm-name/components?name=email&parent=ldap-2",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, []Component{}))
+
+	component, err := kcAdapter.GetComponentByParent(context.Background(), "realm-name", "ldap-1", "email")
+	require.NoError(t, err)
+	require.Equal(t, "mapper-1", component.ID)
+
+	_, err = kcAdapter.GetComponentByParent(context.Background(), "realm-name", "ldap-2", "email")
+	require.True(t, IsErrNotFound(err))
+}
+
+func TestGoCloakAdapter_DeleteComponentByID(t *testing.T) {
+	kcAdapter, _, _ := initAdapter()
+	httpmock.Reset()
+
+	httpmock.RegisterResponder("DELETE", "/admin/realms/realm-name/components/test-id",
+		httpmock.NewStringResponder(http.StatusNoContent, ""))
+
+	require.NoError(t, kcAdapter.DeleteComponentByID(context.Background(), "realm-name", "test-id"))
+	require.Equal(t, 1, httpmock.GetCallCountInfo()["DELETE /admin/realms/realm-name/components/test-id"])
+}
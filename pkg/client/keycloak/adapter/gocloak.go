@@ -42,6 +42,23 @@ type GoCloakClients interface {
 	GetClientScope(ctx context.Context, token, realm, scopeID string) (*gocloak.ClientScope, error)
 	GetClientsDefaultScopes(ctx context.Context, token, realm, clientID string) ([]*gocloak.ClientScope, error)
 	AddDefaultScopeToClient(ctx context.Context, token, realm, clientID, scopeID string) error
+	RemoveDefaultScopeFromClient(ctx context.Context, token, realm, idOfClient, scopeID string) error
+	GetClientsOptionalScopes(ctx context.Context, token, realm, idOfClient string) ([]*gocloak.ClientScope, error)
+	AddOptionalScopeToClient(ctx context.Context, token, realm, idOfClient, scopeID string) error
+	RemoveOptionalScopeFromClient(ctx context.Context, token, realm, idOfClient, scopeID string) error
+	GetClientScopeMappings(ctx context.Context, token, realm, idOfClient string) (*gocloak.MappingsRepresentation, error)
+	CreateClientScopeMappingsRealmRoles(ctx context.Context, token, realm, idOfClient string, roles []gocloak.Role) error
+	DeleteClientScopeMappingsRealmRoles(ctx context.Context, token, realm, idOfClient string, roles []gocloak.Role) error
+	CreateClientScopeMappingsClientRoles(ctx context.Context, token, realm, idOfClient, idOfSelectedClient string,
+		roles []gocloak.Role) error
+	DeleteClientScopeMappingsClientRoles(ctx context.Context, token, realm, idOfClient, idOfSelectedClient string,
+		roles []gocloak.Role) error
+	CreateClientScopesScopeMappingsRealmRoles(ctx context.Context, token, realm, clientScopeID string, roles []gocloak.Role) error
+	DeleteClientScopesScopeMappingsRealmRoles(ctx context.Context, token, realm, clientScopeID string, roles []gocloak.Role) error
+	CreateClientScopesScopeMappingsClientRoles(ctx context.Context, token, realm, idOfClientScope, idOfClient string,
+		roles []gocloak.Role) error
+	DeleteClientScopesScopeMappingsClientRoles(ctx context.Context, token, realm, idOfClientScope, idOfClient string,
+		roles []gocloak.Role) error
 }
 
 type GoCloakUsers interface {
@@ -50,6 +67,10 @@ type GoCloakUsers interface {
 	GetRoleMappingByUserID(ctx context.Context, accessToken, realm,
 		userID string) (*gocloak.MappingsRepresentation, error)
 	UpdateUser(ctx context.Context, accessToken, realm string, user gocloak.User) error
+	GetUserFederatedIdentities(ctx context.Context, token, realm, userID string) ([]*gocloak.FederatedIdentityRepresentation, error)
+	CreateUserFederatedIdentity(ctx context.Context, token, realm, userID, providerID string,
+		federatedIdentityRep gocloak.FederatedIdentityRepresentation) error
+	DeleteUserFederatedIdentity(ctx context.Context, token, realm, userID, providerID string) error
 }
 
 type GoCloakClientRoles interface {
@@ -60,6 +81,10 @@ type GoCloakClientRoles interface {
 	DeleteClientRoleFromUser(ctx context.Context, token, realm, clientID, userID string, roles []gocloak.Role) error
 	AddClientRoleToGroup(ctx context.Context, token, realm, clientID, groupID string, roles []gocloak.Role) error
 	DeleteClientRoleFromGroup(ctx context.Context, token, realm, clientID, groupID string, roles []gocloak.Role) error
+	UpdateRole(ctx context.Context, token, realm, idOfClient string, role gocloak.Role) error
+	DeleteClientRole(ctx context.Context, token, realm, idOfClient, roleName string) error
+	AddClientRoleComposite(ctx context.Context, token, realm, roleID string, roles []gocloak.Role) error
+	DeleteClientRoleComposite(ctx context.Context, token, realm, roleID string, roles []gocloak.Role) error
 }
 
 type GoCloakRealmRoles interface {
@@ -70,7 +95,7 @@ type GoCloakRealmRoles interface {
 	DeleteRealmRole(ctx context.Context, token, realm, roleName string) error
 	AddRealmRoleComposite(ctx context.Context, token, realm, roleName string, roles []gocloak.Role) error
 	DeleteRealmRoleComposite(ctx context.Context, token, realm, roleName string, roles []gocloak.Role) error
-	GetCompositeRealmRolesByRoleID(ctx context.Context, token, realm, roleID string) ([]*gocloak.Role, error)
+	GetCompositeRolesByRoleID(ctx context.Context, token, realm, roleID string) ([]*gocloak.Role, error)
 	DeleteRealmRoleFromUser(ctx context.Context, token, realm, userID string, roles []gocloak.Role) error
 	AddRealmRoleToGroup(ctx context.Context, token, realm, groupID string, roles []gocloak.Role) error
 	DeleteRealmRoleFromGroup(ctx context.Context, token, realm, groupID string, roles []gocloak.Role) error
@@ -82,6 +107,9 @@ type GoCloakGroups interface {
 	UpdateGroup(ctx context.Context, accessToken, realm string, updatedGroup gocloak.Group) error
 	DeleteGroup(ctx context.Context, accessToken, realm, groupID string) error
 	GetGroups(ctx context.Context, accessToken, realm string, params gocloak.GetGroupsParams) ([]*gocloak.Group, error)
+	GetGroup(ctx context.Context, token, realm, groupID string) (*gocloak.Group, error)
+	GetGroupByPath(ctx context.Context, token, realm, groupPath string) (*gocloak.Group, error)
 	GetRoleMappingByGroupID(ctx context.Context, accessToken, realm,
 		groupID string) (*gocloak.MappingsRepresentation, error)
+	GetGroupMembers(ctx context.Context, token, realm, groupID string, params gocloak.GetGroupsParams) ([]*gocloak.User, error)
 }
@@ -32,8 +32,6 @@ const (
 	postClientScope                 = "/admin/realms/{realm}/client-scopes"
 	putClientScope                  = "/admin/realms/{realm}/client-scopes/{id}"
 	getClientProtocolMappers        = "/admin/realms/{realm}/clients/{id}/protocol-mappers/models"
-	mapperToIdentityProvider        = "/admin/realms/{realm}/identity-provider/instances/{alias}/mappers"
-	updateMapperToIdentityProvider  = "/admin/realms/{realm}/identity-provider/instances/{alias}/mappers/{id}"
 	authFlows                       = "/admin/realms/{realm}/authentication/flows"
 	authFlow                        = "/admin/realms/{realm}/authentication/flows/{id}"
 	authFlowExecutionCreate         = "/admin/realms/{realm}/authentication/executions"
@@ -48,11 +46,17 @@ const (
 	putDefaultClientScope           = "/admin/realms/{realm}/default-default-client-scopes/{clientScopeID}"
 	deleteDefaultClientScope        = "/admin/realms/{realm}/default-default-client-scopes/{clientScopeID}"
 	getDefaultClientScopes          = "/admin/realms/{realm}/default-default-client-scopes"
+	realmOptionalClientScope        = "/admin/realms/{realm}/default-optional-client-scopes/{clientScopeID}"
+	realmOptionalClientScopes       = "/admin/realms/{realm}/default-optional-client-scopes"
+	clientScopeScopeMappings        = "/admin/realms/{realm}/client-scopes/{clientScopeID}/scope-mappings"
 	realmEventConfigPut             = "/admin/realms/{realm}/events/config"
 	realmComponent                  = "/admin/realms/{realm}/components"
 	realmComponentEntity            = "/admin/realms/{realm}/components/{id}"
+	realmTestLDAPConnection         = "/admin/realms/{realm}/testLDAPConnection"
+	realmUserStorageSync            = "/admin/realms/{realm}/user-storage/{id}/sync"
 	identityProviderEntity          = "/admin/realms/{realm}/identity-provider/instances/{alias}"
 	identityProviderCreateList      = "/admin/realms/{realm}/identity-provider/instances"
+	identityProviderImportConfig    = "/admin/realms/{realm}/identity-provider/import-config"
 	idpMapperCreateList             = "/admin/realms/{realm}/identity-provider/instances/{alias}/mappers"
 	idpMapperEntity                 = "/admin/realms/{realm}/identity-provider/instances/{alias}/mappers/{id}"
 	deleteRealmUser                 = "/admin/realms/{realm}/users/{id}"
@@ -60,6 +64,23 @@ const (
 	getUserRealmRoleMappings        = "/admin/realms/{realm}/users/{id}/role-mappings/realm"
 	getUserGroupMappings            = "/admin/realms/{realm}/users/{id}/groups"
 	manageUserGroups                = "/admin/realms/{realm}/users/{userID}/groups/{groupID}"
+	realmUsersProfile               = "/admin/realms/{realm}/users/profile"
+	realmUserLogout                 = "/admin/realms/{realm}/users/{id}/logout"
+	realmSession                    = "/admin/realms/{realm}/sessions/{session}"
+	realmPushRevocation             = "/admin/realms/{realm}/push-revocation"
+	clientEntity                    = "/admin/realms/{realm}/clients/{id}"
+	clientUserSessions              = "/admin/realms/{realm}/clients/{id}/user-sessions"
+	clientOfflineSessions           = "/admin/realms/{realm}/clients/{id}/offline-sessions"
+	clientPushRevocation            = "/admin/realms/{realm}/clients/{id}/push-revocation"
+	authzPolicySearch               = "/admin/realms/{realm}/clients/{id}/authz/resource-server/policy/search"
+	authzPolicyCreate               = "/admin/realms/{realm}/clients/{id}/authz/resource-server/policy/{type}"
+	authzPolicyEntity               = "/admin/realms/{realm}/clients/{id}/authz/resource-server/policy/{type}/{policyID}"
+	authzPolicyDelete               = "/admin/realms/{realm}/clients/{id}/authz/resource-server/policy/{policyID}"
+	authzScopePermission            = "/admin/realms/{realm}/clients/{id}/authz/resource-server/permission/scope/{permissionID}"
+	groupManagementPermissions      = "/admin/realms/{realm}/groups/{id}/management/permissions"
+	clientManagementPermissions     = "/admin/realms/{realm}/clients/{id}/management/permissions"
+	usersManagementPermissions      = "/admin/realms/{realm}/users-management-permissions"
+	idpManagementPermissions        = "/admin/realms/{realm}/identity-provider/instances/{alias}/management/permissions"
 	logClientDTO                    = "client dto"
 )
 
@@ -69,6 +90,7 @@ const (
 	keycloakApiParamRealm         = "realm"
 	keycloakApiParamAlias         = "alias"
 	keycloakApiParamClientScopeId = "clientScopeID"
+	keycloakApiParamSession       = "session"
 )
 
 const (
@@ -94,6 +116,9 @@ type GoCloakAdapter struct {
 	log        logr.Logger
 	basePath   string
 	legacyMode bool
+	// serverVersion and serverFeatures are discovered by the keycloak controller, they are empty if unknown.
+	serverVersion  string
+	serverFeatures []string
 }
 
 type JWTPayload struct {
@@ -105,6 +130,58 @@ func (a GoCloakAdapter) GetGoCloak() GoCloak {
 }
 
 func MakeFromToken(url string, tokenData []byte, log logr.Logger) (*GoCloakAdapter, error) {
+	token, err := parseToken(tokenData)
+	if err != nil {
+		return nil, err
+	}
+
+	kcCl, legacyMode, err := makeClientFromToken(url, token.AccessToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make new keycloak client: %w", err)
+	}
+
+	return &GoCloakAdapter{
+		client:     kcCl,
+		token:      token,
+		log:        log,
+		basePath:   url,
+		legacyMode: legacyMode,
+	}, nil
+}
+
+// MakeFromTokenWithLegacyMode creates adapter from token for the server with already known path layout,
+// so the layout is not detected by probing requests to the server.
+func MakeFromTokenWithLegacyMode(url string, tokenData []byte, legacyMode bool, log logr.Logger) (*GoCloakAdapter, error) {
+	token, err := parseToken(tokenData)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GoCloakAdapter{
+		client:     newClientWithLegacyMode(url, legacyMode, resty.New()),
+		token:      token,
+		log:        log,
+		basePath:   url,
+		legacyMode: legacyMode,
+	}, nil
+}
+
+// newClientWithLegacyMode creates keycloak client for the given path layout.
+func newClientWithLegacyMode(url string, legacyMode bool, restyClient *resty.Client) *gocloak.GoCloak {
+	var kcCl *gocloak.GoCloak
+	if legacyMode {
+		kcCl = gocloak.NewClient(url, gocloak.SetLegacyWildFlySupport())
+	} else {
+		kcCl = gocloak.NewClient(url)
+	}
+
+	kcCl.SetRestyClient(restyClient)
+
+	return kcCl
+}
+
+// parseToken decodes token data and checks that the token is not expired.
+func parseToken(tokenData []byte) (*gocloak.JWT, error) {
 	var token gocloak.JWT
 	if err := json.Unmarshal(tokenData, &token); err != nil {
 		return nil, errors.Wrapf(err, "unable decode json data")
@@ -132,18 +209,7 @@ func MakeFromToken(url string, tokenData []byte, log logr.Logger) (*GoCloakAdapt
 		return nil, TokenExpiredError("token is expired")
 	}
 
-	kcCl, legacyMode, err := makeClientFromToken(url, token.AccessToken)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make new keycloak client: %w", err)
-	}
-
-	return &GoCloakAdapter{
-		client:     kcCl,
-		token:      &token,
-		log:        log,
-		basePath:   url,
-		legacyMode: legacyMode,
-	}, nil
+	return &token, nil
 }
 
 // makeClientFromToken returns Keycloak client, a bool flag indicating whether it was created in legacy mode and an error.
@@ -216,6 +282,33 @@ func MakeFromServiceAccount(ctx context.Context,
 	}, nil
 }
 
+// MakeFromServiceAccountWithLegacyMode creates adapter from service account
+// for the server with already known path layout, so the layout is not detected by probing requests to the server.
+func MakeFromServiceAccountWithLegacyMode(ctx context.Context,
+	url, clientID, clientSecret, realm string,
+	legacyMode bool,
+	log logr.Logger, restyClient *resty.Client,
+) (*GoCloakAdapter, error) {
+	if restyClient == nil {
+		restyClient = resty.New()
+	}
+
+	kcCl := newClientWithLegacyMode(url, legacyMode, restyClient)
+
+	token, err := kcCl.LoginClient(ctx, clientID, clientSecret, realm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to login with client creds - clientID: %s, realm: %s: %w", clientID, realm, err)
+	}
+
+	return &GoCloakAdapter{
+		client:     kcCl,
+		token:      token,
+		log:        log,
+		basePath:   url,
+		legacyMode: legacyMode,
+	}, nil
+}
+
 func isNotLegacyResponseCode(err error) bool {
 	apiErr := new(gocloak.APIError)
 	ok := errors.As(err, &apiErr)
@@ -263,6 +356,35 @@ func Make(ctx context.Context, url, user, password string, log logr.Logger, rest
 	}, nil
 }
 
+// MakeWithLegacyMode creates adapter from admin credentials
+// for the server with already known path layout, so the layout is not detected by probing requests to the server.
+func MakeWithLegacyMode(
+	ctx context.Context,
+	url, user, password string,
+	legacyMode bool,
+	log logr.Logger,
+	restyClient *resty.Client,
+) (*GoCloakAdapter, error) {
+	if restyClient == nil {
+		restyClient = resty.New()
+	}
+
+	kcCl := newClientWithLegacyMode(url, legacyMode, restyClient)
+
+	token, err := kcCl.LoginAdmin(ctx, user, password, "master")
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot login to keycloak server with user: %s", user)
+	}
+
+	return &GoCloakAdapter{
+		client:     kcCl,
+		token:      token,
+		log:        log,
+		basePath:   url,
+		legacyMode: legacyMode,
+	}, nil
+}
+
 func (a GoCloakAdapter) ExportToken() ([]byte, error) {
 	tokenData, err := json.Marshal(a.token)
 	if err != nil {
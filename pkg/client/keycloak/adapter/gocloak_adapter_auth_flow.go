@@ -11,6 +11,8 @@ import (
 
 	"github.com/Nerzal/gocloak/v12"
 	"github.com/pkg/errors"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/dto"
 )
@@ -25,6 +27,9 @@ type KeycloakAuthFlow struct {
 	ParentName               string                    `json:"-"`
 	ChildType                string                    `json:"-"`
 	AuthenticationExecutions []AuthenticationExecution `json:"-"`
+	// ExternalChildFlows are aliases of the child flows which are managed by their own resources.
+	// They are kept in the flow even if they are not listed in the executions.
+	ExternalChildFlows []string `json:"-"`
 }
 
 type KeycloakChildAuthFlow struct {
@@ -43,6 +48,8 @@ type AuthenticationExecution struct {
 	AutheticatorFlow    bool                 `json:"autheticatorFlow"`
 	ID                  string               `json:"-"`
 	Alias               string               `json:"-"`
+	// SubFlow is set for sub-flows which are managed within the parent flow.
+	SubFlow *KeycloakAuthFlow `json:"-"`
 }
 
 type FlowExecution struct {
@@ -53,6 +60,7 @@ type FlowExecution struct {
 	FlowID               string   `json:"flowId"`
 	ID                   string   `json:"id"`
 	Index                int      `json:"index"`
+	ProviderID           string   `json:"providerId,omitempty"`
 	Level                int      `json:"level"`
 	Requirement          string   `json:"requirement"`
 	RequirementChoices   []string `json:"requirementChoices"`
@@ -60,6 +68,7 @@ type FlowExecution struct {
 }
 
 type AuthenticatorConfig struct {
+	ID     string            `json:"id,omitempty"`
 	Alias  string            `json:"alias"`
 	Config map[string]string `json:"config"`
 }
@@ -106,41 +115,207 @@ func (a GoCloakAdapter) SyncAuthFlow(realmName string, flow *KeycloakAuthFlow) e
 		return errors.Wrap(err, "unable to sync base auth flow")
 	}
 
-	sort.Sort(orderByPriority(flow.AuthenticationExecutions))
+	if err := a.syncFlowExecutions(realmName, id, flow); err != nil {
+		return errors.Wrap(err, "unable to sync flow executions")
+	}
+
+	return nil
+}
+
+// syncFlowExecutions brings top-level executions of the flow to the desired state.
+// Missing executions and inline sub-flows are added, existing ones are updated in place,
+// executions and sub-flows which are not in the spec are deleted,
+// except child flows which are managed by their own resources.
+// Flow that is already in the desired state is left untouched.
+func (a GoCloakAdapter) syncFlowExecutions(realmName, flowID string, flow *KeycloakAuthFlow) error {
+	execs, err := a.getFlowExecutions(realmName, flow.Alias)
+	if err != nil {
+		return errors.Wrap(err, "unable to get flow executions")
+	}
+
+	current := topLevelFlowExecutions(execs)
+	matched := make(map[string]bool, len(current))
+	missing := make([]*AuthenticationExecution, 0)
+
+	sort.Stable(orderByPriority(flow.AuthenticationExecutions))
+
+	for i := range flow.AuthenticationExecutions {
+		if flowExec := findFlowExecution(current, &flow.AuthenticationExecutions[i], matched); flowExec != nil {
+			matched[flowExec.ID] = true
+			continue
+		}
+
+		missing = append(missing, &flow.AuthenticationExecutions[i])
+	}
+
+	changed := len(missing) > 0
+
+	for i := range current {
+		if matched[current[i].ID] {
+			continue
+		}
 
-	for _, e := range flow.AuthenticationExecutions {
-		if e.AutheticatorFlow {
+		if current[i].AuthenticationFlow && slices.Contains(flow.ExternalChildFlows, current[i].DisplayName) {
 			continue
 		}
 
-		e.ParentFlow = id
-		if err := a.addAuthFlowExecution(realmName, &e); err != nil {
+		if err := a.deleteFlowExecutionWithConfig(realmName, &current[i]); err != nil {
+			return err
+		}
+
+		changed = true
+	}
+
+	for _, authExec := range missing {
+		if err := a.addFlowExecution(realmName, flowID, authExec); err != nil {
+			return err
+		}
+	}
+
+	if changed {
+		execs, err = a.getFlowExecutions(realmName, flow.Alias)
+		if err != nil {
+			return errors.Wrap(err, "unable to get flow executions")
+		}
+
+		current = topLevelFlowExecutions(execs)
+	}
+
+	matched = make(map[string]bool, len(current))
+	desiredOrder := make([]string, 0, len(flow.AuthenticationExecutions))
+
+	for i := range flow.AuthenticationExecutions {
+		authExec := &flow.AuthenticationExecutions[i]
+
+		flowExec := findFlowExecution(current, authExec, matched)
+		if flowExec == nil {
+			return errors.Errorf("unable to find flow execution: %s", authExecName(authExec))
+		}
+
+		matched[flowExec.ID] = true
+		desiredOrder = append(desiredOrder, flowExec.ID)
+
+		if err := a.syncFlowExecution(realmName, flow.Alias, flowExec, authExec); err != nil {
+			return errors.Wrapf(err, "unable to sync flow execution %s", flowExec.DisplayName)
+		}
+
+		if authExec.SubFlow != nil {
+			if err := a.syncFlowExecutions(realmName, flowExec.FlowID, authExec.SubFlow); err != nil {
+				return errors.Wrapf(err, "unable to sync sub-flow %s", authExec.SubFlow.Alias)
+			}
+		}
+	}
+
+	currentOrder := make([]string, 0, len(current))
+	for i := range current {
+		currentOrder = append(currentOrder, current[i].ID)
+	}
+
+	if err := a.reorderFlowExecutions(realmName, currentOrder, desiredOrder); err != nil {
+		return errors.Wrap(err, "unable to reorder flow executions")
+	}
+
+	return nil
+}
+
+// addFlowExecution adds authenticator execution or inline sub-flow to the flow.
+// Child flows which are managed by their own resources can't be added here.
+func (a GoCloakAdapter) addFlowExecution(realmName, flowID string, authExec *AuthenticationExecution) error {
+	if !authExec.AutheticatorFlow {
+		authExec.ParentFlow = flowID
+		if err := a.addAuthFlowExecution(realmName, authExec); err != nil {
 			return errors.Wrap(err, "unable to add auth execution")
 		}
+
+		return nil
 	}
 
-	if err := a.adjustChildFlowsPriority(realmName, flow); err != nil {
-		return errors.Wrap(err, "unable to adjust child flow priority")
+	if authExec.SubFlow == nil {
+		return errors.Errorf("unable to find child flow with name: %s", authExec.Alias)
+	}
+
+	if _, err := a.createChildAuthFlow(realmName, authExec.SubFlow); err != nil {
+		return errors.Wrapf(err, "unable to create sub-flow %s", authExec.SubFlow.Alias)
 	}
 
 	return nil
 }
 
-func (a GoCloakAdapter) adjustChildFlowsPriority(realmName string, flow *KeycloakAuthFlow) error {
-	childFlows := a.makeChildFlows(flow)
-	if len(childFlows) == 0 {
+// syncFlowExecution updates requirement and authenticator config of the existing execution if they differ from the spec.
+func (a GoCloakAdapter) syncFlowExecution(
+	realmName, flowAlias string,
+	flowExec *FlowExecution,
+	authExec *AuthenticationExecution,
+) error {
+	if authExec.Requirement != "" && authExec.Requirement != flowExec.Requirement {
+		flowExec.Requirement = authExec.Requirement
+		if err := a.updateFlowExecution(realmName, flowAlias, flowExec); err != nil {
+			return errors.Wrap(err, "unable to update flow execution")
+		}
+	}
+
+	if authExec.AutheticatorFlow {
 		return nil
 	}
 
-	flowExecs, err := a.getFlowExecutions(realmName, flow.Alias)
+	authExec.ID = flowExec.ID
+
+	if err := a.syncFlowExecutionConfig(realmName, flowExec, authExec); err != nil {
+		return errors.Wrap(err, "unable to sync flow execution config")
+	}
+
+	return nil
+}
+
+func (a GoCloakAdapter) syncFlowExecutionConfig(
+	realmName string,
+	flowExec *FlowExecution,
+	authExec *AuthenticationExecution,
+) error {
+	if authExec.AuthenticatorConfig == nil {
+		if flowExec.AuthenticationConfig == "" {
+			return nil
+		}
+
+		return a.deleteAuthFlowConfig(realmName, flowExec.AuthenticationConfig)
+	}
+
+	if flowExec.AuthenticationConfig == "" {
+		return a.createAuthFlowExecutionConfig(realmName, authExec)
+	}
+
+	config, err := a.getAuthFlowConfig(realmName, flowExec.AuthenticationConfig)
 	if err != nil {
-		return errors.Wrap(err, "unable to get flow executions")
+		return err
 	}
 
-	for i := range flowExecs {
-		if err := a.adjustFlowExecutionPriority(realmName, flow.Alias, &flowExecs[i], len(flowExecs), childFlows); err != nil {
-			return err
+	if config.Alias == authExec.AuthenticatorConfig.Alias && maps.Equal(config.Config, authExec.AuthenticatorConfig.Config) {
+		return nil
+	}
+
+	return a.updateAuthFlowConfig(realmName, flowExec.AuthenticationConfig, authExec.AuthenticatorConfig)
+}
+
+// reorderFlowExecutions moves executions to match the desired order.
+// Priority adjustment calls are made only for executions that are out of place.
+func (a GoCloakAdapter) reorderFlowExecutions(realmName string, currentOrder, desiredOrder []string) error {
+	order := slices.Clone(currentOrder)
+
+	for i, id := range desiredOrder {
+		j := slices.Index(order, id)
+		if j < 0 {
+			return errors.Errorf("unable to find flow execution with id: %s", id)
 		}
+
+		if j == i {
+			continue
+		}
+
+		if err := a.adjustExecutionPriority(realmName, id, j-i); err != nil {
+			return errors.Wrap(err, "unable to adjust execution priority")
+		}
+
+		order = slices.Insert(slices.Delete(order, j, j+1), i, id)
 	}
 
 	return nil
@@ -173,10 +348,6 @@ func (a GoCloakAdapter) syncBaseAuthFlow(realmName string, flow *KeycloakAuthFlo
 		}
 
 		authFlowID = id
-	} else {
-		if err := a.clearFlowExecutions(realmName, flow.Alias); err != nil {
-			return "", errors.Wrap(err, "unable to clear flow executions")
-		}
 	}
 
 	if err := a.validateChildFlowsCreated(realmName, flow); err != nil {
@@ -186,16 +357,18 @@ func (a GoCloakAdapter) syncBaseAuthFlow(realmName string, flow *KeycloakAuthFlo
 	return authFlowID, nil
 }
 
+// validateChildFlowsCreated checks that child flows which are managed by their own resources are already created.
+// Inline sub-flows are not checked as they are created during the flow sync.
 func (a GoCloakAdapter) validateChildFlowsCreated(realmName string, flow *KeycloakAuthFlow) error {
-	childFlows := 0
+	childFlows := make(map[string]bool)
 
 	for _, authExec := range flow.AuthenticationExecutions {
-		if authExec.AutheticatorFlow {
-			childFlows++
+		if authExec.AutheticatorFlow && authExec.SubFlow == nil {
+			childFlows[authExec.Alias] = true
 		}
 	}
 
-	if childFlows == 0 {
+	if len(childFlows) == 0 {
 		return nil
 	}
 
@@ -206,38 +379,27 @@ func (a GoCloakAdapter) validateChildFlowsCreated(realmName string, flow *Keyclo
 
 	for i := range childExecs {
 		if childExecs[i].AuthenticationFlow && childExecs[i].Level == 0 {
-			childFlows--
+			delete(childFlows, childExecs[i].DisplayName)
 		}
 	}
 
-	if childFlows == 0 {
+	if len(childFlows) == 0 {
 		return nil
 	}
 
 	return errors.New("not all child flows created")
 }
 
-func (a GoCloakAdapter) clearFlowExecutions(realmName, flowAlias string) error {
-	execs, err := a.getFlowExecutions(realmName, flowAlias)
-	if err != nil {
-		return errors.Wrap(err, "unable to get flow executions")
+func (a GoCloakAdapter) deleteFlowExecutionWithConfig(realmName string, flowExec *FlowExecution) error {
+	if err := a.deleteFlowExecution(realmName, flowExec.ID); err != nil {
+		return errors.Wrap(err, "unable to delete flow execution")
 	}
 
-	for i := range execs {
-		if execs[i].AuthenticationFlow || execs[i].Level > 0 {
-			continue
-		}
-
-		if err := a.deleteFlowExecution(realmName, execs[i].ID); err != nil {
-			return errors.Wrap(err, "unable to delete flow execution")
-		}
-
-		// after deleting flow execution, we need to delete its config as well
-		// as it is not deleted automatically
-		if execs[i].AuthenticationConfig != "" {
-			if err := a.deleteAuthFlowConfig(realmName, execs[i].AuthenticationConfig); err != nil {
-				return fmt.Errorf("unable to delete flow execution config: %w", err)
-			}
+	// after deleting flow execution, we need to delete its config as well
+	// as it is not deleted automatically
+	if flowExec.AuthenticationConfig != "" {
+		if err := a.deleteAuthFlowConfig(realmName, flowExec.AuthenticationConfig); err != nil {
+			return fmt.Errorf("unable to delete flow execution config: %w", err)
 		}
 	}
 
@@ -537,51 +699,45 @@ func (a GoCloakAdapter) unsetBrowserFlow(realmName, flowAlias string) (realm *go
 	return realm, true, nil
 }
 
-func (a GoCloakAdapter) makeChildFlows(flow *KeycloakAuthFlow) map[string]AuthenticationExecution {
-	childFlows := make(map[string]AuthenticationExecution)
+func topLevelFlowExecutions(execs []FlowExecution) []FlowExecution {
+	topLevel := make([]FlowExecution, 0, len(execs))
 
-	for i, authExec := range flow.AuthenticationExecutions {
-		if authExec.AutheticatorFlow {
-			childFlows[authExec.Alias] = flow.AuthenticationExecutions[i]
+	for i := range execs {
+		if execs[i].Level == 0 {
+			topLevel = append(topLevel, execs[i])
 		}
 	}
 
-	return childFlows
+	sort.SliceStable(topLevel, func(i, j int) bool {
+		return topLevel[i].Index < topLevel[j].Index
+	})
+
+	return topLevel
 }
 
-func (a GoCloakAdapter) adjustFlowExecutionPriority(
-	realmName,
-	flowAlias string,
-	flowExec *FlowExecution,
-	flowExecsCount int,
-	childFlows map[string]AuthenticationExecution,
-) error {
-	if !flowExec.AuthenticationFlow || flowExec.Level != 0 {
-		return nil
+func authExecName(authExec *AuthenticationExecution) string {
+	if authExec.AutheticatorFlow {
+		return authExec.Alias
 	}
 
-	childFlow, ok := childFlows[flowExec.DisplayName]
-	if !ok {
-		return errors.Errorf("unable to find child flow with name: %s", flowExec.DisplayName)
-	}
+	return authExec.Authenticator
+}
 
-	if childFlow.Requirement != flowExec.Requirement {
-		flowExec.Requirement = childFlow.Requirement
-		if err := a.updateFlowExecution(realmName, flowAlias, flowExec); err != nil {
-			return errors.Wrap(err, "unable to update flow execution")
+// findFlowExecution returns the first not matched execution which corresponds to the authentication execution.
+// Child flows are matched by alias, authenticators are matched by provider id.
+func findFlowExecution(execs []FlowExecution, authExec *AuthenticationExecution, matched map[string]bool) *FlowExecution {
+	for i := range execs {
+		if matched[execs[i].ID] || execs[i].AuthenticationFlow != authExec.AutheticatorFlow {
+			continue
 		}
-	}
-
-	if childFlow.Priority == flowExec.Index {
-		return nil
-	}
 
-	if childFlow.Priority < 0 || childFlow.Priority > flowExecsCount {
-		return errors.Errorf("wrong flow priority, flow name: %s, priority: %d", childFlow.Alias, childFlow.Priority)
-	}
+		if authExec.AutheticatorFlow && execs[i].DisplayName == authExec.Alias {
+			return &execs[i]
+		}
 
-	if err := a.adjustExecutionPriority(realmName, flowExec.ID, flowExec.Index-childFlow.Priority); err != nil {
-		return errors.Wrap(err, "unable to adjust flow priority")
+		if !authExec.AutheticatorFlow && execs[i].ProviderID == authExec.Authenticator {
+			return &execs[i]
+		}
 	}
 
 	return nil
@@ -602,6 +758,43 @@ func (a GoCloakAdapter) deleteAuthFlowConfig(realmName, configID string) error {
 	return nil
 }
 
+func (a GoCloakAdapter) getAuthFlowConfig(realmName, configID string) (*AuthenticatorConfig, error) {
+	var config AuthenticatorConfig
+
+	rsp, err := a.startRestyRequest().
+		SetPathParams(map[string]string{
+			keycloakApiParamRealm: realmName,
+			keycloakApiParamId:    configID,
+		}).
+		SetResult(&config).
+		Get(a.buildPath(authFlowConfig))
+
+	if err = a.checkError(err, rsp); err != nil {
+		return nil, fmt.Errorf("unable to get auth flow config: %w", err)
+	}
+
+	return &config, nil
+}
+
+func (a GoCloakAdapter) updateAuthFlowConfig(realmName, configID string, config *AuthenticatorConfig) error {
+	body := *config
+	body.ID = configID
+
+	rsp, err := a.startRestyRequest().
+		SetPathParams(map[string]string{
+			keycloakApiParamRealm: realmName,
+			keycloakApiParamId:    configID,
+		}).
+		SetBody(&body).
+		Put(a.buildPath(authFlowConfig))
+
+	if err = a.checkError(err, rsp); err != nil {
+		return fmt.Errorf("unable to update auth flow config: %w", err)
+	}
+
+	return nil
+}
+
 func (a GoCloakAdapter) updateRedirectConfig(realm *dto.Realm, configID string) error {
 	rsp, err := a.startRestyRequest().
 		SetPathParams(map[string]string{
@@ -62,6 +62,8 @@ func (e *ExecFlowTestSuite) TestCreateAuthFlowParent() {
 }
 
 func (e *ExecFlowTestSuite) TestSyncAuthFlow() {
+	httpmock.ZeroCallCounters()
+
 	flow := KeycloakAuthFlow{
 		Alias:       "alias1",
 		Description: "test description",
@@ -96,33 +98,11 @@ func (e *ExecFlowTestSuite) TestSyncAuthFlow() {
 		httpmock.NewJsonResponderOrPanic(200, []KeycloakAuthFlow{{Alias: flow.Alias, ID: existFlowID},
 			{Alias: "some-another-flow", ID: "321"}}))
 
-	deleteURL := strings.ReplaceAll(authFlow, "{realm}", e.realmName)
-	deleteURL = strings.ReplaceAll(deleteURL, "{id}", existFlowID)
-
-	httpmock.RegisterResponder("DELETE", deleteURL, httpmock.NewStringResponder(200, ""))
-
-	e.goCloakMockClient.On("GetRealm", "token", e.realmName).Return(&gocloak.RealmRepresentation{
-		BrowserFlow: gocloak.StringP("alias1"),
-	}, nil)
-	e.goCloakMockClient.On("UpdateRealm",
-		gocloak.RealmRepresentation{BrowserFlow: gocloak.StringP("some-another-flow")}).
-		Return(nil).Once()
-	e.goCloakMockClient.On("UpdateRealm",
-		gocloak.RealmRepresentation{BrowserFlow: gocloak.StringP("alias1")}).
-		Return(nil).Once()
-
-	createFlowResponse := httpmock.NewStringResponse(200, "")
-	defer closeWithFailOnError(e.T(), createFlowResponse.Body)
-	createFlowResponse.Header.Set("Location", "id/new-flow-id")
-
-	httpmock.RegisterResponder("POST", strings.ReplaceAll(authFlows, "{realm}", e.realmName),
-		httpmock.ResponderFromResponse(createFlowResponse))
-
+	newExecID := "new-exec-id"
 	createExecResponse := httpmock.NewStringResponse(200, "")
 
-	defer closeWithFailOnError(e.T(), createFlowResponse.Body)
+	defer closeWithFailOnError(e.T(), createExecResponse.Body)
 
-	newExecID := "new-exec-id"
 	createExecResponse.Header.Set("Location", fmt.Sprintf("id/%s", newExecID))
 	httpmock.RegisterResponder("POST", strings.ReplaceAll(authFlowExecutionCreate, "{realm}", e.realmName),
 		httpmock.ResponderFromResponse(createExecResponse))
@@ -132,13 +112,30 @@ func (e *ExecFlowTestSuite) TestSyncAuthFlow() {
 
 	httpmock.RegisterResponder("POST", createConfigURL, httpmock.NewStringResponder(200, ""))
 
-	childExecutionID := "child-exec-id1"
+	executionsURL := fmt.Sprintf("/admin/realms/%s/authentication/flows/%s/executions", e.realmName, flow.Alias)
+	getExecutionsCalls := 0
 
+	httpmock.RegisterResponder("GET", executionsURL, func(req *http.Request) (*http.Response, error) {
+		getExecutionsCalls++
+
+		if getExecutionsCalls == 1 {
+			return httpmock.NewJsonResponse(200, []FlowExecution{
+				{ID: "basic-exec-id", ProviderID: "basic-auth", Index: 0, Requirement: "REQUIRED"},
+				{ID: "old-exec-id", ProviderID: "otp", Index: 1, AuthenticationConfig: "authConf1"},
+			})
+		}
+
+		return httpmock.NewJsonResponse(200, []FlowExecution{
+			{ID: "basic-exec-id", ProviderID: "basic-auth", Index: 0, Requirement: "REQUIRED"},
+			{ID: newExecID, ProviderID: "cookie", Index: 1, Requirement: "DISABLED", AuthenticationConfig: "new-conf"},
+		})
+	})
 	httpmock.RegisterResponder("GET",
-		fmt.Sprintf("/admin/realms/%s/authentication/flows/%s/executions", e.realmName, flow.Alias),
-		httpmock.NewJsonResponderOrPanic(200, []FlowExecution{{ID: childExecutionID, AuthenticationConfig: "authConf1"}}))
+		strings.ReplaceAll(strings.ReplaceAll(authFlowConfig, "{realm}", e.realmName), "{id}", "new-conf"),
+		httpmock.NewJsonResponderOrPanic(200, flow.AuthenticationExecutions[1].AuthenticatorConfig))
+	httpmock.RegisterResponder("PUT", executionsURL, httpmock.NewStringResponder(200, ""))
 	httpmock.RegisterResponder("DELETE",
-		fmt.Sprintf("/admin/realms/%s/authentication/executions/%s", e.realmName, childExecutionID),
+		fmt.Sprintf("/admin/realms/%s/authentication/executions/%s", e.realmName, "old-exec-id"),
 		httpmock.NewStringResponder(200, ""))
 	httpmock.RegisterResponder("DELETE",
 		strings.ReplaceAll(
@@ -147,9 +144,240 @@ func (e *ExecFlowTestSuite) TestSyncAuthFlow() {
 			"authConf1"),
 		httpmock.NewStringResponder(200, ""),
 	)
+	httpmock.RegisterResponder("POST",
+		fmt.Sprintf("/admin/realms/%s/authentication/executions/%s/raise-priority", e.realmName, newExecID),
+		httpmock.NewStringResponder(200, ""))
 
 	err := e.adapter.SyncAuthFlow(e.realmName, &flow)
 	assert.NoError(e.T(), err)
+
+	calls := httpmock.GetCallCountInfo()
+	assert.Equal(e.T(), 1, calls["PUT "+executionsURL])
+	assert.Equal(e.T(), 1,
+		calls[fmt.Sprintf("POST /admin/realms/%s/authentication/executions/%s/raise-priority", e.realmName, newExecID)])
+	assert.Equal(e.T(), 1,
+		calls[fmt.Sprintf("DELETE /admin/realms/%s/authentication/executions/old-exec-id", e.realmName)])
+}
+
+func (e *ExecFlowTestSuite) TestSyncAuthFlow_NoChanges() {
+	httpmock.ZeroCallCounters()
+
+	flow := KeycloakAuthFlow{
+		Alias: "alias1",
+		AuthenticationExecutions: []AuthenticationExecution{
+			{
+				AutheticatorFlow: true,
+				Alias:            "child-flow",
+				Priority:         1,
+				Requirement:      "ALTERNATIVE",
+			},
+			{
+				Authenticator: "cookie",
+				Priority:      0,
+				Requirement:   "REQUIRED",
+				AuthenticatorConfig: &AuthenticatorConfig{
+					Alias:  "config-12",
+					Config: map[string]string{"bar": "3"},
+				},
+			},
+		},
+	}
+
+	httpmock.RegisterResponder("GET", strings.ReplaceAll(authFlows, "{realm}", e.realmName),
+		httpmock.NewJsonResponderOrPanic(200, []KeycloakAuthFlow{{Alias: flow.Alias, ID: "flow-id-1"}}))
+	httpmock.RegisterResponder("GET",
+		fmt.Sprintf("/admin/realms/%s/authentication/flows/%s/executions", e.realmName, flow.Alias),
+		httpmock.NewJsonResponderOrPanic(200, []FlowExecution{
+			{ID: "cookie-exec-id", ProviderID: "cookie", Index: 0, Requirement: "REQUIRED", AuthenticationConfig: "conf1"},
+			{ID: "child-exec-id", AuthenticationFlow: true, DisplayName: "child-flow", Index: 1, Requirement: "ALTERNATIVE"},
+			{ID: "nested-exec-id", ProviderID: "auth-otp-form", Index: 0, Level: 1},
+		}))
+	httpmock.RegisterResponder("GET",
+		strings.ReplaceAll(strings.ReplaceAll(authFlowConfig, "{realm}", e.realmName), "{id}", "conf1"),
+		httpmock.NewJsonResponderOrPanic(200, AuthenticatorConfig{
+			ID:     "conf1",
+			Alias:  "config-12",
+			Config: map[string]string{"bar": "3"},
+		}))
+
+	err := e.adapter.SyncAuthFlow(e.realmName, &flow)
+	require.NoError(e.T(), err)
+
+	for call, count := range httpmock.GetCallCountInfo() {
+		if count > 0 {
+			assert.True(e.T(), strings.HasPrefix(call, "GET "), "unexpected mutating call %s", call)
+		}
+	}
+}
+
+func (e *ExecFlowTestSuite) TestSyncAuthFlow_KeepsExternalChildFlows() {
+	httpmock.ZeroCallCounters()
+
+	flow := KeycloakAuthFlow{
+		Alias: "alias2",
+		AuthenticationExecutions: []AuthenticationExecution{
+			{Authenticator: "cookie", Priority: 0, Requirement: "REQUIRED"},
+		},
+		ExternalChildFlows: []string{"external-child"},
+	}
+
+	httpmock.RegisterResponder("GET", strings.ReplaceAll(authFlows, "{realm}", e.realmName),
+		httpmock.NewJsonResponderOrPanic(200, []KeycloakAuthFlow{{Alias: flow.Alias, ID: "flow-id-2"}}))
+	httpmock.RegisterResponder("GET",
+		fmt.Sprintf("/admin/realms/%s/authentication/flows/%s/executions", e.realmName, flow.Alias),
+		httpmock.NewJsonResponderOrPanic(200, []FlowExecution{
+			{ID: "cookie-exec-id", ProviderID: "cookie", Index: 0, Requirement: "REQUIRED"},
+			{ID: "external-exec-id", AuthenticationFlow: true, DisplayName: "external-child", Index: 1},
+		}))
+
+	err := e.adapter.SyncAuthFlow(e.realmName, &flow)
+	require.NoError(e.T(), err)
+
+	for call, count := range httpmock.GetCallCountInfo() {
+		if count > 0 {
+			assert.True(e.T(), strings.HasPrefix(call, "GET "), "unexpected mutating call %s", call)
+		}
+	}
+}
+
+func (e *ExecFlowTestSuite) TestSyncAuthFlow_UpdateConfig() {
+	httpmock.ZeroCallCounters()
+
+	flow := KeycloakAuthFlow{
+		Alias: "alias1",
+		AuthenticationExecutions: []AuthenticationExecution{
+			{
+				Authenticator: "cookie",
+				Requirement:   "REQUIRED",
+				AuthenticatorConfig: &AuthenticatorConfig{
+					Alias:  "config-12",
+					Config: map[string]string{"bar": "4"},
+				},
+			},
+		},
+	}
+
+	configURL := strings.ReplaceAll(strings.ReplaceAll(authFlowConfig, "{realm}", e.realmName), "{id}", "conf1")
+
+	httpmock.RegisterResponder("GET", strings.ReplaceAll(authFlows, "{realm}", e.realmName),
+		httpmock.NewJsonResponderOrPanic(200, []KeycloakAuthFlow{{Alias: flow.Alias, ID: "flow-id-1"}}))
+	httpmock.RegisterResponder("GET",
+		fmt.Sprintf("/admin/realms/%s/authentication/flows/%s/executions", e.realmName, flow.Alias),
+		httpmock.NewJsonResponderOrPanic(200, []FlowExecution{
+			{ID: "cookie-exec-id", ProviderID: "cookie", Requirement: "REQUIRED", AuthenticationConfig: "conf1"},
+		}))
+	httpmock.RegisterResponder("GET", configURL,
+		httpmock.NewJsonResponderOrPanic(200, AuthenticatorConfig{
+			ID:     "conf1",
+			Alias:  "config-12",
+			Config: map[string]string{"bar": "3"},
+		}))
+	httpmock.RegisterResponder("PUT", configURL, httpmock.NewStringResponder(200, ""))
+
+	err := e.adapter.SyncAuthFlow(e.realmName, &flow)
+	require.NoError(e.T(), err)
+	assert.Equal(e.T(), 1, httpmock.GetCallCountInfo()["PUT "+configURL])
+}
+
+func (e *ExecFlowTestSuite) TestSyncAuthFlow_SubFlows() {
+	httpmock.ZeroCallCounters()
+
+	subFlow := KeycloakAuthFlow{
+		Alias:      "conditional-otp",
+		ProviderID: "registration-page-form",
+		ChildType:  "basic-flow",
+		ParentName: "alias1",
+		AuthenticationExecutions: []AuthenticationExecution{
+			{Authenticator: "conditional-user-configured", Requirement: "REQUIRED", Priority: 0},
+			{Authenticator: "auth-otp-form", Requirement: "REQUIRED", Priority: 1},
+		},
+	}
+	flow := KeycloakAuthFlow{
+		Alias: "alias1",
+		AuthenticationExecutions: []AuthenticationExecution{
+			{Authenticator: "auth-username-password-form", Requirement: "REQUIRED", Priority: 0},
+			{
+				AutheticatorFlow: true,
+				Alias:            subFlow.Alias,
+				Requirement:      "CONDITIONAL",
+				Priority:         1,
+				SubFlow:          &subFlow,
+			},
+		},
+	}
+
+	flowExecutionsURL := fmt.Sprintf("/admin/realms/%s/authentication/flows/%s/executions", e.realmName, flow.Alias)
+	subFlowExecutionsURL := fmt.Sprintf("/admin/realms/%s/authentication/flows/%s/executions", e.realmName, subFlow.Alias)
+	flowCalls, subFlowCalls := 0, 0
+
+	httpmock.RegisterResponder("GET", strings.ReplaceAll(authFlows, "{realm}", e.realmName),
+		httpmock.NewJsonResponderOrPanic(200, []KeycloakAuthFlow{{Alias: flow.Alias, ID: "flow-id-1"}}))
+	httpmock.RegisterResponder("GET", flowExecutionsURL, func(req *http.Request) (*http.Response, error) {
+		flowCalls++
+
+		if flowCalls == 1 {
+			return httpmock.NewJsonResponse(200, []FlowExecution{
+				{ID: "form-exec-id", ProviderID: "auth-username-password-form", Index: 0, Requirement: "REQUIRED"},
+				{ID: "old-sub-flow-exec-id", AuthenticationFlow: true, DisplayName: "old-sub-flow", Index: 1},
+			})
+		}
+
+		return httpmock.NewJsonResponse(200, []FlowExecution{
+			{ID: "form-exec-id", ProviderID: "auth-username-password-form", Index: 0, Requirement: "REQUIRED"},
+			{
+				ID:                 "sub-flow-exec-id",
+				AuthenticationFlow: true,
+				DisplayName:        subFlow.Alias,
+				FlowID:             "sub-flow-id",
+				Index:              1,
+				Requirement:        "DISABLED",
+			},
+		})
+	})
+	httpmock.RegisterResponder("GET", subFlowExecutionsURL, func(req *http.Request) (*http.Response, error) {
+		subFlowCalls++
+
+		if subFlowCalls == 1 {
+			return httpmock.NewJsonResponse(200, []FlowExecution{})
+		}
+
+		return httpmock.NewJsonResponse(200, []FlowExecution{
+			{ID: "cond-exec-id", ProviderID: "conditional-user-configured", Index: 0, Requirement: "REQUIRED"},
+			{ID: "otp-exec-id", ProviderID: "auth-otp-form", Index: 1, Requirement: "REQUIRED"},
+		})
+	})
+	httpmock.RegisterResponder("DELETE",
+		fmt.Sprintf("/admin/realms/%s/authentication/executions/old-sub-flow-exec-id", e.realmName),
+		httpmock.NewStringResponder(200, ""))
+
+	createSubFlowResponse := httpmock.NewStringResponse(201, "")
+	defer closeWithFailOnError(e.T(), createSubFlowResponse.Body)
+	createSubFlowResponse.Header.Set("Location", "id/sub-flow-id")
+
+	httpmock.RegisterResponder("POST",
+		fmt.Sprintf("/admin/realms/%s/authentication/flows/%s/executions/flow", e.realmName, flow.Alias),
+		httpmock.ResponderFromResponse(createSubFlowResponse))
+	httpmock.RegisterResponder("PUT", flowExecutionsURL, httpmock.NewStringResponder(200, ""))
+	httpmock.RegisterResponder("POST", strings.ReplaceAll(authFlowExecutionCreate, "{realm}", e.realmName),
+		func(req *http.Request) (*http.Response, error) {
+			rsp := httpmock.NewStringResponse(201, "")
+			rsp.Header.Set("Location", "id/exec-id")
+
+			return rsp, nil
+		})
+
+	err := e.adapter.SyncAuthFlow(e.realmName, &flow)
+	require.NoError(e.T(), err)
+
+	calls := httpmock.GetCallCountInfo()
+	assert.Equal(e.T(), 1,
+		calls[fmt.Sprintf("POST /admin/realms/%s/authentication/flows/%s/executions/flow", e.realmName, flow.Alias)])
+	assert.Equal(e.T(), 1,
+		calls[fmt.Sprintf("DELETE /admin/realms/%s/authentication/executions/old-sub-flow-exec-id", e.realmName)])
+	assert.Equal(e.T(), 1, calls["PUT "+flowExecutionsURL], "sub-flow requirement should be updated")
+	assert.Equal(e.T(), 2,
+		calls["POST "+strings.ReplaceAll(authFlowExecutionCreate, "{realm}", e.realmName)])
+	assert.Equal(e.T(), "sub-flow-id", subFlow.AuthenticationExecutions[0].ParentFlow)
 }
 
 func (e *ExecFlowTestSuite) TestDeleteAuthFlowWithParent() {
@@ -341,47 +569,32 @@ func (e *ExecFlowTestSuite) TestGetFlowExecutionID() {
 	assert.NoError(e.T(), err)
 }
 
-func (e *ExecFlowTestSuite) TestAdjustChildFlowsPriority() {
-	var (
-		flow = KeycloakAuthFlow{
-			Alias: "flow-1",
-			AuthenticationExecutions: []AuthenticationExecution{
-				{
-					AutheticatorFlow: true,
-					Alias:            "child-flow-1",
-					Priority:         1,
-					Requirement:      "REQUIRED",
-				},
-				{
-					Priority: 0,
-				},
-			},
-		}
-		flowExecutionID = "flow-exec-id-1"
-	)
-
-	httpmock.RegisterResponder("GET",
-		fmt.Sprintf("/admin/realms/%s/authentication/flows/%s/executions", e.realmName, flow.Alias),
-		httpmock.NewJsonResponderOrPanic(200, []FlowExecution{
-			{
-				AuthenticationFlow: true,
-				DisplayName:        flow.AuthenticationExecutions[0].Alias,
-				Index:              0,
-				ID:                 flowExecutionID,
-				Requirement:        "ALTERNATIVE",
-			},
-		}))
+func (e *ExecFlowTestSuite) TestReorderFlowExecutions() {
+	httpmock.ZeroCallCounters()
 
 	httpmock.RegisterResponder("POST",
-		fmt.Sprintf("/admin/realms/%s/authentication/executions/%s/lower-priority", e.realmName, flowExecutionID),
+		fmt.Sprintf("/admin/realms/%s/authentication/executions/%s/raise-priority", e.realmName, "exec-3"),
 		httpmock.NewStringResponder(200, ""))
-
-	httpmock.RegisterResponder("PUT",
-		fmt.Sprintf("/admin/realms/%s/authentication/flows/%s/executions", e.realmName, flow.Alias),
+	httpmock.RegisterResponder("POST",
+		fmt.Sprintf("/admin/realms/%s/authentication/executions/%s/raise-priority", e.realmName, "exec-2"),
 		httpmock.NewStringResponder(200, ""))
 
-	err := e.adapter.adjustChildFlowsPriority(e.realmName, &flow)
-	assert.NoError(e.T(), err)
+	err := e.adapter.reorderFlowExecutions(
+		e.realmName,
+		[]string{"exec-1", "exec-2", "exec-3"},
+		[]string{"exec-3", "exec-2", "exec-1"},
+	)
+	require.NoError(e.T(), err)
+
+	calls := httpmock.GetCallCountInfo()
+	assert.Equal(e.T(), 2,
+		calls[fmt.Sprintf("POST /admin/realms/%s/authentication/executions/exec-3/raise-priority", e.realmName)])
+	assert.Equal(e.T(), 1,
+		calls[fmt.Sprintf("POST /admin/realms/%s/authentication/executions/exec-2/raise-priority", e.realmName)])
+
+	err = e.adapter.reorderFlowExecutions(e.realmName, []string{"exec-1"}, []string{"exec-2"})
+	assert.Error(e.T(), err)
+	assert.Contains(e.T(), err.Error(), "unable to find flow execution with id: exec-2")
 }
 
 func TestExecFlowTestSuite(t *testing.T) {
@@ -45,3 +45,154 @@ func (a GoCloakAdapter) AddDefaultScopeToClient(ctx context.Context, realmName,
 
 	return nil
 }
+
+// builtinClientScopes are client scopes which Keycloak assigns to the new clients.
+// They are not removed from the client if they are not in the desired client scopes.
+var builtinClientScopes = map[string]struct{}{
+	"acr":              {},
+	"basic":            {},
+	"email":            {},
+	"profile":          {},
+	"roles":            {},
+	"web-origins":      {},
+	"address":          {},
+	"phone":            {},
+	"offline_access":   {},
+	"microprofile-jwt": {},
+}
+
+// SyncClientScopes sets default and optional client scopes of the client.
+// Nil scopes list means that client scopes of this type are not managed,
+// but the scope which is claimed as the other type is moved anyway.
+// If addOnly is false, client scopes which are not in the list are removed from the client except built-in scopes.
+func (a GoCloakAdapter) SyncClientScopes(
+	ctx context.Context,
+	realmName, clientName string,
+	defaultScopes, optionalScopes []ClientScope,
+	addOnly bool,
+) error {
+	clientID, err := a.GetClientID(clientName, realmName)
+	if err != nil {
+		return errors.Wrap(err, "error during GetClientId")
+	}
+
+	scopes, err := a.client.GetClientsDefaultScopes(ctx, a.token.AccessToken, realmName, clientID)
+	if err != nil {
+		return fmt.Errorf("failed to get default client scopes for client %s: %w", clientName, err)
+	}
+
+	currentDefault := clientScopeIDs(scopes)
+
+	scopes, err = a.client.GetClientsOptionalScopes(ctx, a.token.AccessToken, realmName, clientID)
+	if err != nil {
+		return fmt.Errorf("failed to get optional client scopes for client %s: %w", clientName, err)
+	}
+
+	currentOptional := clientScopeIDs(scopes)
+
+	// Client scopes are removed first, so the scope can be moved between default and optional.
+	if err = removeClientScopes(
+		clientScopesToRemove(currentDefault, defaultScopes, optionalScopes, addOnly),
+		func(scopeID string) error {
+			return a.client.RemoveDefaultScopeFromClient(ctx, a.token.AccessToken, realmName, clientID, scopeID)
+		},
+	); err != nil {
+		return fmt.Errorf("failed to remove default client scope from client %s: %w", clientName, err)
+	}
+
+	if err = removeClientScopes(
+		clientScopesToRemove(currentOptional, optionalScopes, defaultScopes, addOnly),
+		func(scopeID string) error {
+			return a.client.RemoveOptionalScopeFromClient(ctx, a.token.AccessToken, realmName, clientID, scopeID)
+		},
+	); err != nil {
+		return fmt.Errorf("failed to remove optional client scope from client %s: %w", clientName, err)
+	}
+
+	if err = addClientScopes(currentDefault, defaultScopes, func(scopeID string) error {
+		return a.client.AddDefaultScopeToClient(ctx, a.token.AccessToken, realmName, clientID, scopeID)
+	}); err != nil {
+		return fmt.Errorf("failed to add default client scope to client %s: %w", clientName, err)
+	}
+
+	if err = addClientScopes(currentOptional, optionalScopes, func(scopeID string) error {
+		return a.client.AddOptionalScopeToClient(ctx, a.token.AccessToken, realmName, clientID, scopeID)
+	}); err != nil {
+		return fmt.Errorf("failed to add optional client scope to client %s: %w", clientName, err)
+	}
+
+	return nil
+}
+
+// clientScopeIDs converts client scopes to the map of scope id to scope name.
+func clientScopeIDs(scopes []*gocloak.ClientScope) map[string]string {
+	ids := make(map[string]string, len(scopes))
+
+	for _, s := range scopes {
+		if s != nil && s.ID != nil {
+			ids[*s.ID] = gocloak.PString(s.Name)
+		}
+	}
+
+	return ids
+}
+
+// clientScopesToRemove returns current client scopes of one type which should be removed from the client.
+// Scopes claimed as the other type are always removed.
+// Scopes which are not claimed are removed if claimed is not nil and addOnly is false, built-in scopes are kept.
+func clientScopesToRemove(current map[string]string, claimed, claimedOther []ClientScope, addOnly bool) map[string]string {
+	claimedIDs := make(map[string]struct{}, len(claimed))
+	for _, s := range claimed {
+		claimedIDs[s.ID] = struct{}{}
+	}
+
+	claimedOtherIDs := make(map[string]struct{}, len(claimedOther))
+	for _, s := range claimedOther {
+		claimedOtherIDs[s.ID] = struct{}{}
+	}
+
+	toRemove := make(map[string]string)
+
+	for id, name := range current {
+		if _, ok := claimedIDs[id]; ok {
+			continue
+		}
+
+		if _, ok := claimedOtherIDs[id]; ok {
+			toRemove[id] = name
+			continue
+		}
+
+		if _, ok := builtinClientScopes[name]; ok || claimed == nil || addOnly {
+			continue
+		}
+
+		toRemove[id] = name
+	}
+
+	return toRemove
+}
+
+func removeClientScopes(scopes map[string]string, remove func(scopeID string) error) error {
+	for id, name := range scopes {
+		if err := remove(id); err != nil {
+			return fmt.Errorf("unable to remove client scope %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func addClientScopes(current map[string]string, claimed []ClientScope, add func(scopeID string) error) error {
+	for _, s := range claimed {
+		if _, ok := current[s.ID]; ok {
+			continue
+		}
+
+		if err := add(s.ID); err != nil {
+			return fmt.Errorf("unable to add client scope %s: %w", s.Name, err)
+		}
+	}
+
+	return nil
+}
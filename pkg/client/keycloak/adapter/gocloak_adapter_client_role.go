@@ -0,0 +1,71 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Nerzal/gocloak/v12"
+
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/dto"
+)
+
+// SyncClientRole creates or updates client role with its composites and returns role id.
+// clientUUID is an id of the client, not a client id.
+func (a GoCloakAdapter) SyncClientRole(ctx context.Context, realmName, clientUUID string, role *dto.ClientRole) (string, error) {
+	currentRole, err := a.client.GetClientRole(ctx, a.token.AccessToken, realmName, clientUUID, role.Name)
+	if err != nil {
+		if !is404(err) {
+			return "", fmt.Errorf("unable to get client role %s: %w", role.Name, err)
+		}
+
+		if _, err = a.client.CreateClientRole(ctx, a.token.AccessToken, realmName, clientUUID, gocloak.Role{
+			Name:        &role.Name,
+			Description: &role.Description,
+			Attributes:  &role.Attributes,
+			ClientRole:  gocloak.BoolP(true),
+		}); err != nil {
+			return "", fmt.Errorf("unable to create client role %s: %w", role.Name, err)
+		}
+
+		if currentRole, err = a.client.GetClientRole(ctx, a.token.AccessToken, realmName, clientUUID, role.Name); err != nil {
+			return "", fmt.Errorf("unable to get created client role %s: %w", role.Name, err)
+		}
+	} else {
+		currentRole.Description = &role.Description
+		currentRole.Attributes = &role.Attributes
+
+		if err = a.client.UpdateRole(ctx, a.token.AccessToken, realmName, clientUUID, *currentRole); err != nil {
+			return "", fmt.Errorf("unable to update client role %s: %w", role.Name, err)
+		}
+	}
+
+	roleID := gocloak.PString(currentRole.ID)
+
+	if err = a.syncComposites(
+		ctx,
+		realmName,
+		roleID,
+		role.Composites,
+		role.CompositesClientRoles,
+		role.CompositesAddOnly,
+		func(roles []gocloak.Role) error {
+			return a.client.AddClientRoleComposite(ctx, a.token.AccessToken, realmName, roleID, roles)
+		},
+		func(roles []gocloak.Role) error {
+			return a.client.DeleteClientRoleComposite(ctx, a.token.AccessToken, realmName, roleID, roles)
+		},
+	); err != nil {
+		return "", fmt.Errorf("unable to sync client role %s composites: %w", role.Name, err)
+	}
+
+	return roleID, nil
+}
+
+// DeleteClientRole deletes client role. It doesn't return an error if the role or the client is already deleted.
+func (a GoCloakAdapter) DeleteClientRole(ctx context.Context, realmName, clientUUID, roleName string) error {
+	if err := a.client.DeleteClientRole(ctx, a.token.AccessToken, realmName, clientUUID, roleName); err != nil && !is404(err) {
+		return fmt.Errorf("unable to delete client role %s: %w", roleName, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Nerzal/gocloak/v12"
+	testifymock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/dto"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
+)
+
+func TestGoCloakAdapter_SyncClientRole(t *testing.T) {
+	t.Parallel()
+
+	role := dto.ClientRole{
+		Name:        "editor",
+		Description: "Editor role",
+		Attributes:  map[string][]string{"foo": {"bar"}},
+		Composites:  []string{"offline_access"},
+		CompositesClientRoles: map[string][]string{
+			"realm-management": {"view-users"},
+		},
+	}
+	offlineAccess := gocloak.Role{Name: gocloak.StringP("offline_access"), ID: gocloak.StringP("oa-id")}
+	viewUsers := gocloak.Role{Name: gocloak.StringP("view-users"), ID: gocloak.StringP("vu-id"),
+		ClientRole: gocloak.BoolP(true), ContainerID: gocloak.StringP("rm-uuid")}
+	manageUsers := gocloak.Role{Name: gocloak.StringP("manage-users"), ID: gocloak.StringP("mu-id"),
+		ClientRole: gocloak.BoolP(true), ContainerID: gocloak.StringP("rm-uuid")}
+
+	tests := []struct {
+		name      string
+		addOnly   bool
+		setupMock func(m *MockGoCloakClient)
+		wantErr   require.ErrorAssertionFunc
+		wantID    string
+	}{
+		{
+			name: "create role",
+			setupMock: func(m *MockGoCloakClient) {
+				m.On("GetClientRole", "realm", "client-uuid", "editor").
+					Return(nil, errors.New("404 Not Found")).Once()
+				m.On("CreateClientRole", "realm", "client-uuid", testifymock.Anything).Return("", nil)
+				m.On("GetClientRole", "realm", "client-uuid", "editor").
+					Return(&gocloak.Role{ID: gocloak.StringP("role-id"), Name: gocloak.StringP("editor")}, nil).Once()
+				m.On("GetCompositeRolesByRoleID", "realm", "role-id").Return([]*gocloak.Role{}, nil)
+				m.On("GetRealmRole", "realm", "offline_access").Return(&offlineAccess, nil)
+				m.On("GetClients", "realm", gocloak.GetClientsParams{ClientID: gocloak.StringP("realm-management")}).
+					Return([]*gocloak.Client{{ID: gocloak.StringP("rm-uuid"), ClientID: gocloak.StringP("realm-management")}}, nil)
+				m.On("GetClientRole", "realm", "rm-uuid", "view-users").Return(&viewUsers, nil)
+				m.On("AddClientRoleComposite", "realm", "role-id", []gocloak.Role{offlineAccess, viewUsers}).Return(nil)
+			},
+			wantErr: require.NoError,
+			wantID:  "role-id",
+		},
+		{
+			name: "update role and remove stale composites",
+			setupMock: func(m *MockGoCloakClient) {
+				m.On("GetClientRole", "realm", "client-uuid", "editor").
+					Return(&gocloak.Role{ID: gocloak.StringP("role-id"), Name: gocloak.StringP("editor")}, nil)
+				m.On("UpdateRole", "realm", "client-uuid", gocloak.Role{
+					ID:          gocloak.StringP("role-id"),
+					Name:        gocloak.StringP("editor"),
+					Description: gocloak.StringP("Editor role"),
+					Attributes:  &map[string][]string{"foo": {"bar"}},
+				}).Return(nil)
+				m.On("GetCompositeRolesByRoleID", "realm", "role-id").
+					Return([]*gocloak.Role{&offlineAccess, &viewUsers, &manageUsers}, nil)
+				m.On("GetClients", "realm", gocloak.GetClientsParams{ClientID: gocloak.StringP("realm-management")}).
+					Return([]*gocloak.Client{{ID: gocloak.StringP("rm-uuid"), ClientID: gocloak.StringP("realm-management")}}, nil)
+				m.On("DeleteClientRoleComposite", "realm", "role-id", []gocloak.Role{manageUsers}).Return(nil)
+			},
+			wantErr: require.NoError,
+			wantID:  "role-id",
+		},
+		{
+			name:    "update role and keep stale composites in addOnly mode",
+			addOnly: true,
+			setupMock: func(m *MockGoCloakClient) {
+				m.On("GetClientRole", "realm", "client-uuid", "editor").
+					Return(&gocloak.Role{ID: gocloak.StringP("role-id"), Name: gocloak.StringP("editor")}, nil)
+				m.On("UpdateRole", "realm", "client-uuid", testifymock.Anything).Return(nil)
+				m.On("GetCompositeRolesByRoleID", "realm", "role-id").
+					Return([]*gocloak.Role{&offlineAccess, &viewUsers, &manageUsers}, nil)
+				m.On("GetClients", "realm", gocloak.GetClientsParams{ClientID: gocloak.StringP("realm-management")}).
+					Return([]*gocloak.Client{{ID: gocloak.StringP("rm-uuid"), ClientID: gocloak.StringP("realm-management")}}, nil)
+			},
+			wantErr: require.NoError,
+			wantID:  "role-id",
+		},
+		{
+			name: "failed to get role",
+			setupMock: func(m *MockGoCloakClient) {
+				m.On("GetClientRole", "realm", "client-uuid", "editor").Return(nil, errors.New("fatal"))
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.ErrorContains(t, err, "unable to get client role editor")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := &MockGoCloakClient{}
+			tt.setupMock(m)
+
+			a := GoCloakAdapter{client: m, token: &gocloak.JWT{AccessToken: "token"}, log: mock.NewLogr()}
+			r := role
+			r.CompositesAddOnly = tt.addOnly
+
+			id, err := a.SyncClientRole(context.Background(), "realm", "client-uuid", &r)
+			tt.wantErr(t, err)
+			require.Equal(t, tt.wantID, id)
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGoCloakAdapter_DeleteClientRole(t *testing.T) {
+	t.Parallel()
+
+	m := &MockGoCloakClient{}
+	a := GoCloakAdapter{client: m, token: &gocloak.JWT{AccessToken: "token"}, log: mock.NewLogr()}
+
+	m.On("DeleteClientRole", "realm", "client-uuid", "role1").Return(nil)
+	m.On("DeleteClientRole", "realm", "client-uuid", "role2").Return(errors.New("404 Not Found"))
+	m.On("DeleteClientRole", "realm", "client-uuid", "role3").Return(errors.New("fatal"))
+
+	require.NoError(t, a.DeleteClientRole(context.Background(), "realm", "client-uuid", "role1"))
+	require.NoError(t, a.DeleteClientRole(context.Background(), "realm", "client-uuid", "role2"))
+	require.ErrorContains(t, a.DeleteClientRole(context.Background(), "realm", "client-uuid", "role3"), "fatal")
+}
@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
 )
 
 const (
@@ -20,7 +22,9 @@ type ClientScope struct {
 	Attributes      map[string]string `json:"attributes"`
 	Protocol        string            `json:"protocol"`
 	ProtocolMappers []ProtocolMapper  `json:"protocolMappers"`
-	Default         bool              `json:"-"`
+	// Type is a realm assignment type of client scope: default, optional or none.
+	// Empty type means that the client scope is only removed from the realm default client scopes.
+	Type string `json:"-"`
 }
 
 type ProtocolMapper struct {
@@ -48,10 +52,8 @@ func (a GoCloakAdapter) CreateClientScope(ctx context.Context, realmName string,
 		return "", errors.Wrap(err, "unable to get flow id")
 	}
 
-	if scope.Default {
-		if err := a.setDefaultClientScopeForRealm(ctx, realmName, id); err != nil {
-			return id, errors.Wrap(err, "unable to set default client scope for realm")
-		}
+	if err := a.setClientScopeTypeForRealm(ctx, realmName, id, scope.Type); err != nil {
+		return id, err
 	}
 
 	return id, nil
@@ -75,46 +77,85 @@ func (a GoCloakAdapter) UpdateClientScope(ctx context.Context, realmName, scopeI
 		return errors.Wrap(err, "unable to update client scope")
 	}
 
-	needToUpdateDefault, err := a.needToUpdateDefault(ctx, realmName, scope)
+	currentType, err := a.getClientScopeTypeForRealm(ctx, realmName, scope.Name)
 	if err != nil {
-		return errors.Wrap(err, "unable to check if need to update default")
+		return errors.Wrap(err, "unable to get client scope type")
 	}
 
-	if !needToUpdateDefault {
+	if currentType == scope.Type {
 		return nil
 	}
 
-	if scope.Default {
-		if err := a.setDefaultClientScopeForRealm(ctx, realmName, scopeID); err != nil {
-			return errors.Wrap(err, "unable to set default client scope for realm")
+	if scope.Type == "" {
+		if currentType != keycloakApi.ClientScopeTypeDefault {
+			return nil
 		}
 
-		return nil
+		return a.unsetClientScopeTypeForRealm(ctx, realmName, scopeID, currentType)
 	}
 
-	if err := a.unsetDefaultClientScopeForRealm(ctx, realmName, scopeID); err != nil {
-		return errors.Wrap(err, "unable to unset default client scope for realm")
+	if err := a.unsetClientScopeTypeForRealm(ctx, realmName, scopeID, currentType); err != nil {
+		return err
+	}
+
+	if err := a.setClientScopeTypeForRealm(ctx, realmName, scopeID, scope.Type); err != nil {
+		return err
 	}
 
 	return nil
 }
 
-func (a GoCloakAdapter) needToUpdateDefault(ctx context.Context, realmName string, scope *ClientScope) (bool, error) {
+// getClientScopeTypeForRealm returns realm assignment type of client scope with the given name.
+func (a GoCloakAdapter) getClientScopeTypeForRealm(ctx context.Context, realmName, scopeName string) (string, error) {
 	defaultScopes, err := a.GetDefaultClientScopesForRealm(ctx, realmName)
 	if err != nil {
-		return false, errors.Wrap(err, "unable to get default client scopes")
+		return "", errors.Wrap(err, "unable to get default client scopes")
+	}
+
+	if _, err = getClientScope(scopeName, defaultScopes); err == nil {
+		return keycloakApi.ClientScopeTypeDefault, nil
+	}
+
+	optionalScopes, err := a.GetOptionalClientScopesForRealm(ctx, realmName)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to get optional client scopes")
+	}
+
+	if _, err = getClientScope(scopeName, optionalScopes); err == nil {
+		return keycloakApi.ClientScopeTypeOptional, nil
+	}
+
+	return keycloakApi.ClientScopeTypeNone, nil
+}
+
+func (a GoCloakAdapter) setClientScopeTypeForRealm(ctx context.Context, realmName, scopeID, scopeType string) error {
+	switch scopeType {
+	case keycloakApi.ClientScopeTypeDefault:
+		if err := a.setDefaultClientScopeForRealm(ctx, realmName, scopeID); err != nil {
+			return errors.Wrap(err, "unable to set default client scope for realm")
+		}
+	case keycloakApi.ClientScopeTypeOptional:
+		if err := a.setOptionalClientScopeForRealm(ctx, realmName, scopeID); err != nil {
+			return errors.Wrap(err, "unable to set optional client scope for realm")
+		}
 	}
 
-	currentScopeDefaultState := false
+	return nil
+}
 
-	for _, s := range defaultScopes {
-		if s.Name == scope.Name {
-			currentScopeDefaultState = true
-			break
+func (a GoCloakAdapter) unsetClientScopeTypeForRealm(ctx context.Context, realmName, scopeID, scopeType string) error {
+	switch scopeType {
+	case keycloakApi.ClientScopeTypeDefault:
+		if err := a.unsetDefaultClientScopeForRealm(ctx, realmName, scopeID); err != nil {
+			return errors.Wrap(err, "unable to unset default client scope for realm")
+		}
+	case keycloakApi.ClientScopeTypeOptional:
+		if err := a.unsetOptionalClientScopeForRealm(ctx, realmName, scopeID); err != nil {
+			return errors.Wrap(err, "unable to unset optional client scope for realm")
 		}
 	}
 
-	return currentScopeDefaultState != scope.Default, nil
+	return nil
 }
 
 // TODO: add context.
@@ -216,6 +257,10 @@ func (a GoCloakAdapter) DeleteClientScope(ctx context.Context, realmName, scopeI
 		return errors.Wrap(err, "unable to unset default client scope for realm")
 	}
 
+	if err := a.unsetOptionalClientScopeForRealm(ctx, realmName, scopeID); err != nil {
+		return errors.Wrap(err, "unable to unset optional client scope for realm")
+	}
+
 	if err := a.client.DeleteClientScope(ctx, a.token.AccessToken, realmName, scopeID); err != nil {
 		return errors.Wrap(err, "unable to delete client scope")
 	}
@@ -266,6 +311,24 @@ func (a GoCloakAdapter) syncClientScopeProtocolMappers(ctx context.Context, real
 }
 
 func (a GoCloakAdapter) GetDefaultClientScopesForRealm(ctx context.Context, realmName string) ([]ClientScope, error) {
+	scopes, err := a.getRealmClientScopes(ctx, realmName, getDefaultClientScopes)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get default client scopes for realm")
+	}
+
+	return scopes, nil
+}
+
+func (a GoCloakAdapter) GetOptionalClientScopesForRealm(ctx context.Context, realmName string) ([]ClientScope, error) {
+	scopes, err := a.getRealmClientScopes(ctx, realmName, realmOptionalClientScopes)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get optional client scopes for realm")
+	}
+
+	return scopes, nil
+}
+
+func (a GoCloakAdapter) getRealmClientScopes(ctx context.Context, realmName, path string) ([]ClientScope, error) {
 	var scopes []ClientScope
 
 	rsp, err := a.startRestyRequest().
@@ -274,10 +337,10 @@ func (a GoCloakAdapter) GetDefaultClientScopesForRealm(ctx context.Context, real
 			keycloakApiParamRealm: realmName,
 		}).
 		SetResult(&scopes).
-		Get(a.buildPath(getDefaultClientScopes))
+		Get(a.buildPath(path))
 
 	if err = a.checkError(err, rsp); err != nil {
-		return nil, errors.Wrap(err, "unable to get default client scopes for realm")
+		return nil, err
 	}
 
 	return scopes, nil
@@ -319,6 +382,42 @@ func (a GoCloakAdapter) unsetDefaultClientScopeForRealm(ctx context.Context, rea
 	return nil
 }
 
+func (a GoCloakAdapter) setOptionalClientScopeForRealm(ctx context.Context, realm, scopeID string) error {
+	rsp, err := a.startRestyRequest().
+		SetContext(ctx).
+		SetPathParams(map[string]string{
+			keycloakApiParamRealm:         realm,
+			keycloakApiParamClientScopeId: scopeID,
+		}).
+		SetBody(map[string]string{
+			keycloakApiParamRealm:         realm,
+			keycloakApiParamClientScopeId: scopeID,
+		}).
+		Put(a.buildPath(realmOptionalClientScope))
+
+	if err = a.checkError(err, rsp); err != nil {
+		return errors.Wrap(err, "unable to set optional client scope for realm")
+	}
+
+	return nil
+}
+
+func (a GoCloakAdapter) unsetOptionalClientScopeForRealm(ctx context.Context, realm, scopeID string) error {
+	rsp, err := a.startRestyRequest().
+		SetContext(ctx).
+		SetPathParams(map[string]string{
+			keycloakApiParamRealm:         realm,
+			keycloakApiParamClientScopeId: scopeID,
+		}).
+		Delete(a.buildPath(realmOptionalClientScope))
+
+	if err = a.checkError(err, rsp); err != nil {
+		return errors.Wrap(err, "unable to unset optional client scope for realm")
+	}
+
+	return nil
+}
+
 func (a GoCloakAdapter) GetClientScopeMappers(ctx context.Context, realmName, scopeID string) ([]ProtocolMapper, error) {
 	var mappers []ProtocolMapper
 	rsp, err := a.startRestyRequest().
@@ -13,6 +13,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
 )
 
@@ -57,7 +58,7 @@ func TestGoCloakAdapter_CreateClientScope(t *testing.T) {
 	httpmock.RegisterResponder("PUT", defaultClientScopePath, httpmock.NewStringResponder(200, ""))
 
 	id, err := adapter.CreateClientScope(context.Background(), "realm1",
-		&ClientScope{Name: "demo", Default: true})
+		&ClientScope{Name: "demo", Type: keycloakApi.ClientScopeTypeDefault})
 	require.NoError(t, err)
 
 	if id == "" {
@@ -86,7 +87,7 @@ func TestGoCloakAdapter_CreateClientScope_FailureSetDefault(t *testing.T) {
 		httpmock.ResponderFromResponse(rsp))
 
 	_, err := adapter.CreateClientScope(context.Background(), "realm1",
-		&ClientScope{Name: "demo", Default: true})
+		&ClientScope{Name: "demo", Type: keycloakApi.ClientScopeTypeDefault})
 	require.Error(t, err)
 
 	if !strings.Contains(err.Error(), "unable to set default client scope for realm") {
@@ -108,7 +109,7 @@ func TestGoCloakAdapter_CreateClientScope_FailureCreate(t *testing.T) {
 	mockClient.On("RestyClient").Return(restyClient)
 
 	_, err := adapter.CreateClientScope(context.Background(), "realm1",
-		&ClientScope{Name: "demo", Default: true})
+		&ClientScope{Name: "demo", Type: keycloakApi.ClientScopeTypeDefault})
 
 	require.Error(t, err)
 
@@ -136,7 +137,7 @@ func TestGoCloakAdapter_CreateClientScope_FailureGetID(t *testing.T) {
 		httpmock.ResponderFromResponse(rsp))
 
 	_, err := adapter.CreateClientScope(context.Background(), "realm1",
-		&ClientScope{Name: "demo", Default: true})
+		&ClientScope{Name: "demo", Type: keycloakApi.ClientScopeTypeDefault})
 
 	err = errors.Cause(err)
 	require.Error(t, err)
@@ -195,6 +196,8 @@ func TestGoCloakAdapter_UpdateClientScope(t *testing.T) {
 	httpmock.RegisterResponder("PUT", putDefaultClientScope, httpmock.NewStringResponder(200, ""))
 	httpmock.RegisterResponder("GET", "/admin/realms/realm1/default-default-client-scopes",
 		httpmock.NewJsonResponderOrPanic(200, []ClientScope{}))
+	httpmock.RegisterResponder("GET", "/admin/realms/realm1/default-optional-client-scopes",
+		httpmock.NewJsonResponderOrPanic(200, []ClientScope{}))
 
 	if err := adapter.UpdateClientScope(context.Background(), realmName, scopeID, &ClientScope{
 		Name: "scope1",
@@ -214,7 +217,7 @@ func TestGoCloakAdapter_UpdateClientScope(t *testing.T) {
 				Name: "mp2",
 			},
 		},
-		Default: true,
+		Type: keycloakApi.ClientScopeTypeDefault,
 	}); err != nil {
 		t.Fatalf("%+v", err)
 	}
@@ -229,7 +232,7 @@ func TestGoCloakAdapter_UpdateClientScope(t *testing.T) {
 				Name: "mp2",
 			},
 		},
-		Default: false,
+		Type: keycloakApi.ClientScopeTypeNone,
 	}); err != nil {
 		t.Fatalf("%+v", err)
 	}
@@ -275,6 +278,8 @@ func TestGoCloakAdapter_DeleteClientScope(t *testing.T) {
 	deleteDefaultClientScope = strings.ReplaceAll(deleteDefaultClientScope, "{clientScopeID}", "scope1")
 
 	httpmock.RegisterResponder("DELETE", deleteDefaultClientScope, httpmock.NewStringResponder(200, ""))
+	httpmock.RegisterResponder("DELETE", "/admin/realms/realm1/default-optional-client-scopes/scope1",
+		httpmock.NewStringResponder(200, ""))
 	mockClient.On("DeleteClientScope", "realm1", "scope1").Return(nil)
 
 	err := adapter.DeleteClientScope(context.Background(), "realm1", "scope1")
@@ -313,6 +318,8 @@ func TestGoCloakAdapter_DeleteClientScope_Failure(t *testing.T) {
 	deleteDefaultClientScope = strings.ReplaceAll(deleteDefaultClientScope, "{clientScopeID}", "scope1")
 
 	httpmock.RegisterResponder("DELETE", deleteDefaultClientScope, httpmock.NewStringResponder(200, ""))
+	httpmock.RegisterResponder("DELETE", "/admin/realms/realm1/default-optional-client-scopes/scope1",
+		httpmock.NewStringResponder(200, ""))
 	mockClient.On("DeleteClientScope", "realm1", "scope1").Return(errors.New("mock fatal"))
 
 	err = adapter.DeleteClientScope(context.Background(), "realm1", "scope1")
@@ -437,3 +444,105 @@ func TestGoCloakAdapter_GetClientScopesByNames(t *testing.T) {
 		})
 	}
 }
+
+func TestGoCloakAdapter_UpdateClientScope_Type(t *testing.T) {
+	tests := []struct {
+		name           string
+		scopeType      string
+		defaultScopes  []ClientScope
+		optionalScopes []ClientScope
+		wantCalls      []string
+	}{
+		{
+			name:          "demote default to optional",
+			scopeType:     keycloakApi.ClientScopeTypeOptional,
+			defaultScopes: []ClientScope{{Name: "scope1"}},
+			wantCalls: []string{
+				"DELETE /admin/realms/realm1/default-default-client-scopes/scope1",
+				"PUT /admin/realms/realm1/default-optional-client-scopes/scope1",
+			},
+		},
+		{
+			name:           "promote optional to default",
+			scopeType:      keycloakApi.ClientScopeTypeDefault,
+			optionalScopes: []ClientScope{{Name: "scope1"}},
+			wantCalls: []string{
+				"DELETE /admin/realms/realm1/default-optional-client-scopes/scope1",
+				"PUT /admin/realms/realm1/default-default-client-scopes/scope1",
+			},
+		},
+		{
+			name:           "unassign optional",
+			scopeType:      keycloakApi.ClientScopeTypeNone,
+			optionalScopes: []ClientScope{{Name: "scope1"}},
+			wantCalls: []string{
+				"DELETE /admin/realms/realm1/default-optional-client-scopes/scope1",
+			},
+		},
+		{
+			name:           "optional scope is not changed",
+			scopeType:      keycloakApi.ClientScopeTypeOptional,
+			optionalScopes: []ClientScope{{Name: "scope1"}},
+		},
+		{
+			name:          "empty type removes default",
+			defaultScopes: []ClientScope{{Name: "scope1"}},
+			wantCalls: []string{
+				"DELETE /admin/realms/realm1/default-default-client-scopes/scope1",
+			},
+		},
+		{
+			name:           "empty type keeps optional",
+			optionalScopes: []ClientScope{{Name: "scope1"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter, mockClient, _ := initAdapter()
+			mockClient.On("GetClientScope", "realm1", "scope1").Return(&gocloak.ClientScope{ID: gocloak.StringP("scope1")}, nil)
+
+			httpmock.Reset()
+			httpmock.RegisterResponder("PUT", "/admin/realms/realm1/client-scopes/scope1", httpmock.NewStringResponder(200, ""))
+			httpmock.RegisterResponder("GET", "/admin/realms/realm1/default-default-client-scopes",
+				httpmock.NewJsonResponderOrPanic(200, tt.defaultScopes))
+			httpmock.RegisterResponder("GET", "/admin/realms/realm1/default-optional-client-scopes",
+				httpmock.NewJsonResponderOrPanic(200, tt.optionalScopes))
+
+			for _, path := range []string{
+				"/admin/realms/realm1/default-default-client-scopes/scope1",
+				"/admin/realms/realm1/default-optional-client-scopes/scope1",
+			} {
+				httpmock.RegisterResponder("PUT", path, httpmock.NewStringResponder(200, ""))
+				httpmock.RegisterResponder("DELETE", path, httpmock.NewStringResponder(200, ""))
+			}
+
+			err := adapter.UpdateClientScope(context.Background(), "realm1", "scope1", &ClientScope{
+				Name: "scope1",
+				Type: tt.scopeType,
+			})
+			require.NoError(t, err)
+
+			info := httpmock.GetCallCountInfo()
+
+			for _, call := range tt.wantCalls {
+				require.Equal(t, 1, info[call], call)
+			}
+
+			require.Equal(t, len(tt.wantCalls), countRealmScopeTypeChanges(info))
+		})
+	}
+}
+
+func countRealmScopeTypeChanges(info map[string]int) int {
+	count := 0
+
+	for call, n := range info {
+		if (strings.HasPrefix(call, "PUT") || strings.HasPrefix(call, "DELETE")) &&
+			strings.Contains(call, "-client-scopes/") {
+			count += n
+		}
+	}
+
+	return count
+}
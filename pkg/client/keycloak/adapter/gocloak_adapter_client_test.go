@@ -138,3 +138,120 @@ func TestGoCloakAdapter_AddDefaultScopeToClient(t *testing.T) {
 		})
 	}
 }
+
+func TestGoCloakAdapter_SyncClientScopes(t *testing.T) {
+	t.Parallel()
+
+	realm := "rl"
+	clientID := "clid1"
+	clientName := "cl"
+	clients := []*gocloak.Client{{ID: gocloak.StringP(clientID), ClientID: gocloak.StringP(clientName)}}
+
+	tests := map[string]struct {
+		defaultScopes  []ClientScope
+		optionalScopes []ClientScope
+		addOnly        bool
+		setupMock      func(m *MockGoCloakClient)
+		expectErr      bool
+	}{
+		"full sync": {
+			defaultScopes:  []ClientScope{{ID: "sc1", Name: "scope1"}, {ID: "sc2", Name: "scope2"}},
+			optionalScopes: []ClientScope{{ID: "sc3", Name: "scope3"}},
+			setupMock: func(m *MockGoCloakClient) {
+				m.On("GetClientsDefaultScopes", realm, clientID).Return([]*gocloak.ClientScope{
+					{ID: gocloak.StringP("sc1"), Name: gocloak.StringP("scope1")},
+					{ID: gocloak.StringP("sc3"), Name: gocloak.StringP("scope3")},
+					{ID: gocloak.StringP("sc4"), Name: gocloak.StringP("scope4")},
+					{ID: gocloak.StringP("profile-id"), Name: gocloak.StringP("profile")},
+				}, nil)
+				m.On("GetClientsOptionalScopes", realm, clientID).Return([]*gocloak.ClientScope{
+					{ID: gocloak.StringP("sc5"), Name: gocloak.StringP("scope5")},
+					{ID: gocloak.StringP("offline-id"), Name: gocloak.StringP("offline_access")},
+				}, nil)
+				m.On("RemoveDefaultScopeFromClient", realm, clientID, "sc3").Return(nil)
+				m.On("RemoveDefaultScopeFromClient", realm, clientID, "sc4").Return(nil)
+				m.On("RemoveOptionalScopeFromClient", realm, clientID, "sc5").Return(nil)
+				m.On("AddDefaultScopeToClient", realm, clientID, "sc2").Return(nil)
+				m.On("AddOptionalScopeToClient", realm, clientID, "sc3").Return(nil)
+			},
+		},
+		"add only optional scopes": {
+			optionalScopes: []ClientScope{{ID: "sc3", Name: "scope3"}},
+			addOnly:        true,
+			setupMock: func(m *MockGoCloakClient) {
+				m.On("GetClientsDefaultScopes", realm, clientID).Return([]*gocloak.ClientScope{
+					{ID: gocloak.StringP("sc1"), Name: gocloak.StringP("scope1")},
+				}, nil)
+				m.On("GetClientsOptionalScopes", realm, clientID).Return([]*gocloak.ClientScope{
+					{ID: gocloak.StringP("sc5"), Name: gocloak.StringP("scope5")},
+				}, nil)
+				m.On("AddOptionalScopeToClient", realm, clientID, "sc3").Return(nil)
+			},
+		},
+		"move default scope to optional": {
+			optionalScopes: []ClientScope{{ID: "sc1", Name: "scope1"}},
+			setupMock: func(m *MockGoCloakClient) {
+				m.On("GetClientsDefaultScopes", realm, clientID).Return([]*gocloak.ClientScope{
+					{ID: gocloak.StringP("sc1"), Name: gocloak.StringP("scope1")},
+					{ID: gocloak.StringP("sc2"), Name: gocloak.StringP("scope2")},
+				}, nil)
+				m.On("GetClientsOptionalScopes", realm, clientID).Return([]*gocloak.ClientScope{}, nil)
+				m.On("RemoveDefaultScopeFromClient", realm, clientID, "sc1").Return(nil)
+				m.On("AddOptionalScopeToClient", realm, clientID, "sc1").Return(nil)
+			},
+		},
+		"failed to remove default scope": {
+			defaultScopes: []ClientScope{},
+			setupMock: func(m *MockGoCloakClient) {
+				m.On("GetClientsDefaultScopes", realm, clientID).Return([]*gocloak.ClientScope{
+					{ID: gocloak.StringP("sc1"), Name: gocloak.StringP("scope1")},
+				}, nil)
+				m.On("GetClientsOptionalScopes", realm, clientID).Return([]*gocloak.ClientScope{}, nil)
+				m.On("RemoveDefaultScopeFromClient", realm, clientID, "sc1").Return(errors.New("failed"))
+			},
+			expectErr: true,
+		},
+		"failed to add optional scope": {
+			optionalScopes: []ClientScope{{ID: "sc3", Name: "scope3"}},
+			setupMock: func(m *MockGoCloakClient) {
+				m.On("GetClientsDefaultScopes", realm, clientID).Return([]*gocloak.ClientScope{}, nil)
+				m.On("GetClientsOptionalScopes", realm, clientID).Return([]*gocloak.ClientScope{}, nil)
+				m.On("AddOptionalScopeToClient", realm, clientID, "sc3").Return(errors.New("failed"))
+			},
+			expectErr: true,
+		},
+		"failed to get default scopes": {
+			defaultScopes: []ClientScope{{ID: "sc1", Name: "scope1"}},
+			setupMock: func(m *MockGoCloakClient) {
+				m.On("GetClientsDefaultScopes", realm, clientID).Return(nil, errors.New("failed"))
+			},
+			expectErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			adapter, mockClient, _ := initAdapter()
+			mockClient.On("GetClients", realm, gocloak.GetClientsParams{ClientID: gocloak.StringP(clientName)}).Return(clients, nil)
+			tc.setupMock(mockClient)
+
+			err := adapter.SyncClientScopes(context.Background(), realm, clientName, tc.defaultScopes, tc.optionalScopes, tc.addOnly)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			for _, call := range mockClient.ExpectedCalls {
+				if call.Method != "RestyClient" {
+					mockClient.AssertCalled(t, call.Method, call.Arguments...)
+				}
+			}
+		})
+	}
+}
@@ -2,13 +2,19 @@ package adapter
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/Nerzal/gocloak/v12"
 	"github.com/pkg/errors"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1"
 )
 
+const usersPageSize = 100
+
 type NotFoundError string
 
 func (e NotFoundError) Error() string {
@@ -96,37 +102,166 @@ func (a GoCloakAdapter) syncSubGroups(realm string, group *gocloak.Group, subGro
 	return nil
 }
 
-func (a GoCloakAdapter) SyncRealmGroup(realmName string, spec *keycloakApi.KeycloakRealmGroupSpec) (string, error) {
-	group, err := a.getGroup(realmName, spec.Name)
+// RealmGroupRef contains identifiers which are used to find the group and to place it in the groups tree.
+type RealmGroupRef struct {
+	// ID is an id of the existing group. If it is set, the group is looked up by id before the lookup by path.
+	ID string
+
+	// ParentID is an id of the parent group. If it is empty, the group is placed at the top level.
+	ParentID string
+
+	// ManagePlacement enables moving of the existing group to the desired parent or to the top level.
+	// It should be enabled only if the parent is declared or was set by the operator,
+	// so the groups placed manually or by the deprecated subGroups field of another group are not moved.
+	ManagePlacement bool
+}
+
+// SyncRealmGroup creates or updates group and returns it.
+// Group is looked up by id and then by its full path.
+func (a GoCloakAdapter) SyncRealmGroup(
+	ctx context.Context,
+	realmName string,
+	spec *keycloakApi.KeycloakRealmGroupSpec,
+	ref RealmGroupRef,
+) (*gocloak.Group, error) {
+	path := "/" + spec.Name
+
+	if ref.ParentID != "" {
+		parent, err := a.client.GetGroup(ctx, a.token.AccessToken, realmName, ref.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get parent group %s: %w", ref.ParentID, err)
+		}
+
+		path = gocloak.PString(parent.Path) + path
+	}
+
+	group, err := a.findGroup(ctx, realmName, ref.ID, path)
 	if err != nil {
 		if !IsErrNotFound(err) {
-			return "", errors.Wrapf(err, "unable to get group with spec %+v", spec)
+			return nil, fmt.Errorf("unable to get group %s: %w", path, err)
 		}
 
-		group = &gocloak.Group{Name: &spec.Name, Path: &spec.Path, Attributes: &spec.Attributes, Access: &spec.Access}
-
-		groupID, err := a.client.CreateGroup(context.Background(), a.token.AccessToken, realmName, *group)
+		group, err = a.createGroup(ctx, realmName, spec, ref.ParentID)
 		if err != nil {
-			return "", errors.Wrapf(err, "unable to create group with spec %+v", spec)
+			return nil, err
 		}
-
-		group.ID = &groupID
 	} else {
-		group.Path, group.Access, group.Attributes = &spec.Path, &spec.Access, &spec.Attributes
-		if err := a.client.UpdateGroup(context.Background(), a.token.AccessToken, realmName, *group); err != nil {
-			return "", errors.Wrapf(err, "unable to update group, realm: %s, group spec: %+v", realmName, spec)
+		group.Name, group.Access, group.Attributes = &spec.Name, &spec.Access, &spec.Attributes
+		if err = a.client.UpdateGroup(ctx, a.token.AccessToken, realmName, *group); err != nil {
+			return nil, fmt.Errorf("unable to update group %s: %w", path, err)
+		}
+
+		if ref.ManagePlacement && parentGroupPath(gocloak.PString(group.Path)) != parentGroupPath(path) {
+			if group, err = a.moveGroup(ctx, realmName, group, ref.ParentID); err != nil {
+				return nil, err
+			}
 		}
 	}
 
 	if err := a.syncGroupRoles(realmName, *group.ID, spec); err != nil {
-		return "", errors.Wrapf(err, "unable to sync group realm roles, group: %+v with spec %+v", group, spec)
+		return nil, errors.Wrapf(err, "unable to sync group realm roles, group: %+v with spec %+v", group, spec)
 	}
 
-	if err := a.syncSubGroups(realmName, group, spec.SubGroups); err != nil {
-		return "", errors.Wrapf(err, "unable to sync subgroups, group: %+v with spec: %+v", group, spec)
+	if len(spec.SubGroups) > 0 {
+		if err := a.syncSubGroups(realmName, group, spec.SubGroups); err != nil {
+			return nil, errors.Wrapf(err, "unable to sync subgroups, group: %+v with spec: %+v", group, spec)
+		}
 	}
 
-	return *group.ID, nil
+	return group, nil
+}
+
+// findGroup looks up group by id and then by its full path.
+func (a GoCloakAdapter) findGroup(ctx context.Context, realmName, groupID, path string) (*gocloak.Group, error) {
+	if groupID != "" {
+		group, err := a.client.GetGroup(ctx, a.token.AccessToken, realmName, groupID)
+		if err == nil {
+			return group, nil
+		}
+
+		if !is404(err) {
+			return nil, fmt.Errorf("unable to get group by id %s: %w", groupID, err)
+		}
+	}
+
+	group, err := a.client.GetGroupByPath(ctx, a.token.AccessToken, realmName, path)
+	if err != nil {
+		if is404(err) {
+			return nil, NotFoundError("group not found")
+		}
+
+		return nil, fmt.Errorf("unable to get group by path %s: %w", path, err)
+	}
+
+	return group, nil
+}
+
+func (a GoCloakAdapter) createGroup(
+	ctx context.Context,
+	realmName string,
+	spec *keycloakApi.KeycloakRealmGroupSpec,
+	parentID string,
+) (*gocloak.Group, error) {
+	group := gocloak.Group{Name: &spec.Name, Path: &spec.Path, Attributes: &spec.Attributes, Access: &spec.Access}
+
+	var (
+		groupID string
+		err     error
+	)
+
+	if parentID != "" {
+		groupID, err = a.client.CreateChildGroup(ctx, a.token.AccessToken, realmName, parentID, group)
+	} else {
+		groupID, err = a.client.CreateGroup(ctx, a.token.AccessToken, realmName, group)
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to create group with spec %+v", spec)
+	}
+
+	created, err := a.client.GetGroup(ctx, a.token.AccessToken, realmName, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get created group %s: %w", groupID, err)
+	}
+
+	return created, nil
+}
+
+// moveGroup moves existing group to the parent group or to the top level if parentID is empty.
+// Keycloak moves the group if it is posted with its id to the children of another group or to the top level groups.
+func (a GoCloakAdapter) moveGroup(
+	ctx context.Context,
+	realmName string,
+	group *gocloak.Group,
+	parentID string,
+) (*gocloak.Group, error) {
+	var err error
+
+	if parentID != "" {
+		_, err = a.client.CreateChildGroup(ctx, a.token.AccessToken, realmName, parentID, *group)
+	} else {
+		_, err = a.client.CreateGroup(ctx, a.token.AccessToken, realmName, *group)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to move group %s: %w", gocloak.PString(group.Path), err)
+	}
+
+	moved, err := a.client.GetGroup(ctx, a.token.AccessToken, realmName, *group.ID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get moved group %s: %w", *group.ID, err)
+	}
+
+	return moved, nil
+}
+
+// parentGroupPath returns path of the parent group, it is empty for the top level groups.
+func parentGroupPath(path string) string {
+	if i := strings.LastIndex(path, "/"); i > 0 {
+		return path[:i]
+	}
+
+	return ""
 }
 
 func (a GoCloakAdapter) DeleteGroup(ctx context.Context, realm, groupName string) error {
@@ -142,6 +277,187 @@ func (a GoCloakAdapter) DeleteGroup(ctx context.Context, realm, groupName string
 	return nil
 }
 
+// DeleteGroupByID deletes group by id. It doesn't return an error if the group is already deleted,
+// e.g. together with its parent group.
+func (a GoCloakAdapter) DeleteGroupByID(ctx context.Context, realm, groupID string) error {
+	if err := a.client.DeleteGroup(ctx, a.token.AccessToken, realm, groupID); err != nil && !is404(err) {
+		return fmt.Errorf("unable to delete group %s: %w", groupID, err)
+	}
+
+	return nil
+}
+
+// SyncGroupMembers adds users matched by members to the group.
+// If addOnly is false, users which are not matched by members are removed from the group.
+// It returns usernames from members which are not found in keycloak.
+func (a GoCloakAdapter) SyncGroupMembers(
+	ctx context.Context,
+	realmName, groupID string,
+	members []keycloakApi.GroupMember,
+	addOnly bool,
+) ([]string, error) {
+	claimed, missing, err := a.resolveGroupMembers(ctx, realmName, members)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := a.getGroupMembers(ctx, realmName, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	for userID, username := range claimed {
+		if _, ok := current[userID]; ok {
+			continue
+		}
+
+		if err := a.AddUserToGroup(ctx, realmName, userID, groupID); err != nil {
+			return nil, fmt.Errorf("unable to add user %s to group: %w", username, err)
+		}
+	}
+
+	if addOnly {
+		return missing, nil
+	}
+
+	for userID, username := range current {
+		if _, ok := claimed[userID]; ok {
+			continue
+		}
+
+		if err := a.RemoveUserFromGroup(ctx, realmName, userID, groupID); err != nil {
+			return nil, fmt.Errorf("unable to remove user %s from group: %w", username, err)
+		}
+	}
+
+	return missing, nil
+}
+
+// resolveGroupMembers returns map of user id to username for users matched by members
+// and list of usernames which are not found.
+func (a GoCloakAdapter) resolveGroupMembers(
+	ctx context.Context,
+	realmName string,
+	members []keycloakApi.GroupMember,
+) (map[string]string, []string, error) {
+	claimed := make(map[string]string)
+
+	var missing []string
+
+	for _, member := range members {
+		if member.Username != "" {
+			users, err := a.getAllUsers(ctx, realmName, gocloak.GetUsersParams{
+				Username: gocloak.StringP(member.Username),
+				Exact:    gocloak.BoolP(true),
+			})
+			if err != nil {
+				return nil, nil, fmt.Errorf("unable to get user %s: %w", member.Username, err)
+			}
+
+			found := false
+
+			for _, u := range users {
+				if strings.EqualFold(gocloak.PString(u.Username), member.Username) {
+					claimed[*u.ID] = *u.Username
+					found = true
+
+					break
+				}
+			}
+
+			if !found {
+				missing = append(missing, member.Username)
+			}
+		}
+
+		if len(member.AttributeSelector) > 0 {
+			query, err := makeUserAttributesQuery(member.AttributeSelector)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			users, err := a.getAllUsers(ctx, realmName, gocloak.GetUsersParams{
+				Q: gocloak.StringP(query),
+			})
+			if err != nil {
+				return nil, nil, fmt.Errorf("unable to get users by attributes %v: %w", member.AttributeSelector, err)
+			}
+
+			for _, u := range users {
+				claimed[*u.ID] = gocloak.PString(u.Username)
+			}
+		}
+	}
+
+	return claimed, missing, nil
+}
+
+// getAllUsers returns all users page by page, as keycloak limits the number of users in the response.
+func (a GoCloakAdapter) getAllUsers(
+	ctx context.Context,
+	realmName string,
+	params gocloak.GetUsersParams,
+) ([]*gocloak.User, error) {
+	var users []*gocloak.User
+
+	for first := 0; ; first += usersPageSize {
+		params.First, params.Max = gocloak.IntP(first), gocloak.IntP(usersPageSize)
+
+		page, err := a.client.GetUsers(ctx, a.token.AccessToken, realmName, params)
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, page...)
+
+		if len(page) < usersPageSize {
+			return users, nil
+		}
+	}
+}
+
+// getGroupMembers returns map of user id to username for all group members.
+func (a GoCloakAdapter) getGroupMembers(ctx context.Context, realmName, groupID string) (map[string]string, error) {
+	members := make(map[string]string)
+
+	for first := 0; ; first += usersPageSize {
+		page, err := a.client.GetGroupMembers(ctx, a.token.AccessToken, realmName, groupID, gocloak.GetGroupsParams{
+			First: gocloak.IntP(first),
+			Max:   gocloak.IntP(usersPageSize),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to get group members: %w", err)
+		}
+
+		for _, u := range page {
+			members[*u.ID] = gocloak.PString(u.Username)
+		}
+
+		if len(page) < usersPageSize {
+			return members, nil
+		}
+	}
+}
+
+// makeUserAttributesQuery makes query for users search by attributes in format "key1:value1 key2:value2".
+// Keycloak doesn't support escaping in the query, so attributes with separator characters are rejected.
+func makeUserAttributesQuery(attributes map[string]string) (string, error) {
+	keys := maps.Keys(attributes)
+	slices.Sort(keys)
+
+	query := make([]string, 0, len(keys))
+
+	for _, k := range keys {
+		if strings.ContainsAny(k, ": ") || strings.ContainsAny(attributes[k], ": ") {
+			return "", fmt.Errorf("attribute selector %s:%s can't contain ':' and space characters", k, attributes[k])
+		}
+
+		query = append(query, k+":"+attributes[k])
+	}
+
+	return strings.Join(query, " "), nil
+}
+
 func (a GoCloakAdapter) makeCurrentGroups(group *gocloak.Group) map[string]gocloak.Group {
 	currentGroups := make(map[string]gocloak.Group)
 
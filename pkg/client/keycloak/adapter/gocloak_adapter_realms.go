@@ -3,7 +3,6 @@ package adapter
 import (
 	"context"
 	"fmt"
-	"net/http"
 	"strings"
 
 	"github.com/Nerzal/gocloak/v12"
@@ -145,118 +144,35 @@ func (a GoCloakAdapter) DeleteRealm(ctx context.Context, realmName string) error
 	return nil
 }
 
-func (a GoCloakAdapter) SyncRealmIdentityProviderMappers(realmName string, mappers []dto.IdentityProviderMapper) error {
-	realm, err := a.client.GetRealm(context.Background(), a.token.AccessToken, realmName)
-	if err != nil {
-		return errors.Wrapf(err, "unable to get realm by name: %s", realmName)
-	}
-
-	currentMappers := make(map[string]*dto.IdentityProviderMapper)
-
-	if realm.IdentityProviderMappers != nil {
-		for _, idpm := range *realm.IdentityProviderMappers {
-			if idpmTyped, ok := decodeIdentityProviderMapper(idpm); ok {
-				currentMappers[idpmTyped.Name] = idpmTyped
-			}
-		}
-	}
-
-	for _, claimedMapper := range mappers {
-		if idpmTyped, ok := currentMappers[claimedMapper.Name]; ok {
-			claimedMapper.ID = idpmTyped.ID
-			if err := a.updateIdentityProviderMapper(realmName, claimedMapper); err != nil {
-				return errors.Wrapf(err, "unable to update idp mapper: %+v", claimedMapper)
-			}
-
-			continue
-		}
-
-		if err := a.createIdentityProviderMapper(realmName, claimedMapper); err != nil {
-			return errors.Wrapf(err, "unable to create idp mapper: %+v", claimedMapper)
-		}
-	}
-
-	return nil
-}
-
-func decodeIdentityProviderMapper(mp interface{}) (*dto.IdentityProviderMapper, bool) {
-	mapInterface, ok := mp.(map[string]interface{})
-	if !ok {
-		return nil, false
-	}
-
-	mapper := dto.IdentityProviderMapper{
-		Config: make(map[string]string),
-	}
-
-	if idRaw, ok := mapInterface["id"]; ok {
-		if id, ok := idRaw.(string); ok {
-			mapper.ID = id
+// SyncRealmIdentityProviderMappers syncs mappers of the realm identity providers.
+// Mappers are grouped by identity provider alias,
+// mappers of the identity provider which are not in the list are deleted unless addOnly is set.
+func (a GoCloakAdapter) SyncRealmIdentityProviderMappers(
+	realmName string,
+	mappers []dto.IdentityProviderMapper,
+	addOnly bool,
+) error {
+	mappersByAlias := make(map[string][]IdentityProviderMapper)
+	aliases := make([]string, 0, len(mappers))
+
+	for _, m := range mappers {
+		if _, ok := mappersByAlias[m.IdentityProviderAlias]; !ok {
+			aliases = append(aliases, m.IdentityProviderAlias)
 		}
-	}
-
-	if nameRaw, ok := mapInterface["name"]; ok {
-		if name, ok := nameRaw.(string); ok {
-			mapper.Name = name
-		}
-	}
 
-	if identityProviderAliasRaw, ok := mapInterface["identityProviderAlias"]; ok {
-		if identityProviderAlias, ok := identityProviderAliasRaw.(string); ok {
-			mapper.IdentityProviderAlias = identityProviderAlias
-		}
+		mappersByAlias[m.IdentityProviderAlias] = append(mappersByAlias[m.IdentityProviderAlias], IdentityProviderMapper{
+			IdentityProviderAlias:  m.IdentityProviderAlias,
+			IdentityProviderMapper: m.IdentityProviderMapper,
+			Name:                   m.Name,
+			Config:                 m.Config,
+		})
 	}
 
-	if identityProviderMapperRaw, ok := mapInterface["identityProviderMapper"]; ok {
-		if identityProviderMapper, ok := identityProviderMapperRaw.(string); ok {
-			mapper.IdentityProviderMapper = identityProviderMapper
+	for _, alias := range aliases {
+		if err := a.SyncIDPMappers(context.Background(), realmName, alias, mappersByAlias[alias], addOnly); err != nil {
+			return errors.Wrapf(err, "unable to sync mappers of idp %s", alias)
 		}
 	}
 
-	if configRaw, ok := mapInterface["config"]; ok {
-		if configInterface, ok := configRaw.(map[string]interface{}); ok {
-			for k, v := range configInterface {
-				if value, ok := v.(string); ok {
-					mapper.Config[k] = value
-				}
-			}
-		}
-	}
-
-	return &mapper, true
-}
-
-func (a GoCloakAdapter) createIdentityProviderMapper(realmName string, mapper dto.IdentityProviderMapper) error {
-	resp, err := a.startRestyRequest().SetPathParams(map[string]string{
-		keycloakApiParamAlias: mapper.IdentityProviderAlias,
-		keycloakApiParamRealm: realmName,
-	}).SetBody(mapper).Post(a.buildPath(mapperToIdentityProvider))
-
-	if err != nil {
-		return fmt.Errorf("failed to create identity provider mapper - %+v: %w", mapper, err)
-	}
-
-	if resp.StatusCode() != http.StatusCreated {
-		return fmt.Errorf("failed to create identity provider mapper - %+v, response: %s", mapper,
-			resp.String())
-	}
-
-	return nil
-}
-
-func (a GoCloakAdapter) updateIdentityProviderMapper(realmName string, mapper dto.IdentityProviderMapper) error {
-	resp, err := a.startRestyRequest().
-		SetPathParams(map[string]string{
-			keycloakApiParamAlias: mapper.IdentityProviderAlias,
-			keycloakApiParamRealm: realmName,
-			keycloakApiParamId:    mapper.ID,
-		}).
-		SetBody(mapper).
-		Put(a.buildPath(updateMapperToIdentityProvider))
-
-	if err = a.checkError(err, resp); err != nil {
-		return fmt.Errorf("failed to update identity provider mapper - %+v: %w", mapper, err)
-	}
-
 	return nil
 }
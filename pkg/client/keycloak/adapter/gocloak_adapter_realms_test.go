@@ -61,39 +61,42 @@ func TestGoCloakAdapter_UpdateRealmSettings(t *testing.T) {
 }
 
 func TestGoCloakAdapter_SyncRealmIdentityProviderMappers(t *testing.T) {
-	adapter, mockClient, restyClient := initAdapter()
+	adapter, _, restyClient := initAdapter()
 	httpmock.ActivateNonDefault(restyClient.GetClient())
 
+	realmName := "sso-realm-1"
+	idpAlias := "alias-1"
 	currentMapperID := "mp1id"
 
-	mappers := []interface{}{
-		map[string]interface{}{
-			keycloakApiParamId: currentMapperID,
-			"name":             "mp1name",
-		},
-	}
-
-	realm := gocloak.RealmRepresentation{
-		Realm:                   gocloak.StringP("sso-realm-1"),
-		IdentityProviderMappers: &mappers,
-	}
-
-	idpAlias := "alias-1"
+	httpmock.RegisterResponder(
+		"GET",
+		fmt.Sprintf("/admin/realms/%s/identity-provider/instances/%s/mappers", realmName, idpAlias),
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, []IdentityProviderMapper{
+			{ID: currentMapperID, Name: "mp1name", IdentityProviderMapper: "mapper-2"},
+			{ID: "mp2id", Name: "mp2name", IdentityProviderMapper: "mapper-2"},
+		}))
 
-	mockClient.On("GetRealm", adapter.token.AccessToken, *realm.Realm).Return(&realm, nil)
+	createRsp := httpmock.NewStringResponse(http.StatusCreated, "")
+	defer closeWithFailOnError(t, createRsp.Body)
+	createRsp.Header.Set("Location", "id/new-id")
 
 	httpmock.RegisterResponder(
 		"POST",
-		fmt.Sprintf("/admin/realms/%s/identity-provider/instances/%s/mappers", *realm.Realm, idpAlias),
-		httpmock.NewStringResponder(http.StatusCreated, "ok"))
+		fmt.Sprintf("/admin/realms/%s/identity-provider/instances/%s/mappers", realmName, idpAlias),
+		httpmock.ResponderFromResponse(createRsp))
 
 	httpmock.RegisterResponder(
 		"PUT",
-		fmt.Sprintf("/admin/realms/%s/identity-provider/instances/%s/mappers/%s", *realm.Realm, idpAlias,
+		fmt.Sprintf("/admin/realms/%s/identity-provider/instances/%s/mappers/%s", realmName, idpAlias,
 			currentMapperID),
 		httpmock.NewStringResponder(http.StatusOK, "ok"))
 
-	if err := adapter.SyncRealmIdentityProviderMappers(*realm.Realm,
+	httpmock.RegisterResponder(
+		"DELETE",
+		fmt.Sprintf("/admin/realms/%s/identity-provider/instances/%s/mappers/mp2id", realmName, idpAlias),
+		httpmock.NewStringResponder(http.StatusOK, "ok"))
+
+	if err := adapter.SyncRealmIdentityProviderMappers(realmName,
 		[]dto.IdentityProviderMapper{
 			{
 				Name:                   "tname1",
@@ -107,9 +110,13 @@ func TestGoCloakAdapter_SyncRealmIdentityProviderMappers(t *testing.T) {
 				IdentityProviderMapper: "mapper-2",
 				IdentityProviderAlias:  idpAlias,
 			},
-		}); err != nil {
+		}, false); err != nil {
 		t.Fatalf("%+v", err)
 	}
+
+	info := httpmock.GetCallCountInfo()
+	require.Equal(t, 1, info[fmt.Sprintf("DELETE /admin/realms/%s/identity-provider/instances/%s/mappers/mp2id",
+		realmName, idpAlias)])
 }
 
 func TestGoCloakAdapter_CreateRealmWithDefaultConfig(t *testing.T) {
@@ -6,6 +6,8 @@ import (
 
 	"github.com/Nerzal/gocloak/v12"
 	"github.com/pkg/errors"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/dto"
 )
@@ -55,6 +57,14 @@ func (a GoCloakAdapter) createOrUpdateRealmRole(realmName string, role *dto.Prim
 
 		role.ID = currentRealmRole.ID
 
+		if len(role.CompositesClientRoles) == 0 {
+			return nil
+		}
+
+		if err := a.syncRoleComposites(realmName, role, currentRealmRole); err != nil {
+			return errors.Wrap(err, "error during syncRoleComposites")
+		}
+
 		return nil
 	}
 
@@ -102,40 +112,82 @@ func (a GoCloakAdapter) DeleteRealmRole(ctx context.Context, realm, roleName str
 	return nil
 }
 
+// syncRoleComposites syncs realm and client role composites of the realm role.
 func (a GoCloakAdapter) syncRoleComposites(realmName string, role *dto.PrimaryRealmRole, currentRealmRole *gocloak.Role) error {
-	currentComposites, err := a.client.GetCompositeRealmRolesByRoleID(context.Background(), a.token.AccessToken, realmName, *currentRealmRole.ID)
+	ctx := context.Background()
+
+	return a.syncComposites(
+		ctx,
+		realmName,
+		*currentRealmRole.ID,
+		role.Composites,
+		role.CompositesClientRoles,
+		role.CompositesAddOnly,
+		func(roles []gocloak.Role) error {
+			return a.client.AddRealmRoleComposite(ctx, a.token.AccessToken, realmName, role.Name, roles)
+		},
+		func(roles []gocloak.Role) error {
+			return a.client.DeleteRealmRoleComposite(ctx, a.token.AccessToken, realmName, role.Name, roles)
+		},
+	)
+}
+
+// syncComposites syncs realm and client role composites of the role with the given id.
+// Composites which are not in the claimed lists are removed unless addOnly is set.
+// All composites are fetched by role id, as the realm composites endpoint doesn't return client roles.
+func (a GoCloakAdapter) syncComposites(
+	ctx context.Context,
+	realmName, roleID string,
+	claimedRealmComposites []string,
+	claimedClientComposites map[string][]string,
+	addOnly bool,
+	addComposites, deleteComposites func(roles []gocloak.Role) error,
+) error {
+	currentComposites, err := a.client.GetCompositeRolesByRoleID(ctx, a.token.AccessToken, realmName, roleID)
 	if err != nil {
-		return errors.Wrap(err, "unable to get realm role composites")
+		return errors.Wrap(err, "unable to get role composites")
 	}
 
-	if err := a.syncCreateNewComposites(realmName, role, currentComposites); err != nil {
-		return errors.Wrap(err, "error during SyncCreateNewComposites")
+	currentCompositesMap := make(map[string]struct{}, len(currentComposites))
+	for _, c := range currentComposites {
+		currentCompositesMap[compositeKey(c)] = struct{}{}
 	}
 
-	// temporary disable deletion of old composites to remove conflict with keycloak client roles
+	claimedComposites := make(map[string]struct{})
+	rolesToAdd := make([]gocloak.Role, 0)
 
-	// if err := a.syncDeleteOldComposites(realmName, role, currentComposites); err != nil {
-	//	return errors.Wrap(err, "error during SyncDeleteOldComposites")
-	//}
+	for _, claimed := range claimedRealmComposites {
+		claimedComposites[claimed] = struct{}{}
 
-	return nil
-}
+		if _, ok := currentCompositesMap[claimed]; ok {
+			continue
+		}
 
-func (a GoCloakAdapter) syncCreateNewComposites(realmName string, role *dto.PrimaryRealmRole, currentComposites []*gocloak.Role) error {
-	currentCompositesMap := make(map[string]string)
+		compRole, err := a.client.GetRealmRole(ctx, a.token.AccessToken, realmName, claimed)
+		if err != nil {
+			return errors.Wrapf(err, "unable to get realm role %s", claimed)
+		}
 
-	for _, currentComposite := range currentComposites {
-		currentCompositesMap[*currentComposite.Name] = *currentComposite.Name
+		rolesToAdd = append(rolesToAdd, *compRole)
 	}
 
-	rolesToAdd := make([]gocloak.Role, 0, len(role.Composites))
+	for _, clientID := range sortedKeys(claimedClientComposites) {
+		clientUUID, err := a.GetClientID(clientID, realmName)
+		if err != nil {
+			return errors.Wrapf(err, "unable to get client %s", clientID)
+		}
+
+		for _, claimed := range claimedClientComposites[clientID] {
+			key := clientUUID + "/" + claimed
+			claimedComposites[key] = struct{}{}
 
-	for _, claimedComposite := range role.Composites {
-		if _, ok := currentCompositesMap[claimedComposite]; !ok {
-			compRole, err := a.client.GetRealmRole(context.Background(), a.token.AccessToken, realmName,
-				claimedComposite)
+			if _, ok := currentCompositesMap[key]; ok {
+				continue
+			}
+
+			compRole, err := a.client.GetClientRole(ctx, a.token.AccessToken, realmName, clientUUID, claimed)
 			if err != nil {
-				return errors.Wrap(err, "unable to get realm role")
+				return errors.Wrapf(err, "unable to get client role %s of client %s", claimed, clientID)
 			}
 
 			rolesToAdd = append(rolesToAdd, *compRole)
@@ -143,15 +195,48 @@ func (a GoCloakAdapter) syncCreateNewComposites(realmName string, role *dto.Prim
 	}
 
 	if len(rolesToAdd) > 0 {
-		if err := a.client.AddRealmRoleComposite(context.Background(), a.token.AccessToken, realmName,
-			role.Name, rolesToAdd); err != nil {
+		if err := addComposites(rolesToAdd); err != nil {
 			return errors.Wrap(err, "unable to add role composite")
 		}
 	}
 
+	if addOnly {
+		return nil
+	}
+
+	rolesToDelete := make([]gocloak.Role, 0)
+
+	for _, c := range currentComposites {
+		if _, ok := claimedComposites[compositeKey(c)]; !ok {
+			rolesToDelete = append(rolesToDelete, *c)
+		}
+	}
+
+	if len(rolesToDelete) > 0 {
+		if err := deleteComposites(rolesToDelete); err != nil {
+			return errors.Wrap(err, "unable to delete role composite")
+		}
+	}
+
 	return nil
 }
 
+// compositeKey returns name for realm roles and client uuid with name for client roles.
+func compositeKey(role *gocloak.Role) string {
+	if gocloak.PBool(role.ClientRole) {
+		return gocloak.PString(role.ContainerID) + "/" + gocloak.PString(role.Name)
+	}
+
+	return gocloak.PString(role.Name)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := maps.Keys(m)
+	slices.Sort(keys)
+
+	return keys
+}
+
 func (a GoCloakAdapter) makeRoleDefault(ctx context.Context, realmName string, role *dto.PrimaryRealmRole) error {
 	if !role.IsDefault {
 		return nil
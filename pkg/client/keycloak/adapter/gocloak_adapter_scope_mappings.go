@@ -0,0 +1,99 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Nerzal/gocloak/v12"
+)
+
+// SyncClientScopeMappings syncs realm and client roles that may be contained in tokens issued for the client.
+// clientRoles is a map of client roles names with client ID keys.
+func (a GoCloakAdapter) SyncClientScopeMappings(
+	ctx context.Context,
+	realm, idOfClient string,
+	realmRoles []string,
+	clientRoles map[string][]string,
+	addOnly bool,
+) error {
+	mappings, err := a.client.GetClientScopeMappings(ctx, a.token.AccessToken, realm, idOfClient)
+	if err != nil {
+		return fmt.Errorf("unable to get client scope mappings: %w", err)
+	}
+
+	deleteRealmRolesFunc := a.client.DeleteClientScopeMappingsRealmRoles
+	deleteClientRolesFunc := swapClientRolesFuncArgs(a.client.DeleteClientScopeMappingsClientRoles)
+
+	if addOnly {
+		deleteRealmRolesFunc = doNotDeleteRealmRoleFromUser
+		deleteClientRolesFunc = doNotDeleteClientRoleFromUser
+	}
+
+	if err := a.syncEntityRealmRoles(idOfClient, realm, realmRoles, mappings.RealmMappings,
+		a.client.CreateClientScopeMappingsRealmRoles, deleteRealmRolesFunc); err != nil {
+		return fmt.Errorf("unable to sync client realm scope mappings: %w", err)
+	}
+
+	if err := a.syncEntityClientRoles(realm, idOfClient, clientRoles, mappings.ClientMappings,
+		swapClientRolesFuncArgs(a.client.CreateClientScopeMappingsClientRoles), deleteClientRolesFunc); err != nil {
+		return fmt.Errorf("unable to sync client client-level scope mappings: %w", err)
+	}
+
+	return nil
+}
+
+// SyncClientScopeScopeMappings syncs realm and client roles that may be contained in tokens
+// issued for clients with the client scope.
+// clientRoles is a map of client roles names with client ID keys.
+func (a GoCloakAdapter) SyncClientScopeScopeMappings(
+	ctx context.Context,
+	realm, scopeID string,
+	realmRoles []string,
+	clientRoles map[string][]string,
+	addOnly bool,
+) error {
+	var mappings gocloak.MappingsRepresentation
+
+	rsp, err := a.startRestyRequest().
+		SetContext(ctx).
+		SetPathParams(map[string]string{
+			keycloakApiParamRealm:         realm,
+			keycloakApiParamClientScopeId: scopeID,
+		}).
+		SetResult(&mappings).
+		Get(a.buildPath(clientScopeScopeMappings))
+
+	if err = a.checkError(err, rsp); err != nil {
+		return fmt.Errorf("unable to get client scope scope mappings: %w", err)
+	}
+
+	deleteRealmRolesFunc := a.client.DeleteClientScopesScopeMappingsRealmRoles
+	deleteClientRolesFunc := swapClientRolesFuncArgs(a.client.DeleteClientScopesScopeMappingsClientRoles)
+
+	if addOnly {
+		deleteRealmRolesFunc = doNotDeleteRealmRoleFromUser
+		deleteClientRolesFunc = doNotDeleteClientRoleFromUser
+	}
+
+	if err := a.syncEntityRealmRoles(scopeID, realm, realmRoles, mappings.RealmMappings,
+		a.client.CreateClientScopesScopeMappingsRealmRoles, deleteRealmRolesFunc); err != nil {
+		return fmt.Errorf("unable to sync client scope realm scope mappings: %w", err)
+	}
+
+	if err := a.syncEntityClientRoles(realm, scopeID, clientRoles, mappings.ClientMappings,
+		swapClientRolesFuncArgs(a.client.CreateClientScopesScopeMappingsClientRoles), deleteClientRolesFunc); err != nil {
+		return fmt.Errorf("unable to sync client scope client-level scope mappings: %w", err)
+	}
+
+	return nil
+}
+
+// swapClientRolesFuncArgs adapts scope mappings functions that take the owner entity id before the client id
+// to the role functions signature used by syncEntityClientRoles.
+func swapClientRolesFuncArgs(
+	f func(ctx context.Context, token, realm, entityID, idOfClient string, roles []gocloak.Role) error,
+) func(ctx context.Context, token, realm, idOfClient, entityID string, roles []gocloak.Role) error {
+	return func(ctx context.Context, token, realm, idOfClient, entityID string, roles []gocloak.Role) error {
+		return f(ctx, token, realm, entityID, idOfClient, roles)
+	}
+}
@@ -0,0 +1,159 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoCloakAdapter_SyncClientScopeMappings(t *testing.T) {
+	t.Parallel()
+
+	currentMappings := &gocloak.MappingsRepresentation{
+		RealmMappings: &[]gocloak.Role{
+			{Name: gocloak.StringP("realm-role1")},
+			{Name: gocloak.StringP("realm-role2")},
+		},
+		ClientMappings: map[string]*gocloak.ClientMappingsRepresentation{
+			"client2": {
+				ID:       gocloak.StringP("client2-id"),
+				Client:   gocloak.StringP("client2"),
+				Mappings: &[]gocloak.Role{{Name: gocloak.StringP("client2-role1")}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		addOnly   bool
+		setupMock func(m *MockGoCloakClient)
+		wantErr   require.ErrorAssertionFunc
+	}{
+		{
+			name: "full sync",
+			setupMock: func(m *MockGoCloakClient) {
+				m.On("GetClientScopeMappings", "realm", "client-id").Return(currentMappings, nil)
+				m.On("GetRealmRole", "realm", "realm-role3").
+					Return(&gocloak.Role{Name: gocloak.StringP("realm-role3")}, nil)
+				m.On("CreateClientScopeMappingsRealmRoles", "realm", "client-id",
+					[]gocloak.Role{{Name: gocloak.StringP("realm-role3")}}).Return(nil)
+				m.On("DeleteClientScopeMappingsRealmRoles", "realm", "client-id",
+					[]gocloak.Role{{Name: gocloak.StringP("realm-role2")}}).Return(nil)
+				m.On("GetClients", "realm", gocloak.GetClientsParams{ClientID: gocloak.StringP("client1")}).
+					Return([]*gocloak.Client{{ID: gocloak.StringP("client1-id"), ClientID: gocloak.StringP("client1")}}, nil)
+				m.On("GetClientRole", "realm", "client1-id", "client1-role1").
+					Return(&gocloak.Role{Name: gocloak.StringP("client1-role1")}, nil)
+				m.On("CreateClientScopeMappingsClientRoles", "realm", "client-id", "client1-id",
+					[]gocloak.Role{{Name: gocloak.StringP("client1-role1")}}).Return(nil)
+				m.On("DeleteClientScopeMappingsClientRoles", "realm", "client-id", "client2-id",
+					[]gocloak.Role{{Name: gocloak.StringP("client2-role1")}}).Return(nil)
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name:    "add only",
+			addOnly: true,
+			setupMock: func(m *MockGoCloakClient) {
+				m.On("GetClientScopeMappings", "realm", "client-id").Return(currentMappings, nil)
+				m.On("GetRealmRole", "realm", "realm-role3").
+					Return(&gocloak.Role{Name: gocloak.StringP("realm-role3")}, nil)
+				m.On("CreateClientScopeMappingsRealmRoles", "realm", "client-id",
+					[]gocloak.Role{{Name: gocloak.StringP("realm-role3")}}).Return(nil)
+				m.On("GetClients", "realm", gocloak.GetClientsParams{ClientID: gocloak.StringP("client1")}).
+					Return([]*gocloak.Client{{ID: gocloak.StringP("client1-id"), ClientID: gocloak.StringP("client1")}}, nil)
+				m.On("GetClientRole", "realm", "client1-id", "client1-role1").
+					Return(&gocloak.Role{Name: gocloak.StringP("client1-role1")}, nil)
+				m.On("CreateClientScopeMappingsClientRoles", "realm", "client-id", "client1-id",
+					[]gocloak.Role{{Name: gocloak.StringP("client1-role1")}}).Return(nil)
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "failed to get scope mappings",
+			setupMock: func(m *MockGoCloakClient) {
+				m.On("GetClientScopeMappings", "realm", "client-id").Return(nil, errors.New("failed"))
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "unable to get client scope mappings")
+			},
+		},
+		{
+			name: "realm role not found",
+			setupMock: func(m *MockGoCloakClient) {
+				m.On("GetClientScopeMappings", "realm", "client-id").Return(currentMappings, nil)
+				m.On("GetRealmRole", "realm", "realm-role3").Return(nil, errors.New("not found"))
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "unable to sync client realm scope mappings")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockClient := &MockGoCloakClient{}
+			tt.setupMock(mockClient)
+
+			a := GoCloakAdapter{
+				client: mockClient,
+				token:  &gocloak.JWT{AccessToken: "token"},
+			}
+
+			err := a.SyncClientScopeMappings(
+				context.Background(),
+				"realm",
+				"client-id",
+				[]string{"realm-role1", "realm-role3"},
+				map[string][]string{"client1": {"client1-role1"}},
+				tt.addOnly,
+			)
+
+			tt.wantErr(t, err)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGoCloakAdapter_SyncClientScopeScopeMappings(t *testing.T) {
+	a, mockClient, _ := initAdapter()
+
+	httpmock.Reset()
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm/client-scopes/scope-id/scope-mappings",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, gocloak.MappingsRepresentation{
+			RealmMappings: &[]gocloak.Role{{Name: gocloak.StringP("realm-role1")}},
+		}))
+
+	mockClient.On("GetRealmRole", "realm", "realm-role2").
+		Return(&gocloak.Role{Name: gocloak.StringP("realm-role2")}, nil)
+	mockClient.On("CreateClientScopesScopeMappingsRealmRoles", "realm", "scope-id",
+		[]gocloak.Role{{Name: gocloak.StringP("realm-role2")}}).Return(nil)
+	mockClient.On("DeleteClientScopesScopeMappingsRealmRoles", "realm", "scope-id",
+		[]gocloak.Role{{Name: gocloak.StringP("realm-role1")}}).Return(nil)
+
+	err := a.SyncClientScopeScopeMappings(context.Background(), "realm", "scope-id", []string{"realm-role2"}, nil, false)
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+
+	err = a.SyncClientScopeScopeMappings(context.Background(), "realm", "scope-id", []string{"realm-role2"}, nil, true)
+	require.NoError(t, err)
+	mockClient.AssertNumberOfCalls(t, "CreateClientScopesScopeMappingsRealmRoles", 2)
+	mockClient.AssertNumberOfCalls(t, "DeleteClientScopesScopeMappingsRealmRoles", 1)
+
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm/client-scopes/scope-id/scope-mappings",
+		httpmock.NewStringResponder(http.StatusInternalServerError, "error"))
+
+	err = a.SyncClientScopeScopeMappings(context.Background(), "realm", "scope-id", []string{"realm-role2"}, nil, false)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unable to get client scope scope mappings")
+}
@@ -0,0 +1,312 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	serverInfo = "/admin/serverinfo"
+
+	serverInfoCacheTTL = 10 * time.Minute
+
+	spiAuthenticator           = "authenticator"
+	spiFormAction              = "form-action"
+	spiClientAuthenticator     = "client-authenticator"
+	spiIdentityProviderMappers = "identity-provider-mapper"
+
+	// FeatureAdminFineGrainedAuthz is a server feature required for the admin permissions management.
+	FeatureAdminFineGrainedAuthz = "ADMIN_FINE_GRAINED_AUTHZ"
+	// FeatureDeclarativeUserProfile is a server feature required for the user profile management before keycloak 24.
+	FeatureDeclarativeUserProfile = "DECLARATIVE_USER_PROFILE"
+
+	// userProfileAlwaysEnabledVersion is a keycloak major version since which the user profile can't be disabled.
+	userProfileAlwaysEnabledVersion = 24
+)
+
+// ServerInfo is a part of keycloak server info which describes the server version, features and available providers.
+type ServerInfo struct {
+	SystemInfo          SystemInfo                          `json:"systemInfo"`
+	ProfileInfo         ProfileInfo                         `json:"profileInfo"`
+	Features            []FeatureInfo                       `json:"features"`
+	Providers           map[string]SpiInfo                  `json:"providers"`
+	ComponentTypes      map[string][]ComponentTypeInfo      `json:"componentTypes"`
+	IdentityProviders   []ProviderInfo                      `json:"identityProviders"`
+	SocialProviders     []ProviderInfo                      `json:"socialProviders"`
+	ProtocolMapperTypes map[string][]ProtocolMapperTypeInfo `json:"protocolMapperTypes"`
+}
+
+type SystemInfo struct {
+	Version string `json:"version"`
+}
+
+// ProfileInfo contains features which differ from the default server profile.
+type ProfileInfo struct {
+	Name                 string   `json:"name"`
+	DisabledFeatures     []string `json:"disabledFeatures"`
+	PreviewFeatures      []string `json:"previewFeatures"`
+	ExperimentalFeatures []string `json:"experimentalFeatures"`
+}
+
+// FeatureInfo describes server feature. It is available since keycloak 22.
+type FeatureInfo struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+}
+
+type SpiInfo struct {
+	Internal  bool                         `json:"internal"`
+	Providers map[string]ProviderOrderInfo `json:"providers"`
+}
+
+type ProviderOrderInfo struct {
+	Order int `json:"order"`
+}
+
+type ComponentTypeInfo struct {
+	ID       string `json:"id"`
+	HelpText string `json:"helpText"`
+}
+
+type ProviderInfo struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type ProtocolMapperTypeInfo struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Category string `json:"category"`
+}
+
+// UnknownProviderError is returned when provider is not available on the keycloak server.
+type UnknownProviderError struct {
+	Kind      string
+	ID        string
+	Available []string
+}
+
+func (e UnknownProviderError) Error() string {
+	return fmt.Sprintf("unknown %s %q, available: %s", e.Kind, e.ID, strings.Join(e.Available, ", "))
+}
+
+// ValidateProviderID checks that provider id is in the list of available ids.
+func ValidateProviderID(kind, id string, available []string) error {
+	if slices.Contains(available, id) {
+		return nil
+	}
+
+	return UnknownProviderError{
+		Kind:      kind,
+		ID:        id,
+		Available: available,
+	}
+}
+
+// EnabledFeatures returns names of the enabled server features.
+// Older servers don't report the full feature list,
+// so only enabled preview and experimental features are returned for them.
+func (s *ServerInfo) EnabledFeatures() []string {
+	features := make([]string, 0, len(s.Features))
+
+	if len(s.Features) > 0 {
+		for _, f := range s.Features {
+			if f.Enabled {
+				features = append(features, f.Name)
+			}
+		}
+	} else {
+		for _, f := range append(slices.Clone(s.ProfileInfo.PreviewFeatures), s.ProfileInfo.ExperimentalFeatures...) {
+			if !slices.Contains(s.ProfileInfo.DisabledFeatures, f) {
+				features = append(features, f)
+			}
+		}
+	}
+
+	sort.Strings(features)
+
+	return slices.Compact(features)
+}
+
+// AuthenticatorIDs returns ids of providers which can be used in authentication executions.
+func (s *ServerInfo) AuthenticatorIDs() []string {
+	return s.spiProviderIDs(spiAuthenticator, spiFormAction, spiClientAuthenticator)
+}
+
+// IdentityProviderMapperIDs returns ids of identity provider mappers.
+func (s *ServerInfo) IdentityProviderMapperIDs() []string {
+	return s.spiProviderIDs(spiIdentityProviderMappers)
+}
+
+// IdentityProviderIDs returns ids of identity providers including social ones.
+func (s *ServerInfo) IdentityProviderIDs() []string {
+	ids := make([]string, 0, len(s.IdentityProviders)+len(s.SocialProviders))
+
+	for _, p := range s.IdentityProviders {
+		ids = append(ids, p.ID)
+	}
+
+	for _, p := range s.SocialProviders {
+		ids = append(ids, p.ID)
+	}
+
+	sort.Strings(ids)
+
+	return ids
+}
+
+// ComponentProviderTypes returns component provider types.
+func (s *ServerInfo) ComponentProviderTypes() []string {
+	types := maps.Keys(s.ComponentTypes)
+	sort.Strings(types)
+
+	return types
+}
+
+// ComponentProviderIDs returns ids of component providers for the given provider type.
+func (s *ServerInfo) ComponentProviderIDs(providerType string) []string {
+	ids := make([]string, 0, len(s.ComponentTypes[providerType]))
+
+	for _, c := range s.ComponentTypes[providerType] {
+		ids = append(ids, c.ID)
+	}
+
+	sort.Strings(ids)
+
+	return ids
+}
+
+// ComponentProviders returns map of component provider types to the component provider ids.
+func (s *ServerInfo) ComponentProviders() map[string][]string {
+	components := make(map[string][]string, len(s.ComponentTypes))
+
+	for providerType := range s.ComponentTypes {
+		components[providerType] = s.ComponentProviderIDs(providerType)
+	}
+
+	return components
+}
+
+func (s *ServerInfo) spiProviderIDs(spis ...string) []string {
+	ids := make([]string, 0)
+
+	for _, spi := range spis {
+		ids = append(ids, maps.Keys(s.Providers[spi].Providers)...)
+	}
+
+	sort.Strings(ids)
+
+	return slices.Compact(ids)
+}
+
+type serverInfoCacheEntry struct {
+	info      *ServerInfo
+	expiresAt time.Time
+}
+
+// serverInfoCache keeps server info per keycloak instance as provider catalog doesn't change often
+// and server info response is quite big. Adapters are created on each reconciliation,
+// so the cache is shared by all adapters of the same keycloak url.
+var serverInfoCache = struct {
+	sync.Mutex
+	entries map[string]serverInfoCacheEntry
+}{
+	entries: make(map[string]serverInfoCacheEntry),
+}
+
+// GetServerInfo returns providers available on the keycloak server.
+// Result is cached per keycloak instance.
+func (a GoCloakAdapter) GetServerInfo(ctx context.Context) (*ServerInfo, error) {
+	serverInfoCache.Lock()
+	entry, ok := serverInfoCache.entries[a.basePath]
+	serverInfoCache.Unlock()
+
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.info, nil
+	}
+
+	info := &ServerInfo{}
+
+	rsp, err := a.startRestyRequest().
+		SetContext(ctx).
+		SetResult(info).
+		Get(a.buildPath(serverInfo))
+
+	if err = a.checkError(err, rsp); err != nil {
+		return nil, fmt.Errorf("unable to get server info: %w", err)
+	}
+
+	serverInfoCache.Lock()
+	serverInfoCache.entries[a.basePath] = serverInfoCacheEntry{
+		info:      info,
+		expiresAt: time.Now().Add(serverInfoCacheTTL),
+	}
+	serverInfoCache.Unlock()
+
+	return info, nil
+}
+
+// FeatureDisabledError is returned when the request requires the server feature which is not enabled.
+type FeatureDisabledError string
+
+func (e FeatureDisabledError) Error() string {
+	return fmt.Sprintf("keycloak server feature %s is not enabled", string(e))
+}
+
+func IsErrFeatureDisabled(err error) bool {
+	errFeatureDisabled := FeatureDisabledError("")
+
+	return errors.As(err, &errFeatureDisabled)
+}
+
+// SetServerFeatures sets the server version and enabled features which were discovered by the keycloak controller.
+// They are used to reject the requests which are not supported by the server without calling it.
+func (a *GoCloakAdapter) SetServerFeatures(version string, features []string) {
+	a.serverVersion = version
+	a.serverFeatures = features
+}
+
+// checkFeatureEnabled returns FeatureDisabledError if the feature is not enabled on the server.
+// If the server version is unknown, the check is skipped and the server response is used instead.
+func (a GoCloakAdapter) checkFeatureEnabled(feature string) error {
+	if a.serverVersion == "" || slices.Contains(a.serverFeatures, feature) {
+		return nil
+	}
+
+	return FeatureDisabledError(feature)
+}
+
+// checkUserProfileEnabled returns FeatureDisabledError if the user profile is not supported by the server.
+func (a GoCloakAdapter) checkUserProfileEnabled() error {
+	if serverMajorVersion(a.serverVersion) >= userProfileAlwaysEnabledVersion {
+		return nil
+	}
+
+	return a.checkFeatureEnabled(FeatureDeclarativeUserProfile)
+}
+
+// serverMajorVersion returns the major version of the server, e.g. 22 for 22.0.5, or 0 if the version is unknown.
+func serverMajorVersion(version string) int {
+	major, _, _ := strings.Cut(version, ".")
+
+	v, err := strconv.Atoi(major)
+	if err != nil {
+		return 0
+	}
+
+	return v
+}
+
+// LegacyMode returns true if the server uses legacy path layout with /auth prefix.
+func (a GoCloakAdapter) LegacyMode() bool {
+	return a.legacyMode
+}
@@ -0,0 +1,229 @@
+package adapter
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const serverInfoResponse = `{
+	"systemInfo": {"version": "21.1.1"},
+	"providers": {
+		"authenticator": {"providers": {"auth-cookie": {"order": 0}, "auth-otp-form": {"order": 0}}},
+		"form-action": {"providers": {"registration-user-creation": {"order": 0}}},
+		"identity-provider-mapper": {"providers": {"hardcoded-role-idp-mapper": {"order": 0}}}
+	},
+	"componentTypes": {
+		"org.keycloak.keys.KeyProvider": [{"id": "rsa-generated"}, {"id": "hmac-generated"}]
+	},
+	"identityProviders": [{"id": "saml"}, {"id": "oidc"}],
+	"socialProviders": [{"id": "github"}]
+}`
+
+func resetServerInfoCache(t *testing.T, url string) {
+	reset := func() {
+		serverInfoCache.Lock()
+		delete(serverInfoCache.entries, url)
+		serverInfoCache.Unlock()
+	}
+
+	reset()
+	t.Cleanup(reset)
+}
+
+func TestGoCloakAdapter_GetServerInfo(t *testing.T) {
+	kc, _, _ := initAdapter()
+
+	resetServerInfoCache(t, kc.basePath)
+	httpmock.ZeroCallCounters()
+
+	httpmock.RegisterResponder("GET", "/admin/serverinfo",
+		httpmock.NewStringResponder(500, "fatal"))
+
+	_, err := kc.GetServerInfo(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, "unable to get server info: status: 500, body: fatal", err.Error())
+
+	httpmock.RegisterResponder("GET", "/admin/serverinfo",
+		httpmock.NewStringResponder(200, serverInfoResponse).
+			HeaderSet(http.Header{"Content-Type": {"application/json"}}))
+	httpmock.ZeroCallCounters()
+
+	info, err := kc.GetServerInfo(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, "21.1.1", info.SystemInfo.Version)
+	assert.Equal(t, []string{"auth-cookie", "auth-otp-form", "registration-user-creation"}, info.AuthenticatorIDs())
+	assert.Equal(t, []string{"hardcoded-role-idp-mapper"}, info.IdentityProviderMapperIDs())
+	assert.Equal(t, []string{"github", "oidc", "saml"}, info.IdentityProviderIDs())
+	assert.Equal(t, []string{"org.keycloak.keys.KeyProvider"}, info.ComponentProviderTypes())
+	assert.Equal(t,
+		map[string][]string{"org.keycloak.keys.KeyProvider": {"hmac-generated", "rsa-generated"}},
+		info.ComponentProviders(),
+	)
+
+	_, err = kc.GetServerInfo(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, httpmock.GetCallCountInfo()["GET /admin/serverinfo"], "server info should be cached")
+
+	another, _, _ := initAdapter()
+
+	_, err = another.GetServerInfo(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, httpmock.GetCallCountInfo()["GET /admin/serverinfo"],
+		"server info should be shared by adapters of the same keycloak")
+}
+
+func TestGoCloakAdapter_GetServerInfo_CachePerKeycloak(t *testing.T) {
+	httpmock.ZeroCallCounters()
+
+	for _, url := range []string{"https://keycloak-a", "https://keycloak-b"} {
+		resetServerInfoCache(t, url)
+
+		httpmock.RegisterResponder("GET", url+"/admin/serverinfo",
+			httpmock.NewStringResponder(200, serverInfoResponse).
+				HeaderSet(http.Header{"Content-Type": {"application/json"}}))
+	}
+
+	newAdapter := func(url string) *GoCloakAdapter {
+		a, _, _ := initAdapter()
+		a.basePath = url
+
+		return a
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := newAdapter("https://keycloak-a").GetServerInfo(context.Background())
+		require.NoError(t, err)
+	}
+
+	_, err := newAdapter("https://keycloak-b").GetServerInfo(context.Background())
+	require.NoError(t, err)
+
+	calls := httpmock.GetCallCountInfo()
+	assert.Equal(t, 1, calls["GET https://keycloak-a/admin/serverinfo"],
+		"adapters of the same keycloak should share one fetch")
+	assert.Equal(t, 1, calls["GET https://keycloak-b/admin/serverinfo"])
+}
+
+func TestServerInfo_EnabledFeatures(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		info ServerInfo
+		want []string
+	}{
+		{
+			name: "features list",
+			info: ServerInfo{
+				Features: []FeatureInfo{
+					{Name: "ADMIN2", Enabled: true},
+					{Name: "ACCOUNT2", Enabled: true},
+					{Name: "DOCKER", Enabled: false},
+				},
+			},
+			want: []string{"ACCOUNT2", "ADMIN2"},
+		},
+		{
+			name: "profile info",
+			info: ServerInfo{
+				ProfileInfo: ProfileInfo{
+					DisabledFeatures:     []string{"DOCKER", "TOKEN_EXCHANGE"},
+					PreviewFeatures:      []string{"TOKEN_EXCHANGE", "ADMIN_FINE_GRAINED_AUTHZ"},
+					ExperimentalFeatures: []string{"DYNAMIC_SCOPES"},
+				},
+			},
+			want: []string{"ADMIN_FINE_GRAINED_AUTHZ", "DYNAMIC_SCOPES"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, tt.info.EnabledFeatures())
+		})
+	}
+}
+
+func TestValidateProviderID(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, ValidateProviderID("authenticator", "auth-cookie", []string{"auth-cookie"}))
+
+	err := ValidateProviderID("authenticator", "auth-cookies", []string{"auth-cookie", "auth-otp-form"})
+	require.EqualError(t, err, `unknown authenticator "auth-cookies", available: auth-cookie, auth-otp-form`)
+	require.ErrorAs(t, err, &UnknownProviderError{})
+}
+
+func TestGoCloakAdapter_checkFeatureEnabled(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  string
+		features []string
+		wantErr  bool
+	}{
+		{
+			name: "server features are unknown",
+		},
+		{
+			name:     "feature is enabled",
+			version:  "21.1.1",
+			features: []string{FeatureAdminFineGrainedAuthz},
+		},
+		{
+			name:     "feature is disabled",
+			version:  "21.1.1",
+			features: []string{FeatureDeclarativeUserProfile},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			a := GoCloakAdapter{}
+			a.SetServerFeatures(tt.version, tt.features)
+
+			err := a.checkFeatureEnabled(FeatureAdminFineGrainedAuthz)
+			if !tt.wantErr {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.True(t, IsErrFeatureDisabled(err))
+		})
+	}
+}
+
+func TestGoCloakAdapter_checkUserProfileEnabled(t *testing.T) {
+	a := GoCloakAdapter{}
+
+	a.SetServerFeatures("24.0.1", nil)
+	require.NoError(t, a.checkUserProfileEnabled())
+
+	a.SetServerFeatures("22.0.5", []string{FeatureDeclarativeUserProfile})
+	require.NoError(t, a.checkUserProfileEnabled())
+
+	a.SetServerFeatures("22.0.5", nil)
+	require.True(t, IsErrFeatureDisabled(a.checkUserProfileEnabled()))
+
+	_, err := a.GetUsersProfile(context.Background(), "realm")
+	require.True(t, IsErrFeatureDisabled(err))
+
+	_, err = a.SetManagementPermissions(context.Background(), "realm", AdminPermissionTargetUsers, "", true)
+	require.True(t, IsErrFeatureDisabled(err))
+}
+
+func Test_serverMajorVersion(t *testing.T) {
+	assert.Equal(t, 22, serverMajorVersion("22.0.5"))
+	assert.Equal(t, 21, serverMajorVersion("21.1.2.redhat-00001"))
+	assert.Equal(t, 0, serverMajorVersion(""))
+}
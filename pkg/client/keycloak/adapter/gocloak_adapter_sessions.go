@@ -0,0 +1,185 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Nerzal/gocloak/v12"
+)
+
+// LogoutRealmUser removes all user sessions.
+// Keycloak also sets the user not-before, so all previously issued tokens including offline tokens become invalid.
+func (a GoCloakAdapter) LogoutRealmUser(ctx context.Context, realmName, username string) error {
+	users, err := a.client.GetUsers(ctx, a.token.AccessToken, realmName, gocloak.GetUsersParams{
+		Username: &username,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to get users: %w", err)
+	}
+
+	user, exists := checkFullUsernameMatch(username, users)
+	if !exists {
+		return NotFoundError("user not found")
+	}
+
+	rsp, err := a.startRestyRequest().
+		SetContext(ctx).
+		SetPathParams(map[string]string{
+			keycloakApiParamRealm: realmName,
+			keycloakApiParamId:    *user.ID,
+		}).
+		Post(a.buildPath(realmUserLogout))
+
+	if err = a.checkError(err, rsp); err != nil {
+		return fmt.Errorf("unable to logout user %s: %w", username, err)
+	}
+
+	return nil
+}
+
+// RevokeClientSessions removes all online and offline sessions of the client,
+// sets the client not-before to the current time and pushes it to the client admin URL.
+// clientUUID is an id of the client, not a client id. It returns NotFoundError if the client doesn't exist.
+func (a GoCloakAdapter) RevokeClientSessions(ctx context.Context, realmName, clientUUID string) error {
+	if err := a.removeClientSessions(ctx, realmName, clientUUID, clientUserSessions, false); err != nil {
+		return err
+	}
+
+	if err := a.removeClientSessions(ctx, realmName, clientUUID, clientOfflineSessions, true); err != nil {
+		return err
+	}
+
+	if err := a.setClientNotBefore(ctx, realmName, clientUUID, time.Now()); err != nil {
+		return err
+	}
+
+	rsp, err := a.startRestyRequest().
+		SetContext(ctx).
+		SetPathParams(map[string]string{
+			keycloakApiParamRealm: realmName,
+			keycloakApiParamId:    clientUUID,
+		}).
+		Post(a.buildPath(clientPushRevocation))
+
+	if err = a.checkError(err, rsp); err != nil {
+		return fmt.Errorf("unable to push client revocation: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeRealmTokens sets the realm not-before and pushes the revocation to the clients with admin URL.
+// Sessions and tokens issued before notBefore become invalid. Nothing is done if the realm not-before is already set.
+func (a GoCloakAdapter) RevokeRealmTokens(ctx context.Context, realmName string, notBefore time.Time) error {
+	realm, err := a.client.GetRealm(ctx, a.token.AccessToken, realmName)
+	if err != nil {
+		return fmt.Errorf("unable to get realm %s: %w", realmName, err)
+	}
+
+	notBeforeSec := int(notBefore.Unix())
+	if gocloak.PInt(realm.NotBefore) == notBeforeSec {
+		return nil
+	}
+
+	realm.NotBefore = &notBeforeSec
+
+	if err = a.client.UpdateRealm(ctx, a.token.AccessToken, *realm); err != nil {
+		return fmt.Errorf("unable to set realm not before: %w", err)
+	}
+
+	rsp, err := a.startRestyRequest().
+		SetContext(ctx).
+		SetPathParams(map[string]string{
+			keycloakApiParamRealm: realmName,
+		}).
+		Post(a.buildPath(realmPushRevocation))
+
+	if err = a.checkError(err, rsp); err != nil {
+		return fmt.Errorf("unable to push realm revocation: %w", err)
+	}
+
+	return nil
+}
+
+func (a GoCloakAdapter) removeClientSessions(ctx context.Context, realmName, clientUUID, sessionsPath string, offline bool) error {
+	var sessions []gocloak.UserSessionRepresentation
+
+	rsp, err := a.startRestyRequest().
+		SetContext(ctx).
+		SetPathParams(map[string]string{
+			keycloakApiParamRealm: realmName,
+			keycloakApiParamId:    clientUUID,
+		}).
+		SetResult(&sessions).
+		Get(a.buildPath(sessionsPath))
+
+	if err == nil && rsp.StatusCode() == http.StatusNotFound {
+		return NotFoundError("client not found")
+	}
+
+	if err = a.checkError(err, rsp); err != nil {
+		return fmt.Errorf("unable to get client sessions: %w", err)
+	}
+
+	for i := range sessions {
+		sessionID := gocloak.PString(sessions[i].ID)
+
+		rsp, err = a.startRestyRequest().
+			SetContext(ctx).
+			SetPathParams(map[string]string{
+				keycloakApiParamRealm:   realmName,
+				keycloakApiParamSession: sessionID,
+			}).
+			SetQueryParam("isOffline", fmt.Sprint(offline)).
+			Delete(a.buildPath(realmSession))
+
+		// Session can be already expired.
+		if err == nil && rsp.StatusCode() == http.StatusNotFound {
+			continue
+		}
+
+		if err = a.checkError(err, rsp); err != nil {
+			return fmt.Errorf("unable to delete session %s: %w", sessionID, err)
+		}
+	}
+
+	return nil
+}
+
+// setClientNotBefore sets the client not-before. Tokens issued before this time are invalid.
+// The client representation is updated as a map to keep fields which are not supported by gocloak.
+func (a GoCloakAdapter) setClientNotBefore(ctx context.Context, realmName, clientUUID string, notBefore time.Time) error {
+	client := make(map[string]interface{})
+
+	rsp, err := a.startRestyRequest().
+		SetContext(ctx).
+		SetPathParams(map[string]string{
+			keycloakApiParamRealm: realmName,
+			keycloakApiParamId:    clientUUID,
+		}).
+		SetResult(&client).
+		Get(a.buildPath(clientEntity))
+
+	if err = a.checkError(err, rsp); err != nil {
+		return fmt.Errorf("unable to get client: %w", err)
+	}
+
+	client["notBefore"] = notBefore.Unix()
+
+	rsp, err = a.startRestyRequest().
+		SetContext(ctx).
+		SetPathParams(map[string]string{
+			keycloakApiParamRealm: realmName,
+			keycloakApiParamId:    clientUUID,
+		}).
+		SetBody(client).
+		Put(a.buildPath(clientEntity))
+
+	if err = a.checkError(err, rsp); err != nil {
+		return fmt.Errorf("unable to set client not before: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/go-resty/resty/v2"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoCloakAdapter_LogoutRealmUser(t *testing.T) {
+	mockClient := new(MockGoCloakClient)
+	restyClient := resty.New()
+
+	httpmock.Reset()
+	httpmock.ActivateNonDefault(restyClient.GetClient())
+	mockClient.On("RestyClient").Return(restyClient)
+
+	a := GoCloakAdapter{
+		client: mockClient,
+		token:  &gocloak.JWT{AccessToken: "token"},
+	}
+
+	mockClient.On("GetUsers", "realm1", gocloak.GetUsersParams{Username: gocloak.StringP("user1")}).
+		Return([]*gocloak.User{{ID: gocloak.StringP("user-id1"), Username: gocloak.StringP("user1")}}, nil)
+	mockClient.On("GetUsers", "realm1", gocloak.GetUsersParams{Username: gocloak.StringP("user2")}).
+		Return([]*gocloak.User{}, nil)
+	mockClient.On("GetUsers", "realm1", gocloak.GetUsersParams{Username: gocloak.StringP("user3")}).
+		Return(nil, errors.New("get users error"))
+
+	httpmock.RegisterResponder(http.MethodPost, "/admin/realms/realm1/users/user-id1/logout",
+		httpmock.NewStringResponder(http.StatusNoContent, ""))
+
+	require.NoError(t, a.LogoutRealmUser(context.Background(), "realm1", "user1"))
+
+	err := a.LogoutRealmUser(context.Background(), "realm1", "user2")
+	require.Error(t, err)
+	assert.True(t, IsErrNotFound(err))
+
+	err = a.LogoutRealmUser(context.Background(), "realm1", "user3")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to get users")
+}
+
+func TestGoCloakAdapter_RevokeClientSessions(t *testing.T) {
+	mockClient := new(MockGoCloakClient)
+	restyClient := resty.New()
+
+	httpmock.Reset()
+	httpmock.ActivateNonDefault(restyClient.GetClient())
+	mockClient.On("RestyClient").Return(restyClient)
+
+	a := GoCloakAdapter{
+		client: mockClient,
+		token:  &gocloak.JWT{AccessToken: "token"},
+	}
+
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm1/clients/client-uuid/user-sessions",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, []gocloak.UserSessionRepresentation{
+			{ID: gocloak.StringP("session1")},
+			{ID: gocloak.StringP("session2")},
+		}))
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm1/clients/client-uuid/offline-sessions",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, []gocloak.UserSessionRepresentation{
+			{ID: gocloak.StringP("offline1")},
+		}))
+	httpmock.RegisterResponder(http.MethodDelete, "/admin/realms/realm1/sessions/session1",
+		httpmock.NewStringResponder(http.StatusNoContent, ""))
+	httpmock.RegisterResponder(http.MethodDelete, "/admin/realms/realm1/sessions/session2",
+		httpmock.NewStringResponder(http.StatusNotFound, ""))
+	httpmock.RegisterResponder(http.MethodDelete, "/admin/realms/realm1/sessions/offline1",
+		httpmock.NewStringResponder(http.StatusNoContent, ""))
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm1/clients/client-uuid",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]interface{}{
+			"id":       "client-uuid",
+			"clientId": "client1",
+		}))
+	httpmock.RegisterResponder(http.MethodPut, "/admin/realms/realm1/clients/client-uuid",
+		func(req *http.Request) (*http.Response, error) {
+			var body map[string]interface{}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+
+			if body["clientId"] != "client1" || body["notBefore"] == nil {
+				return httpmock.NewStringResponse(http.StatusBadRequest, "wrong client body"), nil
+			}
+
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+	httpmock.RegisterResponder(http.MethodPost, "/admin/realms/realm1/clients/client-uuid/push-revocation",
+		httpmock.NewStringResponder(http.StatusOK, "{}"))
+
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm1/clients/missing/user-sessions",
+		httpmock.NewStringResponder(http.StatusNotFound, ""))
+
+	require.NoError(t, a.RevokeClientSessions(context.Background(), "realm1", "client-uuid"))
+
+	calls := httpmock.GetCallCountInfo()
+	assert.Equal(t, 1, calls["DELETE /admin/realms/realm1/sessions/session1"])
+	assert.Equal(t, 1, calls["DELETE /admin/realms/realm1/sessions/offline1"])
+	assert.Equal(t, 1, calls["POST /admin/realms/realm1/clients/client-uuid/push-revocation"])
+
+	err := a.RevokeClientSessions(context.Background(), "realm1", "missing")
+	require.Error(t, err)
+	assert.True(t, IsErrNotFound(err))
+}
+
+func TestGoCloakAdapter_RevokeRealmTokens(t *testing.T) {
+	mockClient := new(MockGoCloakClient)
+	restyClient := resty.New()
+
+	httpmock.Reset()
+	httpmock.ActivateNonDefault(restyClient.GetClient())
+	mockClient.On("RestyClient").Return(restyClient)
+
+	a := GoCloakAdapter{
+		client: mockClient,
+		token:  &gocloak.JWT{AccessToken: "token"},
+	}
+
+	notBefore := time.Unix(1700000000, 0)
+
+	mockClient.On("GetRealm", "token", "realm1").
+		Return(&gocloak.RealmRepresentation{Realm: gocloak.StringP("realm1")}, nil)
+	mockClient.On("UpdateRealm", gocloak.RealmRepresentation{
+		Realm:     gocloak.StringP("realm1"),
+		NotBefore: gocloak.IntP(1700000000),
+	}).Return(nil)
+	mockClient.On("GetRealm", "token", "realm2").
+		Return(&gocloak.RealmRepresentation{Realm: gocloak.StringP("realm2"), NotBefore: gocloak.IntP(1700000000)}, nil)
+	mockClient.On("GetRealm", "token", "realm3").
+		Return(nil, errors.New("get realm error"))
+
+	httpmock.RegisterResponder(http.MethodPost, "/admin/realms/realm1/push-revocation",
+		httpmock.NewStringResponder(http.StatusOK, "{}"))
+
+	require.NoError(t, a.RevokeRealmTokens(context.Background(), "realm1", notBefore))
+	require.NoError(t, a.RevokeRealmTokens(context.Background(), "realm2", notBefore))
+
+	calls := httpmock.GetCallCountInfo()
+	assert.Equal(t, 1, calls["POST /admin/realms/realm1/push-revocation"])
+	assert.Equal(t, 0, calls["POST /admin/realms/realm2/push-revocation"])
+
+	err := a.RevokeRealmTokens(context.Background(), "realm3", notBefore)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to get realm")
+
+	mockClient.AssertExpectations(t)
+}
@@ -16,9 +16,29 @@ type KeycloakUser struct {
 	LastName            string
 	RequiredUserActions []string
 	Roles               []string
+	ClientRoles         map[string][]string
 	Groups              []string
-	Attributes          map[string]string
+	Attributes          map[string][]string
 	Password            string
+	FederatedIdentities []FederatedIdentity
+
+	// PasswordTemporary indicates that the user must change the password on the next login.
+	PasswordTemporary bool
+
+	// ResetPassword forces to set the password for the existing user.
+	// By default, the password is set only when the user is created.
+	ResetPassword bool
+
+	// UpdateEnabled forces to set the enabled flag for the existing user.
+	// By default, the enabled flag is set only when the user is created.
+	UpdateEnabled bool
+}
+
+// FederatedIdentity is a link of the user to the external identity provider.
+type FederatedIdentity struct {
+	IdentityProvider string
+	UserID           string
+	UserName         string
 }
 
 type UserRealmRoleMapping struct {
@@ -68,10 +88,103 @@ func (a GoCloakAdapter) SyncRealmUser(ctx context.Context, realmName string, use
 		return errors.Wrap(err, "unable to sync user roles")
 	}
 
+	if err := a.syncUserClientRoles(realmName, *keycloakUser.ID, user, addOnly); err != nil {
+		return errors.Wrap(err, "unable to sync user client roles")
+	}
+
 	if err := a.syncUserGroups(ctx, realmName, *keycloakUser.ID, user, addOnly); err != nil {
 		return errors.Wrap(err, "unable to sync user group")
 	}
 
+	if err := a.syncUserFederatedIdentities(ctx, realmName, *keycloakUser.ID, user, addOnly); err != nil {
+		return errors.Wrap(err, "unable to sync user federated identities")
+	}
+
+	return nil
+}
+
+// syncUserClientRoles syncs client roles mappings of the user. Client roles are not changed if the user has no claimed client roles.
+func (a GoCloakAdapter) syncUserClientRoles(realmName, userID string, user *KeycloakUser, addOnly bool) error {
+	if len(user.ClientRoles) == 0 {
+		return nil
+	}
+
+	roleMappings, err := a.client.GetRoleMappingByUserID(context.Background(), a.token.AccessToken, realmName, userID)
+	if err != nil {
+		return errors.Wrap(err, "unable to get user role mappings")
+	}
+
+	deleteClientRoleFromUserFunc := a.client.DeleteClientRoleFromUser
+	if addOnly {
+		deleteClientRoleFromUserFunc = doNotDeleteClientRoleFromUser
+	}
+
+	if err := a.syncEntityClientRoles(realmName, userID, user.ClientRoles, roleMappings.ClientMappings,
+		a.client.AddClientRoleToUser, deleteClientRoleFromUserFunc); err != nil {
+		return errors.Wrap(err, "unable to sync client roles")
+	}
+
+	return nil
+}
+
+// syncUserFederatedIdentities syncs links of the user to the external identity providers.
+// Links are not changed if the user has no claimed federated identities.
+// Keycloak doesn't support link update, so the changed link is recreated.
+func (a GoCloakAdapter) syncUserFederatedIdentities(ctx context.Context, realmName, userID string, user *KeycloakUser, addOnly bool) error {
+	if len(user.FederatedIdentities) == 0 {
+		return nil
+	}
+
+	current, err := a.client.GetUserFederatedIdentities(ctx, a.token.AccessToken, realmName, userID)
+	if err != nil {
+		return errors.Wrap(err, "unable to get user federated identities")
+	}
+
+	currentMap := make(map[string]*gocloak.FederatedIdentityRepresentation, len(current))
+	for _, fi := range current {
+		currentMap[gocloak.PString(fi.IdentityProvider)] = fi
+	}
+
+	claimed := make(map[string]struct{}, len(user.FederatedIdentities))
+
+	for i := range user.FederatedIdentities {
+		fi := user.FederatedIdentities[i]
+		claimed[fi.IdentityProvider] = struct{}{}
+
+		if cur, ok := currentMap[fi.IdentityProvider]; ok {
+			if gocloak.PString(cur.UserID) == fi.UserID && gocloak.PString(cur.UserName) == fi.UserName {
+				continue
+			}
+
+			if err := a.client.DeleteUserFederatedIdentity(ctx, a.token.AccessToken, realmName, userID, fi.IdentityProvider); err != nil {
+				return errors.Wrapf(err, "unable to delete federated identity %s", fi.IdentityProvider)
+			}
+		}
+
+		if err := a.client.CreateUserFederatedIdentity(ctx, a.token.AccessToken, realmName, userID, fi.IdentityProvider,
+			gocloak.FederatedIdentityRepresentation{
+				IdentityProvider: &fi.IdentityProvider,
+				UserID:           &fi.UserID,
+				UserName:         &fi.UserName,
+			}); err != nil {
+			return errors.Wrapf(err, "unable to create federated identity %s", fi.IdentityProvider)
+		}
+	}
+
+	if addOnly {
+		return nil
+	}
+
+	for alias := range currentMap {
+		if _, ok := claimed[alias]; ok {
+			continue
+		}
+
+		if err := a.client.DeleteUserFederatedIdentity(ctx, a.token.AccessToken, realmName, userID, alias); err != nil {
+			return errors.Wrapf(err, "unable to delete federated identity %s", alias)
+		}
+	}
+
 	return nil
 }
 
@@ -232,6 +345,21 @@ func (a GoCloakAdapter) clearUserRealmRoles(ctx context.Context, realmName strin
 	return nil
 }
 
+// setUserProfileFields sets email and names of the existing user. Fields which are not set in the spec are not changed.
+func setUserProfileFields(keycloakUser *gocloak.User, userCR *KeycloakUser) {
+	if userCR.Email != "" {
+		keycloakUser.Email = gocloak.StringP(userCR.Email)
+	}
+
+	if userCR.FirstName != "" {
+		keycloakUser.FirstName = gocloak.StringP(userCR.FirstName)
+	}
+
+	if userCR.LastName != "" {
+		keycloakUser.LastName = gocloak.StringP(userCR.LastName)
+	}
+}
+
 func (a GoCloakAdapter) setUserParams(
 	ctx context.Context,
 	realmName string,
@@ -244,10 +372,22 @@ func (a GoCloakAdapter) setUserParams(
 	}
 
 	if keycloakUser.ID != nil {
+		if userCR.UpdateEnabled {
+			keycloakUser.Enabled = &userCR.Enabled
+		}
+
+		setUserProfileFields(keycloakUser, userCR)
+
 		if err := a.client.UpdateUser(ctx, a.token.AccessToken, realmName, *keycloakUser); err != nil {
 			return errors.Wrap(err, "unable to update user")
 		}
 
+		if userCR.ResetPassword && userCR.Password != "" {
+			if err := a.setUserPassword(realmName, *keycloakUser.ID, userCR.Password, userCR.PasswordTemporary); err != nil {
+				return errors.Wrapf(err, "unable to reset user password, user id: %s", *keycloakUser.ID)
+			}
+		}
+
 		return nil
 	}
 
@@ -257,7 +397,7 @@ func (a GoCloakAdapter) setUserParams(
 	}
 
 	if userCR.Password != "" {
-		if err := a.setUserPassword(realmName, userID, userCR.Password); err != nil {
+		if err := a.setUserPassword(realmName, userID, userCR.Password, userCR.PasswordTemporary); err != nil {
 			return errors.Wrapf(err, "unable to set user password, user id: %s", userID)
 		}
 	}
@@ -267,14 +407,14 @@ func (a GoCloakAdapter) setUserParams(
 	return nil
 }
 
-func (a GoCloakAdapter) setUserPassword(realmName, userID, password string) error {
+func (a GoCloakAdapter) setUserPassword(realmName, userID, password string, temporary bool) error {
 	rsp, err := a.startRestyRequest().
 		SetPathParams(map[string]string{
 			keycloakApiParamRealm: realmName,
 			keycloakApiParamId:    userID,
 		}).
 		SetBody(map[string]interface{}{
-			"temporary": false,
+			"temporary": temporary,
 			"type":      "password",
 			"value":     password,
 		}).
@@ -288,9 +428,9 @@ func (a GoCloakAdapter) setUserPassword(realmName, userID, password string) erro
 }
 
 func (a GoCloakAdapter) makeUserAttributes(keycloakUser *gocloak.User, userCR *KeycloakUser, addOnly bool) *map[string][]string {
-	attrs := make(map[string][]string)
+	attrs := make(map[string][]string, len(userCR.Attributes))
 	for k, v := range userCR.Attributes {
-		attrs[k] = []string{v}
+		attrs[k] = v
 	}
 
 	if addOnly && keycloakUser.Attributes != nil && len(*keycloakUser.Attributes) > 0 {
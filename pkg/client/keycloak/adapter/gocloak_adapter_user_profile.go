@@ -0,0 +1,87 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+)
+
+// UserProfileConfig is a declarative user profile configuration of the realm.
+// Annotations and validations use interface{} values to keep the data which is not managed by the operator.
+type UserProfileConfig struct {
+	Attributes               []UserProfileAttribute `json:"attributes,omitempty"`
+	Groups                   []UserProfileGroup     `json:"groups,omitempty"`
+	UnmanagedAttributePolicy string                 `json:"unmanagedAttributePolicy,omitempty"`
+}
+
+type UserProfileAttribute struct {
+	Name        string                            `json:"name"`
+	DisplayName string                            `json:"displayName,omitempty"`
+	Group       string                            `json:"group,omitempty"`
+	Multivalued bool                              `json:"multivalued,omitempty"`
+	Permissions *UserProfileAttributePermissions  `json:"permissions,omitempty"`
+	Required    *UserProfileAttributeRequired     `json:"required,omitempty"`
+	Selector    *UserProfileAttributeSelector     `json:"selector,omitempty"`
+	Annotations map[string]interface{}            `json:"annotations,omitempty"`
+	Validations map[string]map[string]interface{} `json:"validations,omitempty"`
+}
+
+type UserProfileAttributePermissions struct {
+	Edit []string `json:"edit,omitempty"`
+	View []string `json:"view,omitempty"`
+}
+
+type UserProfileAttributeRequired struct {
+	Roles  []string `json:"roles,omitempty"`
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+type UserProfileAttributeSelector struct {
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+type UserProfileGroup struct {
+	Name               string                 `json:"name"`
+	DisplayHeader      string                 `json:"displayHeader,omitempty"`
+	DisplayDescription string                 `json:"displayDescription,omitempty"`
+	Annotations        map[string]interface{} `json:"annotations,omitempty"`
+}
+
+// GetUsersProfile returns the user profile configuration of the realm.
+func (a GoCloakAdapter) GetUsersProfile(ctx context.Context, realm string) (*UserProfileConfig, error) {
+	if err := a.checkUserProfileEnabled(); err != nil {
+		return nil, err
+	}
+
+	profile := &UserProfileConfig{}
+
+	rsp, err := a.startRestyRequest().
+		SetContext(ctx).
+		SetPathParams(map[string]string{keycloakApiParamRealm: realm}).
+		SetResult(profile).
+		Get(a.buildPath(realmUsersProfile))
+
+	if err = a.checkError(err, rsp); err != nil {
+		return nil, fmt.Errorf("unable to get users profile: %w", err)
+	}
+
+	return profile, nil
+}
+
+// UpdateUsersProfile replaces the user profile configuration of the realm.
+func (a GoCloakAdapter) UpdateUsersProfile(ctx context.Context, realm string, profile *UserProfileConfig) error {
+	if err := a.checkUserProfileEnabled(); err != nil {
+		return err
+	}
+
+	rsp, err := a.startRestyRequest().
+		SetContext(ctx).
+		SetPathParams(map[string]string{keycloakApiParamRealm: realm}).
+		SetBody(profile).
+		Put(a.buildPath(realmUsersProfile))
+
+	if err = a.checkError(err, rsp); err != nil {
+		return fmt.Errorf("unable to update users profile: %w", err)
+	}
+
+	return nil
+}
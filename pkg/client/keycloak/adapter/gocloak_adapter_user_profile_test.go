@@ -0,0 +1,83 @@
+package adapter
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/go-resty/resty/v2"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoCloakAdapter_GetUsersProfile(t *testing.T) {
+	mockClient := new(MockGoCloakClient)
+	restyClient := resty.New()
+	httpmock.ActivateNonDefault(restyClient.GetClient())
+	mockClient.On("RestyClient").Return(restyClient)
+
+	a := GoCloakAdapter{
+		client: mockClient,
+		token:  &gocloak.JWT{AccessToken: "token"},
+	}
+
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm1/users/profile",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]interface{}{
+			"attributes": []map[string]interface{}{
+				{
+					"name":        "username",
+					"validations": map[string]interface{}{"length": map[string]interface{}{"min": 3, "max": 255}},
+					"annotations": map[string]interface{}{"inputType": "text"},
+				},
+			},
+			"groups":                   []map[string]interface{}{{"name": "user-metadata", "displayHeader": "User metadata"}},
+			"unmanagedAttributePolicy": "ENABLED",
+		}))
+
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm2/users/profile",
+		httpmock.NewStringResponder(http.StatusNotFound, "not found"))
+
+	profile, err := a.GetUsersProfile(context.Background(), "realm1")
+	require.NoError(t, err)
+	require.Len(t, profile.Attributes, 1)
+	assert.Equal(t, "username", profile.Attributes[0].Name)
+	assert.Equal(t, map[string]interface{}{"min": float64(3), "max": float64(255)}, profile.Attributes[0].Validations["length"])
+	assert.Equal(t, "text", profile.Attributes[0].Annotations["inputType"])
+	require.Len(t, profile.Groups, 1)
+	assert.Equal(t, "User metadata", profile.Groups[0].DisplayHeader)
+	assert.Equal(t, "ENABLED", profile.UnmanagedAttributePolicy)
+
+	_, err = a.GetUsersProfile(context.Background(), "realm2")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to get users profile")
+}
+
+func TestGoCloakAdapter_UpdateUsersProfile(t *testing.T) {
+	mockClient := new(MockGoCloakClient)
+	restyClient := resty.New()
+	httpmock.ActivateNonDefault(restyClient.GetClient())
+	mockClient.On("RestyClient").Return(restyClient)
+
+	a := GoCloakAdapter{
+		client: mockClient,
+		token:  &gocloak.JWT{AccessToken: "token"},
+	}
+
+	httpmock.RegisterResponder(http.MethodPut, "/admin/realms/realm1/users/profile",
+		httpmock.NewStringResponder(http.StatusOK, ""))
+
+	httpmock.RegisterResponder(http.MethodPut, "/admin/realms/realm2/users/profile",
+		httpmock.NewStringResponder(http.StatusBadRequest, "bad request"))
+
+	profile := &UserProfileConfig{
+		Attributes: []UserProfileAttribute{{Name: "department"}},
+	}
+
+	require.NoError(t, a.UpdateUsersProfile(context.Background(), "realm1", profile))
+
+	err := a.UpdateUsersProfile(context.Background(), "realm2", profile)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to update users profile")
+}
@@ -2,6 +2,8 @@ package adapter
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
 	"testing"
 
 	"github.com/Nerzal/gocloak/v12"
@@ -32,8 +34,8 @@ func TestGoCloakAdapter_SyncRealmUser(t *testing.T) {
 
 	usr := KeycloakUser{
 		Username: "vasia",
-		Attributes: map[string]string{
-			"foo": "bar",
+		Attributes: map[string][]string{
+			"foo": {"bar"},
 		},
 		RequiredUserActions: []string{"FOO"},
 		Groups:              []string{"group1"},
@@ -105,7 +107,7 @@ func TestGoCloakAdapter_SyncRealmUser_UserExists(t *testing.T) {
 	usr := KeycloakUser{
 		Username:   "vasia",
 		Groups:     []string{"foo"},
-		Attributes: map[string]string{"bar": "baz"},
+		Attributes: map[string][]string{"bar": {"baz"}},
 		Roles:      []string{"r3", "r4"},
 	}
 
@@ -154,6 +156,98 @@ func TestGoCloakAdapter_SyncRealmUser_UserExists(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestGoCloakAdapter_SyncRealmUser_ResetPassword(t *testing.T) {
+	mockClient := new(MockGoCloakClient)
+
+	adapter := GoCloakAdapter{
+		client:   mockClient,
+		basePath: "",
+		token:    &gocloak.JWT{AccessToken: "token"},
+	}
+
+	usr := KeycloakUser{
+		Username:          "vasia",
+		Password:          "new-password",
+		PasswordTemporary: true,
+		ResetPassword:     true,
+	}
+
+	realmName := "realm1"
+
+	mockClient.On("GetUsers", realmName, gocloak.GetUsersParams{Username: gocloak.StringP(usr.Username)}).
+		Return([]*gocloak.User{
+			{
+				Username: &usr.Username,
+				ID:       gocloak.StringP("id1"),
+			},
+		}, nil)
+	mockClient.On("UpdateUser", realmName, mock.Anything).Return(nil)
+	mockClient.On("GetGroups", realmName, mock.Anything).Return([]*gocloak.Group{}, nil)
+
+	restyClient := resty.New()
+
+	httpmock.Reset()
+	httpmock.ActivateNonDefault(restyClient.GetClient())
+	mockClient.On("RestyClient").Return(restyClient)
+	httpmock.RegisterResponder("PUT", "/admin/realms/realm1/users/id1/reset-password",
+		func(req *http.Request) (*http.Response, error) {
+			var body map[string]interface{}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+
+			if body["value"] != "new-password" || body["temporary"] != true {
+				return httpmock.NewStringResponse(http.StatusBadRequest, "wrong password body"), nil
+			}
+
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+
+	err := adapter.SyncRealmUser(context.Background(), realmName, &usr, true)
+	require.NoError(t, err)
+	require.Equal(t, 1, httpmock.GetCallCountInfo()["PUT /admin/realms/realm1/users/id1/reset-password"])
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestGoCloakAdapter_SyncRealmUser_UpdateEnabled(t *testing.T) {
+	mockClient := new(MockGoCloakClient)
+
+	adapter := GoCloakAdapter{
+		client:   mockClient,
+		basePath: "",
+		token:    &gocloak.JWT{AccessToken: "token"},
+	}
+
+	usr := KeycloakUser{
+		Username:      "vasia",
+		Enabled:       false,
+		UpdateEnabled: true,
+	}
+
+	realmName := "realm1"
+
+	mockClient.On("GetUsers", realmName, gocloak.GetUsersParams{Username: gocloak.StringP(usr.Username)}).
+		Return([]*gocloak.User{
+			{
+				Username: &usr.Username,
+				ID:       gocloak.StringP("id1"),
+				Enabled:  gocloak.BoolP(true),
+			},
+		}, nil)
+	mockClient.On("UpdateUser", realmName, gocloak.User{
+		ID:       gocloak.StringP("id1"),
+		Username: gocloak.StringP("vasia"),
+		Enabled:  gocloak.BoolP(false),
+	}).Return(nil)
+	mockClient.On("GetGroups", realmName, mock.Anything).Return([]*gocloak.Group{}, nil)
+
+	err := adapter.SyncRealmUser(context.Background(), realmName, &usr, true)
+	require.NoError(t, err)
+
+	mockClient.AssertExpectations(t)
+}
+
 func TestGoCloakAdapter_SyncRealmUser_UserExists_Failure(t *testing.T) {
 	mockClient := new(MockGoCloakClient)
 
@@ -166,7 +260,7 @@ func TestGoCloakAdapter_SyncRealmUser_UserExists_Failure(t *testing.T) {
 	usr := KeycloakUser{
 		Username:   "vasia",
 		Groups:     []string{"foo", "bar"},
-		Attributes: map[string]string{"bar": "baz"},
+		Attributes: map[string][]string{"bar": {"baz"}},
 		Roles:      []string{"r3", "r4"},
 	}
 
@@ -203,3 +297,179 @@ func TestGoCloakAdapter_SyncRealmUser_UserExists_Failure(t *testing.T) {
 		t.Fatalf("wrong error returned: %s", err.Error())
 	}
 }
+
+func TestGoCloakAdapter_syncUserClientRoles(t *testing.T) {
+	t.Parallel()
+
+	currentMappings := &gocloak.MappingsRepresentation{
+		ClientMappings: map[string]*gocloak.ClientMappingsRepresentation{
+			"client1": {
+				ID: gocloak.StringP("client1-uuid"),
+				Mappings: &[]gocloak.Role{
+					{Name: gocloak.StringP("role1")},
+					{Name: gocloak.StringP("role2")},
+				},
+			},
+			"client2": {
+				ID:       gocloak.StringP("client2-uuid"),
+				Mappings: &[]gocloak.Role{{Name: gocloak.StringP("role3")}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		user      KeycloakUser
+		addOnly   bool
+		setupMock func(m *MockGoCloakClient)
+	}{
+		{
+			name: "no client roles",
+			user: KeycloakUser{Username: "user"},
+			setupMock: func(m *MockGoCloakClient) {
+			},
+		},
+		{
+			name: "full sync",
+			user: KeycloakUser{Username: "user", ClientRoles: map[string][]string{"client1": {"role1", "role4"}}},
+			setupMock: func(m *MockGoCloakClient) {
+				m.On("GetRoleMappingByUserID", "realm", "user-id").Return(currentMappings, nil)
+				m.On("GetClients", "realm", gocloak.GetClientsParams{ClientID: gocloak.StringP("client1")}).
+					Return([]*gocloak.Client{{ID: gocloak.StringP("client1-uuid"), ClientID: gocloak.StringP("client1")}}, nil)
+				m.On("GetClientRole", "realm", "client1-uuid", "role4").
+					Return(&gocloak.Role{Name: gocloak.StringP("role4")}, nil)
+				m.On("AddClientRoleToUser", "realm", "client1-uuid", "user-id",
+					[]gocloak.Role{{Name: gocloak.StringP("role4")}}).Return(nil)
+				m.On("DeleteClientRoleFromUser", "realm", "client1-uuid", "user-id",
+					[]gocloak.Role{{Name: gocloak.StringP("role2")}}).Return(nil)
+				m.On("DeleteClientRoleFromUser", "realm", "client2-uuid", "user-id",
+					[]gocloak.Role{{Name: gocloak.StringP("role3")}}).Return(nil)
+			},
+		},
+		{
+			name:    "add only",
+			user:    KeycloakUser{Username: "user", ClientRoles: map[string][]string{"client1": {"role4"}}},
+			addOnly: true,
+			setupMock: func(m *MockGoCloakClient) {
+				m.On("GetRoleMappingByUserID", "realm", "user-id").Return(currentMappings, nil)
+				m.On("GetClients", "realm", gocloak.GetClientsParams{ClientID: gocloak.StringP("client1")}).
+					Return([]*gocloak.Client{{ID: gocloak.StringP("client1-uuid"), ClientID: gocloak.StringP("client1")}}, nil)
+				m.On("GetClientRole", "realm", "client1-uuid", "role4").
+					Return(&gocloak.Role{Name: gocloak.StringP("role4")}, nil)
+				m.On("AddClientRoleToUser", "realm", "client1-uuid", "user-id",
+					[]gocloak.Role{{Name: gocloak.StringP("role4")}}).Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := &MockGoCloakClient{}
+			tt.setupMock(m)
+
+			a := GoCloakAdapter{client: m, token: &gocloak.JWT{AccessToken: "token"}}
+
+			require.NoError(t, a.syncUserClientRoles("realm", "user-id", &tt.user, tt.addOnly))
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGoCloakAdapter_syncUserFederatedIdentities(t *testing.T) {
+	t.Parallel()
+
+	current := []*gocloak.FederatedIdentityRepresentation{
+		{
+			IdentityProvider: gocloak.StringP("corporate"),
+			UserID:           gocloak.StringP("old-id"),
+			UserName:         gocloak.StringP("john"),
+		},
+		{
+			IdentityProvider: gocloak.StringP("github"),
+			UserID:           gocloak.StringP("gh-id"),
+			UserName:         gocloak.StringP("john-gh"),
+		},
+		{
+			IdentityProvider: gocloak.StringP("google"),
+			UserID:           gocloak.StringP("g-id"),
+			UserName:         gocloak.StringP("john-g"),
+		},
+	}
+	user := KeycloakUser{
+		Username: "john",
+		FederatedIdentities: []FederatedIdentity{
+			{IdentityProvider: "corporate", UserID: "new-id", UserName: "john"},
+			{IdentityProvider: "github", UserID: "gh-id", UserName: "john-gh"},
+			{IdentityProvider: "gitlab", UserID: "gl-id", UserName: "john-gl"},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		addOnly   bool
+		setupMock func(m *MockGoCloakClient)
+		wantErr   require.ErrorAssertionFunc
+	}{
+		{
+			name: "full sync",
+			setupMock: func(m *MockGoCloakClient) {
+				m.On("GetUserFederatedIdentities", "realm", "user-id").Return(current, nil)
+				m.On("DeleteUserFederatedIdentity", "realm", "user-id", "corporate").Return(nil)
+				m.On("CreateUserFederatedIdentity", "realm", "user-id", "corporate", gocloak.FederatedIdentityRepresentation{
+					IdentityProvider: gocloak.StringP("corporate"),
+					UserID:           gocloak.StringP("new-id"),
+					UserName:         gocloak.StringP("john"),
+				}).Return(nil)
+				m.On("CreateUserFederatedIdentity", "realm", "user-id", "gitlab", gocloak.FederatedIdentityRepresentation{
+					IdentityProvider: gocloak.StringP("gitlab"),
+					UserID:           gocloak.StringP("gl-id"),
+					UserName:         gocloak.StringP("john-gl"),
+				}).Return(nil)
+				m.On("DeleteUserFederatedIdentity", "realm", "user-id", "google").Return(nil)
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name:    "add only",
+			addOnly: true,
+			setupMock: func(m *MockGoCloakClient) {
+				m.On("GetUserFederatedIdentities", "realm", "user-id").Return(current, nil)
+				m.On("DeleteUserFederatedIdentity", "realm", "user-id", "corporate").Return(nil)
+				m.On("CreateUserFederatedIdentity", "realm", "user-id", "corporate", mock.Anything).Return(nil)
+				m.On("CreateUserFederatedIdentity", "realm", "user-id", "gitlab", mock.Anything).Return(nil)
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "failed to create link",
+			setupMock: func(m *MockGoCloakClient) {
+				m.On("GetUserFederatedIdentities", "realm", "user-id").Return(current, nil)
+				m.On("DeleteUserFederatedIdentity", "realm", "user-id", "corporate").Return(nil)
+				m.On("CreateUserFederatedIdentity", "realm", "user-id", "corporate", mock.Anything).
+					Return(errors.New("identity provider not found"))
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.ErrorContains(t, err, "unable to create federated identity corporate")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := &MockGoCloakClient{}
+			tt.setupMock(m)
+
+			a := GoCloakAdapter{client: m, token: &gocloak.JWT{AccessToken: "token"}}
+			u := user
+
+			tt.wantErr(t, a.syncUserFederatedIdentities(context.Background(), "realm", "user-id", &u, tt.addOnly))
+			m.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	healthCheckPath    = "/realms/master"
+	healthCheckTimeout = 5 * time.Second
+)
+
+// CheckHealth checks that keycloak server is available at the given url.
+// The master realm endpoint is probed for both current and legacy path layouts as it doesn't require authentication.
+func CheckHealth(ctx context.Context, url string, restyClient *resty.Client) error {
+	if restyClient == nil {
+		restyClient = resty.New()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	var status int
+
+	for _, path := range []string{healthCheckPath, "/auth" + healthCheckPath} {
+		rsp, err := restyClient.R().SetContext(ctx).Get(url + path)
+		if err != nil {
+			return fmt.Errorf("unable to reach keycloak at %s: %w", url, err)
+		}
+
+		if rsp.IsSuccess() {
+			return nil
+		}
+
+		status = rsp.StatusCode()
+	}
+
+	return fmt.Errorf("keycloak at %s is not healthy, status: %d", url, status)
+}
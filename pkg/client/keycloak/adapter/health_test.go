@@ -0,0 +1,65 @@
+package adapter
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/epam/edp-keycloak-operator/pkg/fakehttp"
+)
+
+func TestCheckHealth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		mockServer fakehttp.Server
+		wantErr    require.ErrorAssertionFunc
+	}{
+		{
+			name: "current path layout",
+			mockServer: fakehttp.NewServerBuilder().
+				AddStringResponder("/realms/master", "{}").
+				BuildAndStart(),
+			wantErr: require.NoError,
+		},
+		{
+			name: "legacy path layout",
+			mockServer: fakehttp.NewServerBuilder().
+				AddStringResponderWithCode(http.StatusNotFound, "/realms/master", "").
+				AddStringResponder("/auth/realms/master", "{}").
+				BuildAndStart(),
+			wantErr: require.NoError,
+		},
+		{
+			name: "unhealthy server",
+			mockServer: fakehttp.NewServerBuilder().
+				AddStringResponderWithCode(http.StatusServiceUnavailable, "/realms/master", "").
+				AddStringResponderWithCode(http.StatusServiceUnavailable, "/auth/realms/master", "").
+				BuildAndStart(),
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.ErrorContains(t, err, "is not healthy, status: 503")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			defer tt.mockServer.Close()
+
+			tt.wantErr(t, CheckHealth(context.Background(), tt.mockServer.GetURL(), nil))
+		})
+	}
+}
+
+func TestCheckHealth_Unreachable(t *testing.T) {
+	t.Parallel()
+
+	err := CheckHealth(context.Background(), "http://127.0.0.1:1", nil)
+	require.ErrorContains(t, err, "unable to reach keycloak")
+}
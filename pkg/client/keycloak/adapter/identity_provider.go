@@ -1,6 +1,7 @@
 package adapter
 
 import (
+	"bytes"
 	"context"
 	"net/http"
 
@@ -113,6 +114,56 @@ func (a GoCloakAdapter) DeleteIdentityProvider(ctx context.Context, realm, alias
 	return nil
 }
 
+// ImportIdentityProviderConfig imports identity provider config from the given URL.
+// It can be OpenID Connect discovery document URL or SAML metadata URL depending on the provider ID.
+func (a GoCloakAdapter) ImportIdentityProviderConfig(ctx context.Context, realm, providerID, fromURL string) (map[string]string, error) {
+	var config map[string]string
+
+	rsp, err := a.startRestyRequest().
+		SetContext(ctx).
+		SetPathParams(map[string]string{
+			keycloakApiParamRealm: realm,
+		}).
+		SetBody(map[string]string{
+			"providerId": providerID,
+			"fromUrl":    fromURL,
+		}).
+		SetResult(&config).
+		Post(a.buildPath(identityProviderImportConfig))
+
+	if err = a.checkError(err, rsp); err != nil {
+		return nil, errors.Wrap(err, "unable to import idp config")
+	}
+
+	return config, nil
+}
+
+// ImportIdentityProviderConfigFromFile imports identity provider config from the given file content,
+// e.g. SAML metadata.
+func (a GoCloakAdapter) ImportIdentityProviderConfigFromFile(ctx context.Context, realm, providerID string,
+	file []byte) (map[string]string, error) {
+	var config map[string]string
+
+	rsp, err := a.client.RestyClient().R().
+		SetContext(ctx).
+		SetAuthToken(a.token.AccessToken).
+		SetPathParams(map[string]string{
+			keycloakApiParamRealm: realm,
+		}).
+		SetMultipartFormData(map[string]string{
+			"providerId": providerID,
+		}).
+		SetFileReader("file", "metadata", bytes.NewReader(file)).
+		SetResult(&config).
+		Post(a.buildPath(identityProviderImportConfig))
+
+	if err = a.checkError(err, rsp); err != nil {
+		return nil, errors.Wrap(err, "unable to import idp config from file")
+	}
+
+	return config, nil
+}
+
 func (a GoCloakAdapter) CreateIDPMapper(ctx context.Context, realm, idpAlias string,
 	mapper *IdentityProviderMapper) (string, error) {
 	rsp, err := a.startRestyRequest().
@@ -0,0 +1,115 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"golang.org/x/exp/maps"
+)
+
+// SyncIDPMappers syncs mappers of the identity provider with the given mappers matched by name.
+// Existing mappers are updated in place, mappers which are not in the list are deleted unless addOnly is set.
+// Sync doesn't stop on a mapper error, errors of all mappers are returned together.
+func (a GoCloakAdapter) SyncIDPMappers(
+	ctx context.Context,
+	realm, idpAlias string,
+	mappers []IdentityProviderMapper,
+	addOnly bool,
+) error {
+	currentMappers, err := a.GetIDPMappers(ctx, realm, idpAlias)
+	if err != nil {
+		return fmt.Errorf("unable to get current mappers: %w", err)
+	}
+
+	currentMappersByName := make(map[string]IdentityProviderMapper, len(currentMappers))
+	for _, m := range currentMappers {
+		currentMappersByName[m.Name] = m
+	}
+
+	var errs []error
+
+	claimedMappers := make(map[string]struct{}, len(mappers))
+
+	for i := range mappers {
+		mapper := mappers[i]
+		mapper.IdentityProviderAlias = idpAlias
+		claimedMappers[mapper.Name] = struct{}{}
+
+		current, ok := currentMappersByName[mapper.Name]
+		if err := a.syncIDPMapper(ctx, realm, idpAlias, &mapper, &current, ok); err != nil {
+			errs = append(errs, fmt.Errorf("unable to sync idp mapper %s: %w", mapper.Name, err))
+		}
+	}
+
+	if addOnly {
+		return errors.Join(errs...)
+	}
+
+	names := maps.Keys(currentMappersByName)
+	sort.Strings(names)
+
+	for _, name := range names {
+		if _, ok := claimedMappers[name]; ok {
+			continue
+		}
+
+		if err := a.DeleteIDPMapper(ctx, realm, idpAlias, currentMappersByName[name].ID); err != nil {
+			errs = append(errs, fmt.Errorf("unable to delete idp mapper %s: %w", name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (a GoCloakAdapter) syncIDPMapper(
+	ctx context.Context,
+	realm, idpAlias string,
+	mapper, current *IdentityProviderMapper,
+	exists bool,
+) error {
+	if exists && current.IdentityProviderMapper != mapper.IdentityProviderMapper {
+		// Keycloak doesn't allow changing mapper type, so the mapper is recreated.
+		if err := a.DeleteIDPMapper(ctx, realm, idpAlias, current.ID); err != nil {
+			return err
+		}
+
+		exists = false
+	}
+
+	if !exists {
+		_, err := a.CreateIDPMapper(ctx, realm, idpAlias, mapper)
+
+		return err
+	}
+
+	if isIDPMapperConfigApplied(current.Config, mapper.Config) {
+		return nil
+	}
+
+	// Config keys which are not in the desired config are kept.
+	config := maps.Clone(current.Config)
+	if config == nil {
+		config = make(map[string]string, len(mapper.Config))
+	}
+
+	maps.Copy(config, mapper.Config)
+
+	mapper.ID = current.ID
+	mapper.Config = config
+
+	return a.UpdateIDPMapper(ctx, realm, idpAlias, mapper)
+}
+
+// isIDPMapperConfigApplied checks if all keys of the desired config are set in the current config.
+// Keys which are not in the desired config are ignored, Keycloak can add them with default values.
+func isIDPMapperConfigApplied(current, desired map[string]string) bool {
+	for k, v := range desired {
+		if cv, ok := current[k]; !ok || cv != v {
+			return false
+		}
+	}
+
+	return true
+}
@@ -2,10 +2,14 @@ package adapter
 
 import (
 	"context"
+	"encoding/json"
+	"io"
+	"net/http"
 	"testing"
 
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/maps"
 )
 
 func TestGoCloakAdapter_GetIdentityProvider(t *testing.T) {
@@ -191,3 +195,186 @@ func TestGoCloakAdapter_GetIDPMappers(t *testing.T) {
 		t.Fatalf("wrong error returned: %s", err.Error())
 	}
 }
+
+func TestGoCloakAdapter_ImportIdentityProviderConfig(t *testing.T) {
+	kc, _, _ := initAdapter()
+
+	httpmock.RegisterResponder("POST", "/admin/realms/realm1/identity-provider/import-config",
+		func(req *http.Request) (*http.Response, error) {
+			var body map[string]string
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+
+			if body["providerId"] != "oidc" || body["fromUrl"] != "https://idp" {
+				return httpmock.NewStringResponse(http.StatusBadRequest, "bad request"), nil
+			}
+
+			return httpmock.NewJsonResponse(http.StatusOK, map[string]string{"tokenUrl": "https://idp/token"})
+		})
+
+	config, err := kc.ImportIdentityProviderConfig(context.Background(), "realm1", "oidc", "https://idp")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"tokenUrl": "https://idp/token"}, config)
+
+	httpmock.RegisterResponder("POST", "/admin/realms/realm2/identity-provider/import-config",
+		httpmock.NewStringResponder(500, "fatal"))
+
+	_, err = kc.ImportIdentityProviderConfig(context.Background(), "realm2", "oidc", "https://idp")
+	require.EqualError(t, err, "unable to import idp config: status: 500, body: fatal")
+}
+
+func TestGoCloakAdapter_ImportIdentityProviderConfigFromFile(t *testing.T) {
+	kc, _, _ := initAdapter()
+
+	httpmock.RegisterResponder("POST", "/admin/realms/realm1/identity-provider/import-config",
+		func(req *http.Request) (*http.Response, error) {
+			if req.FormValue("providerId") != "saml" {
+				return httpmock.NewStringResponse(http.StatusBadRequest, "bad request"), nil
+			}
+
+			file, _, err := req.FormFile("file")
+			if err != nil {
+				return nil, err
+			}
+
+			content, err := io.ReadAll(file)
+			if err != nil {
+				return nil, err
+			}
+
+			if string(content) != "<EntityDescriptor/>" {
+				return httpmock.NewStringResponse(http.StatusBadRequest, "bad request"), nil
+			}
+
+			return httpmock.NewJsonResponse(http.StatusOK, map[string]string{"signingCertificate": "cert"})
+		})
+
+	config, err := kc.ImportIdentityProviderConfigFromFile(context.Background(), "realm1", "saml",
+		[]byte("<EntityDescriptor/>"))
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"signingCertificate": "cert"}, config)
+
+	httpmock.RegisterResponder("POST", "/admin/realms/realm2/identity-provider/import-config",
+		httpmock.NewStringResponder(500, "fatal"))
+
+	_, err = kc.ImportIdentityProviderConfigFromFile(context.Background(), "realm2", "saml", []byte("metadata"))
+	require.EqualError(t, err, "unable to import idp config from file: status: 500, body: fatal")
+}
+
+func TestGoCloakAdapter_SyncIDPMappers(t *testing.T) {
+	const mappersPath = "/admin/realms/realm1/identity-provider/instances/alias1/mappers"
+
+	currentMappers := []IdentityProviderMapper{
+		{ID: "id1", Name: "unchanged", IdentityProviderMapper: "hardcoded-attribute-idp-mapper",
+			Config: map[string]string{"attribute": "foo", "syncMode": "INHERIT"}},
+		{ID: "id2", Name: "changed", IdentityProviderMapper: "hardcoded-attribute-idp-mapper",
+			Config: map[string]string{"attribute": "foo", "extra": "keep"}},
+		{ID: "id3", Name: "type-changed", IdentityProviderMapper: "hardcoded-attribute-idp-mapper"},
+		{ID: "id4", Name: "stale", IdentityProviderMapper: "hardcoded-attribute-idp-mapper"},
+	}
+
+	claimedMappers := []IdentityProviderMapper{
+		{Name: "unchanged", IdentityProviderMapper: "hardcoded-attribute-idp-mapper",
+			Config: map[string]string{"attribute": "foo"}},
+		{Name: "changed", IdentityProviderMapper: "hardcoded-attribute-idp-mapper",
+			Config: map[string]string{"attribute": "bar", "syncMode": "FORCE"}},
+		{Name: "type-changed", IdentityProviderMapper: "oidc-user-attribute-idp-mapper"},
+		{Name: "new", IdentityProviderMapper: "hardcoded-attribute-idp-mapper"},
+	}
+
+	tests := []struct {
+		name      string
+		addOnly   bool
+		failOn    string
+		wantCalls map[string]int
+		wantErr   require.ErrorAssertionFunc
+	}{
+		{
+			name: "full sync",
+			wantCalls: map[string]int{
+				"PUT " + mappersPath + "/id1":    0,
+				"PUT " + mappersPath + "/id2":    1,
+				"DELETE " + mappersPath + "/id3": 1,
+				"DELETE " + mappersPath + "/id4": 1,
+				"POST " + mappersPath:            2,
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name:    "add only",
+			addOnly: true,
+			wantCalls: map[string]int{
+				"PUT " + mappersPath + "/id2":    1,
+				"DELETE " + mappersPath + "/id3": 1,
+				"DELETE " + mappersPath + "/id4": 0,
+				"POST " + mappersPath:            2,
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name:   "mapper errors are collected",
+			failOn: "PUT " + mappersPath + "/id2",
+			wantCalls: map[string]int{
+				"DELETE " + mappersPath + "/id4": 1,
+				"POST " + mappersPath:            2,
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.ErrorContains(t, err, "unable to sync idp mapper changed")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kc, _, _ := initAdapter()
+			httpmock.Reset()
+
+			httpmock.RegisterResponder(http.MethodGet, mappersPath, httpmock.NewJsonResponderOrPanic(http.StatusOK, currentMappers))
+
+			responder := func(method, url string, r httpmock.Responder) {
+				if method+" "+url == tt.failOn {
+					r = httpmock.NewStringResponder(http.StatusInternalServerError, "fatal")
+				}
+
+				httpmock.RegisterResponder(method, url, r)
+			}
+
+			responder(http.MethodPost, mappersPath, func(req *http.Request) (*http.Response, error) {
+				rsp := httpmock.NewStringResponse(http.StatusCreated, "")
+				rsp.Header.Set("Location", "id/new-id")
+
+				return rsp, nil
+			})
+
+			responder(http.MethodPut, mappersPath+"/id2", func(req *http.Request) (*http.Response, error) {
+				var mapper IdentityProviderMapper
+				if err := json.NewDecoder(req.Body).Decode(&mapper); err != nil {
+					return nil, err
+				}
+
+				if !maps.Equal(map[string]string{"attribute": "bar", "syncMode": "FORCE", "extra": "keep"}, mapper.Config) {
+					return httpmock.NewStringResponse(http.StatusBadRequest, "wrong mapper config"), nil
+				}
+
+				return httpmock.NewStringResponse(http.StatusOK, ""), nil
+			})
+
+			for _, id := range []string{"id1", "id2", "id3", "id4"} {
+				if id != "id2" {
+					responder(http.MethodPut, mappersPath+"/"+id, httpmock.NewStringResponder(http.StatusOK, ""))
+				}
+
+				responder(http.MethodDelete, mappersPath+"/"+id, httpmock.NewStringResponder(http.StatusOK, ""))
+			}
+
+			err := kc.SyncIDPMappers(context.Background(), "realm1", "alias1", claimedMappers, tt.addOnly)
+			tt.wantErr(t, err)
+
+			info := httpmock.GetCallCountInfo()
+			for call, count := range tt.wantCalls {
+				require.Equal(t, count, info[call], call)
+			}
+		})
+	}
+}
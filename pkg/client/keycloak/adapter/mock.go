@@ -2,6 +2,7 @@ package adapter
 
 import (
 	"context"
+	"time"
 
 	"github.com/Nerzal/gocloak/v12"
 	"github.com/stretchr/testify/mock"
@@ -14,6 +15,8 @@ type Mock struct {
 	mock.Mock
 	ExportTokenResult []byte
 	ExportTokenErr    error
+	ServerVersion     string
+	ServerFeatures    []string
 }
 
 func (m *Mock) PutDefaultIdp(realm *dto.Realm) error {
@@ -77,6 +80,16 @@ func (m *Mock) ExistClientRole(role *dto.Client, clientRole string) (bool, error
 	panic("implement me")
 }
 
+func (m *Mock) SyncClientRole(ctx context.Context, realm, clientUUID string, role *dto.ClientRole) (string, error) {
+	called := m.Called(realm, clientUUID, role)
+
+	return called.String(0), called.Error(1)
+}
+
+func (m *Mock) DeleteClientRole(ctx context.Context, realm, clientUUID, roleName string) error {
+	return m.Called(realm, clientUUID, roleName).Error(0)
+}
+
 func (m *Mock) CreateClientRole(role *dto.Client, clientRole string) error {
 	panic("implement me")
 }
@@ -172,6 +185,10 @@ func (m *Mock) HasUserRealmRole(realmName string, user *dto.User, role string) (
 	return called.Bool(0), called.Error(1)
 }
 
+func (m *Mock) SyncClientScopes(ctx context.Context, realmName, clientName string, defaultScopes, optionalScopes []ClientScope, addOnly bool) error {
+	return m.Called(realmName, clientName, defaultScopes, optionalScopes, addOnly).Error(0)
+}
+
 func (m *Mock) AddDefaultScopeToClient(ctx context.Context, realmName, clientName string, scopes []ClientScope) error {
 	return m.Called(ctx, realmName, clientName, scopes).Error(0)
 }
@@ -194,18 +211,48 @@ func (m *Mock) SyncServiceAccountRoles(realm, clientID string, realmRoles []stri
 	return m.Called(realm, clientID, realmRoles, clientRoles, addOnly).Error(0)
 }
 
-func (m *Mock) SyncRealmGroup(realmName string, spec *keycloakApi.KeycloakRealmGroupSpec) (string, error) {
-	called := m.Called(realmName, spec)
-	return called.String(0), called.Error(1)
+func (m *Mock) SyncClientScopeMappings(ctx context.Context, realm, idOfClient string, realmRoles []string,
+	clientRoles map[string][]string, addOnly bool) error {
+	return m.Called(realm, idOfClient, realmRoles, clientRoles, addOnly).Error(0)
+}
+
+func (m *Mock) SyncClientScopeScopeMappings(ctx context.Context, realm, scopeID string, realmRoles []string,
+	clientRoles map[string][]string, addOnly bool) error {
+	return m.Called(realm, scopeID, realmRoles, clientRoles, addOnly).Error(0)
+}
+
+func (m *Mock) SyncRealmGroup(ctx context.Context, realmName string, spec *keycloakApi.KeycloakRealmGroupSpec,
+	ref RealmGroupRef) (*gocloak.Group, error) {
+	called := m.Called(realmName, spec, ref)
+
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).(*gocloak.Group), nil
 }
 
 func (m *Mock) DeleteGroup(ctx context.Context, realm, groupName string) error {
 	return m.Called(realm, groupName).Error(0)
 }
 
+func (m *Mock) SyncGroupMembers(ctx context.Context, realm, groupID string, members []keycloakApi.GroupMember,
+	addOnly bool) ([]string, error) {
+	called := m.Called(realm, groupID, members, addOnly)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).([]string), nil
+}
+
+func (m *Mock) DeleteGroupByID(ctx context.Context, realm, groupID string) error {
+	return m.Called(realm, groupID).Error(0)
+}
+
 func (m *Mock) SyncRealmIdentityProviderMappers(realmName string,
-	mappers []dto.IdentityProviderMapper) error {
-	return m.Called(realmName, mappers).Error(0)
+	mappers []dto.IdentityProviderMapper, addOnly bool) error {
+	return m.Called(realmName, mappers, addOnly).Error(0)
 }
 
 func (m *Mock) DeleteAuthFlow(realmName string, flow *KeycloakAuthFlow) error {
@@ -227,6 +274,18 @@ func (m *Mock) SyncRealmUser(ctx context.Context, realmName string, user *Keyclo
 	return m.Called(realmName, user, addOnly).Error(0)
 }
 
+func (m *Mock) LogoutRealmUser(ctx context.Context, realmName, username string) error {
+	return m.Called(realmName, username).Error(0)
+}
+
+func (m *Mock) RevokeClientSessions(ctx context.Context, realmName, clientUUID string) error {
+	return m.Called(realmName, clientUUID).Error(0)
+}
+
+func (m *Mock) RevokeRealmTokens(ctx context.Context, realmName string, notBefore time.Time) error {
+	return m.Called(realmName, notBefore).Error(0)
+}
+
 func (m *Mock) SetServiceAccountAttributes(realm, clientID string, attributes map[string]string, addOnly bool) error {
 	return m.Called(realm, clientID, attributes, addOnly).Error(0)
 }
@@ -252,6 +311,19 @@ func (m *Mock) SetRealmEventConfig(realmName string, eventConfig *RealmEventConf
 	return m.Called(realmName, eventConfig).Error(0)
 }
 
+func (m *Mock) GetUsersProfile(ctx context.Context, realm string) (*UserProfileConfig, error) {
+	called := m.Called(realm)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).(*UserProfileConfig), nil
+}
+
+func (m *Mock) UpdateUsersProfile(ctx context.Context, realm string, profile *UserProfileConfig) error {
+	return m.Called(realm, profile).Error(0)
+}
+
 func (m *Mock) ExportToken() ([]byte, error) {
 	return m.ExportTokenResult, m.ExportTokenErr
 }
@@ -277,6 +349,106 @@ func (m *Mock) GetComponent(ctx context.Context, realmName, componentName string
 	return called.Get(0).(*Component), nil
 }
 
+func (m *Mock) GetComponentByID(ctx context.Context, realmName, componentID string) (*Component, error) {
+	called := m.Called(realmName, componentID)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).(*Component), nil
+}
+
+func (m *Mock) GetComponentByParent(ctx context.Context, realmName, parentID, componentName string) (*Component, error) {
+	called := m.Called(realmName, parentID, componentName)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).(*Component), nil
+}
+
+func (m *Mock) DeleteComponentByID(ctx context.Context, realmName, componentID string) error {
+	return m.Called(realmName, componentID).Error(0)
+}
+
+func (m *Mock) GetUserFederation(ctx context.Context, realm, parentID, name string) (*Component, error) {
+	called := m.Called(realm, parentID, name)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).(*Component), nil
+}
+
+func (m *Mock) CreateUserFederation(ctx context.Context, realm string, federation *Component) (string, error) {
+	called := m.Called(realm, federation)
+
+	return called.String(0), called.Error(1)
+}
+
+func (m *Mock) UpdateUserFederation(ctx context.Context, realm string, federation *Component) error {
+	return m.Called(realm, federation).Error(0)
+}
+
+func (m *Mock) DeleteUserFederation(ctx context.Context, realm, federationID string) error {
+	return m.Called(realm, federationID).Error(0)
+}
+
+func (m *Mock) SyncUserFederationMappers(ctx context.Context, realm, federationID string, mappers []Component, addOnly bool) error {
+	return m.Called(realm, federationID, mappers, addOnly).Error(0)
+}
+
+func (m *Mock) TestLDAPConnection(ctx context.Context, realm string, test *LDAPConnectionTest) error {
+	return m.Called(realm, test).Error(0)
+}
+
+func (m *Mock) TriggerUserFederationSync(ctx context.Context, realm, federationID, action string) (*UserFederationSyncResult, error) {
+	called := m.Called(realm, federationID, action)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).(*UserFederationSyncResult), nil
+}
+
+func (m *Mock) SetManagementPermissions(ctx context.Context, realm, targetType, targetName string, enabled bool) (*ManagementPermissions, error) {
+	called := m.Called(realm, targetType, targetName, enabled)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).(*ManagementPermissions), nil
+}
+
+func (m *Mock) SyncAdminPermissionPolicy(ctx context.Context, realm string, policy *AdminPermissionPolicy) (string, error) {
+	called := m.Called(realm, policy)
+
+	return called.String(0), called.Error(1)
+}
+
+func (m *Mock) GetAdminPermissionPolicyID(ctx context.Context, realm, name string) (string, error) {
+	called := m.Called(realm, name)
+
+	return called.String(0), called.Error(1)
+}
+
+func (m *Mock) DeleteAdminPermissionPolicy(ctx context.Context, realm, name string) error {
+	return m.Called(realm, name).Error(0)
+}
+
+func (m *Mock) SetScopePermissionPolicies(ctx context.Context, realm, permissionID string, policyIDs []string, decisionStrategy string) error {
+	return m.Called(realm, permissionID, policyIDs, decisionStrategy).Error(0)
+}
+
+func (m *Mock) GetOptionalClientScopesForRealm(ctx context.Context, realm string) ([]ClientScope, error) {
+	called := m.Called(realm)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).([]ClientScope), nil
+}
+
 func (m *Mock) GetDefaultClientScopesForRealm(ctx context.Context, realm string) ([]ClientScope, error) {
 	called := m.Called(realm)
 	if err := called.Error(1); err != nil {
@@ -317,6 +489,30 @@ func (m *Mock) GetIDPMappers(ctx context.Context, realm, idpAlias string) ([]Ide
 	return called.Get(0).([]IdentityProviderMapper), nil
 }
 
+func (m *Mock) ImportIdentityProviderConfig(ctx context.Context, realm, providerID, fromURL string) (map[string]string, error) {
+	called := m.Called(realm, providerID, fromURL)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).(map[string]string), nil
+}
+
+func (m *Mock) ImportIdentityProviderConfigFromFile(ctx context.Context, realm, providerID string,
+	file []byte) (map[string]string, error) {
+	called := m.Called(realm, providerID, file)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).(map[string]string), nil
+}
+
+func (m *Mock) SyncIDPMappers(ctx context.Context, realm, idpAlias string, mappers []IdentityProviderMapper,
+	addOnly bool) error {
+	return m.Called(realm, idpAlias, mappers, addOnly).Error(0)
+}
+
 func (m *Mock) DeleteIdentityProvider(ctx context.Context, realm, alias string) error {
 	return m.Called(realm, alias).Error(0)
 }
@@ -366,3 +562,22 @@ func (m *Mock) GetRealm(ctx context.Context, realmName string) (*gocloak.RealmRe
 
 	return called.Get(0).(*gocloak.RealmRepresentation), nil
 }
+
+func (m *Mock) GetServerInfo(ctx context.Context) (*ServerInfo, error) {
+	called := m.Called(ctx)
+
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).(*ServerInfo), nil
+}
+
+func (m *Mock) LegacyMode() bool {
+	return m.Called().Bool(0)
+}
+
+func (m *Mock) SetServerFeatures(version string, features []string) {
+	m.ServerVersion = version
+	m.ServerFeatures = features
+}
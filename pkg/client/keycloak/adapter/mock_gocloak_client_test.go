@@ -70,7 +70,26 @@ func (m *MockGoCloakClient) CreateClient(ctx context.Context, accessToken, realm
 
 func (m *MockGoCloakClient) CreateClientRole(ctx context.Context, accessToken, realm, clientID string,
 	role gocloak.Role) (string, error) {
-	panic("implement me")
+	called := m.Called(realm, clientID, role)
+	return called.String(0), called.Error(1)
+}
+
+func (m *MockGoCloakClient) UpdateRole(ctx context.Context, token, realm, idOfClient string, role gocloak.Role) error {
+	return m.Called(realm, idOfClient, role).Error(0)
+}
+
+func (m *MockGoCloakClient) DeleteClientRole(ctx context.Context, token, realm, idOfClient, roleName string) error {
+	return m.Called(realm, idOfClient, roleName).Error(0)
+}
+
+func (m *MockGoCloakClient) AddClientRoleComposite(ctx context.Context, token, realm, roleID string,
+	roles []gocloak.Role) error {
+	return m.Called(realm, roleID, roles).Error(0)
+}
+
+func (m *MockGoCloakClient) DeleteClientRoleComposite(ctx context.Context, token, realm, roleID string,
+	roles []gocloak.Role) error {
+	return m.Called(realm, roleID, roles).Error(0)
 }
 
 func (m *MockGoCloakClient) CreateRealmRole(ctx context.Context, token, realm string,
@@ -178,7 +197,7 @@ func (m *MockGoCloakClient) DeleteRealmRoleComposite(ctx context.Context, token,
 	return m.Called(realm, roleName, roles).Error(0)
 }
 
-func (m *MockGoCloakClient) GetCompositeRealmRolesByRoleID(ctx context.Context, token, realm,
+func (m *MockGoCloakClient) GetCompositeRolesByRoleID(ctx context.Context, token, realm,
 	roleID string) ([]*gocloak.Role, error) {
 	called := m.Called(realm, roleID)
 	return called.Get(0).([]*gocloak.Role), called.Error(1)
@@ -265,6 +284,34 @@ func (m *MockGoCloakClient) GetGroups(ctx context.Context, accessToken, realm st
 	return called.Get(0).([]*gocloak.Group), nil
 }
 
+func (m *MockGoCloakClient) GetGroup(ctx context.Context, token, realm, groupID string) (*gocloak.Group, error) {
+	called := m.Called(realm, groupID)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).(*gocloak.Group), nil
+}
+
+func (m *MockGoCloakClient) GetGroupByPath(ctx context.Context, token, realm, groupPath string) (*gocloak.Group, error) {
+	called := m.Called(realm, groupPath)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).(*gocloak.Group), nil
+}
+
+func (m *MockGoCloakClient) GetGroupMembers(ctx context.Context, token, realm, groupID string,
+	params gocloak.GetGroupsParams) ([]*gocloak.User, error) {
+	called := m.Called(realm, groupID, params)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).([]*gocloak.User), nil
+}
+
 func (m *MockGoCloakClient) DeleteGroup(ctx context.Context, accessToken, realm, groupID string) error {
 	return m.Called(realm, groupID).Error(0)
 }
@@ -290,6 +337,25 @@ func (m *MockGoCloakClient) UpdateUser(ctx context.Context, accessToken, realm s
 	return m.Called(realm, user).Error(0)
 }
 
+func (m *MockGoCloakClient) GetUserFederatedIdentities(ctx context.Context, token, realm,
+	userID string) ([]*gocloak.FederatedIdentityRepresentation, error) {
+	called := m.Called(realm, userID)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).([]*gocloak.FederatedIdentityRepresentation), nil
+}
+
+func (m *MockGoCloakClient) CreateUserFederatedIdentity(ctx context.Context, token, realm, userID, providerID string,
+	federatedIdentityRep gocloak.FederatedIdentityRepresentation) error {
+	return m.Called(realm, userID, providerID, federatedIdentityRep).Error(0)
+}
+
+func (m *MockGoCloakClient) DeleteUserFederatedIdentity(ctx context.Context, token, realm, userID, providerID string) error {
+	return m.Called(realm, userID, providerID).Error(0)
+}
+
 func (m *MockGoCloakClient) DeleteClientScope(ctx context.Context, accessToken, realm, scopeID string) error {
 	return m.Called(realm, scopeID).Error(0)
 }
@@ -315,3 +381,74 @@ func (m *MockGoCloakClient) GetClientsDefaultScopes(ctx context.Context, token,
 func (m *MockGoCloakClient) AddDefaultScopeToClient(ctx context.Context, token, realm, clientID, scopeID string) error {
 	return m.Called(realm, clientID, scopeID).Error(0)
 }
+
+func (m *MockGoCloakClient) RemoveDefaultScopeFromClient(ctx context.Context, token, realm, idOfClient, scopeID string) error {
+	return m.Called(realm, idOfClient, scopeID).Error(0)
+}
+
+func (m *MockGoCloakClient) GetClientsOptionalScopes(ctx context.Context, token, realm, idOfClient string) ([]*gocloak.ClientScope, error) {
+	called := m.Called(realm, idOfClient)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).([]*gocloak.ClientScope), nil
+}
+
+func (m *MockGoCloakClient) AddOptionalScopeToClient(ctx context.Context, token, realm, idOfClient, scopeID string) error {
+	return m.Called(realm, idOfClient, scopeID).Error(0)
+}
+
+func (m *MockGoCloakClient) RemoveOptionalScopeFromClient(ctx context.Context, token, realm, idOfClient, scopeID string) error {
+	return m.Called(realm, idOfClient, scopeID).Error(0)
+}
+
+func (m *MockGoCloakClient) GetClientScopeMappings(ctx context.Context, token, realm,
+	idOfClient string) (*gocloak.MappingsRepresentation, error) {
+	called := m.Called(realm, idOfClient)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).(*gocloak.MappingsRepresentation), nil
+}
+
+func (m *MockGoCloakClient) CreateClientScopeMappingsRealmRoles(ctx context.Context, token, realm, idOfClient string,
+	roles []gocloak.Role) error {
+	return m.Called(realm, idOfClient, roles).Error(0)
+}
+
+func (m *MockGoCloakClient) DeleteClientScopeMappingsRealmRoles(ctx context.Context, token, realm, idOfClient string,
+	roles []gocloak.Role) error {
+	return m.Called(realm, idOfClient, roles).Error(0)
+}
+
+func (m *MockGoCloakClient) CreateClientScopeMappingsClientRoles(ctx context.Context, token, realm, idOfClient,
+	idOfSelectedClient string, roles []gocloak.Role) error {
+	return m.Called(realm, idOfClient, idOfSelectedClient, roles).Error(0)
+}
+
+func (m *MockGoCloakClient) DeleteClientScopeMappingsClientRoles(ctx context.Context, token, realm, idOfClient,
+	idOfSelectedClient string, roles []gocloak.Role) error {
+	return m.Called(realm, idOfClient, idOfSelectedClient, roles).Error(0)
+}
+
+func (m *MockGoCloakClient) CreateClientScopesScopeMappingsRealmRoles(ctx context.Context, token, realm,
+	clientScopeID string, roles []gocloak.Role) error {
+	return m.Called(realm, clientScopeID, roles).Error(0)
+}
+
+func (m *MockGoCloakClient) DeleteClientScopesScopeMappingsRealmRoles(ctx context.Context, token, realm,
+	clientScopeID string, roles []gocloak.Role) error {
+	return m.Called(realm, clientScopeID, roles).Error(0)
+}
+
+func (m *MockGoCloakClient) CreateClientScopesScopeMappingsClientRoles(ctx context.Context, token, realm,
+	idOfClientScope, idOfClient string, roles []gocloak.Role) error {
+	return m.Called(realm, idOfClientScope, idOfClient, roles).Error(0)
+}
+
+func (m *MockGoCloakClient) DeleteClientScopesScopeMappingsClientRoles(ctx context.Context, token, realm,
+	idOfClientScope, idOfClient string, roles []gocloak.Role) error {
+	return m.Called(realm, idOfClientScope, idOfClient, roles).Error(0)
+}
@@ -140,6 +140,18 @@ func TestMock_GetDefaultClientScopesForRealm(t *testing.T) {
 	}
 }
 
+func TestMock_GetOptionalClientScopesForRealm(t *testing.T) {
+	m := Mock{}
+	m.On("GetOptionalClientScopesForRealm", "realm").Return([]ClientScope{}, nil).Once()
+	_, err := m.GetOptionalClientScopesForRealm(context.Background(), "realm")
+	require.NoError(t, err)
+
+	m.On("GetOptionalClientScopesForRealm", "realm").Return(nil, errors.New("fatal")).Once()
+	if _, err := m.GetOptionalClientScopesForRealm(context.Background(), "realm"); err == nil {
+		t.Fatal("no error returned")
+	}
+}
+
 func TestMock_GetClientScopeMappers(t *testing.T) {
 	m := Mock{}
 	m.On("GetClientScopeMappers", "realm", "scope").Return([]ProtocolMapper{}, nil).Once()
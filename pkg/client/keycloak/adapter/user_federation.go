@@ -0,0 +1,308 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"golang.org/x/exp/maps"
+)
+
+const (
+	// UserStorageProviderType is a component provider type of the user federation.
+	UserStorageProviderType = "org.keycloak.storage.UserStorageProvider"
+
+	// LDAPStorageMapperType is a component provider type of the LDAP user federation mapper.
+	LDAPStorageMapperType = "org.keycloak.storage.ldap.mappers.LDAPStorageMapper"
+
+	// UserFederationSyncFull is an action to synchronize all users of the user federation.
+	UserFederationSyncFull = "triggerFullSync"
+
+	// UserFederationSyncChanged is an action to synchronize changed users of the user federation.
+	UserFederationSyncChanged = "triggerChangedUsersSync"
+
+	// LDAPTestConnection is an action to test connection to the LDAP server.
+	LDAPTestConnection = "testConnection"
+
+	// LDAPTestAuthentication is an action to test authentication to the LDAP server with bind credentials.
+	LDAPTestAuthentication = "testAuthentication"
+)
+
+// LDAPConnectionTest is a request to test LDAP connection settings.
+type LDAPConnectionTest struct {
+	Action            string `json:"action"`
+	ConnectionURL     string `json:"connectionUrl"`
+	BindDN            string `json:"bindDn,omitempty"`
+	BindCredential    string `json:"bindCredential,omitempty"`
+	UseTruststoreSpi  string `json:"useTruststoreSpi,omitempty"`
+	ConnectionTimeout string `json:"connectionTimeout,omitempty"`
+	StartTLS          string `json:"startTls,omitempty"`
+	AuthType          string `json:"authType,omitempty"`
+	ComponentID       string `json:"componentId,omitempty"`
+}
+
+// UserFederationSyncResult is a result of the user federation synchronization.
+type UserFederationSyncResult struct {
+	Ignored bool   `json:"ignored"`
+	Added   int    `json:"added"`
+	Updated int    `json:"updated"`
+	Removed int    `json:"removed"`
+	Failed  int    `json:"failed"`
+	Status  string `json:"status"`
+}
+
+// GetUserFederation returns user federation component by name among the user federations of the parent.
+// Parent of the user federation is the realm ID.
+func (a GoCloakAdapter) GetUserFederation(ctx context.Context, realm, parentID, name string) (*Component, error) {
+	components, err := a.getComponents(ctx, realm, map[string]string{
+		"type":   UserStorageProviderType,
+		"parent": parentID,
+		"name":   name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range components {
+		if components[i].Name == name {
+			return &components[i], nil
+		}
+	}
+
+	return nil, NotFoundError("user federation not found")
+}
+
+// CreateUserFederation creates user federation component and returns its ID.
+func (a GoCloakAdapter) CreateUserFederation(ctx context.Context, realm string, federation *Component) (string, error) {
+	federation.ProviderType = UserStorageProviderType
+
+	rsp, err := a.startRestyRequest().
+		SetContext(ctx).
+		SetPathParams(map[string]string{
+			keycloakApiParamRealm: realm,
+		}).
+		SetBody(federation).
+		Post(a.buildPath(realmComponent))
+	if err = a.checkError(err, rsp); err != nil {
+		return "", fmt.Errorf("unable to create user federation: %w", err)
+	}
+
+	id, err := getIDFromResponseLocation(rsp.RawResponse)
+	if err != nil {
+		return "", fmt.Errorf("unable to get user federation id: %w", err)
+	}
+
+	return id, nil
+}
+
+// UpdateUserFederation updates user federation component. Component ID must be set.
+func (a GoCloakAdapter) UpdateUserFederation(ctx context.Context, realm string, federation *Component) error {
+	federation.ProviderType = UserStorageProviderType
+
+	rsp, err := a.startRestyRequest().
+		SetContext(ctx).
+		SetPathParams(map[string]string{
+			keycloakApiParamRealm: realm,
+			keycloakApiParamId:    federation.ID,
+		}).
+		SetBody(federation).
+		Put(a.buildPath(realmComponentEntity))
+	if err = a.checkError(err, rsp); err != nil {
+		return fmt.Errorf("unable to update user federation: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteUserFederation deletes user federation component by ID.
+// Mappers and users imported from the user federation are deleted by Keycloak as well.
+func (a GoCloakAdapter) DeleteUserFederation(ctx context.Context, realm, federationID string) error {
+	return a.deleteComponentByID(ctx, realm, federationID)
+}
+
+// SyncUserFederationMappers syncs mappers of the user federation with the given mappers matched by name.
+// Only config properties from the given mappers are compared, so properties set by Keycloak don't cause updates.
+// Mappers which are not in the list are deleted unless addOnly is set.
+func (a GoCloakAdapter) SyncUserFederationMappers(
+	ctx context.Context,
+	realm, federationID string,
+	mappers []Component,
+	addOnly bool,
+) error {
+	currentMappers, err := a.getComponents(ctx, realm, map[string]string{
+		"parent": federationID,
+		"type":   LDAPStorageMapperType,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to get current mappers: %w", err)
+	}
+
+	currentMappersByName := make(map[string]Component, len(currentMappers))
+	for _, m := range currentMappers {
+		currentMappersByName[m.Name] = m
+	}
+
+	var errs []error
+
+	claimedMappers := make(map[string]struct{}, len(mappers))
+
+	for i := range mappers {
+		mapper := mappers[i]
+		mapper.ParentID = federationID
+		mapper.ProviderType = LDAPStorageMapperType
+		claimedMappers[mapper.Name] = struct{}{}
+
+		current, ok := currentMappersByName[mapper.Name]
+		if err := a.syncUserFederationMapper(ctx, realm, &mapper, &current, ok); err != nil {
+			errs = append(errs, fmt.Errorf("unable to sync user federation mapper %s: %w", mapper.Name, err))
+		}
+	}
+
+	if addOnly {
+		return errors.Join(errs...)
+	}
+
+	names := maps.Keys(currentMappersByName)
+	sort.Strings(names)
+
+	for _, name := range names {
+		if _, ok := claimedMappers[name]; ok {
+			continue
+		}
+
+		if err := a.deleteComponentByID(ctx, realm, currentMappersByName[name].ID); err != nil {
+			errs = append(errs, fmt.Errorf("unable to delete user federation mapper %s: %w", name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (a GoCloakAdapter) syncUserFederationMapper(ctx context.Context, realm string, mapper, current *Component, exists bool) error {
+	if exists && current.ProviderID != mapper.ProviderID {
+		// Keycloak doesn't allow changing mapper provider, so the mapper is recreated.
+		if err := a.deleteComponentByID(ctx, realm, current.ID); err != nil {
+			return err
+		}
+
+		exists = false
+	}
+
+	if !exists {
+		return a.CreateComponent(ctx, realm, mapper)
+	}
+
+	if isComponentConfigApplied(current.Config, mapper.Config) {
+		return nil
+	}
+
+	mapper.ID = current.ID
+
+	config := make(map[string][]string, len(current.Config)+len(mapper.Config))
+	maps.Copy(config, current.Config)
+	maps.Copy(config, mapper.Config)
+	mapper.Config = config
+
+	return a.UpdateComponent(ctx, realm, mapper)
+}
+
+// TestLDAPConnection checks LDAP connection or authentication settings depending on the test action.
+func (a GoCloakAdapter) TestLDAPConnection(ctx context.Context, realm string, test *LDAPConnectionTest) error {
+	rsp, err := a.startRestyRequest().
+		SetContext(ctx).
+		SetPathParams(map[string]string{
+			keycloakApiParamRealm: realm,
+		}).
+		SetBody(test).
+		Post(a.buildPath(realmTestLDAPConnection))
+	if err = a.checkError(err, rsp); err != nil {
+		return fmt.Errorf("ldap %s failed: %w", test.Action, err)
+	}
+
+	return nil
+}
+
+// TriggerUserFederationSync starts synchronization of the user federation users.
+// Action should be UserFederationSyncFull or UserFederationSyncChanged.
+func (a GoCloakAdapter) TriggerUserFederationSync(
+	ctx context.Context,
+	realm, federationID, action string,
+) (*UserFederationSyncResult, error) {
+	result := &UserFederationSyncResult{}
+
+	rsp, err := a.startRestyRequest().
+		SetContext(ctx).
+		SetPathParams(map[string]string{
+			keycloakApiParamRealm: realm,
+			keycloakApiParamId:    federationID,
+		}).
+		SetQueryParam("action", action).
+		SetResult(result).
+		Post(a.buildPath(realmUserStorageSync))
+	if err = a.checkError(err, rsp); err != nil {
+		return nil, fmt.Errorf("unable to sync user federation users: %w", err)
+	}
+
+	return result, nil
+}
+
+func (a GoCloakAdapter) getComponents(ctx context.Context, realm string, query map[string]string) ([]Component, error) {
+	var components []Component
+
+	rsp, err := a.startRestyRequest().
+		SetContext(ctx).
+		SetPathParams(map[string]string{
+			keycloakApiParamRealm: realm,
+		}).
+		SetQueryParams(query).
+		SetResult(&components).
+		Get(a.buildPath(realmComponent))
+	if err = a.checkError(err, rsp); err != nil {
+		return nil, fmt.Errorf("unable to get components: %w", err)
+	}
+
+	return components, nil
+}
+
+func (a GoCloakAdapter) deleteComponentByID(ctx context.Context, realm, id string) error {
+	rsp, err := a.startRestyRequest().
+		SetContext(ctx).
+		SetPathParams(map[string]string{
+			keycloakApiParamRealm: realm,
+			keycloakApiParamId:    id,
+		}).
+		Delete(a.buildPath(realmComponentEntity))
+	if err == nil && rsp.StatusCode() == http.StatusNotFound {
+		return nil
+	}
+
+	if err = a.checkError(err, rsp); err != nil {
+		return fmt.Errorf("unable to delete component: %w", err)
+	}
+
+	return nil
+}
+
+// isComponentConfigApplied checks that all properties of the desired config are set in the current config.
+func isComponentConfigApplied(current, desired map[string][]string) bool {
+	for k, v := range desired {
+		cv, ok := current[k]
+		if !ok && len(v) == 0 {
+			continue
+		}
+
+		if !ok || len(cv) != len(v) {
+			return false
+		}
+
+		for i := range v {
+			if cv[i] != v[i] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,220 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+const componentsPath = "/admin/realms/realm1/components"
+
+func TestGoCloakAdapter_GetUserFederation(t *testing.T) {
+	kc, _, _ := initAdapter()
+	httpmock.Reset()
+
+	httpmock.RegisterResponder(http.MethodGet, componentsPath+"?name=ldap&parent=realm-id&type="+UserStorageProviderType,
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, []Component{
+			{ID: "id1", Name: "ldap", ProviderID: "ldap", ProviderType: UserStorageProviderType},
+		}))
+	httpmock.RegisterResponder(http.MethodGet, componentsPath+"?name=missing&parent=realm-id&type="+UserStorageProviderType,
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, []Component{}))
+
+	federation, err := kc.GetUserFederation(context.Background(), "realm1", "realm-id", "ldap")
+	require.NoError(t, err)
+	require.Equal(t, "id1", federation.ID)
+
+	_, err = kc.GetUserFederation(context.Background(), "realm1", "realm-id", "missing")
+	require.Error(t, err)
+	require.True(t, IsErrNotFound(err))
+}
+
+func TestGoCloakAdapter_CreateUserFederation(t *testing.T) {
+	kc, _, _ := initAdapter()
+	httpmock.Reset()
+
+	httpmock.RegisterResponder(http.MethodPost, componentsPath, func(req *http.Request) (*http.Response, error) {
+		var federation Component
+		if err := json.NewDecoder(req.Body).Decode(&federation); err != nil {
+			return nil, err
+		}
+
+		if federation.ProviderType != UserStorageProviderType {
+			return httpmock.NewStringResponse(http.StatusBadRequest, "wrong provider type"), nil
+		}
+
+		rsp := httpmock.NewStringResponse(http.StatusCreated, "")
+		rsp.Header.Set("Location", componentsPath+"/new-id")
+
+		return rsp, nil
+	})
+
+	id, err := kc.CreateUserFederation(context.Background(), "realm1", &Component{Name: "ldap", ProviderID: "ldap"})
+	require.NoError(t, err)
+	require.Equal(t, "new-id", id)
+}
+
+func TestGoCloakAdapter_DeleteUserFederation(t *testing.T) {
+	kc, _, _ := initAdapter()
+	httpmock.Reset()
+
+	httpmock.RegisterResponder(http.MethodDelete, componentsPath+"/id1", httpmock.NewStringResponder(http.StatusNoContent, ""))
+	httpmock.RegisterResponder(http.MethodDelete, componentsPath+"/id2", httpmock.NewStringResponder(http.StatusNotFound, ""))
+	httpmock.RegisterResponder(http.MethodDelete, componentsPath+"/id3", httpmock.NewStringResponder(http.StatusInternalServerError, "fatal"))
+
+	require.NoError(t, kc.DeleteUserFederation(context.Background(), "realm1", "id1"))
+	require.NoError(t, kc.DeleteUserFederation(context.Background(), "realm1", "id2"))
+	require.ErrorContains(t, kc.DeleteUserFederation(context.Background(), "realm1", "id3"), "unable to delete component")
+}
+
+func TestGoCloakAdapter_SyncUserFederationMappers(t *testing.T) {
+	currentMappers := []Component{
+		{ID: "id1", Name: "email", ProviderID: "user-attribute-ldap-mapper",
+			Config: map[string][]string{"ldap.attribute": {"mail"}, "read.only": {"true"}}},
+		{ID: "id2", Name: "first name", ProviderID: "user-attribute-ldap-mapper",
+			Config: map[string][]string{"ldap.attribute": {"cn"}}},
+		{ID: "id3", Name: "groups", ProviderID: "role-ldap-mapper"},
+		{ID: "id4", Name: "stale", ProviderID: "user-attribute-ldap-mapper"},
+	}
+
+	claimedMappers := []Component{
+		{Name: "email", ProviderID: "user-attribute-ldap-mapper",
+			Config: map[string][]string{"ldap.attribute": {"mail"}}},
+		{Name: "first name", ProviderID: "user-attribute-ldap-mapper",
+			Config: map[string][]string{"ldap.attribute": {"givenName"}}},
+		{Name: "groups", ProviderID: "group-ldap-mapper"},
+		{Name: "new", ProviderID: "hardcoded-ldap-role-mapper"},
+	}
+
+	tests := []struct {
+		name      string
+		addOnly   bool
+		failOn    string
+		wantCalls map[string]int
+		wantErr   require.ErrorAssertionFunc
+	}{
+		{
+			name: "full sync",
+			wantCalls: map[string]int{
+				"PUT " + componentsPath + "/id1":    0,
+				"PUT " + componentsPath + "/id2":    1,
+				"DELETE " + componentsPath + "/id3": 1,
+				"DELETE " + componentsPath + "/id4": 1,
+				"POST " + componentsPath:            2,
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name:    "add only",
+			addOnly: true,
+			wantCalls: map[string]int{
+				"PUT " + componentsPath + "/id2":    1,
+				"DELETE " + componentsPath + "/id3": 1,
+				"DELETE " + componentsPath + "/id4": 0,
+				"POST " + componentsPath:            2,
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name:   "mapper errors are collected",
+			failOn: "PUT " + componentsPath + "/id2",
+			wantCalls: map[string]int{
+				"DELETE " + componentsPath + "/id4": 1,
+				"POST " + componentsPath:            2,
+			},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.ErrorContains(t, err, "unable to sync user federation mapper first name")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kc, _, _ := initAdapter()
+			httpmock.Reset()
+
+			httpmock.RegisterResponder(http.MethodGet, componentsPath+"?parent=fed-id&type="+LDAPStorageMapperType,
+				httpmock.NewJsonResponderOrPanic(http.StatusOK, currentMappers))
+
+			responder := func(method, url string, r httpmock.Responder) {
+				if method+" "+url == tt.failOn {
+					r = httpmock.NewStringResponder(http.StatusInternalServerError, "fatal")
+				}
+
+				httpmock.RegisterResponder(method, url, r)
+			}
+
+			responder(http.MethodPost, componentsPath, httpmock.NewStringResponder(http.StatusCreated, ""))
+
+			for _, id := range []string{"id1", "id2", "id3", "id4"} {
+				responder(http.MethodPut, componentsPath+"/"+id, httpmock.NewStringResponder(http.StatusOK, ""))
+				responder(http.MethodDelete, componentsPath+"/"+id, httpmock.NewStringResponder(http.StatusOK, ""))
+			}
+
+			err := kc.SyncUserFederationMappers(context.Background(), "realm1", "fed-id", claimedMappers, tt.addOnly)
+			tt.wantErr(t, err)
+
+			calls := httpmock.GetCallCountInfo()
+			for call, count := range tt.wantCalls {
+				require.Equalf(t, count, calls[call], "unexpected number of calls %s", call)
+			}
+		})
+	}
+}
+
+func TestGoCloakAdapter_TestLDAPConnection(t *testing.T) {
+	kc, _, _ := initAdapter()
+	httpmock.Reset()
+
+	httpmock.RegisterResponder(http.MethodPost, "/admin/realms/realm1/testLDAPConnection",
+		func(req *http.Request) (*http.Response, error) {
+			var test LDAPConnectionTest
+			if err := json.NewDecoder(req.Body).Decode(&test); err != nil {
+				return nil, err
+			}
+
+			if test.Action == LDAPTestAuthentication && test.BindCredential != "secret" {
+				return httpmock.NewStringResponse(http.StatusBadRequest, `{"errorMessage":"LDAPErrorMessage"}`), nil
+			}
+
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+
+	require.NoError(t, kc.TestLDAPConnection(context.Background(), "realm1", &LDAPConnectionTest{
+		Action:        LDAPTestConnection,
+		ConnectionURL: "ldap://ldap:389",
+	}))
+
+	err := kc.TestLDAPConnection(context.Background(), "realm1", &LDAPConnectionTest{
+		Action:         LDAPTestAuthentication,
+		ConnectionURL:  "ldap://ldap:389",
+		BindDN:         "cn=admin",
+		BindCredential: "wrong",
+	})
+	require.ErrorContains(t, err, "ldap testAuthentication failed")
+}
+
+func TestGoCloakAdapter_TriggerUserFederationSync(t *testing.T) {
+	kc, _, _ := initAdapter()
+	httpmock.Reset()
+
+	httpmock.RegisterResponder(http.MethodPost,
+		"/admin/realms/realm1/user-storage/fed-id/sync?action="+UserFederationSyncFull,
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, UserFederationSyncResult{
+			Added:  2,
+			Status: "2 imported users, 0 updated users",
+		}))
+	httpmock.RegisterResponder(http.MethodPost,
+		"/admin/realms/realm1/user-storage/fed-id/sync?action="+UserFederationSyncChanged,
+		httpmock.NewStringResponder(http.StatusInternalServerError, "fatal"))
+
+	result, err := kc.TriggerUserFederationSync(context.Background(), "realm1", "fed-id", UserFederationSyncFull)
+	require.NoError(t, err)
+	require.Equal(t, 2, result.Added)
+
+	_, err = kc.TriggerUserFederationSync(context.Background(), "realm1", "fed-id", UserFederationSyncChanged)
+	require.ErrorContains(t, err, "unable to sync user federation users")
+}
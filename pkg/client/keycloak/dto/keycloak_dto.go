@@ -41,6 +41,9 @@ func ConvertSpecToRole(roleInstance *keycloakApi.KeycloakRealmRole) *PrimaryReal
 		rr.Composites = append(rr.Composites, comp.Name)
 	}
 
+	rr.CompositesClientRoles = ConvertClientRoleComposites(roleInstance.Spec.CompositesClientRoles)
+	rr.CompositesAddOnly = roleInstance.Spec.CompositesReconciliationStrategy != keycloakApi.ReconciliationStrategyFull
+
 	if roleInstance.Status.ID != "" {
 		rr.ID = &roleInstance.Status.ID
 	}
@@ -98,13 +101,61 @@ type Client struct {
 }
 
 type PrimaryRealmRole struct {
-	ID          *string
-	Name        string
-	Composites  []string
-	IsComposite bool
-	Description string
-	Attributes  map[string][]string
-	IsDefault   bool
+	ID                    *string
+	Name                  string
+	Composites            []string
+	CompositesClientRoles map[string][]string
+	CompositesAddOnly     bool
+	IsComposite           bool
+	Description           string
+	Attributes            map[string][]string
+	IsDefault             bool
+}
+
+// ConvertClientRoleComposites converts client role composites from spec to map of client id to role names.
+func ConvertClientRoleComposites(composites map[string][]keycloakApi.Composite) map[string][]string {
+	if len(composites) == 0 {
+		return nil
+	}
+
+	res := make(map[string][]string, len(composites))
+
+	for clientID, roles := range composites {
+		names := make([]string, 0, len(roles))
+		for _, r := range roles {
+			names = append(names, r.Name)
+		}
+
+		res[clientID] = names
+	}
+
+	return res
+}
+
+type ClientRole struct {
+	Name                  string
+	Description           string
+	Attributes            map[string][]string
+	Composites            []string
+	CompositesClientRoles map[string][]string
+	CompositesAddOnly     bool
+}
+
+func ConvertSpecToClientRole(spec *keycloakApi.KeycloakClientRoleSpec) *ClientRole {
+	role := ClientRole{
+		Name:                  spec.Name,
+		Description:           spec.Description,
+		Attributes:            spec.Attributes,
+		Composites:            make([]string, 0, len(spec.Composites)),
+		CompositesClientRoles: ConvertClientRoleComposites(spec.CompositesClientRoles),
+		CompositesAddOnly:     spec.CompositesReconciliationStrategy != keycloakApi.ReconciliationStrategyFull,
+	}
+
+	for _, comp := range spec.Composites {
+		role.Composites = append(role.Composites, comp.Name)
+	}
+
+	return &role
 }
 
 type IncludedRealmRole struct {
@@ -162,10 +213,19 @@ func ConvertSSOMappersToIdentityProviderMappers(idpAlias string,
 	ssoMappers []keycloakApi.SSORealmMapper) []IdentityProviderMapper {
 	idpMappers := make([]IdentityProviderMapper, 0, len(ssoMappers))
 	for _, sm := range ssoMappers {
+		config := make(map[string]string, len(sm.Config)+1)
+		for k, v := range sm.Config {
+			config[k] = v
+		}
+
+		if sm.SyncMode != "" {
+			config["syncMode"] = sm.SyncMode
+		}
+
 		idpMappers = append(idpMappers, IdentityProviderMapper{
 			IdentityProviderAlias:  idpAlias,
 			IdentityProviderMapper: sm.IdentityProviderMapper,
-			Config:                 sm.Config,
+			Config:                 config,
 			Name:                   sm.Name,
 		})
 	}
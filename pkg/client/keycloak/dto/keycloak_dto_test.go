@@ -35,3 +35,29 @@ func TestConvertSpecToClient(t *testing.T) {
 		t.Fatal("sso realm enabled must be false when in spec is false")
 	}
 }
+
+func TestConvertSpecToRole(t *testing.T) {
+	role := ConvertSpecToRole(&keycloakApi.KeycloakRealmRole{
+		Spec: keycloakApi.KeycloakRealmRoleSpec{
+			Name:       "role",
+			Composites: []keycloakApi.Composite{{Name: "realm-role"}},
+			CompositesClientRoles: map[string][]keycloakApi.Composite{
+				"realm-management": {{Name: "view-users"}, {Name: "query-users"}},
+			},
+		},
+	})
+
+	require.Equal(t, []string{"realm-role"}, role.Composites)
+	require.Equal(t, map[string][]string{"realm-management": {"view-users", "query-users"}}, role.CompositesClientRoles)
+	require.Nil(t, role.ID)
+	require.True(t, role.CompositesAddOnly)
+
+	role = ConvertSpecToRole(&keycloakApi.KeycloakRealmRole{
+		Spec: keycloakApi.KeycloakRealmRoleSpec{
+			Name:                             "role",
+			CompositesReconciliationStrategy: keycloakApi.ReconciliationStrategyFull,
+		},
+	})
+
+	require.False(t, role.CompositesAddOnly)
+}
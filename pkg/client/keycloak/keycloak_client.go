@@ -2,6 +2,7 @@ package keycloak
 
 import (
 	"context"
+	"time"
 
 	"github.com/Nerzal/gocloak/v12"
 
@@ -19,8 +20,11 @@ type Client interface {
 	KCloakClientRoles
 	KAuthFlow
 	KCloakComponents
+	KUserFederation
 	KCloakClientScope
 	KIdentityProvider
+	KServerInfo
+	KAdminPermissions
 
 	ExistCentralIdentityProvider(realm *dto.Realm) (bool, error)
 	CreateCentralIdentityProvider(realm *dto.Realm, client *dto.Client) error
@@ -38,11 +42,28 @@ type KIdentityProvider interface {
 	GetIdentityProvider(ctx context.Context, realm, alias string) (*adapter.IdentityProvider, error)
 	IdentityProviderExists(ctx context.Context, realm, alias string) (bool, error)
 	DeleteIdentityProvider(ctx context.Context, realm, alias string) error
+	ImportIdentityProviderConfig(ctx context.Context, realm, providerID, fromURL string) (map[string]string, error)
+	ImportIdentityProviderConfigFromFile(ctx context.Context, realm, providerID string, file []byte) (map[string]string, error)
 
 	CreateIDPMapper(ctx context.Context, realm, idpAlias string, mapper *adapter.IdentityProviderMapper) (string, error)
 	UpdateIDPMapper(ctx context.Context, realm, idpAlias string, mapper *adapter.IdentityProviderMapper) error
 	DeleteIDPMapper(ctx context.Context, realm, idpAlias, mapperID string) error
 	GetIDPMappers(ctx context.Context, realm, idpAlias string) ([]adapter.IdentityProviderMapper, error)
+	SyncIDPMappers(ctx context.Context, realm, idpAlias string, mappers []adapter.IdentityProviderMapper, addOnly bool) error
+}
+
+type KServerInfo interface {
+	GetServerInfo(ctx context.Context) (*adapter.ServerInfo, error)
+	LegacyMode() bool
+	SetServerFeatures(version string, features []string)
+}
+
+type KAdminPermissions interface {
+	SetManagementPermissions(ctx context.Context, realm, targetType, targetName string, enabled bool) (*adapter.ManagementPermissions, error)
+	SyncAdminPermissionPolicy(ctx context.Context, realm string, policy *adapter.AdminPermissionPolicy) (string, error)
+	GetAdminPermissionPolicyID(ctx context.Context, realm, name string) (string, error)
+	DeleteAdminPermissionPolicy(ctx context.Context, realm, name string) error
+	SetScopePermissionPolicies(ctx context.Context, realm, permissionID string, policyIDs []string, decisionStrategy string) error
 }
 
 type KAuthFlow interface {
@@ -52,8 +73,12 @@ type KAuthFlow interface {
 }
 
 type KCloakGroups interface {
-	SyncRealmGroup(realm string, spec *keycloakApi.KeycloakRealmGroupSpec) (string, error)
+	SyncRealmGroup(ctx context.Context, realm string, spec *keycloakApi.KeycloakRealmGroupSpec,
+		ref adapter.RealmGroupRef) (*gocloak.Group, error)
 	DeleteGroup(ctx context.Context, realm, groupName string) error
+	DeleteGroupByID(ctx context.Context, realm, groupID string) error
+	SyncGroupMembers(ctx context.Context, realm, groupID string, members []keycloakApi.GroupMember,
+		addOnly bool) ([]string, error)
 }
 
 type KCloakUsers interface {
@@ -61,6 +86,7 @@ type KCloakUsers interface {
 	CreateRealmUser(realmName string, user *dto.User) error
 	SyncRealmUser(ctx context.Context, realmName string, user *adapter.KeycloakUser, addOnly bool) error
 	DeleteRealmUser(ctx context.Context, realmName, username string) error
+	LogoutRealmUser(ctx context.Context, realmName, username string) error
 }
 
 type KCloakRealms interface {
@@ -68,9 +94,12 @@ type KCloakRealms interface {
 	ExistRealm(realm string) (bool, error)
 	CreateRealmWithDefaultConfig(realm *dto.Realm) error
 	DeleteRealm(ctx context.Context, realmName string) error
-	SyncRealmIdentityProviderMappers(realmName string, mappers []dto.IdentityProviderMapper) error
+	SyncRealmIdentityProviderMappers(realmName string, mappers []dto.IdentityProviderMapper, addOnly bool) error
 	UpdateRealmSettings(realmName string, realmSettings *adapter.RealmSettings) error
 	SetRealmEventConfig(realmName string, eventConfig *adapter.RealmEventConfig) error
+	RevokeRealmTokens(ctx context.Context, realmName string, notBefore time.Time) error
+	GetUsersProfile(ctx context.Context, realm string) (*adapter.UserProfileConfig, error)
+	UpdateUsersProfile(ctx context.Context, realm string, profile *adapter.UserProfileConfig) error
 }
 
 type KCloakClients interface {
@@ -82,6 +111,10 @@ type KCloakClients interface {
 		client *dto.Client, crMappers []gocloak.ProtocolMapperRepresentation, addOnly bool) error
 	GetClientID(clientID, realm string) (string, error)
 	AddDefaultScopeToClient(ctx context.Context, realmName, clientName string, scopes []adapter.ClientScope) error
+	SyncClientScopeMappings(ctx context.Context, realm, idOfClient string, realmRoles []string,
+		clientRoles map[string][]string, addOnly bool) error
+	SyncClientScopes(ctx context.Context, realmName, clientName string, defaultScopes, optionalScopes []adapter.ClientScope, addOnly bool) error
+	RevokeClientSessions(ctx context.Context, realmName, clientUUID string) error
 }
 
 type KCloakClientScope interface {
@@ -91,8 +124,11 @@ type KCloakClientScope interface {
 	UpdateClientScope(ctx context.Context, realmName, scopeID string, scope *adapter.ClientScope) error
 	DeleteClientScope(ctx context.Context, realmName, scopeID string) error
 	GetDefaultClientScopesForRealm(ctx context.Context, realm string) ([]adapter.ClientScope, error)
+	GetOptionalClientScopesForRealm(ctx context.Context, realm string) ([]adapter.ClientScope, error)
 	CreateClientScope(ctx context.Context, realmName string, scope *adapter.ClientScope) (string, error)
 	GetClientScopeMappers(ctx context.Context, realmName, scopeID string) ([]adapter.ProtocolMapper, error)
+	SyncClientScopeScopeMappings(ctx context.Context, realm, scopeID string, realmRoles []string,
+		clientRoles map[string][]string, addOnly bool) error
 }
 
 type KCloakRealmRoles interface {
@@ -108,13 +144,28 @@ type KCloakRealmRoles interface {
 type KCloakClientRoles interface {
 	ExistClientRole(role *dto.Client, clientRole string) (bool, error)
 	CreateClientRole(role *dto.Client, clientRole string) error
+	SyncClientRole(ctx context.Context, realm, clientUUID string, role *dto.ClientRole) (string, error)
+	DeleteClientRole(ctx context.Context, realm, clientUUID, roleName string) error
 	HasUserClientRole(realmName string, clientId string, user *dto.User, role string) (bool, error)
 	AddClientRoleToUser(realmName string, clientId string, user *dto.User, role string) error
 }
 
+type KUserFederation interface {
+	GetUserFederation(ctx context.Context, realm, parentID, name string) (*adapter.Component, error)
+	CreateUserFederation(ctx context.Context, realm string, federation *adapter.Component) (string, error)
+	UpdateUserFederation(ctx context.Context, realm string, federation *adapter.Component) error
+	DeleteUserFederation(ctx context.Context, realm, federationID string) error
+	SyncUserFederationMappers(ctx context.Context, realm, federationID string, mappers []adapter.Component, addOnly bool) error
+	TestLDAPConnection(ctx context.Context, realm string, test *adapter.LDAPConnectionTest) error
+	TriggerUserFederationSync(ctx context.Context, realm, federationID, action string) (*adapter.UserFederationSyncResult, error)
+}
+
 type KCloakComponents interface {
 	CreateComponent(ctx context.Context, realmName string, component *adapter.Component) error
 	UpdateComponent(ctx context.Context, realmName string, component *adapter.Component) error
 	DeleteComponent(ctx context.Context, realmName, componentName string) error
 	GetComponent(ctx context.Context, realmName, componentName string) (*adapter.Component, error)
+	GetComponentByID(ctx context.Context, realmName, componentID string) (*adapter.Component, error)
+	GetComponentByParent(ctx context.Context, realmName, parentID, componentName string) (*adapter.Component, error)
+	DeleteComponentByID(ctx context.Context, realmName, componentID string) error
 }
@@ -0,0 +1,145 @@
+package secretref
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// SecretNamesIndexField is a field index of custom resources by names of the secrets referenced in their configs.
+const SecretNamesIndexField = "secretRefs.name"
+
+// ParseSecretRef returns secret name and key from the secret reference in format '$secretName:secretKey'.
+// It returns false if the value is not a secret reference or it is a reference managed by Keycloak.
+func ParseSecretRef(val string) (name, key string, ok bool) {
+	if !HasSecretRef(val) || strings.HasPrefix(val, keycloakSecretRefPrefix) {
+		return "", "", false
+	}
+
+	ref := strings.Split(val[1:], ":")
+	if len(ref) != 2 || ref[0] == "" {
+		return "", "", false
+	}
+
+	return ref[0], ref[1], true
+}
+
+// SecretNamesFromRefs returns sorted unique names of the secrets referenced in the given values.
+func SecretNamesFromRefs(values ...string) []string {
+	names := make(map[string]struct{})
+
+	for _, v := range values {
+		if name, _, ok := ParseSecretRef(v); ok {
+			names[name] = struct{}{}
+		}
+	}
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	res := make([]string, 0, len(names))
+	for name := range names {
+		res = append(res, name)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+// ConfigSecretsHashes returns hashes of the resolved secret values keyed by secret reference without prefix.
+// refConfig is the config with secret references, config is the same config after MapConfigSecretsRefs.
+func ConfigSecretsHashes(refConfig, config map[string]string) map[string]string {
+	hashes := make(map[string]string)
+
+	for k, v := range refConfig {
+		if _, _, ok := ParseSecretRef(v); ok {
+			hashes[v[1:]] = HashSecretValue(config[k])
+		}
+	}
+
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	return hashes
+}
+
+// ComponentConfigSecretsHashes returns hashes of the resolved secret values keyed by secret reference without prefix.
+// refConfig is the config with secret references, config is the same config after MapComponentConfigSecretsRefs.
+func ComponentConfigSecretsHashes(refConfig, config map[string][]string) map[string]string {
+	hashes := make(map[string]string)
+
+	for k, values := range refConfig {
+		for i, v := range values {
+			if _, _, ok := ParseSecretRef(v); ok && i < len(config[k]) {
+				hashes[v[1:]] = HashSecretValue(config[k][i])
+			}
+		}
+	}
+
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	return hashes
+}
+
+// HashSecretValue returns sha256 hash of the secret value.
+func HashSecretValue(val string) string {
+	hash := sha256.Sum256([]byte(val))
+
+	return hex.EncodeToString(hash[:])
+}
+
+// MapSecretToRequests returns a function that maps a secret to reconcile requests
+// for the custom resources which reference it. Custom resources must be indexed by SecretNamesIndexField.
+// newList should return a new empty list of the custom resources.
+func MapSecretToRequests(k8sClient client.Client, newList func() client.ObjectList) handler.MapFunc {
+	return func(secret client.Object) []reconcile.Request {
+		list := newList()
+		if err := k8sClient.List(
+			context.Background(),
+			list,
+			client.InNamespace(secret.GetNamespace()),
+			client.MatchingFields{SecretNamesIndexField: secret.GetName()},
+		); err != nil {
+			ctrl.Log.Error(err, "unable to list custom resources for secret", "secret", secret.GetName())
+			return nil
+		}
+
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			ctrl.Log.Error(err, "unable to extract custom resources list", "secret", secret.GetName())
+			return nil
+		}
+
+		requests := make([]reconcile.Request, 0, len(items))
+
+		for _, item := range items {
+			obj, ok := item.(client.Object)
+			if !ok {
+				continue
+			}
+
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: obj.GetNamespace(),
+					Name:      obj.GetName(),
+				},
+			})
+		}
+
+		return requests
+	}
+}
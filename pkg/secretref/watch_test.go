@@ -0,0 +1,140 @@
+package secretref
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestParseSecretRef(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		val      string
+		wantName string
+		wantKey  string
+		wantOk   bool
+	}{
+		{name: "secret ref", val: "$secret:key", wantName: "secret", wantKey: "key", wantOk: true},
+		{name: "keycloak ref", val: "${vault.key}"},
+		{name: "plain value", val: "value"},
+		{name: "invalid ref", val: "$secret"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			name, key, ok := ParseSecretRef(tt.val)
+
+			assert.Equal(t, tt.wantName, name)
+			assert.Equal(t, tt.wantKey, key)
+			assert.Equal(t, tt.wantOk, ok)
+		})
+	}
+}
+
+func TestSecretNamesFromRefs(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t,
+		[]string{"a-secret", "b-secret"},
+		SecretNamesFromRefs("$b-secret:key", "value", "$a-secret:key", "${vault.key}", "$b-secret:other"),
+	)
+	assert.Nil(t, SecretNamesFromRefs("value"))
+}
+
+func TestConfigSecretsHashes(t *testing.T) {
+	t.Parallel()
+
+	refConfig := map[string]string{
+		"clientId":     "client",
+		"clientSecret": "$idp-secret:clientSecret",
+	}
+	config := map[string]string{
+		"clientId":     "client",
+		"clientSecret": "secretValue",
+	}
+
+	assert.Equal(t,
+		map[string]string{"idp-secret:clientSecret": HashSecretValue("secretValue")},
+		ConfigSecretsHashes(refConfig, config),
+	)
+	assert.Nil(t, ConfigSecretsHashes(config, config))
+}
+
+func TestComponentConfigSecretsHashes(t *testing.T) {
+	t.Parallel()
+
+	refConfig := map[string][]string{
+		"bindDn":         {"uid=serviceaccount"},
+		"bindCredential": {"$bind-credential:data"},
+	}
+	config := map[string][]string{
+		"bindDn":         {"uid=serviceaccount"},
+		"bindCredential": {"secretValue"},
+	}
+
+	assert.Equal(t,
+		map[string]string{"bind-credential:data": HashSecretValue("secretValue")},
+		ComponentConfigSecretsHashes(refConfig, config),
+	)
+	assert.Nil(t, ComponentConfigSecretsHashes(config, config))
+}
+
+func TestMapSecretToRequests(t *testing.T) {
+	t.Parallel()
+
+	s := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(s))
+
+	indexSecretNames := func(obj client.Object) []string {
+		cm, ok := obj.(*corev1.ConfigMap)
+		if !ok {
+			return nil
+		}
+
+		return SecretNamesFromRefs(cm.Data["secret"])
+	}
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "with-ref", Namespace: "ns"},
+				Data:       map[string]string{"secret": "$my-secret:key"},
+			},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "other-ref", Namespace: "ns"},
+				Data:       map[string]string{"secret": "$other-secret:key"},
+			},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "other-ns", Namespace: "other"},
+				Data:       map[string]string{"secret": "$my-secret:key"},
+			},
+		).
+		WithIndex(&corev1.ConfigMap{}, SecretNamesIndexField, indexSecretNames).
+		Build()
+
+	mapFunc := MapSecretToRequests(k8sClient, func() client.ObjectList {
+		return &corev1.ConfigMapList{}
+	})
+
+	requests := mapFunc(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-secret", Namespace: "ns"},
+	})
+
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "with-ref"}},
+	}, requests)
+}